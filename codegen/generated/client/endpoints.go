@@ -0,0 +1,2694 @@
+// Code generated by codegen/main.go. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+//
+// anilist
+//
+
+// GetAnimeCollectionGet returns the user's AniList anime collection.
+// Calling GET will return the cached anime collection.
+// The manga collection is also refreshed in the background, and upon completion, a WebSocket event is sent.
+// Calling POST will refetch both the anime and manga collections.
+//
+//	GET /api/v1/anilist/collection
+func (c *Client) GetAnimeCollectionGet(ctx context.Context) (*AL_AnimeCollection, error) {
+	var ret *AL_AnimeCollection
+	err := c.do(ctx, "GET", "/api/v1/anilist/collection", nil, nil, &ret)
+	return ret, err
+}
+
+// GetAnimeCollectionPost returns the user's AniList anime collection.
+// Calling GET will return the cached anime collection.
+// The manga collection is also refreshed in the background, and upon completion, a WebSocket event is sent.
+// Calling POST will refetch both the anime and manga collections.
+//
+//	POST /api/v1/anilist/collection
+func (c *Client) GetAnimeCollectionPost(ctx context.Context) (*AL_AnimeCollection, error) {
+	var ret *AL_AnimeCollection
+	err := c.do(ctx, "POST", "/api/v1/anilist/collection", nil, nil, &ret)
+	return ret, err
+}
+
+// GetRawAnimeCollectionGet returns the user's AniList anime collection without filtering out custom lists.
+// Calling GET will return the cached anime collection.
+//
+//	GET /api/v1/anilist/collection/raw
+func (c *Client) GetRawAnimeCollectionGet(ctx context.Context) (*AL_AnimeCollection, error) {
+	var ret *AL_AnimeCollection
+	err := c.do(ctx, "GET", "/api/v1/anilist/collection/raw", nil, nil, &ret)
+	return ret, err
+}
+
+// GetRawAnimeCollectionPost returns the user's AniList anime collection without filtering out custom lists.
+// Calling GET will return the cached anime collection.
+//
+//	POST /api/v1/anilist/collection/raw
+func (c *Client) GetRawAnimeCollectionPost(ctx context.Context) (*AL_AnimeCollection, error) {
+	var ret *AL_AnimeCollection
+	err := c.do(ctx, "POST", "/api/v1/anilist/collection/raw", nil, nil, &ret)
+	return ret, err
+}
+
+// EditAnilistListEntry updates the user's list entry on Anilist.
+// This is used to edit an entry on AniList.
+// The "type" field is used to determine if the entry is an anime or manga and refreshes the collection accordingly.
+// The client should refetch collection-dependent queries after this mutation.
+// "customLists" replaces the custom lists of the entry, they are left unchanged if it is omitted.
+//
+//	POST /api/v1/anilist/list-entry
+func (c *Client) EditAnilistListEntry(ctx context.Context, body *EditAnilistListEntryRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/anilist/list-entry", nil, body, &ret)
+	return ret, err
+}
+
+// GetAnilistAnimeDetails returns more details about an AniList anime entry.
+// This fetches more fields omitted from the base queries.
+//
+//	GET /api/v1/anilist/media-details/{id}
+func (c *Client) GetAnilistAnimeDetails(ctx context.Context, id int) (*AL_AnimeDetailsById_Media, error) {
+	var ret *AL_AnimeDetailsById_Media
+	err := c.do(ctx, "GET", "/api/v1/anilist/media-details/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetAnilistStudioDetails returns details about a studio.
+// This fetches media produced by the studio.
+//
+//	GET /api/v1/anilist/studio-details/{id}
+func (c *Client) GetAnilistStudioDetails(ctx context.Context, id int) (*AL_StudioDetails, error) {
+	var ret *AL_StudioDetails
+	err := c.do(ctx, "GET", "/api/v1/anilist/studio-details/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// DeleteAnilistListEntry deletes an entry from the user's AniList list.
+// This is used to delete an entry on AniList.
+// The "type" field is used to determine if the entry is an anime or manga and refreshes the collection accordingly.
+// The client should refetch collection-dependent queries after this mutation.
+//
+//	DELETE /api/v1/anilist/list-entry
+func (c *Client) DeleteAnilistListEntry(ctx context.Context, body *DeleteAnilistListEntryRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/anilist/list-entry", nil, body, &ret)
+	return ret, err
+}
+
+// AnilistListAnime returns a list of anime based on the search parameters.
+// This is used by the "Discover" and "Advanced Search".
+//
+//	POST /api/v1/anilist/list-anime
+func (c *Client) AnilistListAnime(ctx context.Context, body *AnilistListAnimeRequest) (*AL_ListAnime, error) {
+	var ret *AL_ListAnime
+	err := c.do(ctx, "POST", "/api/v1/anilist/list-anime", nil, body, &ret)
+	return ret, err
+}
+
+// AnilistListRecentAiringAnime returns a list of recently aired anime.
+// This is used by the "Schedule" page to display recently aired anime.
+//
+//	POST /api/v1/anilist/list-recent-anime
+func (c *Client) AnilistListRecentAiringAnime(ctx context.Context, body *AnilistListRecentAiringAnimeRequest) (*AL_ListRecentAnime, error) {
+	var ret *AL_ListRecentAnime
+	err := c.do(ctx, "POST", "/api/v1/anilist/list-recent-anime", nil, body, &ret)
+	return ret, err
+}
+
+// AnilistListMissedSequels returns a list of sequels not in the user's list.
+// This is used by the "Discover" page to display sequels the user may have missed.
+//
+//	GET /api/v1/anilist/list-missed-sequels
+func (c *Client) AnilistListMissedSequels(ctx context.Context) ([]AL_BaseAnime, error) {
+	var ret []AL_BaseAnime
+	err := c.do(ctx, "GET", "/api/v1/anilist/list-missed-sequels", nil, nil, &ret)
+	return ret, err
+}
+
+// GetAniListStats returns the anilist stats.
+// This returns the AniList stats for the user.
+//
+//	GET /api/v1/anilist/stats
+func (c *Client) GetAniListStats(ctx context.Context) (*AL_Stats, error) {
+	var ret *AL_Stats
+	err := c.do(ctx, "GET", "/api/v1/anilist/stats", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// anime_collection
+//
+
+// GetLibraryCollectionGet returns the main local anime collection.
+// This creates a new LibraryCollection struct and returns it.
+// This is used to get the main anime collection of the user.
+// It uses the cached Anilist anime collection for the GET method.
+// It refreshes the AniList anime collection if the POST method is used.
+//
+//	GET /api/v1/library/collection
+func (c *Client) GetLibraryCollectionGet(ctx context.Context) (*Anime_LibraryCollection, error) {
+	var ret *Anime_LibraryCollection
+	err := c.do(ctx, "GET", "/api/v1/library/collection", nil, nil, &ret)
+	return ret, err
+}
+
+// GetLibraryCollectionPost returns the main local anime collection.
+// This creates a new LibraryCollection struct and returns it.
+// This is used to get the main anime collection of the user.
+// It uses the cached Anilist anime collection for the GET method.
+// It refreshes the AniList anime collection if the POST method is used.
+//
+//	POST /api/v1/library/collection
+func (c *Client) GetLibraryCollectionPost(ctx context.Context) (*Anime_LibraryCollection, error) {
+	var ret *Anime_LibraryCollection
+	err := c.do(ctx, "POST", "/api/v1/library/collection", nil, nil, &ret)
+	return ret, err
+}
+
+// AddUnknownMedia adds the given media to the user's AniList planning collections
+// Since media not found in the user's AniList collection are not displayed in the library, this route is used to add them.
+// The response is ignored in the frontend, the client should just refetch the entire library collection.
+//
+//	POST /api/v1/library/unknown-media
+func (c *Client) AddUnknownMedia(ctx context.Context, body *AddUnknownMediaRequest) (*AL_AnimeCollection, error) {
+	var ret *AL_AnimeCollection
+	err := c.do(ctx, "POST", "/api/v1/library/unknown-media", nil, body, &ret)
+	return ret, err
+}
+
+//
+// anime_entries
+//
+
+// GetAnimeEntry return a media entry for the given AniList anime media id.
+// This is used by the anime media entry pages to get all the data about the anime.
+// This includes episodes and metadata (if any), AniList list data, download info...
+//
+//	GET /api/v1/library/anime-entry/{id}
+func (c *Client) GetAnimeEntry(ctx context.Context, id int) (*Anime_Entry, error) {
+	var ret *Anime_Entry
+	err := c.do(ctx, "GET", "/api/v1/library/anime-entry/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// AnimeEntryBulkAction perform given action on all the local files for the given media id.
+// This is used to unmatch or toggle the lock status of all the local files for a specific media entry
+// The response is not used in the frontend. The client should just refetch the entire media entry data.
+//
+//	PATCH /api/v1/library/anime-entry/bulk-action
+func (c *Client) AnimeEntryBulkAction(ctx context.Context, body *AnimeEntryBulkActionRequest) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "PATCH", "/api/v1/library/anime-entry/bulk-action", nil, body, &ret)
+	return ret, err
+}
+
+// OpenAnimeEntryInExplorer opens the directory of a media entry in the file explorer.
+// This finds a common directory for all media entry local files and opens it in the file explorer.
+// Returns 'true' whether the operation was successful or not, errors are ignored.
+//
+//	POST /api/v1/library/anime-entry/open-in-explorer
+func (c *Client) OpenAnimeEntryInExplorer(ctx context.Context, body *OpenAnimeEntryInExplorerRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/library/anime-entry/open-in-explorer", nil, body, &ret)
+	return ret, err
+}
+
+// FetchAnimeEntrySuggestions returns a list of media suggestions for files in the given directory.
+// This is used by the "Resolve unmatched media" feature to suggest media entries for the local files in the given directory.
+// If some matches files are found in the directory, it will ignore them and base the suggestions on the remaining files.
+//
+//	POST /api/v1/library/anime-entry/suggestions
+func (c *Client) FetchAnimeEntrySuggestions(ctx context.Context, body *FetchAnimeEntrySuggestionsRequest) ([]AL_BaseAnime, error) {
+	var ret []AL_BaseAnime
+	err := c.do(ctx, "POST", "/api/v1/library/anime-entry/suggestions", nil, body, &ret)
+	return ret, err
+}
+
+// AnimeEntryManualMatch matches un-matched local files in the given directory to the given media.
+// It is used by the "Resolve unmatched media" feature to manually match local files to a specific media entry.
+// Matching involves the use of scanner.FileHydrator. It will also lock the files.
+// The response is not used in the frontend. The client should just refetch the entire library collection.
+//
+//	POST /api/v1/library/anime-entry/manual-match
+func (c *Client) AnimeEntryManualMatch(ctx context.Context, body *AnimeEntryManualMatchRequest) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "POST", "/api/v1/library/anime-entry/manual-match", nil, body, &ret)
+	return ret, err
+}
+
+// GetMissingEpisodes returns a list of episodes missing from the user's library collection
+// It detects missing episodes by comparing the user's AniList collection 'next airing' data with the local files.
+// This route can be called multiple times, as it does not bypass the cache.
+//
+//	GET /api/v1/library/missing-episodes
+func (c *Client) GetMissingEpisodes(ctx context.Context) (*Anime_MissingEpisodes, error) {
+	var ret *Anime_MissingEpisodes
+	err := c.do(ctx, "GET", "/api/v1/library/missing-episodes", nil, nil, &ret)
+	return ret, err
+}
+
+// GetAnimeEntrySilenceStatus returns the silence status of a media entry.
+//
+//	GET /api/v1/library/anime-entry/silence/{id}
+func (c *Client) GetAnimeEntrySilenceStatus(ctx context.Context, id int) (*Models_SilencedMediaEntry, error) {
+	var ret *Models_SilencedMediaEntry
+	err := c.do(ctx, "GET", "/api/v1/library/anime-entry/silence/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// ToggleAnimeEntrySilenceStatus toggles the silence status of a media entry.
+// The missing episodes should be re-fetched after this.
+//
+//	POST /api/v1/library/anime-entry/silence
+func (c *Client) ToggleAnimeEntrySilenceStatus(ctx context.Context, body *ToggleAnimeEntrySilenceStatusRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/library/anime-entry/silence", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateAnimeEntryProgress update the progress of the given anime media entry.
+// This is used to update the progress of the given anime media entry on AniList and MyAnimeList (if an account is linked).
+// The response is not used in the frontend, the client should just refetch the entire media entry data.
+// NOTE: This is currently only used by the 'Online streaming' feature since anime progress updates are handled by the Playback Manager.
+//
+//	POST /api/v1/library/anime-entry/update-progress
+func (c *Client) UpdateAnimeEntryProgress(ctx context.Context, body *UpdateAnimeEntryProgressRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/library/anime-entry/update-progress", nil, body, &ret)
+	return ret, err
+}
+
+//
+// auth
+//
+
+// Login logs in the user by saving the JWT token in the database.
+// This is called when the JWT token is obtained from AniList after logging in with redirection on the client.
+// It also fetches the Viewer data from AniList and saves it in the database.
+// It creates a new handlers.Status and refreshes App modules.
+//
+//	POST /api/v1/auth/login
+func (c *Client) Login(ctx context.Context, body *LoginRequest) (*Handlers_Status, error) {
+	var ret *Handlers_Status
+	err := c.do(ctx, "POST", "/api/v1/auth/login", nil, body, &ret)
+	return ret, err
+}
+
+// Logout logs out the user by removing JWT token from the database.
+// It removes JWT token and Viewer data from the database.
+// It creates a new handlers.Status and refreshes App modules.
+//
+//	POST /api/v1/auth/logout
+func (c *Client) Logout(ctx context.Context) (*Handlers_Status, error) {
+	var ret *Handlers_Status
+	err := c.do(ctx, "POST", "/api/v1/auth/logout", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// auto_downloader
+//
+
+// RunAutoDownloader tells the AutoDownloader to check for new episodes if enabled.
+// This will run the AutoDownloader if it is enabled.
+// It does nothing if the AutoDownloader is disabled.
+//
+//	POST /api/v1/auto-downloader/run
+func (c *Client) RunAutoDownloader(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/auto-downloader/run", nil, nil, &ret)
+	return ret, err
+}
+
+// GetAutoDownloaderRule returns the rule with the given DB id.
+// This is used to get a specific rule, useful for editing.
+//
+//	GET /api/v1/auto-downloader/rule/{id}
+func (c *Client) GetAutoDownloaderRule(ctx context.Context, id int) (*Anime_AutoDownloaderRule, error) {
+	var ret *Anime_AutoDownloaderRule
+	err := c.do(ctx, "GET", "/api/v1/auto-downloader/rule/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetAutoDownloaderRulesByAnime returns the rules with the given media id.
+//
+//	GET /api/v1/auto-downloader/rule/anime/{id}
+func (c *Client) GetAutoDownloaderRulesByAnime(ctx context.Context, id int) ([]Anime_AutoDownloaderRule, error) {
+	var ret []Anime_AutoDownloaderRule
+	err := c.do(ctx, "GET", "/api/v1/auto-downloader/rule/anime/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetAutoDownloaderRules returns all rules.
+// This is used to list all rules. It returns an empty slice if there are no rules.
+//
+//	GET /api/v1/auto-downloader/rules
+func (c *Client) GetAutoDownloaderRules(ctx context.Context) ([]Anime_AutoDownloaderRule, error) {
+	var ret []Anime_AutoDownloaderRule
+	err := c.do(ctx, "GET", "/api/v1/auto-downloader/rules", nil, nil, &ret)
+	return ret, err
+}
+
+// CreateAutoDownloaderRule creates a new rule.
+// The body should contain the same fields as entities.AutoDownloaderRule.
+// It returns the created rule.
+//
+//	POST /api/v1/auto-downloader/rule
+func (c *Client) CreateAutoDownloaderRule(ctx context.Context, body *CreateAutoDownloaderRuleRequest) (*Anime_AutoDownloaderRule, error) {
+	var ret *Anime_AutoDownloaderRule
+	err := c.do(ctx, "POST", "/api/v1/auto-downloader/rule", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateAutoDownloaderRule updates a rule.
+// The body should contain the same fields as entities.AutoDownloaderRule.
+// It returns the updated rule.
+//
+//	PATCH /api/v1/auto-downloader/rule
+func (c *Client) UpdateAutoDownloaderRule(ctx context.Context, body *UpdateAutoDownloaderRuleRequest) (*Anime_AutoDownloaderRule, error) {
+	var ret *Anime_AutoDownloaderRule
+	err := c.do(ctx, "PATCH", "/api/v1/auto-downloader/rule", nil, body, &ret)
+	return ret, err
+}
+
+// DeleteAutoDownloaderRule deletes a rule.
+// It returns 'true' if the rule was deleted.
+//
+//	DELETE /api/v1/auto-downloader/rule/{id}
+func (c *Client) DeleteAutoDownloaderRule(ctx context.Context, id int) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/auto-downloader/rule/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetAutoDownloaderItems returns all queued items.
+// Queued items are episodes that are downloaded but not scanned or not yet downloaded.
+// The AutoDownloader uses these items in order to not download the same episode twice.
+//
+//	GET /api/v1/auto-downloader/items
+func (c *Client) GetAutoDownloaderItems(ctx context.Context) ([]Models_AutoDownloaderItem, error) {
+	var ret []Models_AutoDownloaderItem
+	err := c.do(ctx, "GET", "/api/v1/auto-downloader/items", nil, nil, &ret)
+	return ret, err
+}
+
+// DeleteAutoDownloaderItem delete a queued item.
+// This is used to remove a queued item from the list.
+// Returns 'true' if the item was deleted.
+//
+//	DELETE /api/v1/auto-downloader/item
+func (c *Client) DeleteAutoDownloaderItem(ctx context.Context, id int, body *DeleteAutoDownloaderItemRequest) (bool, error) {
+	query := url.Values{}
+	query.Set("id", fmt.Sprint(id))
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/auto-downloader/item", query, body, &ret)
+	return ret, err
+}
+
+//
+// backup
+//
+
+// GetBackups returns the backups stored in the backup directory.
+// The backups are sorted from newest to oldest.
+//
+//	GET /api/v1/backups
+func (c *Client) GetBackups(ctx context.Context) ([]BackupFile, error) {
+	var ret []BackupFile
+	err := c.do(ctx, "GET", "/api/v1/backups", nil, nil, &ret)
+	return ret, err
+}
+
+// CreateBackup creates a backup in the backup directory.
+// The archive contains the database, the offline database, the watch history and the installed extensions.
+// The oldest backups are deleted if there are more than the maximum number of backups.
+// The archive can be downloaded with HandleDownloadBackup.
+//
+//	POST /api/v1/backups
+func (c *Client) CreateBackup(ctx context.Context) (*BackupFile, error) {
+	var ret *BackupFile
+	err := c.do(ctx, "POST", "/api/v1/backups", nil, nil, &ret)
+	return ret, err
+}
+
+// DeleteBackup deletes a backup from the backup directory.
+//
+//	DELETE /api/v1/backups
+func (c *Client) DeleteBackup(ctx context.Context, body *DeleteBackupRequest) ([]BackupFile, error) {
+	var ret []BackupFile
+	err := c.do(ctx, "DELETE", "/api/v1/backups", nil, body, &ret)
+	return ret, err
+}
+
+// RestoreBackup restores a backup.
+// The archive can be uploaded as a multipart form file named "file", or a backup from the backup directory can be selected by name.
+// The archive is validated and staged, it replaces the current data when Seanime is restarted.
+// Stored credentials can only be read back if the encryption key is the same as the one used when the backup was created.
+// 'undecryptableCredentials' lists the credentials that were encrypted with another key, they will be empty after the restore and need to be entered again.
+// To keep them, copy the key file of the previous installation to the data directory (or set SEANIME_ENCRYPTION_KEY) before restoring.
+//
+//	POST /api/v1/backups/restore
+func (c *Client) RestoreBackup(ctx context.Context, body *RestoreBackupRequest) (*RestoreResult, error) {
+	var ret *RestoreResult
+	err := c.do(ctx, "POST", "/api/v1/backups/restore", nil, body, &ret)
+	return ret, err
+}
+
+// GetBackupSettings returns the settings of the scheduled backups.
+//
+//	GET /api/v1/backups/settings
+func (c *Client) GetBackupSettings(ctx context.Context) (*Models_BackupSettings, error) {
+	var ret *Models_BackupSettings
+	err := c.do(ctx, "GET", "/api/v1/backups/settings", nil, nil, &ret)
+	return ret, err
+}
+
+// SaveBackupSettings saves the settings of the scheduled backups.
+//
+//	PATCH /api/v1/backups/settings
+func (c *Client) SaveBackupSettings(ctx context.Context, body *SaveBackupSettingsRequest) (*Models_BackupSettings, error) {
+	var ret *Models_BackupSettings
+	err := c.do(ctx, "PATCH", "/api/v1/backups/settings", nil, body, &ret)
+	return ret, err
+}
+
+//
+// calendar
+//
+
+// GetCalendar returns the airing calendar of the anime being watched or planned.
+// 'range' is either 'week' (default) or 'month', 'date' (YYYY-MM-DD) is any day in the range and defaults to today.
+// 'timezone' is an IANA time zone name used to group the episodes by day, the server time zone is used by default.
+// Each episode is annotated with its local availability and auto downloader rule status.
+//
+//	GET /api/v1/calendar
+func (c *Client) GetCalendar(ctx context.Context) (*View, error) {
+	var ret *View
+	err := c.do(ctx, "GET", "/api/v1/calendar", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// continuity
+//
+
+// UpdateContinuityWatchHistoryItem Updates watch history item.
+// This endpoint is used to update a watch history item.
+// Since this is low priority, we ignore any errors.
+// The playback position of the online and media streams is also recorded in the watch stats.
+//
+//	PATCH /api/v1/continuity/item
+func (c *Client) UpdateContinuityWatchHistoryItem(ctx context.Context, body *UpdateContinuityWatchHistoryItemRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "PATCH", "/api/v1/continuity/item", nil, body, &ret)
+	return ret, err
+}
+
+// GetContinuityWatchHistoryItem Returns a watch history item.
+// This endpoint is used to retrieve a watch history item.
+//
+//	GET /api/v1/continuity/item/{id}
+func (c *Client) GetContinuityWatchHistoryItem(ctx context.Context, id int) (*Continuity_WatchHistoryItemResponse, error) {
+	var ret *Continuity_WatchHistoryItemResponse
+	err := c.do(ctx, "GET", "/api/v1/continuity/item/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetContinuityWatchHistory Returns the continuity watch history
+// This endpoint is used to retrieve all watch history items.
+//
+//	GET /api/v1/continuity/history
+func (c *Client) GetContinuityWatchHistory(ctx context.Context) (Continuity_WatchHistory, error) {
+	var ret Continuity_WatchHistory
+	err := c.do(ctx, "GET", "/api/v1/continuity/history", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// debrid
+//
+
+// GetDebridSettings get debrid settings.
+// This returns the debrid settings.
+//
+//	GET /api/v1/debrid/settings
+func (c *Client) GetDebridSettings(ctx context.Context) (*Models_DebridSettings, error) {
+	var ret *Models_DebridSettings
+	err := c.do(ctx, "GET", "/api/v1/debrid/settings", nil, nil, &ret)
+	return ret, err
+}
+
+// SaveDebridSettings save debrid settings.
+// This saves the debrid settings.
+// The client should refetch the server status.
+//
+//	PATCH /api/v1/debrid/settings
+func (c *Client) SaveDebridSettings(ctx context.Context, body *SaveDebridSettingsRequest) (*Models_DebridSettings, error) {
+	var ret *Models_DebridSettings
+	err := c.do(ctx, "PATCH", "/api/v1/debrid/settings", nil, body, &ret)
+	return ret, err
+}
+
+// DebridAddTorrents add torrent to debrid.
+// This adds a torrent to the debrid service.
+//
+//	POST /api/v1/debrid/torrents
+func (c *Client) DebridAddTorrents(ctx context.Context, body *DebridAddTorrentsRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/debrid/torrents", nil, body, &ret)
+	return ret, err
+}
+
+// DebridDownloadTorrent download torrent from debrid.
+// Manually downloads a torrent from the debrid service locally.
+//
+//	POST /api/v1/debrid/torrents/download
+func (c *Client) DebridDownloadTorrent(ctx context.Context, body *DebridDownloadTorrentRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/debrid/torrents/download", nil, body, &ret)
+	return ret, err
+}
+
+// DebridCancelDownload cancel download from debrid.
+// This cancels a download from the debrid service.
+//
+//	POST /api/v1/debrid/torrents/cancel
+func (c *Client) DebridCancelDownload(ctx context.Context, body *DebridCancelDownloadRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/debrid/torrents/cancel", nil, body, &ret)
+	return ret, err
+}
+
+// DebridDeleteTorrent remove torrent from debrid.
+// This removes a torrent from the debrid service.
+//
+//	DELETE /api/v1/debrid/torrent
+func (c *Client) DebridDeleteTorrent(ctx context.Context, body *DebridDeleteTorrentRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/debrid/torrent", nil, body, &ret)
+	return ret, err
+}
+
+// DebridGetTorrents get torrents from debrid.
+// This gets the torrents from the debrid service.
+//
+//	GET /api/v1/debrid/torrents
+func (c *Client) DebridGetTorrents(ctx context.Context) ([]Debrid_TorrentItem, error) {
+	var ret []Debrid_TorrentItem
+	err := c.do(ctx, "GET", "/api/v1/debrid/torrents", nil, nil, &ret)
+	return ret, err
+}
+
+// DebridGetTorrentInfo get torrent info from debrid.
+// This gets the torrent info from the debrid service.
+//
+//	POST /api/v1/debrid/torrents/info
+func (c *Client) DebridGetTorrentInfo(ctx context.Context, body *DebridGetTorrentInfoRequest) (*Debrid_TorrentInfo, error) {
+	var ret *Debrid_TorrentInfo
+	err := c.do(ctx, "POST", "/api/v1/debrid/torrents/info", nil, body, &ret)
+	return ret, err
+}
+
+// DebridStartStream start stream from debrid.
+// This starts streaming a torrent from the debrid service.
+//
+//	POST /api/v1/debrid/stream/start
+func (c *Client) DebridStartStream(ctx context.Context, body *DebridStartStreamRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/debrid/stream/start", nil, body, &ret)
+	return ret, err
+}
+
+// DebridCancelStream cancel stream from debrid.
+// This cancels a stream from the debrid service.
+//
+//	POST /api/v1/debrid/stream/cancel
+func (c *Client) DebridCancelStream(ctx context.Context, body *DebridCancelStreamRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/debrid/stream/cancel", nil, body, &ret)
+	return ret, err
+}
+
+//
+// directory_selector
+//
+
+// DirectorySelector returns directory content based on the input path.
+// This used by the directory selector component to get directory validation and suggestions.
+// It returns subdirectories based on the input path.
+// It returns 500 error if the directory does not exist (or cannot be accessed).
+//
+//	POST /api/v1/directory-selector
+func (c *Client) DirectorySelector(ctx context.Context, body *DirectorySelectorRequest) (*DirectorySelectorResponse, error) {
+	var ret *DirectorySelectorResponse
+	err := c.do(ctx, "POST", "/api/v1/directory-selector", nil, body, &ret)
+	return ret, err
+}
+
+//
+// discord
+//
+
+// SetDiscordMangaActivity sets manga activity for discord rich presence.
+//
+//	POST /api/v1/discord/presence/manga
+func (c *Client) SetDiscordMangaActivity(ctx context.Context, body *SetDiscordMangaActivityRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/discord/presence/manga", nil, body, &ret)
+	return ret, err
+}
+
+// CancelDiscordActivity cancels the current discord rich presence activity.
+//
+//	POST /api/v1/discord/presence/cancel
+func (c *Client) CancelDiscordActivity(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/discord/presence/cancel", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// docs
+//
+
+// GetDocs returns the API documentation
+//
+//	GET /api/v1/internal/docs
+func (c *Client) GetDocs(ctx context.Context) ([]ApiDocsGroup, error) {
+	var ret []ApiDocsGroup
+	err := c.do(ctx, "GET", "/api/v1/internal/docs", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// download
+//
+
+// DownloadTorrentFile downloads torrent files to the destination folder
+//
+//	POST /api/v1/download-torrent-file
+func (c *Client) DownloadTorrentFile(ctx context.Context, body *DownloadTorrentFileRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/download-torrent-file", nil, body, &ret)
+	return ret, err
+}
+
+// DownloadRelease downloads selected release asset to the destination folder.
+// Downloads the selected release asset to the destination folder and extracts it if possible.
+// If the extraction fails, the error message will be returned in the successful response.
+// The successful response will contain the destination path of the extracted files.
+// It only returns an error if the download fails.
+//
+//	POST /api/v1/download-release
+func (c *Client) DownloadRelease(ctx context.Context, body *DownloadReleaseRequest) (*DownloadReleaseResponse, error) {
+	var ret *DownloadReleaseResponse
+	err := c.do(ctx, "POST", "/api/v1/download-release", nil, body, &ret)
+	return ret, err
+}
+
+//
+// event_stream
+//
+
+// GetEventHistory returns the events recorded in the event journal after the given sequence number.
+// If "types" is empty, all events are returned. The journal keeps the latest 1000 events.
+//
+//	POST /api/v1/events/history
+func (c *Client) GetEventHistory(ctx context.Context, body *GetEventHistoryRequest) ([]Events_JournalEntry, error) {
+	var ret []Events_JournalEntry
+	err := c.do(ctx, "POST", "/api/v1/events/history", nil, body, &ret)
+	return ret, err
+}
+
+//
+// explorer
+//
+
+// OpenInExplorer opens the given directory in the file explorer.
+// It returns 'true' whether the operation was successful or not.
+//
+//	POST /api/v1/open-in-explorer
+func (c *Client) OpenInExplorer(ctx context.Context, body *OpenInExplorerRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/open-in-explorer", nil, body, &ret)
+	return ret, err
+}
+
+//
+// extensions
+//
+
+// FetchExternalExtensionData returns the extension data from the given manifest uri.
+//
+//	POST /api/v1/extensions/external/fetch
+func (c *Client) FetchExternalExtensionData(ctx context.Context, body *FetchExternalExtensionDataRequest) (*Extension_Extension, error) {
+	var ret *Extension_Extension
+	err := c.do(ctx, "POST", "/api/v1/extensions/external/fetch", nil, body, &ret)
+	return ret, err
+}
+
+// InstallExternalExtension installs the extension from the given manifest uri.
+//
+//	POST /api/v1/extensions/external/install
+func (c *Client) InstallExternalExtension(ctx context.Context, body *InstallExternalExtensionRequest) (*ExtensionRepo_ExtensionInstallResponse, error) {
+	var ret *ExtensionRepo_ExtensionInstallResponse
+	err := c.do(ctx, "POST", "/api/v1/extensions/external/install", nil, body, &ret)
+	return ret, err
+}
+
+// UninstallExternalExtension uninstalls the extension with the given ID.
+//
+//	POST /api/v1/extensions/external/uninstall
+func (c *Client) UninstallExternalExtension(ctx context.Context, body *UninstallExternalExtensionRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/extensions/external/uninstall", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateExtensionCode updates the extension code with the given ID and reloads the extensions.
+//
+//	POST /api/v1/extensions/external/edit-payload
+func (c *Client) UpdateExtensionCode(ctx context.Context, body *UpdateExtensionCodeRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/extensions/external/edit-payload", nil, body, &ret)
+	return ret, err
+}
+
+// ReloadExternalExtensions reloads the external extensions.
+//
+//	POST /api/v1/extensions/external/reload
+func (c *Client) ReloadExternalExtensions(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/extensions/external/reload", nil, nil, &ret)
+	return ret, err
+}
+
+// ListExtensionData returns the loaded extensions
+//
+//	GET /api/v1/extensions/list
+func (c *Client) ListExtensionData(ctx context.Context) ([]Extension_Extension, error) {
+	var ret []Extension_Extension
+	err := c.do(ctx, "GET", "/api/v1/extensions/list", nil, nil, &ret)
+	return ret, err
+}
+
+// GetAllExtensions returns all loaded and invalid extensions.
+//
+//	POST /api/v1/extensions/all
+func (c *Client) GetAllExtensions(ctx context.Context, body *GetAllExtensionsRequest) (*ExtensionRepo_AllExtensions, error) {
+	var ret *ExtensionRepo_AllExtensions
+	err := c.do(ctx, "POST", "/api/v1/extensions/all", nil, body, &ret)
+	return ret, err
+}
+
+// ListMangaProviderExtensions returns the installed manga providers.
+//
+//	GET /api/v1/extensions/list/manga-provider
+func (c *Client) ListMangaProviderExtensions(ctx context.Context) ([]ExtensionRepo_MangaProviderExtensionItem, error) {
+	var ret []ExtensionRepo_MangaProviderExtensionItem
+	err := c.do(ctx, "GET", "/api/v1/extensions/list/manga-provider", nil, nil, &ret)
+	return ret, err
+}
+
+// ListOnlinestreamProviderExtensions returns the installed online streaming providers.
+//
+//	GET /api/v1/extensions/list/onlinestream-provider
+func (c *Client) ListOnlinestreamProviderExtensions(ctx context.Context) ([]ExtensionRepo_OnlinestreamProviderExtensionItem, error) {
+	var ret []ExtensionRepo_OnlinestreamProviderExtensionItem
+	err := c.do(ctx, "GET", "/api/v1/extensions/list/onlinestream-provider", nil, nil, &ret)
+	return ret, err
+}
+
+// ListAnimeTorrentProviderExtensions returns the installed torrent providers.
+//
+//	GET /api/v1/extensions/list/anime-torrent-provider
+func (c *Client) ListAnimeTorrentProviderExtensions(ctx context.Context) ([]ExtensionRepo_AnimeTorrentProviderExtensionItem, error) {
+	var ret []ExtensionRepo_AnimeTorrentProviderExtensionItem
+	err := c.do(ctx, "GET", "/api/v1/extensions/list/anime-torrent-provider", nil, nil, &ret)
+	return ret, err
+}
+
+// RunExtensionPlaygroundCode runs the code in the extension playground.
+// Returns the logs
+//
+//	POST /api/v1/extensions/playground/run
+func (c *Client) RunExtensionPlaygroundCode(ctx context.Context, body *RunExtensionPlaygroundCodeRequest) (*RunPlaygroundCodeResponse, error) {
+	var ret *RunPlaygroundCodeResponse
+	err := c.do(ctx, "POST", "/api/v1/extensions/playground/run", nil, body, &ret)
+	return ret, err
+}
+
+// GetExtensionUserConfig returns the user config definition and current values for the extension with the given ID.
+//
+//	GET /api/v1/extensions/user-config/{id}
+func (c *Client) GetExtensionUserConfig(ctx context.Context, id string) (*ExtensionRepo_ExtensionUserConfig, error) {
+	var ret *ExtensionRepo_ExtensionUserConfig
+	err := c.do(ctx, "GET", "/api/v1/extensions/user-config/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// SaveExtensionUserConfig saves the user config for the extension with the given ID and reloads it.
+//
+//	POST /api/v1/extensions/user-config
+func (c *Client) SaveExtensionUserConfig(ctx context.Context, body *SaveExtensionUserConfigRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/extensions/user-config", nil, body, &ret)
+	return ret, err
+}
+
+//
+// filecache
+//
+
+// GetFileCacheTotalSize returns the total size of cache files.
+// The total size of the cache files is returned in human-readable format.
+//
+//	GET /api/v1/filecache/total-size
+func (c *Client) GetFileCacheTotalSize(ctx context.Context) (string, error) {
+	var ret string
+	err := c.do(ctx, "GET", "/api/v1/filecache/total-size", nil, nil, &ret)
+	return ret, err
+}
+
+// RemoveFileCacheBucket deletes all buckets with the given prefix.
+// The bucket value is the prefix of the cache files that should be deleted.
+// Returns 'true' if the operation was successful.
+//
+//	DELETE /api/v1/filecache/bucket
+func (c *Client) RemoveFileCacheBucket(ctx context.Context, body *RemoveFileCacheBucketRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/filecache/bucket", nil, body, &ret)
+	return ret, err
+}
+
+// GetFileCacheMediastreamVideoFilesTotalSize returns the total size of cached video file data.
+// The total size of the cache video file data is returned in human-readable format.
+//
+//	GET /api/v1/filecache/mediastream/videofiles/total-size
+func (c *Client) GetFileCacheMediastreamVideoFilesTotalSize(ctx context.Context) (string, error) {
+	var ret string
+	err := c.do(ctx, "GET", "/api/v1/filecache/mediastream/videofiles/total-size", nil, nil, &ret)
+	return ret, err
+}
+
+// ClearFileCacheMediastreamVideoFiles deletes the contents of the mediastream video file cache directory.
+// Returns 'true' if the operation was successful.
+//
+//	DELETE /api/v1/filecache/mediastream/videofiles
+func (c *Client) ClearFileCacheMediastreamVideoFiles(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/filecache/mediastream/videofiles", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// kitsu
+//
+
+// KitsuLogin logs the user in to Kitsu.
+// This uses the OAuth password grant to fetch the access and refresh tokens.
+// It will save the info in the database, the password is not stored.
+// The client should re-fetch the server status after this.
+//
+//	POST /api/v1/kitsu/login
+func (c *Client) KitsuLogin(ctx context.Context, body *KitsuLoginRequest) (*Kitsu_User, error) {
+	var ret *Kitsu_User
+	err := c.do(ctx, "POST", "/api/v1/kitsu/login", nil, body, &ret)
+	return ret, err
+}
+
+// KitsuLogout logs the user out of Kitsu.
+// This will delete the Kitsu info from the database, effectively logging the user out.
+// The client should re-fetch the server status after this.
+//
+//	POST /api/v1/kitsu/logout
+func (c *Client) KitsuLogout(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/kitsu/logout", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// list_export
+//
+
+// ExportList exports the collection as a file.
+// Supported formats are 'mal-xml' (MyAnimeList import file), 'json' and 'csv'.
+// The 'type' query parameter ('anime' or 'manga') is required for MyAnimeList exports, both collections are exported otherwise.
+// Entries without a MyAnimeList ID are reported in 'missingMalId' and left out of MyAnimeList exports.
+//
+//	GET /api/v1/list-export
+func (c *Client) ExportList(ctx context.Context) (*ExportResult, error) {
+	var ret *ExportResult
+	err := c.do(ctx, "GET", "/api/v1/list-export", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// list_import
+//
+
+// GetListImportStatus returns the status of the list import of the current profile.
+// The preview is set once the entries are matched, until all of them are applied.
+//
+//	GET /api/v1/list-import
+func (c *Client) GetListImportStatus(ctx context.Context) (*Listtransfer_Status, error) {
+	var ret *Listtransfer_Status
+	err := c.do(ctx, "GET", "/api/v1/list-import", nil, nil, &ret)
+	return ret, err
+}
+
+// GetListImportPreview parses an exported list and starts mapping its entries to AniList media.
+// Supported formats are 'mal-xml' (MyAnimeList export), 'json' and 'csv'.
+// The entries are mapped in the background, the progress is sent with the 'list-import-status' event.
+// The preview can be fetched once the state is 'ready'. Nothing is added to the collection, the preview is kept until it is applied.
+//
+//	POST /api/v1/list-import/preview
+func (c *Client) GetListImportPreview(ctx context.Context, body *GetListImportPreviewRequest) (*Listtransfer_Status, error) {
+	var ret *Listtransfer_Status
+	err := c.do(ctx, "POST", "/api/v1/list-import/preview", nil, body, &ret)
+	return ret, err
+}
+
+// ApplyListImport starts adding the entries of the import preview to the collection.
+// If 'mediaIds' is empty, all matched entries are added.
+// Entries already in the collection are skipped unless 'overwrite' is true.
+// The entries are added to the local collection when offline.
+// The entries are added in the background, the progress and the result are sent with the 'list-import-status' event.
+// Entries that could not be added are kept in the preview so that they can be applied again.
+//
+//	POST /api/v1/list-import/apply
+func (c *Client) ApplyListImport(ctx context.Context, body *ApplyListImportRequest) (*Listtransfer_Status, error) {
+	var ret *Listtransfer_Status
+	err := c.do(ctx, "POST", "/api/v1/list-import/apply", nil, body, &ret)
+	return ret, err
+}
+
+//
+// list_sync
+//
+
+// GetListSyncPreview returns the changes a list synchronization would make.
+// The entries are matched by AniList ID, nothing is applied.
+// The 'origin' query parameter overrides the origin from the settings.
+//
+//	GET /api/v1/list-sync/preview
+func (c *Client) GetListSyncPreview(ctx context.Context) (*ListSync_Result, error) {
+	var ret *ListSync_Result
+	err := c.do(ctx, "GET", "/api/v1/list-sync/preview", nil, nil, &ret)
+	return ret, err
+}
+
+// RunListSync synchronizes the AniList and MyAnimeList lists.
+// Entries missing on a platform are added to it, entries that differ are resolved using the origin.
+// The origin from the settings is used if 'origin' is empty.
+//
+//	POST /api/v1/list-sync/sync
+func (c *Client) RunListSync(ctx context.Context, body *RunListSyncRequest) (*ListSync_Result, error) {
+	var ret *ListSync_Result
+	err := c.do(ctx, "POST", "/api/v1/list-sync/sync", nil, body, &ret)
+	return ret, err
+}
+
+// SaveListSyncSettings updates the list sync settings.
+// When 'automatic' is true, entries are synchronized after they are updated and on a schedule.
+//
+//	PATCH /api/v1/settings/list-sync
+func (c *Client) SaveListSyncSettings(ctx context.Context, body *SaveListSyncSettingsRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "PATCH", "/api/v1/settings/list-sync", nil, body, &ret)
+	return ret, err
+}
+
+//
+// localfiles
+//
+
+// GetLocalFiles returns all local files.
+// Reminder that local files are scanned from the library path.
+//
+//	GET /api/v1/library/local-files
+func (c *Client) GetLocalFiles(ctx context.Context) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "GET", "/api/v1/library/local-files", nil, nil, &ret)
+	return ret, err
+}
+
+// ImportLocalFiles imports local files from the given path.
+// This will import local files from the given path.
+// The response is ignored, the client should refetch the entire library collection and media entry.
+//
+//	POST /api/v1/library/local-files/import
+func (c *Client) ImportLocalFiles(ctx context.Context, body *ImportLocalFilesRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/library/local-files/import", nil, body, &ret)
+	return ret, err
+}
+
+// LocalFileBulkAction performs an action on all local files.
+// This will perform the given action on all local files.
+// The response is ignored, the client should refetch the entire library collection and media entry.
+//
+//	POST /api/v1/library/local-files
+func (c *Client) LocalFileBulkAction(ctx context.Context, body *LocalFileBulkActionRequest) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "POST", "/api/v1/library/local-files", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateLocalFileData updates the local file with the given path.
+// This will update the local file with the given path.
+// The response is ignored, the client should refetch the entire library collection and media entry.
+//
+//	PATCH /api/v1/library/local-file
+func (c *Client) UpdateLocalFileData(ctx context.Context, body *UpdateLocalFileDataRequest) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "PATCH", "/api/v1/library/local-file", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateLocalFiles updates local files with the given paths.
+// The client should refetch the entire library collection and media entry.
+//
+//	PATCH /api/v1/library/local-files
+func (c *Client) UpdateLocalFiles(ctx context.Context, body *UpdateLocalFilesRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "PATCH", "/api/v1/library/local-files", nil, body, &ret)
+	return ret, err
+}
+
+// DeleteLocalFiles deletes the local file with the given paths.
+// The response is ignored, the client should refetch the entire library collection and media entry.
+//
+//	DELETE /api/v1/library/local-files
+func (c *Client) DeleteLocalFiles(ctx context.Context, body *DeleteLocalFilesRequest) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "DELETE", "/api/v1/library/local-files", nil, body, &ret)
+	return ret, err
+}
+
+// RemoveEmptyDirectories deletes the empty directories from the library path.
+//
+//	DELETE /api/v1/library/empty-directories
+func (c *Client) RemoveEmptyDirectories(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/library/empty-directories", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// mal
+//
+
+// MALAuth fetches the access and refresh tokens for the given code.
+// This is used to authenticate the user with MyAnimeList.
+// It will save the info in the database, effectively logging the user in.
+// The client should re-fetch the server status after this.
+//
+//	POST /api/v1/mal/auth
+func (c *Client) MALAuth(ctx context.Context, body *MALAuthRequest) (*MalAuthResponse, error) {
+	var ret *MalAuthResponse
+	err := c.do(ctx, "POST", "/api/v1/mal/auth", nil, body, &ret)
+	return ret, err
+}
+
+// EditMALListEntryProgress updates the progress of a MAL list entry.
+//
+//	POST /api/v1/mal/list-entry/progress
+func (c *Client) EditMALListEntryProgress(ctx context.Context, body *EditMALListEntryProgressRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/mal/list-entry/progress", nil, body, &ret)
+	return ret, err
+}
+
+// MALLogout logs the user out of MyAnimeList.
+// This will delete the MAL info from the database, effectively logging the user out.
+// The client should re-fetch the server status after this.
+//
+//	POST /api/v1/mal/logout
+func (c *Client) MALLogout(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/mal/logout", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// manga
+//
+
+// GetAnilistMangaCollection returns the user's AniList manga collection.
+//
+//	GET /api/v1/manga/anilist/collection
+func (c *Client) GetAnilistMangaCollection(ctx context.Context, body *GetAnilistMangaCollectionRequest) (*AL_MangaCollection, error) {
+	var ret *AL_MangaCollection
+	err := c.do(ctx, "GET", "/api/v1/manga/anilist/collection", nil, body, &ret)
+	return ret, err
+}
+
+// GetRawAnilistMangaCollectionGet returns the user's AniList manga collection.
+//
+//	GET /api/v1/manga/anilist/collection/raw
+func (c *Client) GetRawAnilistMangaCollectionGet(ctx context.Context) (*AL_MangaCollection, error) {
+	var ret *AL_MangaCollection
+	err := c.do(ctx, "GET", "/api/v1/manga/anilist/collection/raw", nil, nil, &ret)
+	return ret, err
+}
+
+// GetRawAnilistMangaCollectionPost returns the user's AniList manga collection.
+//
+//	POST /api/v1/manga/anilist/collection/raw
+func (c *Client) GetRawAnilistMangaCollectionPost(ctx context.Context) (*AL_MangaCollection, error) {
+	var ret *AL_MangaCollection
+	err := c.do(ctx, "POST", "/api/v1/manga/anilist/collection/raw", nil, nil, &ret)
+	return ret, err
+}
+
+// GetMangaCollection returns the user's main manga collection.
+// This is an object that contains all the user's manga entries in a structured format.
+//
+//	GET /api/v1/manga/collection
+func (c *Client) GetMangaCollection(ctx context.Context) (*Manga_Collection, error) {
+	var ret *Manga_Collection
+	err := c.do(ctx, "GET", "/api/v1/manga/collection", nil, nil, &ret)
+	return ret, err
+}
+
+// GetMangaEntry returns a manga entry for the given AniList manga id.
+// This is used by the manga media entry pages to get all the data about the anime. It includes metadata and AniList list data.
+//
+//	GET /api/v1/manga/entry/{id}
+func (c *Client) GetMangaEntry(ctx context.Context, id int) (*Manga_Entry, error) {
+	var ret *Manga_Entry
+	err := c.do(ctx, "GET", "/api/v1/manga/entry/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetMangaEntryDetails returns more details about an AniList manga entry.
+// This fetches more fields omitted from the base queries.
+//
+//	GET /api/v1/manga/entry/{id}/details
+func (c *Client) GetMangaEntryDetails(ctx context.Context, id int) (*AL_MangaDetailsById_Media, error) {
+	var ret *AL_MangaDetailsById_Media
+	err := c.do(ctx, "GET", "/api/v1/manga/entry/"+url.PathEscape(fmt.Sprint(id))+"/details", nil, nil, &ret)
+	return ret, err
+}
+
+// EmptyMangaEntryCache empties the cache for a manga entry.
+// This will empty the cache for a manga entry (chapter lists and pages), allowing the client to fetch fresh data.
+// HandleGetMangaEntryChapters should be called after this to fetch the new chapter list.
+// Returns 'true' if the operation was successful.
+//
+//	DELETE /api/v1/manga/entry/cache
+func (c *Client) EmptyMangaEntryCache(ctx context.Context, body *EmptyMangaEntryCacheRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/manga/entry/cache", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaEntryChapters returns the chapters for a manga entry based on the provider.
+//
+//	POST /api/v1/manga/chapters
+func (c *Client) GetMangaEntryChapters(ctx context.Context, body *GetMangaEntryChaptersRequest) (*Manga_ChapterContainer, error) {
+	var ret *Manga_ChapterContainer
+	err := c.do(ctx, "POST", "/api/v1/manga/chapters", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaEntryPages returns the pages for a manga entry based on the provider and chapter id.
+// This will return the pages for a manga chapter.
+// If the app is offline and the chapter is not downloaded, it will return an error.
+// If the app is online and the chapter is not downloaded, it will return the pages from the provider.
+// If the chapter is downloaded, it will return the appropriate struct.
+// If 'double page' is requested, it will fetch image sizes and include the dimensions in the response.
+//
+//	POST /api/v1/manga/pages
+func (c *Client) GetMangaEntryPages(ctx context.Context, body *GetMangaEntryPagesRequest) (*Manga_PageContainer, error) {
+	var ret *Manga_PageContainer
+	err := c.do(ctx, "POST", "/api/v1/manga/pages", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaEntryDownloadedChapters returns all download chapters for a manga entry,
+//
+//	GET /api/v1/manga/downloaded-chapters/{id}
+func (c *Client) GetMangaEntryDownloadedChapters(ctx context.Context, id int) ([]Manga_ChapterContainer, error) {
+	var ret []Manga_ChapterContainer
+	err := c.do(ctx, "GET", "/api/v1/manga/downloaded-chapters/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// AnilistListManga returns a list of manga based on the search parameters.
+// This is used by "Advanced Search" and search function.
+//
+//	POST /api/v1/manga/anilist/list
+func (c *Client) AnilistListManga(ctx context.Context, body *AnilistListMangaRequest) (*AL_ListManga, error) {
+	var ret *AL_ListManga
+	err := c.do(ctx, "POST", "/api/v1/manga/anilist/list", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateMangaProgress updates the progress of a manga entry.
+// Note: MyAnimeList is not supported
+//
+//	POST /api/v1/manga/update-progress
+func (c *Client) UpdateMangaProgress(ctx context.Context, body *UpdateMangaProgressRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/update-progress", nil, body, &ret)
+	return ret, err
+}
+
+// MangaManualSearch returns search results for a manual search.
+// Returns search results for a manual search.
+//
+//	POST /api/v1/manga/search
+func (c *Client) MangaManualSearch(ctx context.Context, body *MangaManualSearchRequest) ([]HibikeManga_SearchResult, error) {
+	var ret []HibikeManga_SearchResult
+	err := c.do(ctx, "POST", "/api/v1/manga/search", nil, body, &ret)
+	return ret, err
+}
+
+// MangaManualMapping manually maps a manga entry to a manga ID from the provider.
+// This is used to manually map a manga entry to a manga ID from the provider.
+// The client should re-fetch the chapter container after this.
+//
+//	POST /api/v1/manga/manual-mapping
+func (c *Client) MangaManualMapping(ctx context.Context, body *MangaManualMappingRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/manual-mapping", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaMapping returns the mapping for a manga entry.
+// This is used to get the mapping for a manga entry.
+// An empty string is returned if there's no manual mapping. If there is, the manga ID will be returned.
+//
+//	POST /api/v1/manga/get-mapping
+func (c *Client) GetMangaMapping(ctx context.Context, body *GetMangaMappingRequest) (*Manga_MappingResponse, error) {
+	var ret *Manga_MappingResponse
+	err := c.do(ctx, "POST", "/api/v1/manga/get-mapping", nil, body, &ret)
+	return ret, err
+}
+
+// RemoveMangaMapping removes the mapping for a manga entry.
+// This is used to remove the mapping for a manga entry.
+// The client should re-fetch the chapter container after this.
+//
+//	POST /api/v1/manga/remove-mapping
+func (c *Client) RemoveMangaMapping(ctx context.Context, body *RemoveMangaMappingRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/remove-mapping", nil, body, &ret)
+	return ret, err
+}
+
+//
+// manga_download
+//
+
+// DownloadMangaChapters adds chapters to the download queue.
+//
+//	POST /api/v1/manga/download-chapters
+func (c *Client) DownloadMangaChapters(ctx context.Context, body *DownloadMangaChaptersRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/download-chapters", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaDownloadData returns the download data for a specific media.
+// This is used to display information about the downloaded and queued chapters in the UI.
+// If the 'cached' parameter is false, it will refresh the data by rescanning the download folder.
+//
+//	POST /api/v1/manga/download-data
+func (c *Client) GetMangaDownloadData(ctx context.Context, body *GetMangaDownloadDataRequest) (*Manga_MediaDownloadData, error) {
+	var ret *Manga_MediaDownloadData
+	err := c.do(ctx, "POST", "/api/v1/manga/download-data", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaDownloadQueue returns the items in the download queue.
+//
+//	GET /api/v1/manga/download-queue
+func (c *Client) GetMangaDownloadQueue(ctx context.Context) ([]Models_ChapterDownloadQueueItem, error) {
+	var ret []Models_ChapterDownloadQueueItem
+	err := c.do(ctx, "GET", "/api/v1/manga/download-queue", nil, nil, &ret)
+	return ret, err
+}
+
+// StartMangaDownloadQueue starts the download queue if it's not already running.
+// This will start the download queue if it's not already running.
+// Returns 'true' whether the queue was started or not.
+//
+//	POST /api/v1/manga/download-queue/start
+func (c *Client) StartMangaDownloadQueue(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/download-queue/start", nil, nil, &ret)
+	return ret, err
+}
+
+// StopMangaDownloadQueue stops the manga download queue.
+// This will stop the manga download queue.
+// Returns 'true' whether the queue was stopped or not.
+//
+//	POST /api/v1/manga/download-queue/stop
+func (c *Client) StopMangaDownloadQueue(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/download-queue/stop", nil, nil, &ret)
+	return ret, err
+}
+
+// ClearAllChapterDownloadQueue clears all chapters from the download queue.
+// This will clear all chapters from the download queue.
+// Returns 'true' whether the queue was cleared or not.
+// This will also send a websocket event telling the client to refetch the download queue.
+//
+//	DELETE /api/v1/manga/download-queue
+func (c *Client) ClearAllChapterDownloadQueue(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/manga/download-queue", nil, nil, &ret)
+	return ret, err
+}
+
+// ResetErroredChapterDownloadQueue resets the errored chapters in the download queue.
+// This will reset the errored chapters in the download queue, so they can be re-downloaded.
+// Returns 'true' whether the queue was reset or not.
+// This will also send a websocket event telling the client to refetch the download queue.
+//
+//	POST /api/v1/manga/download-queue/reset-errored
+func (c *Client) ResetErroredChapterDownloadQueue(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/manga/download-queue/reset-errored", nil, nil, &ret)
+	return ret, err
+}
+
+// DeleteMangaDownloadedChapters deletes downloaded chapters.
+// This will delete downloaded chapters from the filesystem.
+// Returns 'true' whether the chapters were deleted or not.
+// The client should refetch the download data after this.
+//
+//	DELETE /api/v1/manga/download-chapter
+func (c *Client) DeleteMangaDownloadedChapters(ctx context.Context, body *DeleteMangaDownloadedChaptersRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/manga/download-chapter", nil, body, &ret)
+	return ret, err
+}
+
+// GetMangaDownloadsList displays the list of downloaded manga.
+// This analyzes the download folder and returns a well-formatted structure for displaying downloaded manga.
+// It returns a list of manga.DownloadListItem where the media data might be nil if it's not in the AniList collection.
+//
+//	GET /api/v1/manga/downloads
+func (c *Client) GetMangaDownloadsList(ctx context.Context) ([]Manga_DownloadListItem, error) {
+	var ret []Manga_DownloadListItem
+	err := c.do(ctx, "GET", "/api/v1/manga/downloads", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// manual_dump
+//
+
+// TestDump this is a dummy handler for testing purposes.
+//
+//	POST /api/v1/test-dump
+func (c *Client) TestDump(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/test-dump", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// mediaplayer
+//
+
+// StartDefaultMediaPlayer launches the default media player (vlc or mpc-hc).
+//
+//	POST /api/v1/media-player/start
+func (c *Client) StartDefaultMediaPlayer(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/media-player/start", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// mediastream
+//
+
+// GetMediastreamSettings get mediastream settings.
+// This returns the mediastream settings.
+//
+//	GET /api/v1/mediastream/settings
+func (c *Client) GetMediastreamSettings(ctx context.Context) (*Models_MediastreamSettings, error) {
+	var ret *Models_MediastreamSettings
+	err := c.do(ctx, "GET", "/api/v1/mediastream/settings", nil, nil, &ret)
+	return ret, err
+}
+
+// SaveMediastreamSettings save mediastream settings.
+// This saves the mediastream settings.
+//
+//	PATCH /api/v1/mediastream/settings
+func (c *Client) SaveMediastreamSettings(ctx context.Context, body *SaveMediastreamSettingsRequest) (*Models_MediastreamSettings, error) {
+	var ret *Models_MediastreamSettings
+	err := c.do(ctx, "PATCH", "/api/v1/mediastream/settings", nil, body, &ret)
+	return ret, err
+}
+
+// RequestMediastreamMediaContainer request media stream.
+// This requests a media stream and returns the media container to start the playback.
+//
+//	POST /api/v1/mediastream/request
+func (c *Client) RequestMediastreamMediaContainer(ctx context.Context, body *RequestMediastreamMediaContainerRequest) (*Mediastream_MediaContainer, error) {
+	var ret *Mediastream_MediaContainer
+	err := c.do(ctx, "POST", "/api/v1/mediastream/request", nil, body, &ret)
+	return ret, err
+}
+
+// PreloadMediastreamMediaContainer preloads media stream for playback.
+// This preloads a media stream by extracting the media information and attachments.
+//
+//	POST /api/v1/mediastream/preload
+func (c *Client) PreloadMediastreamMediaContainer(ctx context.Context, body *PreloadMediastreamMediaContainerRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/mediastream/preload", nil, body, &ret)
+	return ret, err
+}
+
+// MediastreamShutdownTranscodeStream shuts down the transcode stream
+// This requests the transcoder to shut down. It should be called when unmounting the player (playback is no longer needed).
+// This will also send an events.MediastreamShutdownStream event.
+// It will not return any error and is safe to call multiple times.
+//
+//	POST /api/v1/mediastream/shutdown-transcode
+func (c *Client) MediastreamShutdownTranscodeStream(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/mediastream/shutdown-transcode", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// metadata
+//
+
+// PopulateTVDBEpisodes populate cache with TVDB episode metadata.
+// This will populate the cache with TVDB episode metadata for the given media.
+//
+//	POST /api/v1/metadata-provider/tvdb-episodes
+func (c *Client) PopulateTVDBEpisodes(ctx context.Context, body *PopulateTVDBEpisodesRequest) ([]TVDB_Episode, error) {
+	var ret []TVDB_Episode
+	err := c.do(ctx, "POST", "/api/v1/metadata-provider/tvdb-episodes", nil, body, &ret)
+	return ret, err
+}
+
+// EmptyTVDBEpisodes empties TVDB episode metadata cache.
+// This will empty the TVDB episode metadata cache for the given media.
+//
+//	DELETE /api/v1/metadata-provider/tvdb-episodes
+func (c *Client) EmptyTVDBEpisodes(ctx context.Context, body *EmptyTVDBEpisodesRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/metadata-provider/tvdb-episodes", nil, body, &ret)
+	return ret, err
+}
+
+// PopulateFillerData fetches and caches filler data for the given media.
+// This will fetch and cache filler data for the given media.
+//
+//	POST /api/v1/metadata-provider/filler
+func (c *Client) PopulateFillerData(ctx context.Context, body *PopulateFillerDataRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/metadata-provider/filler", nil, body, &ret)
+	return ret, err
+}
+
+// RemoveFillerData removes filler data cache.
+// This will remove the filler data cache for the given media.
+//
+//	DELETE /api/v1/metadata-provider/filler
+func (c *Client) RemoveFillerData(ctx context.Context, body *RemoveFillerDataRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/metadata-provider/filler", nil, body, &ret)
+	return ret, err
+}
+
+//
+// notification_channels
+//
+
+// GetNotificationChannels returns the notification channels.
+// The tokens and passwords are redacted.
+//
+//	GET /api/v1/notification-channels
+func (c *Client) GetNotificationChannels(ctx context.Context) ([]Models_NotificationChannel, error) {
+	var ret []Models_NotificationChannel
+	err := c.do(ctx, "GET", "/api/v1/notification-channels", nil, nil, &ret)
+	return ret, err
+}
+
+// GetNotificationKinds returns the notification kinds a channel can receive.
+//
+//	GET /api/v1/notification-channels/kinds
+func (c *Client) GetNotificationKinds(ctx context.Context) ([]Notification, error) {
+	var ret []Notification
+	err := c.do(ctx, "GET", "/api/v1/notification-channels/kinds", nil, nil, &ret)
+	return ret, err
+}
+
+// CreateNotificationChannel creates a notification channel.
+// If no kinds are selected, the channel receives all notifications.
+// The title and message templates use Go template syntax with the fields "Kind", "Message" and "Time".
+//
+//	POST /api/v1/notification-channels
+func (c *Client) CreateNotificationChannel(ctx context.Context, body *Models_NotificationChannel) (*Models_NotificationChannel, error) {
+	var ret *Models_NotificationChannel
+	err := c.do(ctx, "POST", "/api/v1/notification-channels", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateNotificationChannel updates a notification channel.
+// The stored credentials are kept if the redacted values are sent back.
+//
+//	PATCH /api/v1/notification-channels/{id}
+func (c *Client) UpdateNotificationChannel(ctx context.Context, id int, body *Models_NotificationChannel) (*Models_NotificationChannel, error) {
+	var ret *Models_NotificationChannel
+	err := c.do(ctx, "PATCH", "/api/v1/notification-channels/"+url.PathEscape(fmt.Sprint(id)), nil, body, &ret)
+	return ret, err
+}
+
+// DeleteNotificationChannel deletes a notification channel.
+//
+//	DELETE /api/v1/notification-channels/{id}
+func (c *Client) DeleteNotificationChannel(ctx context.Context, id int) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/notification-channels/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// TestNotificationChannel sends a test notification to a channel.
+// The request waits for the channel to respond and returns its error, if any. The rate limit of the channel is ignored.
+//
+//	POST /api/v1/notification-channels/{id}/test
+func (c *Client) TestNotificationChannel(ctx context.Context, id int) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/notification-channels/"+url.PathEscape(fmt.Sprint(id))+"/test", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// notifications
+//
+
+// GetNotifications returns the notifications stored in the inbox.
+// The notifications are sorted from newest to oldest.
+// "total" is the number of notifications matching the filter, "unreadCount" is the number of unread notifications in the inbox.
+//
+//	POST /api/v1/notifications/list
+func (c *Client) GetNotifications(ctx context.Context, body *GetNotificationsRequest) (*NotificationInbox, error) {
+	var ret *NotificationInbox
+	err := c.do(ctx, "POST", "/api/v1/notifications/list", nil, body, &ret)
+	return ret, err
+}
+
+// GetUnreadNotificationCount returns the number of unread notifications.
+//
+//	GET /api/v1/notifications/unread-count
+func (c *Client) GetUnreadNotificationCount(ctx context.Context) (int, error) {
+	var ret int
+	err := c.do(ctx, "GET", "/api/v1/notifications/unread-count", nil, nil, &ret)
+	return ret, err
+}
+
+// MarkNotificationsRead marks the given notifications as read.
+// The new unread count is sent to the clients.
+//
+//	POST /api/v1/notifications/read
+func (c *Client) MarkNotificationsRead(ctx context.Context, body *MarkNotificationsReadRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/notifications/read", nil, body, &ret)
+	return ret, err
+}
+
+// MarkAllNotificationsRead marks all notifications as read.
+// The new unread count is sent to the clients.
+//
+//	POST /api/v1/notifications/read-all
+func (c *Client) MarkAllNotificationsRead(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/notifications/read-all", nil, nil, &ret)
+	return ret, err
+}
+
+// PruneNotifications deletes the notifications older than the given number of days.
+// If "readOnly" is true, unread notifications are kept. A value of 0 days deletes all matching notifications.
+// Returns the number of deleted notifications.
+//
+//	POST /api/v1/notifications/prune
+func (c *Client) PruneNotifications(ctx context.Context, body *PruneNotificationsRequest) (int, error) {
+	var ret int
+	err := c.do(ctx, "POST", "/api/v1/notifications/prune", nil, body, &ret)
+	return ret, err
+}
+
+//
+// onlinestream
+//
+
+// GetOnlineStreamEpisodeList returns the episode list for the given media and provider.
+// It returns the episode list for the given media and provider.
+// The episodes are cached using a file cache.
+// The episode list is just a list of episodes with no video sources, it's what the client uses to display the episodes and subsequently fetch the sources.
+// The episode list might be nil or empty if nothing could be found, but the media will always be returned.
+//
+//	POST /api/v1/onlinestream/episode-list
+func (c *Client) GetOnlineStreamEpisodeList(ctx context.Context, body *GetOnlineStreamEpisodeListRequest) (*Onlinestream_EpisodeListResponse, error) {
+	var ret *Onlinestream_EpisodeListResponse
+	err := c.do(ctx, "POST", "/api/v1/onlinestream/episode-list", nil, body, &ret)
+	return ret, err
+}
+
+// GetOnlineStreamEpisodeSource returns the video sources for the given media, episode number and provider.
+//
+//	POST /api/v1/onlinestream/episode-source
+func (c *Client) GetOnlineStreamEpisodeSource(ctx context.Context, body *GetOnlineStreamEpisodeSourceRequest) (*Onlinestream_EpisodeSource, error) {
+	var ret *Onlinestream_EpisodeSource
+	err := c.do(ctx, "POST", "/api/v1/onlinestream/episode-source", nil, body, &ret)
+	return ret, err
+}
+
+// OnlineStreamEmptyCache empties the cache for the given media.
+//
+//	DELETE /api/v1/onlinestream/cache
+func (c *Client) OnlineStreamEmptyCache(ctx context.Context, body *OnlineStreamEmptyCacheRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/onlinestream/cache", nil, body, &ret)
+	return ret, err
+}
+
+// OnlinestreamManualSearch returns search results for a manual search.
+// Returns search results for a manual search.
+//
+//	POST /api/v1/onlinestream/search
+func (c *Client) OnlinestreamManualSearch(ctx context.Context, body *OnlinestreamManualSearchRequest) ([]HibikeOnlinestream_SearchResult, error) {
+	var ret []HibikeOnlinestream_SearchResult
+	err := c.do(ctx, "POST", "/api/v1/onlinestream/search", nil, body, &ret)
+	return ret, err
+}
+
+// OnlinestreamManualMapping manually maps an anime entry to an anime ID from the provider.
+// This is used to manually map an anime entry to an anime ID from the provider.
+// The client should re-fetch the chapter container after this.
+//
+//	POST /api/v1/onlinestream/manual-mapping
+func (c *Client) OnlinestreamManualMapping(ctx context.Context, body *OnlinestreamManualMappingRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/onlinestream/manual-mapping", nil, body, &ret)
+	return ret, err
+}
+
+// GetOnlinestreamMapping returns the mapping for an anime entry.
+// This is used to get the mapping for an anime entry.
+// An empty string is returned if there's no manual mapping. If there is, the anime ID will be returned.
+//
+//	POST /api/v1/onlinestream/get-mapping
+func (c *Client) GetOnlinestreamMapping(ctx context.Context, body *GetOnlinestreamMappingRequest) (*Onlinestream_MappingResponse, error) {
+	var ret *Onlinestream_MappingResponse
+	err := c.do(ctx, "POST", "/api/v1/onlinestream/get-mapping", nil, body, &ret)
+	return ret, err
+}
+
+// RemoveOnlinestreamMapping removes the mapping for an anime entry.
+// This is used to remove the mapping for an anime entry.
+// The client should re-fetch the chapter container after this.
+//
+//	POST /api/v1/onlinestream/remove-mapping
+func (c *Client) RemoveOnlinestreamMapping(ctx context.Context, body *RemoveOnlinestreamMappingRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/onlinestream/remove-mapping", nil, body, &ret)
+	return ret, err
+}
+
+//
+// platform_mutations
+//
+
+// GetPlatformMutations returns the queued writes to the tracking platform.
+// Writes that failed are retried with a backoff, writes to the same media are collapsed.
+// Mutations that are still failing after the maximum number of attempts have the status "failed".
+//
+//	GET /api/v1/platform-mutations
+func (c *Client) GetPlatformMutations(ctx context.Context) ([]Models_PlatformMutation, error) {
+	var ret []Models_PlatformMutation
+	err := c.do(ctx, "GET", "/api/v1/platform-mutations", nil, nil, &ret)
+	return ret, err
+}
+
+// RetryPlatformMutation sends a queued write to the tracking platform right away.
+// The attempts are reset. If it fails again, it is retried with a backoff.
+//
+//	POST /api/v1/platform-mutations/{id}/retry
+func (c *Client) RetryPlatformMutation(ctx context.Context, id int) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/platform-mutations/"+url.PathEscape(fmt.Sprint(id))+"/retry", nil, nil, &ret)
+	return ret, err
+}
+
+// DiscardPlatformMutation deletes a queued write without sending it.
+//
+//	DELETE /api/v1/platform-mutations/{id}
+func (c *Client) DiscardPlatformMutation(ctx context.Context, id int) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/platform-mutations/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+//
+// playback_manager
+//
+
+// PlaybackPlayVideo plays the video with the given path using the default media player.
+// This tells the Playback Manager to play the video using the default media player and start tracking progress.
+// This returns 'true' if the video was successfully played.
+//
+//	POST /api/v1/playback-manager/play
+func (c *Client) PlaybackPlayVideo(ctx context.Context, body *PlaybackPlayVideoRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/play", nil, body, &ret)
+	return ret, err
+}
+
+// PlaybackPlayRandomVideo plays a random, unwatched video using the default media player.
+// This tells the Playback Manager to play a random, unwatched video using the media player and start tracking progress.
+// It respects the user's progress data and will prioritize "current" and "repeating" media if they are many of them.
+// This returns 'true' if the video was successfully played.
+//
+//	POST /api/v1/playback-manager/play-random
+func (c *Client) PlaybackPlayRandomVideo(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/play-random", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackSyncCurrentProgress updates the AniList progress of the currently playing media.
+// This is called after 'Update progress' is clicked when watching a media.
+// This route returns the media ID of the currently playing media, so the client can refetch the media entry data.
+//
+//	POST /api/v1/playback-manager/sync-current-progress
+func (c *Client) PlaybackSyncCurrentProgress(ctx context.Context) (int, error) {
+	var ret int
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/sync-current-progress", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackPlayNextEpisode plays the next episode of the currently playing media.
+// This will play the next episode of the currently playing media.
+// This is non-blocking so the client should prevent multiple calls until the next status is received.
+//
+//	POST /api/v1/playback-manager/next-episode
+func (c *Client) PlaybackPlayNextEpisode(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/next-episode", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackGetNextEpisode gets the next episode of the currently playing media.
+// This is used by the client's autoplay feature
+//
+//	GET /api/v1/playback-manager/next-episode
+func (c *Client) PlaybackGetNextEpisode(ctx context.Context) (*Anime_LocalFile, error) {
+	var ret *Anime_LocalFile
+	err := c.do(ctx, "GET", "/api/v1/playback-manager/next-episode", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackAutoPlayNextEpisode plays the next episode of the currently playing media.
+// This will play the next episode of the currently playing media.
+//
+//	POST /api/v1/playback-manager/autoplay-next-episode
+func (c *Client) PlaybackAutoPlayNextEpisode(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/autoplay-next-episode", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackStartPlaylist starts playing a playlist.
+// The client should refetch playlists.
+//
+//	POST /api/v1/playback-manager/start-playlist
+func (c *Client) PlaybackStartPlaylist(ctx context.Context, body *PlaybackStartPlaylistRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/start-playlist", nil, body, &ret)
+	return ret, err
+}
+
+// PlaybackCancelCurrentPlaylist ends the current playlist.
+// This will stop the current playlist. This is non-blocking.
+//
+//	POST /api/v1/playback-manager/cancel-playlist
+func (c *Client) PlaybackCancelCurrentPlaylist(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/cancel-playlist", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackPlaylistNext moves to the next item in the current playlist.
+// This is non-blocking so the client should prevent multiple calls until the next status is received.
+//
+//	POST /api/v1/playback-manager/playlist-next
+func (c *Client) PlaybackPlaylistNext(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/playlist-next", nil, nil, &ret)
+	return ret, err
+}
+
+// PlaybackStartManualTracking starts manual tracking of a media.
+// Used for tracking progress of media that is not played through any integrated media player.
+// This should only be used for trackable episodes (episodes that count towards progress).
+// This returns 'true' if the tracking was successfully started.
+//
+//	POST /api/v1/playback-manager/manual-tracking/start
+func (c *Client) PlaybackStartManualTracking(ctx context.Context, body *PlaybackStartManualTrackingRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/manual-tracking/start", nil, body, &ret)
+	return ret, err
+}
+
+// PlaybackCancelManualTracking cancels manual tracking of a media.
+// This will stop the server from expecting progress updates for the media.
+//
+//	POST /api/v1/playback-manager/manual-tracking/cancel
+func (c *Client) PlaybackCancelManualTracking(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/playback-manager/manual-tracking/cancel", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// playlist
+//
+
+// CreatePlaylist creates a new playlist.
+// This will create a new playlist with the given name and local file paths.
+// The response is ignored, the client should re-fetch the playlists after this.
+//
+//	POST /api/v1/playlist
+func (c *Client) CreatePlaylist(ctx context.Context, body *CreatePlaylistRequest) (*Anime_Playlist, error) {
+	var ret *Anime_Playlist
+	err := c.do(ctx, "POST", "/api/v1/playlist", nil, body, &ret)
+	return ret, err
+}
+
+// GetPlaylists returns all playlists.
+//
+//	GET /api/v1/playlists
+func (c *Client) GetPlaylists(ctx context.Context) ([]Anime_Playlist, error) {
+	var ret []Anime_Playlist
+	err := c.do(ctx, "GET", "/api/v1/playlists", nil, nil, &ret)
+	return ret, err
+}
+
+// UpdatePlaylist updates a playlist.
+// The response is ignored, the client should re-fetch the playlists after this.
+//
+//	PATCH /api/v1/playlist
+func (c *Client) UpdatePlaylist(ctx context.Context, id int, body *UpdatePlaylistRequest) (*Anime_Playlist, error) {
+	query := url.Values{}
+	query.Set("id", fmt.Sprint(id))
+	var ret *Anime_Playlist
+	err := c.do(ctx, "PATCH", "/api/v1/playlist", query, body, &ret)
+	return ret, err
+}
+
+// DeletePlaylist deletes a playlist.
+//
+//	DELETE /api/v1/playlist
+func (c *Client) DeletePlaylist(ctx context.Context, body *DeletePlaylistRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/playlist", nil, body, &ret)
+	return ret, err
+}
+
+// GetPlaylistEpisodes returns all the local files of a playlist media entry that have not been watched.
+//
+//	GET /api/v1/playlist/episodes/{id}/{progress}
+func (c *Client) GetPlaylistEpisodes(ctx context.Context, id int, progress int) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "GET", "/api/v1/playlist/episodes/"+url.PathEscape(fmt.Sprint(id))+"/"+url.PathEscape(fmt.Sprint(progress)), nil, nil, &ret)
+	return ret, err
+}
+
+//
+// profile
+//
+
+// GetProfiles returns all the profiles.
+// This route is accessible without selecting a profile so that the client can display the profile picker.
+//
+//	GET /api/v1/profiles
+func (c *Client) GetProfiles(ctx context.Context) ([]Models_Profile, error) {
+	var ret []Models_Profile
+	err := c.do(ctx, "GET", "/api/v1/profiles", nil, nil, &ret)
+	return ret, err
+}
+
+// GetCurrentProfile returns the profile making the request.
+//
+//	GET /api/v1/profiles/current
+func (c *Client) GetCurrentProfile(ctx context.Context) (*Models_Profile, error) {
+	var ret *Models_Profile
+	err := c.do(ctx, "GET", "/api/v1/profiles/current", nil, nil, &ret)
+	return ret, err
+}
+
+// CreateProfile creates a new profile.
+// The PIN is optional.
+//
+//	POST /api/v1/profiles
+func (c *Client) CreateProfile(ctx context.Context, body *CreateProfileRequest) (*Models_Profile, error) {
+	var ret *Models_Profile
+	err := c.do(ctx, "POST", "/api/v1/profiles", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateProfile updates the current profile.
+// The name and Discord settings are only updated if they are provided.
+// The Discord settings are ignored for the default profile, which uses the ones from the settings.
+//
+//	PATCH /api/v1/profiles
+func (c *Client) UpdateProfile(ctx context.Context, body *UpdateProfileRequest) (*Models_Profile, error) {
+	var ret *Models_Profile
+	err := c.do(ctx, "PATCH", "/api/v1/profiles", nil, body, &ret)
+	return ret, err
+}
+
+// SetProfilePin sets or removes the PIN of the current profile.
+// An empty PIN removes it. Other clients using the profile will need to select it again.
+// The new profile token is returned and set as a cookie.
+//
+//	PATCH /api/v1/profiles/pin
+func (c *Client) SetProfilePin(ctx context.Context, body *SetProfilePinRequest) (*ProfileSelection, error) {
+	var ret *ProfileSelection
+	err := c.do(ctx, "PATCH", "/api/v1/profiles/pin", nil, body, &ret)
+	return ret, err
+}
+
+// DeleteProfile deletes a profile and its data.
+// The PIN of the profile is required if it has one.
+// The default profile cannot be deleted.
+//
+//	DELETE /api/v1/profiles
+func (c *Client) DeleteProfile(ctx context.Context, body *DeleteProfileRequest) ([]Models_Profile, error) {
+	var ret []Models_Profile
+	err := c.do(ctx, "DELETE", "/api/v1/profiles", nil, body, &ret)
+	return ret, err
+}
+
+// SelectProfile selects the profile used by the client.
+// The PIN is required if the profile has one.
+// It returns a token that should be sent with each request, the token is also set as a cookie.
+//
+//	POST /api/v1/profiles/select
+func (c *Client) SelectProfile(ctx context.Context, body *SelectProfileRequest) (*ProfileSelection, error) {
+	var ret *ProfileSelection
+	err := c.do(ctx, "POST", "/api/v1/profiles/select", nil, body, &ret)
+	return ret, err
+}
+
+//
+// recommendation
+//
+
+// GetRecommendations returns anime or manga recommendations based on the collection.
+// Candidates are the AniList recommendations and follow-ups of the completed and high-scored entries, and the planned entries.
+// They are scored against the genres, tags and studios of these entries. Each recommendation comes with the reasons behind it.
+// 'type' is either 'anime' (default) or 'manga'. 'format', 'season' and 'seasonYear' filter the results, e.g. 'TV', 'WINTER' and '2024'.
+// The ranking is cached for a few hours, 'refresh=true' computes it again.
+//
+//	GET /api/v1/recommendations
+func (c *Client) GetRecommendations(ctx context.Context) ([]Recommendation, error) {
+	var ret []Recommendation
+	err := c.do(ctx, "GET", "/api/v1/recommendations", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// releases
+//
+
+// InstallLatestUpdate installs the latest update.
+// This will install the latest update and launch the new version.
+//
+//	POST /api/v1/install-update
+func (c *Client) InstallLatestUpdate(ctx context.Context, body *InstallLatestUpdateRequest) (json.RawMessage, error) {
+	var ret json.RawMessage
+	err := c.do(ctx, "POST", "/api/v1/install-update", nil, body, &ret)
+	return ret, err
+}
+
+// GetLatestUpdate returns the latest update.
+// This will return the latest update.
+// If an error occurs, it will return an empty update.
+//
+//	GET /api/v1/latest-update
+func (c *Client) GetLatestUpdate(ctx context.Context) (*Updater_Update, error) {
+	var ret *Updater_Update
+	err := c.do(ctx, "GET", "/api/v1/latest-update", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// scan
+//
+
+// ScanLocalFiles scans the user's library.
+// This will scan the user's library.
+// The response is ignored, the client should re-fetch the library after this.
+//
+//	POST /api/v1/library/scan
+func (c *Client) ScanLocalFiles(ctx context.Context, body *ScanLocalFilesRequest) ([]Anime_LocalFile, error) {
+	var ret []Anime_LocalFile
+	err := c.do(ctx, "POST", "/api/v1/library/scan", nil, body, &ret)
+	return ret, err
+}
+
+//
+// scan_summary
+//
+
+// GetScanSummaries returns the latest scan summaries.
+//
+//	GET /api/v1/library/scan-summaries
+func (c *Client) GetScanSummaries(ctx context.Context) ([]DB_ScanSummaryItem, error) {
+	var ret []DB_ScanSummaryItem
+	err := c.do(ctx, "GET", "/api/v1/library/scan-summaries", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// scheduler
+//
+
+// GetScheduledJobs returns the background jobs.
+// Each job contains its schedule, its last run and its next run.
+// The next run is not set if the job is disabled or cannot run in offline mode.
+//
+//	GET /api/v1/scheduler/jobs
+func (c *Client) GetScheduledJobs(ctx context.Context) ([]JobStatus, error) {
+	var ret []JobStatus
+	err := c.do(ctx, "GET", "/api/v1/scheduler/jobs", nil, nil, &ret)
+	return ret, err
+}
+
+// UpdateScheduledJob updates the schedule of a background job.
+// The schedule can be a cron expression (e.g. "0 */6 * * *"), a descriptor (e.g. "@daily", "@every 1h") or an interval (e.g. "30m").
+// An empty schedule resets the job to its default schedule.
+//
+//	PATCH /api/v1/scheduler/jobs/{name}
+func (c *Client) UpdateScheduledJob(ctx context.Context, name string, body *UpdateScheduledJobRequest) (*JobStatus, error) {
+	var ret *JobStatus
+	err := c.do(ctx, "PATCH", "/api/v1/scheduler/jobs/"+url.PathEscape(fmt.Sprint(name)), nil, body, &ret)
+	return ret, err
+}
+
+// RunScheduledJob runs a background job immediately.
+// The job runs in the background, even if it is disabled.
+// The client should re-fetch the jobs to see the result of the run.
+//
+//	POST /api/v1/scheduler/jobs/{name}/run
+func (c *Client) RunScheduledJob(ctx context.Context, name string) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/scheduler/jobs/"+url.PathEscape(fmt.Sprint(name))+"/run", nil, nil, &ret)
+	return ret, err
+}
+
+// GetScheduledJobRuns returns the latest runs of a background job.
+// The runs are sorted from newest to oldest.
+//
+//	GET /api/v1/scheduler/jobs/{name}/runs
+func (c *Client) GetScheduledJobRuns(ctx context.Context, name string) ([]Models_ScheduledJobRun, error) {
+	var ret []Models_ScheduledJobRun
+	err := c.do(ctx, "GET", "/api/v1/scheduler/jobs/"+url.PathEscape(fmt.Sprint(name))+"/runs", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// server_auth
+//
+
+// GetServerAuthStatus returns the server authentication status.
+// This route is accessible without a token so that the client knows if it needs to log in.
+//
+//	GET /api/v1/server-auth/status
+func (c *Client) GetServerAuthStatus(ctx context.Context) (*ServerAuthStatus, error) {
+	var ret *ServerAuthStatus
+	err := c.do(ctx, "GET", "/api/v1/server-auth/status", nil, nil, &ret)
+	return ret, err
+}
+
+// ServerAuthLogin logs in a device using the server password.
+// It returns a token that should be sent with each request.
+// The token is also set as a cookie so that the web interface does not need to handle it.
+//
+//	POST /api/v1/server-auth/login
+func (c *Client) ServerAuthLogin(ctx context.Context, body *ServerAuthLoginRequest) (*ServerAuthLoginResponse, error) {
+	var ret *ServerAuthLoginResponse
+	err := c.do(ctx, "POST", "/api/v1/server-auth/login", nil, body, &ret)
+	return ret, err
+}
+
+// ServerAuthLogout logs out the current device.
+// The device's token is revoked and the cookie is cleared.
+//
+//	POST /api/v1/server-auth/logout
+func (c *Client) ServerAuthLogout(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/server-auth/logout", nil, nil, &ret)
+	return ret, err
+}
+
+// GetServerAuthDevices returns the devices that are logged in.
+//
+//	GET /api/v1/server-auth/devices
+func (c *Client) GetServerAuthDevices(ctx context.Context) ([]Models_AuthDevice, error) {
+	var ret []Models_AuthDevice
+	err := c.do(ctx, "GET", "/api/v1/server-auth/devices", nil, nil, &ret)
+	return ret, err
+}
+
+// RevokeServerAuthDevice revokes the token of a device.
+// The device will need to log in again.
+//
+//	DELETE /api/v1/server-auth/device
+func (c *Client) RevokeServerAuthDevice(ctx context.Context, body *RevokeServerAuthDeviceRequest) ([]Models_AuthDevice, error) {
+	var ret []Models_AuthDevice
+	err := c.do(ctx, "DELETE", "/api/v1/server-auth/device", nil, body, &ret)
+	return ret, err
+}
+
+// CreateServerAuthCalendarFeedToken creates a token that can only be used to access the calendar feed.
+// The token is meant to be added to the feed's URL as the 'token' query parameter, it does not give access to any other route.
+// It is listed with the devices and can be revoked the same way.
+//
+//	POST /api/v1/server-auth/calendar-feed-token
+func (c *Client) CreateServerAuthCalendarFeedToken(ctx context.Context, body *CreateServerAuthCalendarFeedTokenRequest) (*ServerAuthCalendarFeedTokenResponse, error) {
+	var ret *ServerAuthCalendarFeedTokenResponse
+	err := c.do(ctx, "POST", "/api/v1/server-auth/calendar-feed-token", nil, body, &ret)
+	return ret, err
+}
+
+// SetServerPassword sets or removes the server password.
+// An empty password disables password protection.
+// All other devices are logged out when the password changes.
+// When the password is set for the first time, the current device is logged in and its token is returned.
+// This will fail if the password is defined in the config file.
+//
+//	PATCH /api/v1/server-auth/password
+func (c *Client) SetServerPassword(ctx context.Context, body *SetServerPasswordRequest) (*ServerAuthStatus, error) {
+	var ret *ServerAuthStatus
+	err := c.do(ctx, "PATCH", "/api/v1/server-auth/password", nil, body, &ret)
+	return ret, err
+}
+
+//
+// settings
+//
+
+// GetSettings returns the app settings.
+//
+//	GET /api/v1/settings
+func (c *Client) GetSettings(ctx context.Context) (*Models_Settings, error) {
+	var ret *Models_Settings
+	err := c.do(ctx, "GET", "/api/v1/settings", nil, nil, &ret)
+	return ret, err
+}
+
+// GettingStarted updates the app settings.
+// This will update the app settings.
+// The client should re-fetch the server status after this.
+//
+//	POST /api/v1/start
+func (c *Client) GettingStarted(ctx context.Context, body *GettingStartedRequest) (*Handlers_Status, error) {
+	var ret *Handlers_Status
+	err := c.do(ctx, "POST", "/api/v1/start", nil, body, &ret)
+	return ret, err
+}
+
+// SaveSettings updates the app settings.
+// This will update the app settings.
+// The client should re-fetch the server status after this.
+//
+//	PATCH /api/v1/settings
+func (c *Client) SaveSettings(ctx context.Context, body *SaveSettingsRequest) (*Handlers_Status, error) {
+	var ret *Handlers_Status
+	err := c.do(ctx, "PATCH", "/api/v1/settings", nil, body, &ret)
+	return ret, err
+}
+
+// SaveAutoDownloaderSettings updates the auto-downloader settings.
+//
+//	PATCH /api/v1/settings/auto-downloader
+func (c *Client) SaveAutoDownloaderSettings(ctx context.Context, body *SaveAutoDownloaderSettingsRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "PATCH", "/api/v1/settings/auto-downloader", nil, body, &ret)
+	return ret, err
+}
+
+//
+// status
+//
+
+// GetStatus returns the server status.
+// The server status includes app info, auth info and settings.
+// The client uses this to set the UI.
+// It is called on every page load to get the most up-to-date data.
+// It should be called right after updating the settings.
+//
+//	GET /api/v1/status
+func (c *Client) GetStatus(ctx context.Context) (*Handlers_Status, error) {
+	var ret *Handlers_Status
+	err := c.do(ctx, "GET", "/api/v1/status", nil, nil, &ret)
+	return ret, err
+}
+
+// GetLogFilenames returns the log filenames.
+// This returns the filenames of all log files in the logs directory.
+//
+//	GET /api/v1/logs/filenames
+func (c *Client) GetLogFilenames(ctx context.Context) ([]string, error) {
+	var ret []string
+	err := c.do(ctx, "GET", "/api/v1/logs/filenames", nil, nil, &ret)
+	return ret, err
+}
+
+// DeleteLogs deletes certain log files.
+// This deletes the log files with the given filenames.
+//
+//	DELETE /api/v1/logs
+func (c *Client) DeleteLogs(ctx context.Context, body *DeleteLogsRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/logs", nil, body, &ret)
+	return ret, err
+}
+
+//
+// sync
+//
+
+// SyncGetTrackedMediaItems gets all tracked media.
+//
+//	GET /api/v1/sync/track
+func (c *Client) SyncGetTrackedMediaItems(ctx context.Context) ([]Sync_TrackedMediaItem, error) {
+	var ret []Sync_TrackedMediaItem
+	err := c.do(ctx, "GET", "/api/v1/sync/track", nil, nil, &ret)
+	return ret, err
+}
+
+// SyncAddMedia adds one or multiple media to be tracked for offline sync.
+//
+//	POST /api/v1/sync/track
+func (c *Client) SyncAddMedia(ctx context.Context, body *SyncAddMediaRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/sync/track", nil, body, &ret)
+	return ret, err
+}
+
+// SyncRemoveMedia remove media from being tracked for offline sync.
+// This will remove anime from being tracked for offline sync and delete any associated data.
+//
+//	DELETE /api/v1/sync/track
+func (c *Client) SyncRemoveMedia(ctx context.Context, body *SyncRemoveMediaRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/sync/track", nil, body, &ret)
+	return ret, err
+}
+
+// SyncGetIsMediaTracked checks if media is being tracked for offline sync.
+//
+//	GET /api/v1/sync/track/{id}/{type}
+func (c *Client) SyncGetIsMediaTracked(ctx context.Context, id int, typeParam string) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "GET", "/api/v1/sync/track/"+url.PathEscape(fmt.Sprint(id))+"/"+url.PathEscape(fmt.Sprint(typeParam)), nil, nil, &ret)
+	return ret, err
+}
+
+// SyncLocalData syncs local data with AniList.
+//
+//	POST /api/v1/sync/local
+func (c *Client) SyncLocalData(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/sync/local", nil, nil, &ret)
+	return ret, err
+}
+
+// SyncGetQueueState gets the current sync queue state.
+// This will return the list of media that are currently queued for syncing.
+//
+//	GET /api/v1/sync/queue
+func (c *Client) SyncGetQueueState(ctx context.Context) (*Sync_QueueState, error) {
+	var ret *Sync_QueueState
+	err := c.do(ctx, "GET", "/api/v1/sync/queue", nil, nil, &ret)
+	return ret, err
+}
+
+// SyncAnilistData syncs AniList data with local.
+//
+//	POST /api/v1/sync/anilist
+func (c *Client) SyncAnilistData(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/sync/anilist", nil, nil, &ret)
+	return ret, err
+}
+
+// SyncSetHasLocalChanges sets the flag to determine if there are local changes that need to be synced with AniList.
+//
+//	POST /api/v1/sync/updated
+func (c *Client) SyncSetHasLocalChanges(ctx context.Context, body *SyncSetHasLocalChangesRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/sync/updated", nil, body, &ret)
+	return ret, err
+}
+
+// SyncGetHasLocalChanges gets the flag to determine if there are local changes that need to be synced with AniList.
+//
+//	GET /api/v1/sync/updated
+func (c *Client) SyncGetHasLocalChanges(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "GET", "/api/v1/sync/updated", nil, nil, &ret)
+	return ret, err
+}
+
+// SyncGetLocalStorageSize gets the size of the local storage in a human-readable format.
+//
+//	GET /api/v1/sync/storage/size
+func (c *Client) SyncGetLocalStorageSize(ctx context.Context) (string, error) {
+	var ret string
+	err := c.do(ctx, "GET", "/api/v1/sync/storage/size", nil, nil, &ret)
+	return ret, err
+}
+
+//
+// theme
+//
+
+// GetTheme returns the theme settings.
+//
+//	GET /api/v1/theme
+func (c *Client) GetTheme(ctx context.Context) (*Models_Theme, error) {
+	var ret *Models_Theme
+	err := c.do(ctx, "GET", "/api/v1/theme", nil, nil, &ret)
+	return ret, err
+}
+
+// UpdateTheme updates the theme settings.
+// The server status should be re-fetched after this on the client.
+//
+//	PATCH /api/v1/theme
+func (c *Client) UpdateTheme(ctx context.Context, body *UpdateThemeRequest) (*Models_Theme, error) {
+	var ret *Models_Theme
+	err := c.do(ctx, "PATCH", "/api/v1/theme", nil, body, &ret)
+	return ret, err
+}
+
+//
+// torrent_client
+//
+
+// GetActiveTorrentList returns all active torrents.
+// This handler is used by the client to display the active torrents.
+//
+//	GET /api/v1/torrent-client/list
+func (c *Client) GetActiveTorrentList(ctx context.Context) ([]TorrentClient_Torrent, error) {
+	var ret []TorrentClient_Torrent
+	err := c.do(ctx, "GET", "/api/v1/torrent-client/list", nil, nil, &ret)
+	return ret, err
+}
+
+// TorrentClientAction performs an action on a torrent.
+// This handler is used to pause, resume or remove a torrent.
+//
+//	POST /api/v1/torrent-client/action
+func (c *Client) TorrentClientAction(ctx context.Context, body *TorrentClientActionRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/torrent-client/action", nil, body, &ret)
+	return ret, err
+}
+
+// TorrentClientDownload adds torrents to the torrent client.
+// It fetches the magnets from the provided URLs and adds them to the torrent client.
+// If smart select is enabled, it will try to select the best torrent based on the missing episodes.
+//
+//	POST /api/v1/torrent-client/download
+func (c *Client) TorrentClientDownload(ctx context.Context, body *TorrentClientDownloadRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/torrent-client/download", nil, body, &ret)
+	return ret, err
+}
+
+// TorrentClientAddMagnetFromRule adds magnets to the torrent client based on the AutoDownloader item.
+// This is used to download torrents that were queued by the AutoDownloader.
+// The item will be removed from the queue if the magnet was added successfully.
+// The AutoDownloader items should be re-fetched after this.
+//
+//	POST /api/v1/torrent-client/rule-magnet
+func (c *Client) TorrentClientAddMagnetFromRule(ctx context.Context, body *TorrentClientAddMagnetFromRuleRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/torrent-client/rule-magnet", nil, body, &ret)
+	return ret, err
+}
+
+//
+// torrent_search
+//
+
+// SearchTorrent searches torrents and returns a list of torrents and their previews.
+// This will search for torrents and return a list of torrents with previews.
+// If smart search is enabled, it will filter the torrents based on search parameters.
+//
+//	POST /api/v1/torrent/search
+func (c *Client) SearchTorrent(ctx context.Context, body *SearchTorrentRequest) (*Torrent_SearchData, error) {
+	var ret *Torrent_SearchData
+	err := c.do(ctx, "POST", "/api/v1/torrent/search", nil, body, &ret)
+	return ret, err
+}
+
+//
+// torrentstream
+//
+
+// GetTorrentstreamEpisodeCollection get list of episodes
+// This returns a list of episodes.
+//
+//	GET /api/v1/torrentstream/episodes/{id}
+func (c *Client) GetTorrentstreamEpisodeCollection(ctx context.Context, id int) (*Torrentstream_EpisodeCollection, error) {
+	var ret *Torrentstream_EpisodeCollection
+	err := c.do(ctx, "GET", "/api/v1/torrentstream/episodes/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// GetTorrentstreamSettings get torrentstream settings.
+// This returns the torrentstream settings.
+//
+//	GET /api/v1/torrentstream/settings
+func (c *Client) GetTorrentstreamSettings(ctx context.Context) (*Models_TorrentstreamSettings, error) {
+	var ret *Models_TorrentstreamSettings
+	err := c.do(ctx, "GET", "/api/v1/torrentstream/settings", nil, nil, &ret)
+	return ret, err
+}
+
+// SaveTorrentstreamSettings save torrentstream settings.
+// This saves the torrentstream settings.
+// The client should refetch the server status.
+//
+//	PATCH /api/v1/torrentstream/settings
+func (c *Client) SaveTorrentstreamSettings(ctx context.Context, body *SaveTorrentstreamSettingsRequest) (*Models_TorrentstreamSettings, error) {
+	var ret *Models_TorrentstreamSettings
+	err := c.do(ctx, "PATCH", "/api/v1/torrentstream/settings", nil, body, &ret)
+	return ret, err
+}
+
+// GetTorrentstreamTorrentFilePreviews get list of torrent files from a batch
+// This returns a list of file previews from the torrent
+//
+//	POST /api/v1/torrentstream/torrent-file-previews
+func (c *Client) GetTorrentstreamTorrentFilePreviews(ctx context.Context, body *GetTorrentstreamTorrentFilePreviewsRequest) ([]Torrentstream_FilePreview, error) {
+	var ret []Torrentstream_FilePreview
+	err := c.do(ctx, "POST", "/api/v1/torrentstream/torrent-file-previews", nil, body, &ret)
+	return ret, err
+}
+
+// TorrentstreamStartStream starts a torrent stream.
+// This starts the entire streaming process.
+//
+//	POST /api/v1/torrentstream/start
+func (c *Client) TorrentstreamStartStream(ctx context.Context, body *TorrentstreamStartStreamRequest) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/torrentstream/start", nil, body, &ret)
+	return ret, err
+}
+
+// TorrentstreamStopStream stop a torrent stream.
+// This stops the entire streaming process and drops the torrent if it's below a threshold.
+// This is made to be used while the stream is running.
+//
+//	POST /api/v1/torrentstream/stop
+func (c *Client) TorrentstreamStopStream(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/torrentstream/stop", nil, nil, &ret)
+	return ret, err
+}
+
+// TorrentstreamDropTorrent drops a torrent stream.
+// This stops the entire streaming process and drops the torrent completely.
+// This is made to be used to force drop a torrent.
+//
+//	POST /api/v1/torrentstream/drop
+func (c *Client) TorrentstreamDropTorrent(ctx context.Context) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "POST", "/api/v1/torrentstream/drop", nil, nil, &ret)
+	return ret, err
+}
+
+// GetTorrentstreamBatchHistory returns the most recent batch selected.
+// This returns the most recent batch selected.
+//
+//	POST /api/v1/torrentstream/batch-history
+func (c *Client) GetTorrentstreamBatchHistory(ctx context.Context, body *GetTorrentstreamBatchHistoryRequest) (*Torrentstream_BatchHistoryResponse, error) {
+	var ret *Torrentstream_BatchHistoryResponse
+	err := c.do(ctx, "POST", "/api/v1/torrentstream/batch-history", nil, body, &ret)
+	return ret, err
+}
+
+//
+// watch_stats
+//
+
+// GetWatchStatsActivity returns the time watched per day or week.
+// 'interval' is either 'day' (default) or 'week', weeks start on Monday.
+// 'from' and 'to' (YYYY-MM-DD) are the first and last days included, the last 30 days or 12 weeks are returned by default.
+// 'timezone' is an IANA time zone name used to group the sessions by day, the server time zone is used by default.
+//
+//	GET /api/v1/watch-stats/activity
+func (c *Client) GetWatchStatsActivity(ctx context.Context) (*Watchstats_Activity, error) {
+	var ret *Watchstats_Activity
+	err := c.do(ctx, "GET", "/api/v1/watch-stats/activity", nil, nil, &ret)
+	return ret, err
+}
+
+// GetWatchStatsStreaks returns the current and longest watching streaks.
+// A streak is a run of consecutive days with at least one playback session.
+// 'timezone' is an IANA time zone name, the server time zone is used by default.
+//
+//	GET /api/v1/watch-stats/streaks
+func (c *Client) GetWatchStatsStreaks(ctx context.Context) (*Streaks, error) {
+	var ret *Streaks
+	err := c.do(ctx, "GET", "/api/v1/watch-stats/streaks", nil, nil, &ret)
+	return ret, err
+}
+
+// GetWatchStatsTop returns the most-watched anime, genres and studios.
+// 'from' and 'to' (YYYY-MM-DD) are the first and last days included, all the sessions are used by default.
+// 'limit' is the number of items in each list, 10 by default.
+//
+//	GET /api/v1/watch-stats/top
+func (c *Client) GetWatchStatsTop(ctx context.Context) (*Top, error) {
+	var ret *Top
+	err := c.do(ctx, "GET", "/api/v1/watch-stats/top", nil, nil, &ret)
+	return ret, err
+}
+
+// GetWatchStatsYearSummary returns the year-in-review report.
+// The report contains the time watched per month and per source, the busiest day, the longest streak and the most-watched anime, genres and studios.
+// 'timezone' is an IANA time zone name, the server time zone is used by default.
+//
+//	GET /api/v1/watch-stats/year/{year}
+func (c *Client) GetWatchStatsYearSummary(ctx context.Context, year int) (*YearSummary, error) {
+	var ret *YearSummary
+	err := c.do(ctx, "GET", "/api/v1/watch-stats/year/"+url.PathEscape(fmt.Sprint(year)), nil, nil, &ret)
+	return ret, err
+}
+
+//
+// webhooks
+//
+
+// GetWebhooks returns the webhooks.
+// The secrets are redacted.
+//
+//	GET /api/v1/webhooks
+func (c *Client) GetWebhooks(ctx context.Context) ([]Models_Webhook, error) {
+	var ret []Models_Webhook
+	err := c.do(ctx, "GET", "/api/v1/webhooks", nil, nil, &ret)
+	return ret, err
+}
+
+// GetWebhookEvents returns the events a webhook can subscribe to.
+//
+//	GET /api/v1/webhooks/events
+func (c *Client) GetWebhookEvents(ctx context.Context) ([]Webhook_Event, error) {
+	var ret []Webhook_Event
+	err := c.do(ctx, "GET", "/api/v1/webhooks/events", nil, nil, &ret)
+	return ret, err
+}
+
+// CreateWebhook creates a webhook.
+// If no events are selected, the webhook receives all events.
+// The payloads are signed with HMAC-SHA256 using the secret, the signature is sent in the "X-Seanime-Signature" header.
+//
+//	POST /api/v1/webhooks
+func (c *Client) CreateWebhook(ctx context.Context, body *Models_Webhook) (*Models_Webhook, error) {
+	var ret *Models_Webhook
+	err := c.do(ctx, "POST", "/api/v1/webhooks", nil, body, &ret)
+	return ret, err
+}
+
+// UpdateWebhook updates a webhook.
+// The stored secret is kept if the redacted secret is sent back.
+//
+//	PATCH /api/v1/webhooks/{id}
+func (c *Client) UpdateWebhook(ctx context.Context, id int, body *Models_Webhook) (*Models_Webhook, error) {
+	var ret *Models_Webhook
+	err := c.do(ctx, "PATCH", "/api/v1/webhooks/"+url.PathEscape(fmt.Sprint(id)), nil, body, &ret)
+	return ret, err
+}
+
+// DeleteWebhook deletes a webhook and its delivery log.
+//
+//	DELETE /api/v1/webhooks/{id}
+func (c *Client) DeleteWebhook(ctx context.Context, id int) (bool, error) {
+	var ret bool
+	err := c.do(ctx, "DELETE", "/api/v1/webhooks/"+url.PathEscape(fmt.Sprint(id)), nil, nil, &ret)
+	return ret, err
+}
+
+// TestWebhook sends a ping event to a webhook.
+// The request waits for the response of the webhook. Failed test deliveries are not retried.
+//
+//	POST /api/v1/webhooks/{id}/test
+func (c *Client) TestWebhook(ctx context.Context, id int) (*Models_WebhookDelivery, error) {
+	var ret *Models_WebhookDelivery
+	err := c.do(ctx, "POST", "/api/v1/webhooks/"+url.PathEscape(fmt.Sprint(id))+"/test", nil, nil, &ret)
+	return ret, err
+}
+
+// GetWebhookDeliveries returns the delivery log of a webhook.
+// The deliveries are sorted from newest to oldest.
+//
+//	GET /api/v1/webhooks/{id}/deliveries
+func (c *Client) GetWebhookDeliveries(ctx context.Context, id int) ([]Models_WebhookDelivery, error) {
+	var ret []Models_WebhookDelivery
+	err := c.do(ctx, "GET", "/api/v1/webhooks/"+url.PathEscape(fmt.Sprint(id))+"/deliveries", nil, nil, &ret)
+	return ret, err
+}
+
+// RedeliverWebhook sends the payload of a previous delivery again.
+// A new delivery is added to the log. The client should re-fetch the deliveries to see the result.
+//
+//	POST /api/v1/webhooks/deliveries/{id}/redeliver
+func (c *Client) RedeliverWebhook(ctx context.Context, id int) (*Models_WebhookDelivery, error) {
+	var ret *Models_WebhookDelivery
+	err := c.do(ctx, "POST", "/api/v1/webhooks/deliveries/"+url.PathEscape(fmt.Sprint(id))+"/redeliver", nil, nil, &ret)
+	return ret, err
+}
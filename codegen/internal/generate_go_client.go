@@ -0,0 +1,632 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	goClientPackageName       = "client"
+	goClientFileName          = "client.go"
+	goClientEndpointsFileName = "endpoints.go"
+	goClientTypesFileName     = "types.go"
+	goClientHeader            = "// Code generated by codegen/main.go. DO NOT EDIT.\n\n"
+)
+
+// goClientSkippedHandlers are the handlers that do not respond with the JSON envelope.
+var goClientSkippedHandlers = []string{
+	"HandleDownloadBackup",
+	"HandleGetCalendarFeed",
+	"HandleGetEventStream",
+	"HandleGetOpenAPISpec",
+}
+
+// goClientReservedNames are the identifiers declared by the client runtime.
+var goClientReservedNames = []string{
+	"APIError",
+	"Client",
+	"DefaultBaseURL",
+	"New",
+	"Option",
+	"WithHTTPClient",
+	"WithProfileToken",
+	"WithToken",
+}
+
+type (
+	// goClientGenerator resolves the Go types used by the handlers to the types declared by the client package.
+	goClientGenerator struct {
+		// e.g. map["models.User"]*GoStruct
+		goStructsMap map[string]*GoStruct
+		// Number of structs using the same formatted name, used to avoid collisions
+		formattedNameCount map[string]int
+		// Declarations of the generated types, by name
+		types map[string]string
+		// Names of the generated struct types, returned as pointers
+		structTypes map[string]struct{}
+		// Packages imported by the type declarations
+		typeImports map[string]struct{}
+	}
+
+	// goClientFile is the source of a generated file and the packages it imports.
+	goClientFile struct {
+		imports map[string]struct{}
+		body    strings.Builder
+	}
+)
+
+// GenerateGoClient generates a typed Go client package from the route handlers and the public structs.
+// Each route handler is a method of the client, and the structs they use are declared by the package.
+// Since the package only depends on the standard library, it can be used outside of this module.
+func GenerateGoClient(docsFilePath string, publicStructsFilePath string, outDir string) {
+
+	handlers := LoadHandlers(docsFilePath)
+
+	goStructs := LoadPublicStructs(publicStructsFilePath)
+
+	g := &goClientGenerator{
+		goStructsMap:       make(map[string]*GoStruct),
+		formattedNameCount: make(map[string]int),
+		types:              make(map[string]string),
+		structTypes:        make(map[string]struct{}),
+		typeImports:        make(map[string]struct{}),
+	}
+
+	for _, goStruct := range goStructs {
+		g.goStructsMap[goStruct.Package+"."+goStruct.Name] = goStruct
+	}
+	for _, goStruct := range g.goStructsMap {
+		g.formattedNameCount[goStruct.FormattedName]++
+	}
+	for _, name := range goClientReservedNames {
+		g.formattedNameCount[name]++
+	}
+
+	// Expand the structs with embedded structs
+	for _, goStruct := range goStructs {
+		for _, embeddedStructType := range goStruct.EmbeddedStructTypes {
+			if embeddedStructType != "" {
+				if usedStruct, ok := g.goStructsMap[embeddedStructType]; ok {
+					goStruct.Fields = append(goStruct.Fields, usedStruct.Fields...)
+				}
+			}
+		}
+	}
+
+	// Group the handlers by file
+	groupedByFile := make(map[string][]*RouteHandler)
+	filenames := make([]string, 0)
+	for _, handler := range handlers {
+		if handler.Api == nil || handler.Api.Endpoint == "" || len(handler.Api.Methods) == 0 {
+			continue
+		}
+		if slices.Contains(goClientSkippedHandlers, handler.Name) {
+			continue
+		}
+		if _, ok := groupedByFile[handler.Filename]; !ok {
+			filenames = append(filenames, handler.Filename)
+		}
+		groupedByFile[handler.Filename] = append(groupedByFile[handler.Filename], handler)
+	}
+	sort.Strings(filenames)
+
+	endpointsFile := newGoClientFile("context")
+	requestsFile := newGoClientFile()
+
+	for _, filename := range filenames {
+		endpointsFile.body.WriteString("//\n// " + strings.TrimSuffix(filename, ".go") + "\n//\n\n")
+
+		for _, handler := range groupedByFile[filename] {
+			for _, method := range handler.Api.Methods {
+				method = strings.ToUpper(strings.TrimSpace(method))
+
+				name := handler.TrimmedName
+				if len(handler.Api.Methods) > 1 {
+					name += method[:1] + strings.ToLower(method[1:])
+				}
+
+				g.writeEndpoint(endpointsFile, requestsFile, handler, name, method)
+			}
+		}
+	}
+
+	// Declare the types used by the endpoints, sorted by name
+	typesFile := newGoClientFile()
+	typeNames := make([]string, 0, len(g.types))
+	for name := range g.types {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		typesFile.body.WriteString(g.types[name])
+	}
+	// The request bodies are declared after the shared types
+	typesFile.body.WriteString(requestsFile.body.String())
+	for imp := range requestsFile.imports {
+		typesFile.imports[imp] = struct{}{}
+	}
+	for imp := range g.typeImports {
+		typesFile.imports[imp] = struct{}{}
+	}
+
+	_ = os.MkdirAll(outDir, os.ModePerm)
+	writeGoClientFile(filepath.Join(outDir, goClientFileName), goClientRuntime)
+	writeGoClientFile(filepath.Join(outDir, goClientEndpointsFileName), endpointsFile.source())
+	writeGoClientFile(filepath.Join(outDir, goClientTypesFileName), typesFile.source())
+
+	fmt.Println("Go client saved to", outDir)
+}
+
+// writeEndpoint writes the client method of a route handler.
+//
+//	func (c *Client) UpdateWebhook(ctx context.Context, id int, body *Models_Webhook) (*Models_Webhook, error)
+func (g *goClientGenerator) writeEndpoint(f *goClientFile, requestsFile *goClientFile, handler *RouteHandler, name string, method string) {
+	args := []string{"ctx context.Context"}
+	usedNames := map[string]struct{}{"c": {}, "ctx": {}, "body": {}, "query": {}, "ret": {}, "err": {}}
+
+	pathExpr := ""
+	hasQuery := false
+	queryLines := make([]string, 0)
+
+	// Path and query parameters
+	paramNames := make(map[string]string)
+	for _, param := range handler.Api.Params {
+		argName := goClientArgName(param.JsonName, usedNames)
+		paramNames[param.Name] = argName
+		args = append(args, argName+" "+g.goType(param.GoType, "handlers", f.imports))
+		if !strings.Contains(handler.Api.Endpoint, "{"+param.Name+"}") {
+			hasQuery = true
+			queryLines = append(queryLines, fmt.Sprintf("\tquery.Set(%q, fmt.Sprint(%s))\n", param.JsonName, argName))
+		}
+	}
+
+	// e.g. "/api/v1/webhooks/{id}/test" => "/api/v1/webhooks/" + url.PathEscape(fmt.Sprint(id)) + "/test"
+	rest := handler.Api.Endpoint
+	parts := make([]string, 0)
+	for {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start == -1 || end < start {
+			break
+		}
+		if start > 0 {
+			parts = append(parts, fmt.Sprintf("%q", rest[:start]))
+		}
+		argName, ok := paramNames[rest[start+1:end]]
+		if !ok {
+			// Undocumented path parameter
+			argName = goClientArgName(rest[start+1:end], usedNames)
+			args = append(args, argName+" string")
+		}
+		parts = append(parts, fmt.Sprintf("url.PathEscape(fmt.Sprint(%s))", argName))
+		f.imports["fmt"] = struct{}{}
+		f.imports["net/url"] = struct{}{}
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	pathExpr = strings.Join(parts, " + ")
+
+	// Request body
+	bodyArg := "nil"
+	if len(handler.Api.BodyFields) > 0 {
+		requestName := name + "Request"
+		args = append(args, "body *"+requestName)
+		bodyArg = "body"
+		g.writeRequest(requestsFile, handler, requestName)
+	} else if handler.Api.BodyType != "" && method != "GET" {
+		args = append(args, "body "+g.pointerType(g.goType(handler.Api.BodyType, "handlers", f.imports)))
+		bodyArg = "body"
+	}
+
+	// Return type
+	retType := "bool"
+	switch handler.Api.Returns {
+	case "", "bool", "boolean", "true", "false":
+	default:
+		retType = g.pointerType(g.goType(handler.Api.Returns, "handlers", f.imports))
+	}
+
+	// Doc comment
+	summary := strings.TrimSpace(handler.Api.Summary)
+	if summary == "" {
+		summary = "calls the " + handler.Api.Endpoint + " route."
+	}
+	f.body.WriteString("// " + name + " " + summary + "\n")
+	for _, desc := range handler.Api.Descriptions {
+		if desc = strings.TrimSpace(desc); desc != "" {
+			f.body.WriteString("// " + desc + "\n")
+		}
+	}
+	f.body.WriteString("//\n//\t" + method + " " + handler.Api.Endpoint + "\n")
+
+	f.body.WriteString(fmt.Sprintf("func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(args, ", "), retType))
+	queryArg := "nil"
+	if hasQuery {
+		f.imports["fmt"] = struct{}{}
+		f.imports["net/url"] = struct{}{}
+		queryArg = "query"
+		f.body.WriteString("\tquery := url.Values{}\n")
+		for _, line := range queryLines {
+			f.body.WriteString(line)
+		}
+	}
+	f.body.WriteString("\tvar ret " + retType + "\n")
+	f.body.WriteString(fmt.Sprintf("\terr := c.do(ctx, %q, %s, %s, %s, &ret)\n", method, pathExpr, queryArg, bodyArg))
+	f.body.WriteString("\treturn ret, err\n")
+	f.body.WriteString("}\n\n")
+}
+
+// writeRequest writes the struct of a request body.
+func (g *goClientGenerator) writeRequest(f *goClientFile, handler *RouteHandler, requestName string) {
+	f.body.WriteString(fmt.Sprintf("// %s is the request body of %s.\n", requestName, strings.TrimSuffix(requestName, "Request")))
+	f.body.WriteString("type " + requestName + " struct {\n")
+	for _, field := range handler.Api.BodyFields {
+		for _, cmt := range field.Descriptions {
+			if cmt = strings.TrimSpace(cmt); cmt != "" {
+				f.body.WriteString("\t// " + cmt + "\n")
+			}
+		}
+		goType := g.goType(qualifyGoType(field.GoType, field.UsedStructType), "handlers", f.imports)
+		if !field.Required {
+			goType = g.pointerType(goType)
+		}
+		f.body.WriteString(fmt.Sprintf("\t%s %s %s\n", field.Name, goType, goClientJsonTag(field.JsonName, field.Required)))
+	}
+	f.body.WriteString("}\n\n")
+}
+
+// pointerType returns a pointer to the type if it is a generated struct.
+func (g *goClientGenerator) pointerType(goType string) string {
+	if _, ok := g.structTypes[goType]; ok {
+		return "*" + goType
+	}
+	return goType
+}
+
+// goType returns the client type of a Go type, e.g. "[]models.User" => "[]Models_User".
+// Types without a package prefix are resolved in the given package.
+// Types that are not declared by the client, e.g. from a dependency, are decoded as raw JSON.
+func (g *goClientGenerator) goType(goType string, packageName string, imports map[string]struct{}) string {
+	goType = strings.TrimSpace(goType)
+
+	if strings.HasPrefix(goType, "*") {
+		return "*" + g.goType(goType[1:], packageName, imports)
+	}
+
+	if goType == "[]byte" {
+		return goType
+	}
+
+	if strings.HasPrefix(goType, "[]") {
+		return "[]" + g.goType(goType[2:], packageName, imports)
+	}
+
+	if strings.HasPrefix(goType, "map[") {
+		// Find the closing bracket of the key type
+		depth := 0
+		for i := 3; i < len(goType); i++ {
+			switch goType[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					return "map[" + g.goType(goType[4:i], packageName, imports) + "]" + g.goType(goType[i+1:], packageName, imports)
+				}
+			}
+		}
+		return "map[string]interface{}"
+	}
+
+	switch goType {
+	case "string", "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "byte", "rune":
+		return goType
+	case "boolean", "true", "false":
+		return "bool"
+	case "number":
+		return "int"
+	case "float":
+		return "float64"
+	case "time.Time", "time.Duration":
+		imports["time"] = struct{}{}
+		return goType
+	case "", "interface{}", "any", "null", "nil":
+		return "interface{}"
+	}
+
+	key := goType
+	if !strings.Contains(key, ".") {
+		key = packageName + "." + key
+	}
+	if goStruct, ok := g.goStructsMap[key]; ok {
+		return g.declareType(goStruct)
+	}
+
+	// Unknown type, e.g. from a dependency
+	imports["encoding/json"] = struct{}{}
+	return "json.RawMessage"
+}
+
+// declareType adds the declaration of the struct to the generated types and returns its name.
+func (g *goClientGenerator) declareType(goStruct *GoStruct) string {
+	name := goStruct.FormattedName
+	if g.formattedNameCount[name] > 1 {
+		// e.g. "Manifest" is declared by several packages without a type prefix
+		name = strings.ReplaceAll(goStruct.Package, "_", "") + "_" + goStruct.Name
+	}
+	// The types are exported, e.g. "handlers_Status" => "Handlers_Status"
+	name = string(unicode.ToUpper(rune(name[0]))) + name[1:]
+
+	if _, ok := g.types[name]; ok {
+		return name
+	}
+	// Added before resolving the fields so that recursive types terminate
+	g.types[name] = ""
+
+	imports := g.typeImports
+
+	var b strings.Builder
+	for _, cmt := range goStruct.Comments {
+		if cmt = strings.TrimSpace(cmt); cmt != "" {
+			b.WriteString("// " + cmt + "\n")
+		}
+	}
+
+	if goStruct.AliasOf != nil {
+		aliasType := g.goType(qualifyGoType(goStruct.AliasOf.GoType, goStruct.AliasOf.UsedStructType), goStruct.Package, imports)
+		if aliasType == name {
+			aliasType = "json.RawMessage"
+		}
+		b.WriteString("type " + name + " " + aliasType + "\n\n")
+		g.types[name] = b.String()
+		return name
+	}
+
+	g.structTypes[name] = struct{}{}
+
+	b.WriteString("type " + name + " struct {\n")
+	added := make(map[string]struct{})
+	for _, field := range goStruct.Fields {
+		if !field.Public || field.JsonName == "-" || field.JsonName == "" {
+			continue
+		}
+		// Fields of embedded structs are shadowed by the fields of the struct
+		if _, ok := added[field.Name]; ok {
+			continue
+		}
+		added[field.Name] = struct{}{}
+
+		for _, cmt := range field.Comments {
+			if cmt = strings.TrimSpace(cmt); cmt != "" {
+				b.WriteString("\t// " + cmt + "\n")
+			}
+		}
+		fieldType := g.goType(qualifyGoType(field.GoType, field.UsedStructType), goStruct.Package, imports)
+		if !field.Required {
+			fieldType = g.pointerType(fieldType)
+		}
+		b.WriteString(fmt.Sprintf("\t%s %s %s\n", field.Name, fieldType, goClientJsonTag(field.JsonName, field.Required)))
+	}
+	b.WriteString("}\n\n")
+
+	g.types[name] = b.String()
+	return name
+}
+
+// goClientArgName returns a unique argument name for a parameter, e.g. "type" => "typeParam".
+func goClientArgName(name string, usedNames map[string]struct{}) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	if _, ok := usedNames[name]; ok || token.IsKeyword(name) {
+		name += "Param"
+	}
+	usedNames[name] = struct{}{}
+	return name
+}
+
+func goClientJsonTag(jsonName string, required bool) string {
+	if required {
+		return fmt.Sprintf("`json:\"%s\"`", jsonName)
+	}
+	return fmt.Sprintf("`json:\"%s,omitempty\"`", jsonName)
+}
+
+func newGoClientFile(imports ...string) *goClientFile {
+	f := &goClientFile{imports: make(map[string]struct{})}
+	for _, imp := range imports {
+		f.imports[imp] = struct{}{}
+	}
+	return f
+}
+
+func (f *goClientFile) source() string {
+	var b strings.Builder
+	b.WriteString(goClientHeader)
+	b.WriteString("package " + goClientPackageName + "\n\n")
+	if len(f.imports) > 0 {
+		imports := make([]string, 0, len(f.imports))
+		for imp := range f.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		b.WriteString("import (\n")
+		for _, imp := range imports {
+			b.WriteString(fmt.Sprintf("\t%q\n", imp))
+		}
+		b.WriteString(")\n\n")
+	}
+	b.WriteString(f.body.String())
+	return b.String()
+}
+
+// writeGoClientFile formats and writes a generated file.
+func writeGoClientFile(path string, src string) {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		// Write the unformatted source to help debugging
+		fmt.Println("Error:", path, err)
+		formatted = []byte(src)
+	}
+	if err := os.WriteFile(path, formatted, 0644); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
+
+// goClientRuntime is the part of the client package that does not depend on the route handlers.
+const goClientRuntime = goClientHeader + `// Package client is a typed client for the Seanime API.
+//
+// Every route handler is a method of the Client, e.g.
+//
+//	c := client.New(client.DefaultBaseURL, client.WithToken(token))
+//	rules, err := c.GetAutoDownloaderRules(ctx)
+//
+// The package is generated from the route handlers, a change of the API breaks its users at compile time.
+package ` + goClientPackageName + `
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the address of a server running locally on the default port.
+const DefaultBaseURL = "http://127.0.0.1:43211"
+
+type (
+	// Client sends requests to a Seanime server.
+	// It is safe for concurrent use once configured.
+	Client struct {
+		baseURL      string
+		token        string
+		profileToken string
+		httpClient   *http.Client
+	}
+
+	// Option configures a Client.
+	Option func(c *Client)
+
+	// APIError is returned when the server responds with an error.
+	APIError struct {
+		StatusCode int
+		Message    string
+	}
+
+	// envelope is the body of every response, {"data": ...} on success and {"error": "..."} on failure.
+	envelope struct {
+		Error string          ` + "`json:\"error,omitempty\"`" + `
+		Data  json.RawMessage ` + "`json:\"data,omitempty\"`" + `
+	}
+)
+
+// New creates a client for the server at the base URL, e.g. "http://127.0.0.1:43211".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithToken sets the device token sent with every request, required when a server password is set.
+// The token is returned by ServerAuthLogin.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithProfileToken sets the token of the profile making the requests.
+// Requests without a profile token use the default profile.
+func WithProfileToken(token string) Option {
+	return func(c *Client) {
+		c.profileToken = token
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("seanime: %s (status %d)", e.Message, e.StatusCode)
+}
+
+// do sends the request and decodes the data of the response into out.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.profileToken != "" {
+		req.Header.Set("X-Seanime-Profile", c.profileToken)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var env envelope
+	if err := json.NewDecoder(res.Body).Decode(&env); err != nil && err != io.EOF {
+		if res.StatusCode >= 400 {
+			return &APIError{StatusCode: res.StatusCode, Message: res.Status}
+		}
+		return fmt.Errorf("seanime: failed to decode response: %w", err)
+	}
+
+	if env.Error != "" || res.StatusCode >= 400 {
+		message := env.Error
+		if message == "" {
+			message = res.Status
+		}
+		return &APIError{StatusCode: res.StatusCode, Message: message}
+	}
+
+	if out == nil || len(env.Data) == 0 || string(env.Data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+`
@@ -0,0 +1,103 @@
+package codegen
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGoClientGoType(t *testing.T) {
+	g := &goClientGenerator{
+		goStructsMap: map[string]*GoStruct{
+			"models.User": {
+				Name:          "User",
+				FormattedName: "Models_User",
+				Package:       "models",
+				Fields: []*GoStructField{
+					{Name: "ID", JsonName: "id", GoType: "int", Required: true, Public: true},
+					{Name: "Friends", JsonName: "friends", GoType: "[]User", UsedStructType: "models.User", Public: true},
+					{Name: "Parent", JsonName: "parent", GoType: "User", UsedStructType: "models.User", Public: true},
+					{Name: "CreatedAt", JsonName: "createdAt", GoType: "time.Time", Required: true, Public: true},
+					{Name: "password", JsonName: "password", GoType: "string", Public: false},
+					{Name: "Internal", JsonName: "-", GoType: "string", Public: true},
+				},
+			},
+			"handlers.Status": {
+				Name:          "Status",
+				FormattedName: "Status",
+				Package:       "handlers",
+				AliasOf:       &GoAlias{GoType: "string"},
+			},
+		},
+		formattedNameCount: map[string]int{"Models_User": 1, "Status": 2},
+		types:              make(map[string]string),
+		structTypes:        make(map[string]struct{}),
+		typeImports:        make(map[string]struct{}),
+	}
+
+	imports := make(map[string]struct{})
+	require.Equal(t, "map[string][]*Models_User", g.goType("map[string][]*models.User", "handlers", imports))
+	require.Empty(t, imports)
+
+	// Recursive struct, optional structs are pointers
+	require.Equal(t, "type Models_User struct {\n"+
+		"\tID int `json:\"id\"`\n"+
+		"\tFriends []Models_User `json:\"friends,omitempty\"`\n"+
+		"\tParent *Models_User `json:\"parent,omitempty\"`\n"+
+		"\tCreatedAt time.Time `json:\"createdAt\"`\n"+
+		"}\n\n", g.types["Models_User"])
+	require.Contains(t, g.typeImports, "time")
+
+	// Colliding names are prefixed with the package
+	require.Equal(t, "Handlers_Status", g.goType("Status", "handlers", imports))
+	require.Equal(t, "type Handlers_Status string\n\n", g.types["Handlers_Status"])
+
+	require.Equal(t, "bool", g.goType("boolean", "handlers", imports))
+	require.Equal(t, "json.RawMessage", g.goType("zerolog.Logger", "handlers", imports))
+	require.Contains(t, imports, "encoding/json")
+}
+
+func TestGoClientWriteEndpoint(t *testing.T) {
+	g := &goClientGenerator{
+		goStructsMap:       make(map[string]*GoStruct),
+		formattedNameCount: make(map[string]int),
+		types:              make(map[string]string),
+		structTypes:        make(map[string]struct{}),
+		typeImports:        make(map[string]struct{}),
+	}
+
+	handler := &RouteHandler{
+		Name:        "HandleSyncGetIsMediaTracked",
+		TrimmedName: "SyncGetIsMediaTracked",
+		Api: &RouteHandlerApi{
+			Summary:  "checks if media is being tracked.",
+			Endpoint: "/api/v1/sync/track/{id}/{type}",
+			Methods:  []string{"POST"},
+			Params: []*RouteHandlerParam{
+				{Name: "id", JsonName: "id", GoType: "int", Required: true},
+				{Name: "type", JsonName: "type", GoType: "string", Required: true},
+				{Name: "page", JsonName: "page", GoType: "int"},
+			},
+			BodyFields: []*RouteHandlerParam{
+				{Name: "Force", JsonName: "force", GoType: "bool"},
+			},
+			Returns: "bool",
+		},
+	}
+
+	f := newGoClientFile("context")
+	requestsFile := newGoClientFile()
+	g.writeEndpoint(f, requestsFile, handler, handler.TrimmedName, "POST")
+
+	require.Equal(t, "// SyncGetIsMediaTracked checks if media is being tracked.\n"+
+		"//\n"+
+		"//\tPOST /api/v1/sync/track/{id}/{type}\n"+
+		"func (c *Client) SyncGetIsMediaTracked(ctx context.Context, id int, typeParam string, page int, body *SyncGetIsMediaTrackedRequest) (bool, error) {\n"+
+		"\tquery := url.Values{}\n"+
+		"\tquery.Set(\"page\", fmt.Sprint(page))\n"+
+		"\tvar ret bool\n"+
+		"\terr := c.do(ctx, \"POST\", \"/api/v1/sync/track/\" + url.PathEscape(fmt.Sprint(id)) + \"/\" + url.PathEscape(fmt.Sprint(typeParam)), query, body, &ret)\n"+
+		"\treturn ret, err\n"+
+		"}\n\n", f.body.String())
+	require.Contains(t, f.imports, "net/url")
+	require.Contains(t, requestsFile.body.String(), "type SyncGetIsMediaTrackedRequest struct {\n\tForce bool `json:\"force,omitempty\"`\n}")
+}
@@ -28,6 +28,7 @@ type (
 		Methods              []string             `json:"methods"`
 		Params               []*RouteHandlerParam `json:"params"`
 		BodyFields           []*RouteHandlerParam `json:"bodyFields"`
+		BodyType             string               `json:"bodyType,omitempty"` // e.g., models.Webhook, set when the body is parsed into a declared type
 		Returns              string               `json:"returns"`
 		ReturnGoType         string               `json:"returnGoType"`
 		ReturnTypescriptType string               `json:"returnTypescriptType"`
@@ -239,6 +240,11 @@ func GenerateHandlers(dir string, outDir string) {
 				}
 			}
 
+			bodyType := ""
+			if body != nil && len(bodyFields) == 0 {
+				bodyType = getBodyParserType(body)
+			}
+
 			// Add the route handler
 			routeHandler := &RouteHandler{
 				Name:        name,
@@ -253,6 +259,7 @@ func GenerateHandlers(dir string, outDir string) {
 					Methods:              methods,
 					Params:               params,
 					BodyFields:           bodyFields,
+					BodyType:             bodyType,
 					Returns:              returns,
 					ReturnGoType:         getUnformattedGoType(returns),
 					ReturnTypescriptType: stringGoTypeToTypescriptType(returns),
@@ -287,3 +294,61 @@ func GenerateHandlers(dir string, outDir string) {
 
 	return
 }
+
+// getBodyParserType returns the type of the variable the request body is parsed into, e.g. "models.Webhook".
+//
+//	var b models.Webhook
+//	if err := c.Fiber.BodyParser(&b); err != nil {
+func getBodyParserType(body *ast.BlockStmt) string {
+	// Variables declared in the function body
+	varTypes := make(map[string]ast.Expr)
+	for _, stmt := range body.List {
+		declStmt, ok := stmt.(*ast.DeclStmt)
+		if !ok {
+			continue
+		}
+		genDecl, ok := declStmt.Decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || valueSpec.Type == nil {
+				continue
+			}
+			for _, name := range valueSpec.Names {
+				varTypes[name.Name] = valueSpec.Type
+			}
+		}
+	}
+
+	ret := ""
+	ast.Inspect(body, func(n ast.Node) bool {
+		if ret != "" {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "BodyParser" {
+			return true
+		}
+		arg, ok := call.Args[0].(*ast.UnaryExpr)
+		if !ok || arg.Op != token.AND {
+			return true
+		}
+		ident, ok := arg.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		// Local "body" structs are described by the body fields
+		if t, ok := varTypes[ident.Name]; ok && fieldTypeString(t) != "body" {
+			ret = fieldTypeString(t)
+		}
+		return true
+	})
+
+	return ret
+}
@@ -0,0 +1,495 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"seanime/internal/constants"
+	"strconv"
+	"strings"
+)
+
+const (
+	openAPIFileName = "openapi.json"
+)
+
+// pathParamRegex matches the path parameters of an endpoint, e.g. "{id}"
+var pathParamRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+type (
+	OpenAPISpec struct {
+		OpenAPI    string                                  `json:"openapi"`
+		Info       *OpenAPIInfo                            `json:"info"`
+		Servers    []*OpenAPIServer                        `json:"servers"`
+		Security   []map[string][]string                   `json:"security"`
+		Tags       []*OpenAPITag                           `json:"tags"`
+		Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+		Components *OpenAPIComponents                      `json:"components"`
+	}
+
+	OpenAPIInfo struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	}
+
+	OpenAPIServer struct {
+		URL string `json:"url"`
+	}
+
+	OpenAPITag struct {
+		Name string `json:"name"`
+	}
+
+	OpenAPIOperation struct {
+		OperationID string                      `json:"operationId"`
+		Summary     string                      `json:"summary,omitempty"`
+		Description string                      `json:"description,omitempty"`
+		Tags        []string                    `json:"tags"`
+		Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+		RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*OpenAPIResponse `json:"responses"`
+	}
+
+	OpenAPIParameter struct {
+		Name        string         `json:"name"`
+		In          string         `json:"in"` // "path" or "query"
+		Description string         `json:"description,omitempty"`
+		Required    bool           `json:"required"`
+		Schema      *OpenAPISchema `json:"schema"`
+	}
+
+	OpenAPIRequestBody struct {
+		Required bool                         `json:"required"`
+		Content  map[string]*OpenAPIMediaType `json:"content"`
+	}
+
+	OpenAPIResponse struct {
+		Ref         string                       `json:"$ref,omitempty"`
+		Description string                       `json:"description,omitempty"`
+		Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+	}
+
+	OpenAPIMediaType struct {
+		Schema *OpenAPISchema `json:"schema"`
+	}
+
+	OpenAPISchema struct {
+		Ref                  string                    `json:"$ref,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Format               string                    `json:"format,omitempty"`
+		Description          string                    `json:"description,omitempty"`
+		Items                *OpenAPISchema            `json:"items,omitempty"`
+		Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+		AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+		Required             []string                  `json:"required,omitempty"`
+		Enum                 []interface{}             `json:"enum,omitempty"`
+		AllOf                []*OpenAPISchema          `json:"allOf,omitempty"`
+	}
+
+	OpenAPIComponents struct {
+		Schemas         map[string]*OpenAPISchema         `json:"schemas"`
+		Responses       map[string]*OpenAPIResponse       `json:"responses"`
+		SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes"`
+	}
+
+	OpenAPISecurityScheme struct {
+		Type        string `json:"type"`
+		Scheme      string `json:"scheme,omitempty"`
+		In          string `json:"in,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// openAPIGenerator resolves the Go types used by the handlers to OpenAPI schemas.
+	openAPIGenerator struct {
+		// e.g. map["models.User"]*GoStruct
+		goStructsMap map[string]*GoStruct
+		// Number of structs using the same formatted name, used to avoid collisions
+		formattedNameCount map[string]int
+		schemas            map[string]*OpenAPISchema
+	}
+)
+
+// GenerateOpenAPISpec generates an OpenAPI 3 document from the route handlers and the public structs.
+// The response schemas follow the SeaResponse envelope of the handlers package.
+func GenerateOpenAPISpec(docsFilePath string, publicStructsFilePath string, outDir string) {
+
+	handlers := LoadHandlers(docsFilePath)
+
+	goStructs := LoadPublicStructs(publicStructsFilePath)
+
+	g := &openAPIGenerator{
+		goStructsMap:       make(map[string]*GoStruct),
+		formattedNameCount: make(map[string]int),
+		schemas:            make(map[string]*OpenAPISchema),
+	}
+
+	for _, goStruct := range goStructs {
+		g.goStructsMap[goStruct.Package+"."+goStruct.Name] = goStruct
+	}
+	for _, goStruct := range g.goStructsMap {
+		g.formattedNameCount[goStruct.FormattedName]++
+	}
+
+	// Expand the structs with embedded structs
+	for _, goStruct := range goStructs {
+		for _, embeddedStructType := range goStruct.EmbeddedStructTypes {
+			if embeddedStructType != "" {
+				if usedStruct, ok := g.goStructsMap[embeddedStructType]; ok {
+					goStruct.Fields = append(goStruct.Fields, usedStruct.Fields...)
+				}
+			}
+		}
+	}
+
+	spec := &OpenAPISpec{
+		OpenAPI: "3.0.3",
+		Info: &OpenAPIInfo{
+			Title:       "Seanime",
+			Description: "Every response is wrapped in an envelope: {\"data\": ...} on success, {\"error\": \"...\"} on failure.",
+			Version:     constants.Version,
+		},
+		Servers: []*OpenAPIServer{{URL: "/"}},
+		Security: []map[string][]string{
+			{},
+			{"bearerAuth": {}},
+			{"tokenHeader": {}},
+			{"tokenQuery": {}},
+		},
+		Tags:  make([]*OpenAPITag, 0),
+		Paths: make(map[string]map[string]*OpenAPIOperation),
+		Components: &OpenAPIComponents{
+			Schemas: g.schemas,
+			Responses: map[string]*OpenAPIResponse{
+				"Error": {
+					Description: "The request failed",
+					Content: map[string]*OpenAPIMediaType{
+						"application/json": {Schema: &OpenAPISchema{
+							Type: "object",
+							Properties: map[string]*OpenAPISchema{
+								"error": {Type: "string"},
+							},
+							Required: []string{"error"},
+						}},
+					},
+				},
+			},
+			SecuritySchemes: map[string]*OpenAPISecurityScheme{
+				"bearerAuth": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "Server password token, required when a server password is set",
+				},
+				"tokenHeader": {
+					Type: "apiKey",
+					In:   "header",
+					Name: "X-Seanime-Token",
+				},
+				"tokenQuery": {
+					Type: "apiKey",
+					In:   "query",
+					Name: "token",
+				},
+			},
+		},
+	}
+
+	addedTags := make(map[string]struct{})
+
+	for _, handler := range handlers {
+		if handler.Api == nil || handler.Api.Endpoint == "" || len(handler.Api.Methods) == 0 {
+			continue
+		}
+
+		tag := strings.TrimSuffix(handler.Filename, ".go")
+		if _, ok := addedTags[tag]; !ok {
+			addedTags[tag] = struct{}{}
+			spec.Tags = append(spec.Tags, &OpenAPITag{Name: tag})
+		}
+
+		path, ok := spec.Paths[handler.Api.Endpoint]
+		if !ok {
+			path = make(map[string]*OpenAPIOperation)
+			spec.Paths[handler.Api.Endpoint] = path
+		}
+
+		for _, method := range handler.Api.Methods {
+			method = strings.ToLower(strings.TrimSpace(method))
+
+			operationId := handler.TrimmedName
+			if len(handler.Api.Methods) > 1 {
+				operationId += strings.ToUpper(method[:1]) + method[1:]
+			}
+
+			path[method] = g.operation(handler, operationId, tag)
+		}
+	}
+
+	// Write the document
+	_ = os.MkdirAll(outDir, os.ModePerm)
+	file, err := os.Create(filepath.Join(outDir, openAPIFileName))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(spec); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("OpenAPI specification saved to openapi.json")
+}
+
+func (g *openAPIGenerator) operation(handler *RouteHandler, operationId string, tag string) *OpenAPIOperation {
+	op := &OpenAPIOperation{
+		OperationID: operationId,
+		Summary:     handler.Api.Summary,
+		Description: strings.Join(handler.Api.Descriptions, "\n"),
+		Tags:        []string{tag},
+		Responses: map[string]*OpenAPIResponse{
+			"200": {
+				Description: "OK",
+				Content: map[string]*OpenAPIMediaType{
+					"application/json": {Schema: &OpenAPISchema{
+						Type: "object",
+						Properties: map[string]*OpenAPISchema{
+							"data": g.schemaForGoType(handler.Api.Returns, "handlers"),
+						},
+					}},
+				},
+			},
+			"500": {Ref: "#/components/responses/Error"},
+		},
+	}
+
+	for _, param := range handler.Api.Params {
+		in := "query"
+		if strings.Contains(handler.Api.Endpoint, "{"+param.Name+"}") {
+			in = "path"
+		}
+		op.Parameters = append(op.Parameters, &OpenAPIParameter{
+			Name:        param.JsonName,
+			In:          in,
+			Description: strings.Join(param.Descriptions, "\n"),
+			Required:    param.Required || in == "path",
+			Schema:      g.schemaForGoType(param.GoType, "handlers"),
+		})
+	}
+
+	// Path parameters are required by OpenAPI, even if they are not documented
+	for _, match := range pathParamRegex.FindAllStringSubmatch(handler.Api.Endpoint, -1) {
+		found := false
+		for _, param := range op.Parameters {
+			if param.In == "path" && param.Name == match[1] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			op.Parameters = append(op.Parameters, &OpenAPIParameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &OpenAPISchema{Type: "string"},
+			})
+		}
+	}
+
+	if len(handler.Api.BodyFields) > 0 {
+		body := &OpenAPISchema{
+			Type:       "object",
+			Properties: make(map[string]*OpenAPISchema),
+		}
+		for _, field := range handler.Api.BodyFields {
+			schema := g.schemaForGoType(qualifyGoType(field.GoType, field.UsedStructType), "handlers")
+			if description := strings.TrimSpace(strings.Join(field.Descriptions, "\n")); description != "" {
+				schema = withDescription(schema, description)
+			}
+			body.Properties[field.JsonName] = schema
+			if field.Required {
+				body.Required = append(body.Required, field.JsonName)
+			}
+		}
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]*OpenAPIMediaType{
+				"application/json": {Schema: body},
+			},
+		}
+	} else if handler.Api.BodyType != "" {
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]*OpenAPIMediaType{
+				"application/json": {Schema: g.schemaForGoType(handler.Api.BodyType, "handlers")},
+			},
+		}
+	}
+
+	return op
+}
+
+// schemaForGoType returns the schema of a Go type, e.g. "[]models.User".
+// Types without a package prefix are resolved in the given package.
+func (g *openAPIGenerator) schemaForGoType(goType string, packageName string) *OpenAPISchema {
+	goType = strings.TrimSpace(goType)
+
+	if strings.HasPrefix(goType, "*") {
+		return g.schemaForGoType(goType[1:], packageName)
+	}
+
+	if goType == "[]byte" {
+		return &OpenAPISchema{Type: "string", Format: "byte"}
+	}
+
+	if strings.HasPrefix(goType, "[]") {
+		return &OpenAPISchema{Type: "array", Items: g.schemaForGoType(goType[2:], packageName)}
+	}
+
+	if strings.HasPrefix(goType, "map[") {
+		// Find the closing bracket of the key type
+		depth := 0
+		for i := 3; i < len(goType); i++ {
+			switch goType[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					return &OpenAPISchema{Type: "object", AdditionalProperties: g.schemaForGoType(goType[i+1:], packageName)}
+				}
+			}
+		}
+		return &OpenAPISchema{Type: "object"}
+	}
+
+	switch goType {
+	case "string":
+		return &OpenAPISchema{Type: "string"}
+	case "bool", "boolean", "true", "false":
+		return &OpenAPISchema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "byte", "rune", "number":
+		return &OpenAPISchema{Type: "integer"}
+	case "int64", "uint64", "time.Duration":
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case "float", "float32", "float64":
+		return &OpenAPISchema{Type: "number"}
+	case "time.Time":
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case "", "interface{}", "any", "json.RawMessage", "null", "nil":
+		return &OpenAPISchema{}
+	}
+
+	key := goType
+	if !strings.Contains(key, ".") {
+		key = packageName + "." + key
+	}
+	if goStruct, ok := g.goStructsMap[key]; ok {
+		return &OpenAPISchema{Ref: "#/components/schemas/" + g.componentSchema(goStruct)}
+	}
+
+	// Unknown type, e.g. from a dependency
+	return &OpenAPISchema{}
+}
+
+// componentSchema adds the schema of the struct to the components and returns its name.
+func (g *openAPIGenerator) componentSchema(goStruct *GoStruct) string {
+	name := goStruct.FormattedName
+	if g.formattedNameCount[name] > 1 {
+		// e.g. "Manifest" is declared by several packages without a type prefix
+		name = strings.ReplaceAll(goStruct.Package, "_", "") + "_" + goStruct.Name
+	}
+
+	if _, ok := g.schemas[name]; ok {
+		return name
+	}
+
+	schema := &OpenAPISchema{}
+	// Added before resolving the fields so that recursive types terminate
+	g.schemas[name] = schema
+
+	comments := make([]string, 0, len(goStruct.Comments))
+	for _, cmt := range goStruct.Comments {
+		comments = append(comments, strings.TrimSpace(cmt))
+	}
+	schema.Description = strings.TrimSpace(strings.Join(comments, "\n"))
+
+	if goStruct.AliasOf != nil {
+		alias := g.schemaForGoType(qualifyGoType(goStruct.AliasOf.GoType, goStruct.AliasOf.UsedStructType), goStruct.Package)
+		if alias.Ref != "" {
+			// Siblings of $ref are ignored
+			schema.AllOf = []*OpenAPISchema{{Ref: alias.Ref}}
+		}
+		schema.Type = alias.Type
+		schema.Format = alias.Format
+		schema.Items = alias.Items
+		schema.AdditionalProperties = alias.AdditionalProperties
+		for _, value := range goStruct.AliasOf.DeclaredValues {
+			schema.Enum = append(schema.Enum, parseDeclaredValue(value))
+		}
+		return name
+	}
+
+	schema.Type = "object"
+	schema.Properties = make(map[string]*OpenAPISchema)
+	for _, field := range goStruct.Fields {
+		if !field.Public || field.JsonName == "-" || field.JsonName == "" {
+			continue
+		}
+		fieldSchema := g.schemaForGoType(qualifyGoType(field.GoType, field.UsedStructType), goStruct.Package)
+		if len(field.Comments) > 0 && fieldSchema.Ref == "" {
+			comments := make([]string, 0, len(field.Comments))
+			for _, cmt := range field.Comments {
+				comments = append(comments, strings.TrimSpace(cmt))
+			}
+			fieldSchema.Description = strings.Join(comments, "\n")
+		}
+		schema.Properties[field.JsonName] = fieldSchema
+		if field.Required {
+			schema.Required = append(schema.Required, field.JsonName)
+		}
+	}
+
+	return name
+}
+
+// withDescription returns the schema with the description.
+// References are wrapped in allOf since the siblings of $ref are ignored.
+func withDescription(schema *OpenAPISchema, description string) *OpenAPISchema {
+	if schema.Ref != "" {
+		return &OpenAPISchema{AllOf: []*OpenAPISchema{schema}, Description: description}
+	}
+	schema.Description = description
+	return schema
+}
+
+// qualifyGoType replaces the base type of a Go type with the used struct type.
+//
+//	e.g. qualifyGoType("[]User", "anilist.User") => "[]anilist.User"
+func qualifyGoType(goType string, usedStructType string) string {
+	if usedStructType == "" {
+		return goType
+	}
+	base := getUnformattedGoType(goType)
+	return strings.TrimSuffix(goType, base) + usedStructType
+}
+
+// parseDeclaredValue converts a declared constant value to a JSON value, e.g. "\"CURRENT\"" => "CURRENT".
+func parseDeclaredValue(value string) interface{} {
+	if s, err := strconv.Unquote(value); err == nil {
+		return s
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
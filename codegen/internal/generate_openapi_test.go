@@ -0,0 +1,97 @@
+package codegen
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSchemaForGoType(t *testing.T) {
+	g := &openAPIGenerator{
+		goStructsMap: map[string]*GoStruct{
+			"models.User": {
+				Name:          "User",
+				FormattedName: "Models_User",
+				Package:       "models",
+				Fields: []*GoStructField{
+					{Name: "ID", JsonName: "id", GoType: "int", Required: true, Public: true},
+					{Name: "Friends", JsonName: "friends", GoType: "[]User", UsedStructType: "models.User", Public: true},
+					{Name: "password", JsonName: "password", GoType: "string", Public: false},
+					{Name: "Internal", JsonName: "-", GoType: "string", Public: true},
+				},
+			},
+			"models.Admin": {
+				Name:          "Admin",
+				FormattedName: "Models_Admin",
+				Package:       "models",
+				AliasOf:       &GoAlias{GoType: "User"},
+			},
+			"models.Status": {
+				Name:          "Status",
+				FormattedName: "Models_Status",
+				Package:       "models",
+				AliasOf: &GoAlias{
+					GoType:         "string",
+					DeclaredValues: []string{"\"active\"", "\"banned\""},
+				},
+			},
+		},
+		formattedNameCount: map[string]int{"Models_User": 1, "Models_Admin": 1, "Models_Status": 1},
+		schemas:            make(map[string]*OpenAPISchema),
+	}
+
+	schema := g.schemaForGoType("map[string][]*models.User", "handlers")
+	require.Equal(t, "object", schema.Type)
+	require.Equal(t, "array", schema.AdditionalProperties.Type)
+	require.Equal(t, "#/components/schemas/Models_User", schema.AdditionalProperties.Items.Ref)
+
+	// Recursive struct
+	user := g.schemas["Models_User"]
+	require.Len(t, user.Properties, 2)
+	require.Equal(t, []string{"id"}, user.Required)
+	require.Equal(t, "#/components/schemas/Models_User", user.Properties["friends"].Items.Ref)
+
+	// Unqualified types are resolved in the given package
+	require.Equal(t, "#/components/schemas/Models_Status", g.schemaForGoType("Status", "models").Ref)
+	require.Equal(t, []interface{}{"active", "banned"}, g.schemas["Models_Status"].Enum)
+
+	// Aliases of structs are wrapped in allOf, siblings of $ref are ignored
+	require.Equal(t, "#/components/schemas/Models_Admin", g.schemaForGoType("models.Admin", "handlers").Ref)
+	admin := g.schemas["Models_Admin"]
+	require.Empty(t, admin.Ref)
+	require.Equal(t, "#/components/schemas/Models_User", admin.AllOf[0].Ref)
+
+	require.Equal(t, "date-time", g.schemaForGoType("time.Time", "models").Format)
+	require.Equal(t, "boolean", g.schemaForGoType("bool", "handlers").Type)
+	require.Empty(t, g.schemaForGoType("zerolog.Logger", "handlers").Ref)
+}
+
+func TestOperationPathParams(t *testing.T) {
+	g := &openAPIGenerator{
+		goStructsMap:       make(map[string]*GoStruct),
+		formattedNameCount: make(map[string]int),
+		schemas:            make(map[string]*OpenAPISchema),
+	}
+
+	op := g.operation(&RouteHandler{
+		Name: "HandleGetItem",
+		Api: &RouteHandlerApi{
+			Endpoint: "/api/v1/items/{id}/{name}",
+			Params: []*RouteHandlerParam{
+				{Name: "id", JsonName: "id", GoType: "int", Required: true},
+			},
+		},
+	}, "HandleGetItem", "items")
+
+	// Undocumented path parameters are added
+	require.Len(t, op.Parameters, 2)
+	require.Equal(t, "integer", op.Parameters[0].Schema.Type)
+	require.Equal(t, "name", op.Parameters[1].Name)
+	require.Equal(t, "path", op.Parameters[1].In)
+	require.True(t, op.Parameters[1].Required)
+}
+
+func TestQualifyGoType(t *testing.T) {
+	require.Equal(t, "[]anilist.User", qualifyGoType("[]User", "anilist.User"))
+	require.Equal(t, "map[string]*anilist.User", qualifyGoType("map[string]*User", "anilist.User"))
+	require.Equal(t, "int", qualifyGoType("int", ""))
+}
@@ -1,4 +1,4 @@
-//go:generate go run main.go --skipHandlers=false --skipStructs=false --skipTypes=false
+//go:generate go run main.go --skipHandlers=false --skipStructs=false --skipTypes=false --skipOpenAPI=false --skipGoClient=false
 
 package main
 
@@ -18,6 +18,12 @@ func main() {
 	var skipTypes bool
 	flag.BoolVar(&skipTypes, "skipTypes", false, "Skip generating types")
 
+	var skipOpenAPI bool
+	flag.BoolVar(&skipOpenAPI, "skipOpenAPI", false, "Skip generating the OpenAPI specification")
+
+	var skipGoClient bool
+	flag.BoolVar(&skipGoClient, "skipGoClient", false, "Skip generating the Go client")
+
 	flag.Parse()
 
 	if !skipHandlers {
@@ -33,4 +39,12 @@ func main() {
 		codegen.GenerateTypescriptFile("./generated/handlers.json", "./generated/public_structs.json", "../seanime-web/src/api/generated", goStructStrs)
 	}
 
+	if !skipOpenAPI {
+		codegen.GenerateOpenAPISpec("./generated/handlers.json", "./generated/public_structs.json", "./generated")
+	}
+
+	if !skipGoClient {
+		codegen.GenerateGoClient("./generated/handlers.json", "./generated/public_structs.json", "./generated/client")
+	}
+
 }
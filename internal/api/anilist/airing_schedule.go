@@ -0,0 +1,80 @@
+package anilist
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+const airingSchedulesQuery = `query AiringSchedules ($page: Int, $mediaIds: [Int], $airingAt_greater: Int, $airingAt_lesser: Int) {
+	Page(page: $page, perPage: 50) {
+		pageInfo {
+			hasNextPage
+		}
+		airingSchedules(mediaId_in: $mediaIds, airingAt_greater: $airingAt_greater, airingAt_lesser: $airingAt_lesser, sort: TIME) {
+			mediaId
+			episode
+			airingAt
+		}
+	}
+}`
+
+// maxAiringSchedulePages caps the number of requests made by FetchAiringSchedules
+const maxAiringSchedulePages = 20
+
+type AiringEpisode struct {
+	MediaID  int   `json:"mediaId"`
+	Episode  int   `json:"episode"`
+	AiringAt int64 `json:"airingAt"`
+}
+
+// FetchAiringSchedules returns the episodes of the given media that air between airingAtGreater and airingAtLesser (unix timestamps).
+func FetchAiringSchedules(mediaIds []int, airingAtGreater int64, airingAtLesser int64, logger *zerolog.Logger) ([]*AiringEpisode, error) {
+	ret := make([]*AiringEpisode, 0)
+	if len(mediaIds) == 0 {
+		return ret, nil
+	}
+
+	for page := 1; page <= maxAiringSchedulePages; page++ {
+		requestBody, err := json.Marshal(map[string]interface{}{
+			"query": airingSchedulesQuery,
+			"variables": map[string]interface{}{
+				"page":             page,
+				"mediaIds":         mediaIds,
+				"airingAt_greater": airingAtGreater,
+				"airingAt_lesser":  airingAtLesser,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := customQuery(requestBody, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var res struct {
+			Page struct {
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+				AiringSchedules []*AiringEpisode `json:"airingSchedules"`
+			} `json:"Page"`
+		}
+		if err := json.Unmarshal(m, &res); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, res.Page.AiringSchedules...)
+		if !res.Page.PageInfo.HasNextPage {
+			break
+		}
+	}
+
+	return ret, nil
+}
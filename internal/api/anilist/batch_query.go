@@ -0,0 +1,142 @@
+package anilist
+
+import (
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"strings"
+)
+
+// batchQuerySize is the maximum number of media returned by a page.
+const batchQuerySize = 50
+
+// FetchBaseAnimeByMalIDs returns the anime with the given MyAnimeList IDs, keyed by MAL ID.
+// Anime that are not on AniList are omitted.
+func FetchBaseAnimeByMalIDs(malIds []int, logger *zerolog.Logger) (map[int]*BaseAnime, error) {
+	media, err := fetchMediaBatch[BaseAnime](malIds, "idMal_in", "ANIME", "baseAnime", BaseAnimeByIDDocument, logger)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[int]*BaseAnime, len(media))
+	for _, m := range media {
+		if m.GetIDMal() != nil {
+			ret[*m.GetIDMal()] = m
+		}
+	}
+	return ret, nil
+}
+
+// FetchBaseMangaByMalIDs returns the manga with the given MyAnimeList IDs, keyed by MAL ID.
+// Manga that are not on AniList are omitted.
+func FetchBaseMangaByMalIDs(malIds []int, logger *zerolog.Logger) (map[int]*BaseManga, error) {
+	media, err := fetchMediaBatch[BaseManga](malIds, "idMal_in", "MANGA", "baseManga", BaseMangaByIDDocument, logger)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[int]*BaseManga, len(media))
+	for _, m := range media {
+		if m.GetIDMal() != nil {
+			ret[*m.GetIDMal()] = m
+		}
+	}
+	return ret, nil
+}
+
+// FetchBaseAnimeByIDs returns the anime with the given AniList IDs, keyed by ID.
+func FetchBaseAnimeByIDs(ids []int, logger *zerolog.Logger) (map[int]*BaseAnime, error) {
+	media, err := fetchMediaBatch[BaseAnime](ids, "id_in", "ANIME", "baseAnime", BaseAnimeByIDDocument, logger)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[int]*BaseAnime, len(media))
+	for _, m := range media {
+		ret[m.GetID()] = m
+	}
+	return ret, nil
+}
+
+// FetchBaseMangaByIDs returns the manga with the given AniList IDs, keyed by ID.
+func FetchBaseMangaByIDs(ids []int, logger *zerolog.Logger) (map[int]*BaseManga, error) {
+	media, err := fetchMediaBatch[BaseManga](ids, "id_in", "MANGA", "baseManga", BaseMangaByIDDocument, logger)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[int]*BaseManga, len(media))
+	for _, m := range media {
+		ret[m.GetID()] = m
+	}
+	return ret, nil
+}
+
+// FetchCompleteAnimeByIDs returns the anime with the given AniList IDs and their relations, keyed by ID.
+func FetchCompleteAnimeByIDs(ids []int, logger *zerolog.Logger) (map[int]*CompleteAnime, error) {
+	media, err := fetchMediaBatch[CompleteAnime](ids, "id_in", "ANIME", "completeAnime", CompleteAnimeByIDDocument, logger)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[int]*CompleteAnime, len(media))
+	for _, m := range media {
+		ret[m.GetID()] = m
+	}
+	return ret, nil
+}
+
+// fetchMediaBatch fetches the media matching the IDs, 50 at a time.
+// The fragments are taken from the generated document, e.g. "fragment baseAnime on Media { ... }".
+func fetchMediaBatch[T any](ids []int, filter string, mediaType string, fragmentName string, document string, logger *zerolog.Logger) ([]*T, error) {
+	ret := make([]*T, 0, len(ids))
+	if len(ids) == 0 {
+		return ret, nil
+	}
+
+	fragmentIdx := strings.Index(document, "fragment ")
+	if fragmentIdx == -1 {
+		return nil, fmt.Errorf("anilist: no fragment in document")
+	}
+
+	query := fmt.Sprintf(`query MediaBatch ($ids: [Int]) {
+	Page(page: 1, perPage: %d) {
+		media(%s: $ids, type: %s) {
+			...%s
+		}
+	}
+}
+%s`, batchQuerySize, filter, mediaType, fragmentName, document[fragmentIdx:])
+
+	for i := 0; i < len(ids); i += batchQuerySize {
+		end := min(i+batchQuerySize, len(ids))
+
+		requestBody, err := json.Marshal(map[string]interface{}{
+			"query": query,
+			"variables": map[string]interface{}{
+				"ids": ids[i:end],
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := customQuery(requestBody, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var res struct {
+			Page struct {
+				Media []*T `json:"media"`
+			} `json:"Page"`
+		}
+		if err := json.Unmarshal(m, &res); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, res.Page.Media...)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,247 @@
+package anilist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/goccy/go-json"
+	"seanime/internal/util/filecache"
+	"strconv"
+	"time"
+)
+
+type (
+	// CachedAnilistClient caches the responses of the read queries.
+	// Updating a list entry invalidates the cached responses of its media.
+	// DeleteEntry is not intercepted since it only knows the ID of the list entry, which no response is keyed by.
+	CachedAnilistClient struct {
+		AnilistClient
+		fileCacher *filecache.Cacher
+		// viewer identifies the user the requests are authenticated as, it is empty for anonymous requests
+		viewer string
+	}
+
+	// CachePolicy decides how long the responses of a query are cached.
+	CachePolicy struct {
+		TTL time.Duration
+		// ByMedia is true if the responses are keyed by media ID and invalidated by the mutations of the media.
+		ByMedia bool
+		// ByViewer is true if the responses depend on the user, e.g. the characters they favourited.
+		// They are cached separately for each user.
+		ByViewer bool
+	}
+)
+
+// CachePolicies are the policies of the cached queries, queries without a policy are never cached.
+// The collections, the viewer and the mutations are never cached.
+var CachePolicies = map[string]CachePolicy{
+	"BaseAnimeByID":        {TTL: time.Hour, ByMedia: true},
+	"BaseAnimeByMalID":     {TTL: 24 * time.Hour},
+	"CompleteAnimeByID":    {TTL: time.Hour, ByMedia: true},
+	"AnimeDetailsByID":     {TTL: 6 * time.Hour, ByMedia: true, ByViewer: true},
+	"BaseMangaByID":        {TTL: time.Hour, ByMedia: true},
+	"MangaDetailsByID":     {TTL: 6 * time.Hour, ByMedia: true, ByViewer: true},
+	"StudioDetails":        {TTL: 24 * time.Hour},
+	"SearchBaseAnimeByIds": {TTL: time.Hour},
+	"SearchBaseManga":      {TTL: 15 * time.Minute},
+	"ListAnime":            {TTL: 15 * time.Minute},
+	"ListManga":            {TTL: 15 * time.Minute},
+}
+
+// NewCachedAnilistClient wraps the client with a response cache.
+// The cache is shared by the clients using the same file cacher.
+func NewCachedAnilistClient(client AnilistClient, fileCacher *filecache.Cacher) *CachedAnilistClient {
+	ret := &CachedAnilistClient{
+		AnilistClient: client,
+		fileCacher:    fileCacher,
+	}
+	// The token is hashed so that it doesn't end up in the cache files
+	if impl, ok := client.(*AnilistClientImpl); ok && impl.token != "" {
+		ret.viewer = cacheKey(impl.token)
+	}
+	return ret
+}
+
+func cacheBucket(query string, policy CachePolicy) filecache.Bucket {
+	return filecache.NewBucket("anilist_query_"+query, policy.TTL)
+}
+
+// cacheKey returns the key of the query's variables.
+func cacheKey(variables ...interface{}) string {
+	data, _ := json.Marshal(variables)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// policyKey returns the key of the response in the query's bucket.
+func (c *CachedAnilistClient) policyKey(policy CachePolicy, key string) string {
+	if policy.ByViewer && c.viewer != "" {
+		return c.viewer + "_" + key
+	}
+	return key
+}
+
+// cached returns the cached response of the query or fetches it.
+// Requests with interceptors are not cached since they can change the response.
+func cached[T any](c *CachedAnilistClient, query string, key string, interceptors []clientv2.RequestInterceptor, fetch func() (*T, error)) (*T, error) {
+	policy, found := CachePolicies[query]
+	if !found || c.fileCacher == nil || len(interceptors) > 0 {
+		return fetch()
+	}
+
+	bucket := cacheBucket(query, policy)
+	key = c.policyKey(policy, key)
+
+	var ret T
+	if found, _ := c.fileCacher.Get(bucket, key, &ret); found {
+		return &ret, nil
+	}
+
+	res, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	_ = c.fileCacher.Set(bucket, key, res)
+
+	return res, nil
+}
+
+// InvalidateMedia deletes the cached responses of the media.
+// Responses cached for other users are kept until they expire.
+func (c *CachedAnilistClient) InvalidateMedia(mediaID int) {
+	if c.fileCacher == nil {
+		return
+	}
+	key := strconv.Itoa(mediaID)
+	for query, policy := range CachePolicies {
+		if policy.ByMedia {
+			_ = c.fileCacher.Delete(cacheBucket(query, policy), c.policyKey(policy, key))
+		}
+	}
+}
+
+// ClearCache deletes all the cached responses.
+func (c *CachedAnilistClient) ClearCache() {
+	if c.fileCacher == nil {
+		return
+	}
+	for query, policy := range CachePolicies {
+		_ = c.fileCacher.Empty(cacheBucket(query, policy))
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (c *CachedAnilistClient) UpdateMediaListEntry(ctx context.Context, mediaID *int, status *MediaListStatus, scoreRaw *int, progress *int, startedAt *FuzzyDateInput, completedAt *FuzzyDateInput, customLists []*string, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntry, error) {
+	ret, err := c.AnilistClient.UpdateMediaListEntry(ctx, mediaID, status, scoreRaw, progress, startedAt, completedAt, customLists, interceptors...)
+	if mediaID != nil {
+		c.InvalidateMedia(*mediaID)
+	}
+	return ret, err
+}
+
+func (c *CachedAnilistClient) UpdateMediaListEntryProgress(ctx context.Context, mediaID *int, progress *int, status *MediaListStatus, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntryProgress, error) {
+	ret, err := c.AnilistClient.UpdateMediaListEntryProgress(ctx, mediaID, progress, status, interceptors...)
+	if mediaID != nil {
+		c.InvalidateMedia(*mediaID)
+	}
+	return ret, err
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (c *CachedAnilistClient) BaseAnimeByID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*BaseAnimeByID, error) {
+	if id == nil {
+		return c.AnilistClient.BaseAnimeByID(ctx, id, interceptors...)
+	}
+	return cached(c, "BaseAnimeByID", strconv.Itoa(*id), interceptors, func() (*BaseAnimeByID, error) {
+		return c.AnilistClient.BaseAnimeByID(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) BaseAnimeByMalID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*BaseAnimeByMalID, error) {
+	if id == nil {
+		return c.AnilistClient.BaseAnimeByMalID(ctx, id, interceptors...)
+	}
+	return cached(c, "BaseAnimeByMalID", strconv.Itoa(*id), interceptors, func() (*BaseAnimeByMalID, error) {
+		return c.AnilistClient.BaseAnimeByMalID(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) CompleteAnimeByID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*CompleteAnimeByID, error) {
+	if id == nil {
+		return c.AnilistClient.CompleteAnimeByID(ctx, id, interceptors...)
+	}
+	return cached(c, "CompleteAnimeByID", strconv.Itoa(*id), interceptors, func() (*CompleteAnimeByID, error) {
+		return c.AnilistClient.CompleteAnimeByID(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) AnimeDetailsByID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*AnimeDetailsByID, error) {
+	if id == nil {
+		return c.AnilistClient.AnimeDetailsByID(ctx, id, interceptors...)
+	}
+	return cached(c, "AnimeDetailsByID", strconv.Itoa(*id), interceptors, func() (*AnimeDetailsByID, error) {
+		return c.AnilistClient.AnimeDetailsByID(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) BaseMangaByID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*BaseMangaByID, error) {
+	if id == nil {
+		return c.AnilistClient.BaseMangaByID(ctx, id, interceptors...)
+	}
+	return cached(c, "BaseMangaByID", strconv.Itoa(*id), interceptors, func() (*BaseMangaByID, error) {
+		return c.AnilistClient.BaseMangaByID(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) MangaDetailsByID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*MangaDetailsByID, error) {
+	if id == nil {
+		return c.AnilistClient.MangaDetailsByID(ctx, id, interceptors...)
+	}
+	return cached(c, "MangaDetailsByID", strconv.Itoa(*id), interceptors, func() (*MangaDetailsByID, error) {
+		return c.AnilistClient.MangaDetailsByID(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) StudioDetails(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*StudioDetails, error) {
+	if id == nil {
+		return c.AnilistClient.StudioDetails(ctx, id, interceptors...)
+	}
+	return cached(c, "StudioDetails", strconv.Itoa(*id), interceptors, func() (*StudioDetails, error) {
+		return c.AnilistClient.StudioDetails(ctx, id, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) SearchBaseAnimeByIds(ctx context.Context, ids []*int, page *int, perPage *int, status []*MediaStatus, inCollection *bool, sort []*MediaSort, season *MediaSeason, year *int, genre *string, format *MediaFormat, interceptors ...clientv2.RequestInterceptor) (*SearchBaseAnimeByIds, error) {
+	// The response depends on the user's collection
+	if inCollection != nil {
+		return c.AnilistClient.SearchBaseAnimeByIds(ctx, ids, page, perPage, status, inCollection, sort, season, year, genre, format, interceptors...)
+	}
+	key := cacheKey(ids, page, perPage, status, sort, season, year, genre, format)
+	return cached(c, "SearchBaseAnimeByIds", key, interceptors, func() (*SearchBaseAnimeByIds, error) {
+		return c.AnilistClient.SearchBaseAnimeByIds(ctx, ids, page, perPage, status, inCollection, sort, season, year, genre, format, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) SearchBaseManga(ctx context.Context, page *int, perPage *int, sort []*MediaSort, search *string, status []*MediaStatus, interceptors ...clientv2.RequestInterceptor) (*SearchBaseManga, error) {
+	key := cacheKey(page, perPage, sort, search, status)
+	return cached(c, "SearchBaseManga", key, interceptors, func() (*SearchBaseManga, error) {
+		return c.AnilistClient.SearchBaseManga(ctx, page, perPage, sort, search, status, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) ListAnime(ctx context.Context, page *int, search *string, perPage *int, sort []*MediaSort, status []*MediaStatus, genres []*string, averageScoreGreater *int, season *MediaSeason, seasonYear *int, format *MediaFormat, isAdult *bool, interceptors ...clientv2.RequestInterceptor) (*ListAnime, error) {
+	key := cacheKey(page, search, perPage, sort, status, genres, averageScoreGreater, season, seasonYear, format, isAdult)
+	return cached(c, "ListAnime", key, interceptors, func() (*ListAnime, error) {
+		return c.AnilistClient.ListAnime(ctx, page, search, perPage, sort, status, genres, averageScoreGreater, season, seasonYear, format, isAdult, interceptors...)
+	})
+}
+
+func (c *CachedAnilistClient) ListManga(ctx context.Context, page *int, search *string, perPage *int, sort []*MediaSort, status []*MediaStatus, genres []*string, averageScoreGreater *int, startDateGreater *string, startDateLesser *string, format *MediaFormat, isAdult *bool, interceptors ...clientv2.RequestInterceptor) (*ListManga, error) {
+	key := cacheKey(page, search, perPage, sort, status, genres, averageScoreGreater, startDateGreater, startDateLesser, format, isAdult)
+	return cached(c, "ListManga", key, interceptors, func() (*ListManga, error) {
+		return c.AnilistClient.ListManga(ctx, page, search, perPage, sort, status, genres, averageScoreGreater, startDateGreater, startDateLesser, format, isAdult, interceptors...)
+	})
+}
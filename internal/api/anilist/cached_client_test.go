@@ -0,0 +1,107 @@
+package anilist
+
+import (
+	"context"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/util/filecache"
+	"testing"
+)
+
+// countingClient returns a new response for each request.
+type countingClient struct {
+	AnilistClient
+	requests int
+}
+
+func (c *countingClient) BaseAnimeByID(_ context.Context, id *int, _ ...clientv2.RequestInterceptor) (*BaseAnimeByID, error) {
+	c.requests++
+	return &BaseAnimeByID{Media: &BaseAnime{ID: *id, Episodes: lo.ToPtr(c.requests)}}, nil
+}
+
+func (c *countingClient) AnimeDetailsByID(_ context.Context, id *int, _ ...clientv2.RequestInterceptor) (*AnimeDetailsByID, error) {
+	c.requests++
+	return &AnimeDetailsByID{Media: &AnimeDetailsById_Media{ID: *id}}, nil
+}
+
+func (c *countingClient) UpdateMediaListEntryProgress(_ context.Context, _ *int, _ *int, _ *MediaListStatus, _ ...clientv2.RequestInterceptor) (*UpdateMediaListEntryProgress, error) {
+	return &UpdateMediaListEntryProgress{}, nil
+}
+
+func TestCachedAnilistClient(t *testing.T) {
+	fileCacher, err := filecache.NewCacher(t.TempDir())
+	require.NoError(t, err)
+
+	client := &countingClient{}
+	cachedClient := NewCachedAnilistClient(client, fileCacher)
+	ctx := context.Background()
+
+	res, err := cachedClient.BaseAnimeByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, *res.GetMedia().GetEpisodes())
+
+	// Cached
+	res, err = cachedClient.BaseAnimeByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, res.GetMedia().GetID())
+	require.Equal(t, 1, *res.GetMedia().GetEpisodes())
+	require.Equal(t, 1, client.requests)
+
+	// Other media
+	_, err = cachedClient.BaseAnimeByID(ctx, lo.ToPtr(2))
+	require.NoError(t, err)
+	require.Equal(t, 2, client.requests)
+
+	// Updating the entry invalidates the cached response
+	_, err = cachedClient.UpdateMediaListEntryProgress(ctx, lo.ToPtr(1), lo.ToPtr(3), nil)
+	require.NoError(t, err)
+	res, err = cachedClient.BaseAnimeByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	require.Equal(t, 3, *res.GetMedia().GetEpisodes())
+
+	// Media 2 is still cached
+	_, err = cachedClient.BaseAnimeByID(ctx, lo.ToPtr(2))
+	require.NoError(t, err)
+	require.Equal(t, 3, client.requests)
+
+	cachedClient.ClearCache()
+	_, err = cachedClient.BaseAnimeByID(ctx, lo.ToPtr(2))
+	require.NoError(t, err)
+	require.Equal(t, 4, client.requests)
+}
+
+func TestCachedAnilistClient_ByViewer(t *testing.T) {
+	fileCacher, err := filecache.NewCacher(t.TempDir())
+	require.NoError(t, err)
+
+	client := &countingClient{}
+	alice := NewCachedAnilistClient(client, fileCacher)
+	alice.viewer = cacheKey("alice")
+	bob := NewCachedAnilistClient(client, fileCacher)
+	bob.viewer = cacheKey("bob")
+	ctx := context.Background()
+
+	// Responses that depend on the user are not shared
+	_, err = alice.AnimeDetailsByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	_, err = bob.AnimeDetailsByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	_, err = alice.AnimeDetailsByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	require.Equal(t, 2, client.requests)
+
+	// Other responses are
+	_, err = alice.BaseAnimeByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	_, err = bob.BaseAnimeByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	require.Equal(t, 3, client.requests)
+
+	// Updating the entry invalidates the responses of the user
+	_, err = alice.UpdateMediaListEntryProgress(ctx, lo.ToPtr(1), lo.ToPtr(3), nil)
+	require.NoError(t, err)
+	_, err = alice.AnimeDetailsByID(ctx, lo.ToPtr(1))
+	require.NoError(t, err)
+	require.Equal(t, 4, client.requests)
+}
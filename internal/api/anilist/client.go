@@ -12,7 +12,6 @@ import (
 	"io"
 	"net/http"
 	"seanime/internal/util"
-	"strconv"
 	"time"
 )
 
@@ -26,7 +25,7 @@ type AnilistClient interface {
 	AnimeDetailsByID(ctx context.Context, id *int, interceptors ...clientv2.RequestInterceptor) (*AnimeDetailsByID, error)
 	ListAnime(ctx context.Context, page *int, search *string, perPage *int, sort []*MediaSort, status []*MediaStatus, genres []*string, averageScoreGreater *int, season *MediaSeason, seasonYear *int, format *MediaFormat, isAdult *bool, interceptors ...clientv2.RequestInterceptor) (*ListAnime, error)
 	ListRecentAnime(ctx context.Context, page *int, perPage *int, airingAtGreater *int, airingAtLesser *int, notYetAired *bool, interceptors ...clientv2.RequestInterceptor) (*ListRecentAnime, error)
-	UpdateMediaListEntry(ctx context.Context, mediaID *int, status *MediaListStatus, scoreRaw *int, progress *int, startedAt *FuzzyDateInput, completedAt *FuzzyDateInput, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntry, error)
+	UpdateMediaListEntry(ctx context.Context, mediaID *int, status *MediaListStatus, scoreRaw *int, progress *int, startedAt *FuzzyDateInput, completedAt *FuzzyDateInput, customLists []*string, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntry, error)
 	UpdateMediaListEntryProgress(ctx context.Context, mediaID *int, progress *int, status *MediaListStatus, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntryProgress, error)
 	DeleteEntry(ctx context.Context, mediaListEntryID *int, interceptors ...clientv2.RequestInterceptor) (*DeleteEntry, error)
 	MangaCollection(ctx context.Context, userName *string, interceptors ...clientv2.RequestInterceptor) (*MangaCollection, error)
@@ -44,6 +43,7 @@ type (
 	AnilistClientImpl struct {
 		Client *Client
 		logger *zerolog.Logger
+		token  string
 	}
 )
 
@@ -63,6 +63,7 @@ func NewAnilistClient(token string) *AnilistClientImpl {
 				}),
 		},
 		logger: util.NewLogger(),
+		token:  token,
 	}
 
 	ac.Client.Client.CustomDo = ac.customDoFunc
@@ -70,9 +71,9 @@ func NewAnilistClient(token string) *AnilistClientImpl {
 	return ac
 }
 
-func (ac *AnilistClientImpl) UpdateMediaListEntry(ctx context.Context, mediaID *int, status *MediaListStatus, scoreRaw *int, progress *int, startedAt *FuzzyDateInput, completedAt *FuzzyDateInput, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntry, error) {
+func (ac *AnilistClientImpl) UpdateMediaListEntry(ctx context.Context, mediaID *int, status *MediaListStatus, scoreRaw *int, progress *int, startedAt *FuzzyDateInput, completedAt *FuzzyDateInput, customLists []*string, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntry, error) {
 	ac.logger.Debug().Int("mediaId", *mediaID).Msg("anilist: Updating media list entry")
-	return ac.Client.UpdateMediaListEntry(ctx, mediaID, status, scoreRaw, progress, startedAt, completedAt, interceptors...)
+	return ac.Client.UpdateMediaListEntry(ctx, mediaID, status, scoreRaw, progress, startedAt, completedAt, customLists, interceptors...)
 }
 
 func (ac *AnilistClientImpl) UpdateMediaListEntryProgress(ctx context.Context, mediaID *int, progress *int, status *MediaListStatus, interceptors ...clientv2.RequestInterceptor) (*UpdateMediaListEntryProgress, error) {
@@ -193,13 +194,9 @@ func (ac *AnilistClientImpl) customDoFunc(ctx context.Context, req *http.Request
 	client := http.DefaultClient
 	var resp *http.Response
 
-	retryCount := 2
-
-	for i := 0; i < retryCount; i++ {
-
-		// Reset response body for retry
-		if resp != nil && resp.Body != nil {
-			resp.Body.Close()
+	for attempt := 0; ; attempt++ {
+		if err = globalRateLimiter.wait(ctx); err != nil {
+			return err
 		}
 
 		// Recreate the request body if it was read in a previous attempt
@@ -217,27 +214,28 @@ func (ac *AnilistClientImpl) customDoFunc(ctx context.Context, req *http.Request
 		}
 
 		rlRemainingStr = resp.Header.Get("X-Ratelimit-Remaining")
-		rlRetryAfterStr := resp.Header.Get("Retry-After")
-		//println("Remaining:", rlRemainingStr, " | RetryAfter:", rlRetryAfterStr)
-
-		// If we have a rate limit, sleep for the time
-		rlRetryAfter, err := strconv.Atoi(rlRetryAfterStr)
-		if err == nil {
-			ac.logger.Warn().Msgf("anilist: Rate limited, retrying in %d seconds", rlRetryAfter+1)
-			select {
-			case <-time.After(time.Duration(rlRetryAfter+1) * time.Second):
-				continue
-			}
+		globalRateLimiter.update(resp.Header)
+
+		if !shouldRetry(resp) || attempt+1 >= maxRequestAttempts {
+			break
 		}
 
-		if rlRemainingStr == "" {
-			select {
-			case <-time.After(5 * time.Second):
-				continue
-			}
+		delay := retryDelay(resp, attempt)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// Stop the other requests too, the limiter waits for the delay
+			ac.logger.Warn().Msgf("anilist: Rate limited, retrying in %s", delay.Truncate(time.Second))
+			globalRateLimiter.pause(delay)
+			continue
 		}
 
-		break
+		ac.logger.Warn().Int("status", resp.StatusCode).Msgf("anilist: Server error, retrying in %s", delay.Truncate(time.Second))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
 	defer resp.Body.Close()
@@ -0,0 +1,46 @@
+package anilist
+
+// Custom lists are returned by AniList as lists with no status.
+// Their entries are copies of the entries in the status lists.
+
+func (l *AnimeCollection_MediaListCollection_Lists) IsCustom() bool {
+	return l != nil && l.GetIsCustomList() != nil && *l.GetIsCustomList()
+}
+
+func (l *MangaCollection_MediaListCollection_Lists) IsCustom() bool {
+	return l != nil && l.GetIsCustomList() != nil && *l.GetIsCustomList()
+}
+
+// GetCustomListNames returns the names of the custom lists that contain the anime.
+func (ac *AnimeCollection) GetCustomListNames(mediaId int) []string {
+	ret := make([]string, 0)
+	for _, l := range ac.GetMediaListCollection().GetLists() {
+		if !l.IsCustom() || l.GetName() == nil {
+			continue
+		}
+		for _, e := range l.GetEntries() {
+			if e.GetMedia().GetID() == mediaId {
+				ret = append(ret, *l.GetName())
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// GetCustomListNames returns the names of the custom lists that contain the manga.
+func (mc *MangaCollection) GetCustomListNames(mediaId int) []string {
+	ret := make([]string, 0)
+	for _, l := range mc.GetMediaListCollection().GetLists() {
+		if !l.IsCustom() || l.GetName() == nil {
+			continue
+		}
+		for _, e := range l.GetEntries() {
+			if e.GetMedia().GetID() == mediaId {
+				ret = append(ret, *l.GetName())
+				break
+			}
+		}
+	}
+	return ret
+}
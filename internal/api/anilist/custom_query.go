@@ -3,13 +3,13 @@ package anilist
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/goccy/go-json"
 	"github.com/rs/zerolog"
 	"net/http"
 	"seanime/internal/util"
-	"strconv"
 	"time"
 )
 
@@ -50,15 +50,11 @@ func customQuery(body []byte, logger *zerolog.Logger, token ...string) (data int
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token[0]))
 	}
 
-	// Send request
-	retryCount := 2
-
+	// Send request, the requests are limited with the ones of the client
 	var resp *http.Response
-	for i := 0; i < retryCount; i++ {
-
-		// Reset response body for retry
-		if resp != nil && resp.Body != nil {
-			resp.Body.Close()
+	for attempt := 0; ; attempt++ {
+		if err = globalRateLimiter.wait(context.Background()); err != nil {
+			return nil, err
 		}
 
 		// Recreate the request body if it was read in a previous attempt
@@ -76,24 +72,23 @@ func customQuery(body []byte, logger *zerolog.Logger, token ...string) (data int
 		}
 
 		rlRemainingStr = resp.Header.Get("X-Ratelimit-Remaining")
-		rlRetryAfterStr := resp.Header.Get("Retry-After")
-		rlRetryAfter, err := strconv.Atoi(rlRetryAfterStr)
-		if err == nil {
-			logger.Warn().Msgf("anilist: Rate limited, retrying in %d seconds", rlRetryAfter+1)
-			select {
-			case <-time.After(time.Duration(rlRetryAfter+1) * time.Second):
-				continue
-			}
+		globalRateLimiter.update(resp.Header)
+
+		if !shouldRetry(resp) || attempt+1 >= maxRequestAttempts {
+			break
 		}
 
-		if rlRemainingStr == "" {
-			select {
-			case <-time.After(5 * time.Second):
-				continue
-			}
+		delay := retryDelay(resp, attempt)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			logger.Warn().Msgf("anilist: Rate limited, retrying in %s", delay.Truncate(time.Second))
+			globalRateLimiter.pause(delay)
+			continue
 		}
 
-		break
+		logger.Warn().Int("status", resp.StatusCode).Msgf("anilist: Server error, retrying in %s", delay.Truncate(time.Second))
+		time.Sleep(delay)
 	}
 
 	defer resp.Body.Close()
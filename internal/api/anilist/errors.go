@@ -0,0 +1,34 @@
+package anilist
+
+import (
+	"errors"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"net/http"
+)
+
+// StatusCode returns the HTTP status code of an AniList error response.
+// It returns 0 if the request did not get a response, e.g. because of a network error.
+func StatusCode(err error) int {
+	var errResponse *clientv2.ErrorResponse
+	if !errors.As(err, &errResponse) {
+		return 0
+	}
+	if errResponse.NetworkError != nil {
+		return errResponse.NetworkError.Code
+	}
+	// GraphQL errors are returned with a 200 status
+	if errResponse.GqlErrors != nil {
+		for _, gqlErr := range *errResponse.GqlErrors {
+			if gqlErr.Message == "Not Found." {
+				return http.StatusNotFound
+			}
+		}
+		return http.StatusOK
+	}
+	return 0
+}
+
+// IsNotFound returns true if AniList responded that the requested media does not exist.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
@@ -0,0 +1,24 @@
+package anilist
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	notFound := &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: http.StatusNotFound}}
+	require.True(t, IsNotFound(notFound))
+	require.True(t, IsNotFound(fmt.Errorf("anilist: %w", notFound)))
+
+	validation := &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: http.StatusBadRequest}}
+	require.Equal(t, http.StatusBadRequest, StatusCode(validation))
+	require.False(t, IsNotFound(validation))
+
+	// Network errors have no status
+	require.Zero(t, StatusCode(errors.New("dial tcp: connection refused")))
+	require.False(t, IsNotFound(errors.New("dial tcp: connection refused")))
+}
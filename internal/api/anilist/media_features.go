@@ -0,0 +1,209 @@
+package anilist
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+)
+
+const mediaFeaturesQuery = `query MediaFeatures ($ids: [Int], $perPage: Int, $withRecommendations: Boolean = false) {
+	Page(page: 1, perPage: $perPage) {
+		media(id_in: $ids) {
+			id
+			type
+			format
+			status(version: 2)
+			season
+			seasonYear
+			isAdult
+			meanScore
+			genres
+			title {
+				userPreferred
+				romaji
+				english
+			}
+			coverImage {
+				large
+				color
+			}
+			tags {
+				name
+				rank
+			}
+			studios(isMain: true) {
+				nodes {
+					id
+					name
+				}
+			}
+			relations {
+				edges {
+					relationType(version: 2)
+					node {
+						id
+						type
+					}
+				}
+			}
+			recommendations(perPage: 10, sort: RATING_DESC) @include(if: $withRecommendations) {
+				nodes {
+					rating
+					mediaRecommendation {
+						id
+						type
+					}
+				}
+			}
+		}
+	}
+}`
+
+// mediaFeaturesPerPage is kept low because of the nested connections
+const mediaFeaturesPerPage = 25
+
+type (
+	// MediaFeatures holds the media fields used to compare anime and manga.
+	MediaFeatures struct {
+		ID         int                    `json:"id"`
+		Type       MediaType              `json:"type"`
+		Format     *MediaFormat           `json:"format,omitempty"`
+		Status     *MediaStatus           `json:"status,omitempty"`
+		Season     *MediaSeason           `json:"season,omitempty"`
+		SeasonYear *int                   `json:"seasonYear,omitempty"`
+		IsAdult    bool                   `json:"isAdult"`
+		MeanScore  *int                   `json:"meanScore,omitempty"`
+		Genres     []string               `json:"genres"`
+		Title      *MediaFeaturesTitle    `json:"title"`
+		CoverImage *MediaFeaturesCover    `json:"coverImage"`
+		Tags       []*MediaFeaturesTag    `json:"tags"`
+		Studios    []*MediaFeaturesStudio `json:"studios"`
+		// Relations and Recommendations are not sent to the client
+		Relations       []*MediaFeaturesRelation       `json:"-"`
+		Recommendations []*MediaFeaturesRecommendation `json:"-"`
+	}
+
+	MediaFeaturesTitle struct {
+		UserPreferred *string `json:"userPreferred,omitempty"`
+		Romaji        *string `json:"romaji,omitempty"`
+		English       *string `json:"english,omitempty"`
+	}
+
+	MediaFeaturesCover struct {
+		Large *string `json:"large,omitempty"`
+		Color *string `json:"color,omitempty"`
+	}
+
+	MediaFeaturesTag struct {
+		Name string `json:"name"`
+		Rank int    `json:"rank"` // 0-100
+	}
+
+	MediaFeaturesStudio struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	MediaFeaturesRelation struct {
+		ID           int           `json:"id"`
+		Type         MediaType     `json:"type"`
+		RelationType MediaRelation `json:"relationType"`
+	}
+
+	MediaFeaturesRecommendation struct {
+		ID     int       `json:"id"`
+		Type   MediaType `json:"type"`
+		Rating int       `json:"rating"`
+	}
+)
+
+// FetchMediaFeatures returns the features of the given anime and manga.
+// The recommendation edges are only fetched if withRecommendations is true.
+func FetchMediaFeatures(ids []int, withRecommendations bool, logger *zerolog.Logger) ([]*MediaFeatures, error) {
+	ret := make([]*MediaFeatures, 0, len(ids))
+
+	for i := 0; i < len(ids); i += mediaFeaturesPerPage {
+		chunk := ids[i:min(i+mediaFeaturesPerPage, len(ids))]
+
+		requestBody, err := json.Marshal(map[string]interface{}{
+			"query": mediaFeaturesQuery,
+			"variables": map[string]interface{}{
+				"ids":                 chunk,
+				"perPage":             len(chunk),
+				"withRecommendations": withRecommendations,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := customQuery(requestBody, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var res struct {
+			Page struct {
+				Media []*struct {
+					MediaFeatures
+					Studios struct {
+						Nodes []*MediaFeaturesStudio `json:"nodes"`
+					} `json:"studios"`
+					Relations struct {
+						Edges []struct {
+							RelationType MediaRelation `json:"relationType"`
+							Node         struct {
+								ID   int       `json:"id"`
+								Type MediaType `json:"type"`
+							} `json:"node"`
+						} `json:"edges"`
+					} `json:"relations"`
+					Recommendations struct {
+						Nodes []struct {
+							Rating              int `json:"rating"`
+							MediaRecommendation *struct {
+								ID   int       `json:"id"`
+								Type MediaType `json:"type"`
+							} `json:"mediaRecommendation"`
+						} `json:"nodes"`
+					} `json:"recommendations"`
+				} `json:"media"`
+			} `json:"Page"`
+		}
+		if err := json.Unmarshal(m, &res); err != nil {
+			return nil, err
+		}
+
+		for _, media := range res.Page.Media {
+			features := media.MediaFeatures
+			features.Studios = media.Studios.Nodes
+			features.Relations = make([]*MediaFeaturesRelation, 0, len(media.Relations.Edges))
+			for _, edge := range media.Relations.Edges {
+				features.Relations = append(features.Relations, &MediaFeaturesRelation{
+					ID:           edge.Node.ID,
+					Type:         edge.Node.Type,
+					RelationType: edge.RelationType,
+				})
+			}
+			features.Recommendations = make([]*MediaFeaturesRecommendation, 0, len(media.Recommendations.Nodes))
+			for _, node := range media.Recommendations.Nodes {
+				// Deleted media
+				if node.MediaRecommendation == nil {
+					continue
+				}
+				features.Recommendations = append(features.Recommendations, &MediaFeaturesRecommendation{
+					ID:     node.MediaRecommendation.ID,
+					Type:   node.MediaRecommendation.Type,
+					Rating: node.Rating,
+				})
+			}
+			ret = append(ret, &features)
+		}
+	}
+
+	return ret, nil
+}
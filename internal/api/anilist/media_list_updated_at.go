@@ -0,0 +1,70 @@
+package anilist
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+const mediaListUpdatedAtQuery = `query MediaListUpdatedAt ($userName: String, $type: MediaType) {
+	MediaListCollection(userName: $userName, type: $type, forceSingleCompletedList: true) {
+		lists {
+			entries {
+				mediaId
+				updatedAt
+			}
+		}
+	}
+}`
+
+// FetchMediaListUpdatedAt returns when the list entries of the user were last updated, keyed by media ID.
+// mediaType is either "ANIME" or "MANGA". The token is needed to include private entries.
+func FetchMediaListUpdatedAt(userName string, mediaType string, token string, logger *zerolog.Logger) (map[int]time.Time, error) {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"query": mediaListUpdatedAtQuery,
+		"variables": map[string]interface{}{
+			"userName": userName,
+			"type":     mediaType,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if token != "" {
+		data, err = customQuery(requestBody, logger, token)
+	} else {
+		data, err = customQuery(requestBody, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		MediaListCollection struct {
+			Lists []struct {
+				Entries []struct {
+					MediaID   int   `json:"mediaId"`
+					UpdatedAt int64 `json:"updatedAt"`
+				} `json:"entries"`
+			} `json:"lists"`
+		} `json:"MediaListCollection"`
+	}
+	if err := json.Unmarshal(m, &res); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[int]time.Time)
+	for _, list := range res.MediaListCollection.Lists {
+		for _, entry := range list.Entries {
+			ret[entry.MediaID] = time.Unix(entry.UpdatedAt, 0)
+		}
+	}
+	return ret, nil
+}
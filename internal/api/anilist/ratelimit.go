@@ -0,0 +1,145 @@
+package anilist
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRateLimit is the number of requests per minute allowed by AniList.
+	// It is updated from the X-RateLimit-Limit header, e.g. when the API is in degraded mode.
+	defaultRateLimit = 90
+
+	maxRequestAttempts = 4
+	// defaultRetryAfter is used when a rate limited response doesn't have a Retry-After header
+	defaultRetryAfter     = time.Minute
+	maxServerErrorBackoff = 8 * time.Second
+)
+
+// rateLimiter is a token bucket shared by the clients, since AniList limits the requests per IP.
+// It refills at the rate given by AniList and stops all requests when AniList asks us to retry later.
+type rateLimiter struct {
+	mu          sync.Mutex
+	limit       int // requests per minute
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+var globalRateLimiter = newRateLimiter(defaultRateLimit)
+
+func newRateLimiter(limit int) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		tokens: float64(limit),
+		last:   time.Now(),
+	}
+}
+
+// refill adds the tokens earned since the last call. mu must be held.
+func (rl *rateLimiter) refill(now time.Time) {
+	rl.tokens += now.Sub(rl.last).Minutes() * float64(rl.limit)
+	if rl.tokens > float64(rl.limit) {
+		rl.tokens = float64(rl.limit)
+	}
+	rl.last = now
+}
+
+// wait blocks until a request can be sent.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	for {
+		rl.mu.Lock()
+		now := time.Now()
+		rl.refill(now)
+
+		var delay time.Duration
+		switch {
+		case now.Before(rl.pausedUntil):
+			delay = rl.pausedUntil.Sub(now)
+		case rl.tokens >= 1:
+			rl.tokens--
+			rl.mu.Unlock()
+			return nil
+		default:
+			delay = time.Duration((1 - rl.tokens) / float64(rl.limit) * float64(time.Minute))
+		}
+		rl.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// update adapts the bucket to the rate limit headers of a response.
+func (rl *rateLimiter) update(header http.Header) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refill(time.Now())
+
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil && limit > 0 {
+		rl.limit = limit
+		if rl.tokens > float64(limit) {
+			rl.tokens = float64(limit)
+		}
+	}
+
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil && remaining >= 0 {
+		if float64(remaining) < rl.tokens {
+			rl.tokens = float64(remaining)
+		}
+		// Wait for the reset if there are no requests left
+		if remaining == 0 {
+			if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				if resetAt := time.Unix(reset, 0); resetAt.After(rl.pausedUntil) {
+					rl.pausedUntil = resetAt
+				}
+			}
+		}
+	}
+}
+
+// pause stops the requests for the given duration.
+func (rl *rateLimiter) pause(d time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(rl.pausedUntil) {
+		rl.pausedUntil = until
+	}
+	rl.tokens = 0
+}
+
+// retryDelay returns how long to wait before retrying a rate limited or failed request.
+// Retry-After is honored when present, server errors are retried with an exponential backoff.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds)*time.Second + time.Second
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return time.Until(date) + time.Second
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return defaultRetryAfter
+	}
+
+	ret := time.Second << attempt
+	if ret > maxServerErrorBackoff {
+		return maxServerErrorBackoff
+	}
+	return ret
+}
+
+// shouldRetry returns true if the request failed because of the rate limit or a server error.
+func shouldRetry(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
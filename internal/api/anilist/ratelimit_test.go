@@ -0,0 +1,79 @@
+package anilist
+
+import (
+	"context"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"seanime/internal/util"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterUpdate(t *testing.T) {
+	rl := newRateLimiter(90)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "30")
+	header.Set("X-RateLimit-Remaining", "10")
+	rl.update(header)
+	require.Equal(t, 30, rl.limit)
+	require.InDelta(t, 10, rl.tokens, 0.1)
+
+	reset := time.Now().Add(30 * time.Second)
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	rl.update(header)
+	require.Equal(t, reset.Unix(), rl.pausedUntil.Unix())
+
+	// The limiter waits for the reset
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, rl.wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRetryDelay(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	require.Equal(t, defaultRetryAfter, retryDelay(resp, 0))
+
+	resp.Header.Set("Retry-After", "10")
+	require.Equal(t, 11*time.Second, retryDelay(resp, 0))
+
+	resp = &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+	require.True(t, shouldRetry(resp))
+	require.Equal(t, time.Second, retryDelay(resp, 0))
+	require.Equal(t, 4*time.Second, retryDelay(resp, 2))
+	require.Equal(t, maxServerErrorBackoff, retryDelay(resp, 10))
+
+	require.False(t, shouldRetry(&http.Response{StatusCode: http.StatusBadRequest}))
+}
+
+func TestCustomDoFuncRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "90")
+		switch requests.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("X-RateLimit-Remaining", "80")
+			_, _ = w.Write([]byte(`{"data":{"Media":{"id":1}}}`))
+		}
+	}))
+	defer server.Close()
+
+	ac := &AnilistClientImpl{logger: util.NewLogger()}
+	ac.Client = &Client{Client: clientv2.NewClient(http.DefaultClient, server.URL, nil)}
+	ac.Client.Client.CustomDo = ac.customDoFunc
+
+	res, err := ac.BaseAnimeByID(context.Background(), new(int))
+	require.NoError(t, err)
+	require.Equal(t, 1, res.GetMedia().GetID())
+	require.EqualValues(t, 3, requests.Load())
+}
@@ -0,0 +1,108 @@
+package kitsu
+
+import "time"
+
+const (
+	ApiBaseURL string = "https://kitsu.app/api/edge"
+	OAuthURL   string = "https://kitsu.app/api/oauth/token"
+)
+
+type (
+	MediaType          string
+	LibraryEntryStatus string
+	ExternalSite       string
+)
+
+const (
+	MediaTypeAnime MediaType = "anime"
+	MediaTypeManga MediaType = "manga"
+
+	LibraryEntryStatusCurrent   LibraryEntryStatus = "current"
+	LibraryEntryStatusPlanned   LibraryEntryStatus = "planned"
+	LibraryEntryStatusCompleted LibraryEntryStatus = "completed"
+	LibraryEntryStatusOnHold    LibraryEntryStatus = "on_hold"
+	LibraryEntryStatusDropped   LibraryEntryStatus = "dropped"
+
+	ExternalSiteAnilistAnime     ExternalSite = "anilist/anime"
+	ExternalSiteAnilistManga     ExternalSite = "anilist/manga"
+	ExternalSiteMyAnimeListAnime ExternalSite = "myanimelist/anime"
+	ExternalSiteMyAnimeListManga ExternalSite = "myanimelist/manga"
+)
+
+type (
+	User struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// LibraryEntry is a library entry with the IDs of its media.
+	LibraryEntry struct {
+		ID             string             `json:"id"`
+		Status         LibraryEntryStatus `json:"status"`
+		Progress       int                `json:"progress"`
+		Reconsuming    bool               `json:"reconsuming"`
+		ReconsumeCount int                `json:"reconsumeCount"`
+		RatingTwenty   *int               `json:"ratingTwenty"` // 2-20, nil if not rated
+		Notes          string             `json:"notes"`
+		Private        bool               `json:"private"`
+		StartedAt      *time.Time         `json:"startedAt"`
+		FinishedAt     *time.Time         `json:"finishedAt"`
+		UpdatedAt      *time.Time         `json:"updatedAt"`
+
+		MediaType MediaType `json:"-"`
+		MediaID   string    `json:"-"` // Kitsu ID of the media
+		Title     string    `json:"-"`
+		AnilistID int       `json:"-"` // 0 if Kitsu has no AniList mapping
+		MalID     int       `json:"-"` // 0 if Kitsu has no MyAnimeList mapping
+	}
+
+	// LibraryEntryParams are the attributes to update, nil fields are left unchanged.
+	LibraryEntryParams struct {
+		Status         *LibraryEntryStatus
+		Progress       *int
+		Reconsuming    *bool
+		ReconsumeCount *int
+		RatingTwenty   *int    // 0 removes the rating
+		StartedAt      *string // e.g. "2024-01-31T00:00:00.000Z", empty to remove the date
+		FinishedAt     *string // e.g. "2024-01-31T00:00:00.000Z", empty to remove the date
+	}
+)
+
+// attributes returns the JSON:API attributes of the params.
+func (p *LibraryEntryParams) attributes() map[string]interface{} {
+	ret := make(map[string]interface{})
+	if p.Status != nil {
+		ret["status"] = *p.Status
+	}
+	if p.Progress != nil {
+		ret["progress"] = *p.Progress
+	}
+	if p.Reconsuming != nil {
+		ret["reconsuming"] = *p.Reconsuming
+	}
+	if p.ReconsumeCount != nil {
+		ret["reconsumeCount"] = *p.ReconsumeCount
+	}
+	if p.RatingTwenty != nil {
+		if *p.RatingTwenty == 0 {
+			ret["ratingTwenty"] = nil
+		} else {
+			ret["ratingTwenty"] = *p.RatingTwenty
+		}
+	}
+	if p.StartedAt != nil {
+		if *p.StartedAt == "" {
+			ret["startedAt"] = nil
+		} else {
+			ret["startedAt"] = *p.StartedAt
+		}
+	}
+	if p.FinishedAt != nil {
+		if *p.FinishedAt == "" {
+			ret["finishedAt"] = nil
+		} else {
+			ret["finishedAt"] = *p.FinishedAt
+		}
+	}
+	return ret
+}
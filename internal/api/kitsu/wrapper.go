@@ -0,0 +1,462 @@
+package kitsu
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"io"
+	"net/http"
+	"net/url"
+	"seanime/internal/constants"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"strings"
+	"time"
+)
+
+const contentType = "application/vnd.api+json"
+
+// httpClient is shared by the wrappers and the authentication requests.
+// A stalled request would otherwise block the library fetches and the login.
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+type (
+	Wrapper struct {
+		AccessToken string
+		UserID      string
+		client      *http.Client
+		logger      *zerolog.Logger
+	}
+
+	// document is a JSON:API document
+	document struct {
+		Data     json.RawMessage `json:"data"`
+		Included []*resource     `json:"included"`
+		Links    struct {
+			Next string `json:"next"`
+		} `json:"links"`
+	}
+
+	resource struct {
+		ID            string                   `json:"id"`
+		Type          string                   `json:"type"`
+		Attributes    json.RawMessage          `json:"attributes"`
+		Relationships map[string]*relationship `json:"relationships"`
+	}
+
+	relationship struct {
+		Data json.RawMessage `json:"data"` // Either an identifier, a list of identifiers or null
+	}
+
+	identifier struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+)
+
+func NewWrapper(accessToken string, userID string, logger *zerolog.Logger) *Wrapper {
+	return &Wrapper{
+		AccessToken: accessToken,
+		UserID:      userID,
+		client:      httpClient,
+		logger:      logger,
+	}
+}
+
+func (w *Wrapper) doRequest(method, uri string, body interface{}, data interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, uri, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Add("Accept", contentType)
+	if w.AccessToken != "" {
+		req.Header.Add("Authorization", "Bearer "+w.AccessToken)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !((resp.StatusCode >= 200) && (resp.StatusCode <= 299)) {
+		return fmt.Errorf("invalid response status %s", resp.Status)
+	}
+
+	if data == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(data)
+}
+
+// GetCurrentUser returns the user the access token belongs to.
+func (w *Wrapper) GetCurrentUser() (*User, error) {
+	w.logger.Debug().Msg("kitsu: Getting current user")
+
+	var doc document
+	err := w.doRequest("GET", ApiBaseURL+"/users?filter[self]=true&fields[users]=name", nil, &doc)
+	if err != nil {
+		w.logger.Error().Err(err).Msg("kitsu: Failed to get current user")
+		return nil, err
+	}
+
+	var users []*resource
+	if err := json.Unmarshal(doc.Data, &users); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("kitsu: User not found")
+	}
+
+	var attributes struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(users[0].Attributes, &attributes); err != nil {
+		return nil, err
+	}
+
+	return &User{ID: users[0].ID, Name: attributes.Name}, nil
+}
+
+// GetLibrary returns the library entries of the user for the given media type.
+func (w *Wrapper) GetLibrary(mediaType MediaType) ([]*LibraryEntry, error) {
+	w.logger.Debug().Str("type", string(mediaType)).Msg("kitsu: Getting library")
+
+	query := url.Values{}
+	query.Set("filter[userId]", w.UserID)
+	query.Set("filter[kind]", string(mediaType))
+	query.Set("include", fmt.Sprintf("%s,%s.mappings", mediaType, mediaType))
+	query.Set(fmt.Sprintf("fields[%s]", mediaType), "canonicalTitle,mappings")
+	query.Set("fields[mappings]", "externalSite,externalId")
+	query.Set("page[limit]", "500")
+	reqUrl := fmt.Sprintf("%s/library-entries?%s", ApiBaseURL, query.Encode())
+
+	ret := make([]*LibraryEntry, 0)
+	for reqUrl != "" {
+		var doc document
+		err := w.doRequest("GET", reqUrl, nil, &doc)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("kitsu: Failed to get library")
+			return nil, err
+		}
+
+		entries, err := parseLibraryEntries(&doc, mediaType)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, entries...)
+		reqUrl = doc.Links.Next
+	}
+
+	w.logger.Info().Int("count", len(ret)).Str("type", string(mediaType)).Msg("kitsu: Fetched library")
+
+	return ret, nil
+}
+
+// GetLibraryEntry returns the library entry of the media, or nil if it's not in the library.
+func (w *Wrapper) GetLibraryEntry(mediaType MediaType, mediaID string) (*LibraryEntry, error) {
+	query := url.Values{}
+	query.Set("filter[userId]", w.UserID)
+	query.Set("filter[kind]", string(mediaType))
+	query.Set(fmt.Sprintf("filter[%sId]", mediaType), mediaID)
+	reqUrl := fmt.Sprintf("%s/library-entries?%s", ApiBaseURL, query.Encode())
+
+	var doc document
+	err := w.doRequest("GET", reqUrl, nil, &doc)
+	if err != nil {
+		w.logger.Error().Err(err).Str("mediaId", mediaID).Msg("kitsu: Failed to get library entry")
+		return nil, err
+	}
+
+	entries, err := parseLibraryEntries(&doc, mediaType)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	entries[0].MediaID = mediaID
+	return entries[0], nil
+}
+
+// CreateLibraryEntry adds the media to the library and returns the ID of the new entry.
+func (w *Wrapper) CreateLibraryEntry(mediaType MediaType, mediaID string, params *LibraryEntryParams) (string, error) {
+	w.logger.Debug().Str("mediaId", mediaID).Msg("kitsu: Creating library entry")
+
+	body := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "libraryEntries",
+			"attributes": params.attributes(),
+			"relationships": map[string]interface{}{
+				"user": map[string]interface{}{
+					"data": identifier{ID: w.UserID, Type: "users"},
+				},
+				string(mediaType): map[string]interface{}{
+					"data": identifier{ID: mediaID, Type: string(mediaType)},
+				},
+			},
+		},
+	}
+
+	var doc document
+	err := w.doRequest("POST", ApiBaseURL+"/library-entries", body, &doc)
+	if err != nil {
+		w.logger.Error().Err(err).Str("mediaId", mediaID).Msg("kitsu: Failed to create library entry")
+		return "", err
+	}
+
+	var res identifier
+	if err := json.Unmarshal(doc.Data, &res); err != nil {
+		return "", err
+	}
+
+	w.logger.Info().Str("mediaId", mediaID).Msg("kitsu: Created library entry")
+
+	return res.ID, nil
+}
+
+// UpdateLibraryEntry updates the library entry with the given ID.
+func (w *Wrapper) UpdateLibraryEntry(entryID string, params *LibraryEntryParams) error {
+	w.logger.Debug().Str("entryId", entryID).Msg("kitsu: Updating library entry")
+
+	body := map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":         entryID,
+			"type":       "libraryEntries",
+			"attributes": params.attributes(),
+		},
+	}
+
+	err := w.doRequest("PATCH", fmt.Sprintf("%s/library-entries/%s", ApiBaseURL, entryID), body, nil)
+	if err != nil {
+		w.logger.Error().Err(err).Str("entryId", entryID).Msg("kitsu: Failed to update library entry")
+		return err
+	}
+	return nil
+}
+
+// DeleteLibraryEntry removes the library entry with the given ID.
+func (w *Wrapper) DeleteLibraryEntry(entryID string) error {
+	w.logger.Debug().Str("entryId", entryID).Msg("kitsu: Deleting library entry")
+
+	err := w.doRequest("DELETE", fmt.Sprintf("%s/library-entries/%s", ApiBaseURL, entryID), nil, nil)
+	if err != nil {
+		w.logger.Error().Err(err).Str("entryId", entryID).Msg("kitsu: Failed to delete library entry")
+		return err
+	}
+
+	w.logger.Info().Str("entryId", entryID).Msg("kitsu: Deleted library entry")
+
+	return nil
+}
+
+// GetMediaIDByExternalID returns the Kitsu ID of the media mapped to the external ID, or an empty string if there is none.
+func (w *Wrapper) GetMediaIDByExternalID(site ExternalSite, externalID int) (string, error) {
+	query := url.Values{}
+	query.Set("filter[externalSite]", string(site))
+	query.Set("filter[externalId]", fmt.Sprintf("%d", externalID))
+	query.Set("fields[mappings]", "item")
+	reqUrl := fmt.Sprintf("%s/mappings?%s", ApiBaseURL, query.Encode())
+
+	var doc document
+	err := w.doRequest("GET", reqUrl, nil, &doc)
+	if err != nil {
+		w.logger.Error().Err(err).Str("site", string(site)).Int("externalId", externalID).Msg("kitsu: Failed to get mapping")
+		return "", err
+	}
+
+	var mappings []*resource
+	if err := json.Unmarshal(doc.Data, &mappings); err != nil {
+		return "", err
+	}
+	for _, mapping := range mappings {
+		if item, ok := mapping.Relationships["item"]; ok {
+			if ids := item.identifiers(); len(ids) > 0 {
+				return ids[0].ID, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseLibraryEntries returns the library entries of the document.
+// The media and their mappings are read from the included resources if present.
+func parseLibraryEntries(doc *document, mediaType MediaType) ([]*LibraryEntry, error) {
+	var data []*resource
+	if err := json.Unmarshal(doc.Data, &data); err != nil {
+		return nil, err
+	}
+
+	included := make(map[string]*resource, len(doc.Included))
+	for _, r := range doc.Included {
+		included[r.Type+"/"+r.ID] = r
+	}
+
+	ret := make([]*LibraryEntry, 0, len(data))
+	for _, r := range data {
+		entry := &LibraryEntry{}
+		if err := json.Unmarshal(r.Attributes, entry); err != nil {
+			return nil, err
+		}
+		entry.ID = r.ID
+		entry.MediaType = mediaType
+
+		if rel, ok := r.Relationships[string(mediaType)]; ok {
+			if ids := rel.identifiers(); len(ids) > 0 {
+				entry.MediaID = ids[0].ID
+			}
+		}
+
+		if media, ok := included[string(mediaType)+"/"+entry.MediaID]; ok {
+			var attributes struct {
+				CanonicalTitle string `json:"canonicalTitle"`
+			}
+			_ = json.Unmarshal(media.Attributes, &attributes)
+			entry.Title = attributes.CanonicalTitle
+
+			if rel, ok := media.Relationships["mappings"]; ok {
+				for _, id := range rel.identifiers() {
+					mapping, ok := included["mappings/"+id.ID]
+					if !ok {
+						continue
+					}
+					var m struct {
+						ExternalSite ExternalSite `json:"externalSite"`
+						ExternalID   string       `json:"externalId"`
+					}
+					if err := json.Unmarshal(mapping.Attributes, &m); err != nil {
+						continue
+					}
+					var externalID int
+					if _, err := fmt.Sscanf(m.ExternalID, "%d", &externalID); err != nil {
+						continue
+					}
+					switch m.ExternalSite {
+					case ExternalSiteAnilistAnime, ExternalSiteAnilistManga:
+						entry.AnilistID = externalID
+					case ExternalSiteMyAnimeListAnime, ExternalSiteMyAnimeListManga:
+						entry.MalID = externalID
+					}
+				}
+			}
+		}
+
+		ret = append(ret, entry)
+	}
+
+	return ret, nil
+}
+
+// identifiers returns the resource identifiers of the relationship.
+func (r *relationship) identifiers() []identifier {
+	if r == nil || len(r.Data) == 0 || string(r.Data) == "null" {
+		return nil
+	}
+	if r.Data[0] == '[' {
+		var ret []identifier
+		_ = json.Unmarshal(r.Data, &ret)
+		return ret
+	}
+	var ret identifier
+	if err := json.Unmarshal(r.Data, &ret); err != nil {
+		return nil
+	}
+	return []identifier{ret}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type AuthResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+}
+
+// Login fetches the access and refresh tokens using the OAuth password grant.
+func Login(username string, password string) (*AuthResponse, error) {
+	urlData := url.Values{}
+	urlData.Set("grant_type", "password")
+	urlData.Set("username", username)
+	urlData.Set("password", password)
+	return requestToken(urlData)
+}
+
+// VerifyKitsuAuth refreshes the access token if it has expired and saves it.
+func VerifyKitsuAuth(kitsuInfo *models.Kitsu, db *db.Database, logger *zerolog.Logger) (*models.Kitsu, error) {
+
+	// Token has not expired
+	if kitsuInfo.TokenExpiresAt.After(time.Now()) {
+		logger.Debug().Msg("kitsu: Token is still valid")
+		return kitsuInfo, nil
+	}
+
+	urlData := url.Values{}
+	urlData.Set("grant_type", "refresh_token")
+	urlData.Set("refresh_token", kitsuInfo.RefreshToken)
+	ret, err := requestToken(urlData)
+	if err != nil {
+		logger.Error().Err(err).Msg("kitsu: Failed to refresh token")
+		return kitsuInfo, err
+	}
+
+	updatedKitsuInfo := *kitsuInfo
+	updatedKitsuInfo.UpdatedAt = time.Now()
+	updatedKitsuInfo.AccessToken = ret.AccessToken
+	updatedKitsuInfo.RefreshToken = ret.RefreshToken
+	updatedKitsuInfo.TokenExpiresAt = time.Now().Add(time.Duration(ret.ExpiresIn) * time.Second)
+
+	_, err = db.UpsertKitsuInfo(&updatedKitsuInfo)
+	if err != nil {
+		logger.Error().Err(err).Msg("kitsu: Failed to save updated Kitsu info")
+		return kitsuInfo, err
+	}
+
+	logger.Info().Msg("kitsu: Refreshed token")
+
+	return &updatedKitsuInfo, nil
+}
+
+func requestToken(urlData url.Values) (*AuthResponse, error) {
+	urlData.Set("client_id", constants.KitsuClientId)
+	urlData.Set("client_secret", constants.KitsuClientSecret)
+
+	req, err := http.NewRequest("POST", OAuthURL, strings.NewReader(urlData.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	ret := AuthResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&ret); err != nil {
+		return nil, err
+	}
+
+	if ret.AccessToken == "" {
+		return nil, fmt.Errorf("kitsu: Failed to get token %s", res.Status)
+	}
+
+	return &ret, nil
+}
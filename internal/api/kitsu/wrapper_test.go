@@ -0,0 +1,72 @@
+package kitsu
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseLibraryEntries(t *testing.T) {
+	data := `{
+		"data": [
+			{
+				"id": "100",
+				"type": "libraryEntries",
+				"attributes": {"status": "current", "progress": 4, "reconsuming": true, "reconsumeCount": 1, "ratingTwenty": 17, "startedAt": "2024-01-31T00:00:00.000Z", "finishedAt": null},
+				"relationships": {"anime": {"data": {"type": "anime", "id": "7442"}}}
+			},
+			{
+				"id": "101",
+				"type": "libraryEntries",
+				"attributes": {"status": "planned", "progress": 0, "ratingTwenty": null},
+				"relationships": {"anime": {"data": null}}
+			}
+		],
+		"included": [
+			{
+				"id": "7442",
+				"type": "anime",
+				"attributes": {"canonicalTitle": "Attack on Titan"},
+				"relationships": {"mappings": {"data": [{"type": "mappings", "id": "1"}, {"type": "mappings", "id": "2"}]}}
+			},
+			{"id": "1", "type": "mappings", "attributes": {"externalSite": "anilist/anime", "externalId": "16498"}},
+			{"id": "2", "type": "mappings", "attributes": {"externalSite": "myanimelist/anime", "externalId": "16498"}}
+		],
+		"links": {}
+	}`
+
+	var doc document
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+
+	entries, err := parseLibraryEntries(&doc, MediaTypeAnime)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entry := entries[0]
+	require.Equal(t, "100", entry.ID)
+	require.Equal(t, LibraryEntryStatusCurrent, entry.Status)
+	require.True(t, entry.Reconsuming)
+	require.Equal(t, 17, *entry.RatingTwenty)
+	require.Equal(t, 2024, entry.StartedAt.Year())
+	require.Nil(t, entry.FinishedAt)
+	require.Equal(t, "7442", entry.MediaID)
+	require.Equal(t, "Attack on Titan", entry.Title)
+	require.Equal(t, 16498, entry.AnilistID)
+	require.Equal(t, 16498, entry.MalID)
+
+	require.Nil(t, entries[1].RatingTwenty)
+	require.Empty(t, entries[1].MediaID)
+}
+
+func TestLibraryEntryParamsAttributes(t *testing.T) {
+	status := LibraryEntryStatusCompleted
+	rating := 0
+	startedAt := ""
+	attributes := (&LibraryEntryParams{Status: &status, RatingTwenty: &rating, StartedAt: &startedAt}).attributes()
+
+	require.Equal(t, map[string]interface{}{
+		"status":       LibraryEntryStatusCompleted,
+		"ratingTwenty": nil,
+		"startedAt":    nil,
+	}, attributes)
+}
@@ -7,6 +7,8 @@ import (
 
 const (
 	BaseAnimeFields string = "id,title,main_picture,alternative_titles,start_date,end_date,start_season,nsfw,synopsis,num_episodes,mean,rank,popularity,media_type,status"
+	// AnimeListStatusFields are the fields of the list entries
+	AnimeListStatusFields string = "list_status{status,score,num_episodes_watched,is_rewatching,updated_at,start_date,finish_date,num_times_rewatched,comments}"
 )
 
 type (
@@ -52,6 +54,10 @@ type (
 			NumEpisodesWatched int             `json:"num_episodes_watched"`
 			Score              int             `json:"score"`
 			UpdatedAt          string          `json:"updated_at"`
+			StartDate          string          `json:"start_date"`  // e.g. "2024-01-31", "2024-01" or "2024"
+			FinishDate         string          `json:"finish_date"` // e.g. "2024-01-31", "2024-01" or "2024"
+			NumTimesRewatched  int             `json:"num_times_rewatched"`
+			Comments           string          `json:"comments"`
 		} `json:"list_status"`
 	}
 )
@@ -80,22 +86,30 @@ func (w *Wrapper) GetAnimeDetails(mId int) (*BasicAnime, error) {
 func (w *Wrapper) GetAnimeCollection() ([]*AnimeListEntry, error) {
 	w.logger.Debug().Msg("mal: Getting anime collection")
 
-	reqUrl := fmt.Sprintf("%s/users/@me/animelist?fields=list_status&limit=1000", ApiBaseURL)
+	reqUrl := fmt.Sprintf("%s/users/@me/animelist?fields=%s&limit=1000&nsfw=true", ApiBaseURL, AnimeListStatusFields)
 
 	type response struct {
-		Data []*AnimeListEntry `json:"data"`
+		Data   []*AnimeListEntry `json:"data"`
+		Paging struct {
+			Next string `json:"next"`
+		} `json:"paging"`
 	}
 
-	var data response
-	err := w.doQuery("GET", reqUrl, nil, "application/json", &data)
-	if err != nil {
-		w.logger.Error().Err(err).Msg("mal: Failed to get anime collection")
-		return nil, err
+	ret := make([]*AnimeListEntry, 0)
+	for reqUrl != "" {
+		var data response
+		err := w.doQuery("GET", reqUrl, nil, "application/json", &data)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("mal: Failed to get anime collection")
+			return nil, err
+		}
+		ret = append(ret, data.Data...)
+		reqUrl = data.Paging.Next
 	}
 
-	w.logger.Info().Msg("mal: Fetched anime collection")
+	w.logger.Info().Int("count", len(ret)).Msg("mal: Fetched anime collection")
 
-	return data.Data, nil
+	return ret, nil
 }
 
 type AnimeListProgressParams struct {
@@ -138,6 +152,9 @@ type AnimeListStatusParams struct {
 	IsRewatching       *bool
 	NumEpisodesWatched *int
 	Score              *int
+	StartDate          *string // e.g. "2024-01-31", empty to remove the date
+	FinishDate         *string // e.g. "2024-01-31", empty to remove the date
+	NumTimesRewatched  *int
 }
 
 func (w *Wrapper) UpdateAnimeListStatus(opts *AnimeListStatusParams, mId int) error {
@@ -159,6 +176,15 @@ func (w *Wrapper) UpdateAnimeListStatus(opts *AnimeListStatusParams, mId int) er
 	if opts.Score != nil {
 		urlData.Set("score", fmt.Sprintf("%d", *opts.Score))
 	}
+	if opts.StartDate != nil {
+		urlData.Set("start_date", *opts.StartDate)
+	}
+	if opts.FinishDate != nil {
+		urlData.Set("finish_date", *opts.FinishDate)
+	}
+	if opts.NumTimesRewatched != nil {
+		urlData.Set("num_times_rewatched", fmt.Sprintf("%d", *opts.NumTimesRewatched))
+	}
 	encodedData := urlData.Encode()
 
 	err := w.doMutation("PATCH", reqUrl, encodedData)
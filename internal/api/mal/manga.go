@@ -7,6 +7,8 @@ import (
 
 const (
 	BaseMangaFields string = "id,title,main_picture,alternative_titles,start_date,end_date,nsfw,synopsis,num_volumes,num_chapters,mean,rank,popularity,media_type,status"
+	// MangaListStatusFields are the fields of the list entries
+	MangaListStatusFields string = "list_status{status,score,num_volumes_read,num_chapters_read,is_rereading,updated_at,start_date,finish_date,num_times_reread,comments}"
 )
 
 type (
@@ -51,6 +53,10 @@ type (
 			NumChaptersRead int             `json:"num_chapters_read"`
 			Score           int             `json:"score"`
 			UpdatedAt       string          `json:"updated_at"`
+			StartDate       string          `json:"start_date"`  // e.g. "2024-01-31", "2024-01" or "2024"
+			FinishDate      string          `json:"finish_date"` // e.g. "2024-01-31", "2024-01" or "2024"
+			NumTimesReread  int             `json:"num_times_reread"`
+			Comments        string          `json:"comments"`
 		} `json:"list_status"`
 	}
 )
@@ -79,22 +85,30 @@ func (w *Wrapper) GetMangaDetails(mId int) (*BasicManga, error) {
 func (w *Wrapper) GetMangaCollection() ([]*MangaListEntry, error) {
 	w.logger.Debug().Msg("mal: Getting manga collection")
 
-	reqUrl := fmt.Sprintf("%s/users/@me/mangalist?fields=list_status&limit=1000", ApiBaseURL)
+	reqUrl := fmt.Sprintf("%s/users/@me/mangalist?fields=%s&limit=1000&nsfw=true", ApiBaseURL, MangaListStatusFields)
 
 	type response struct {
-		Data []*MangaListEntry `json:"data"`
+		Data   []*MangaListEntry `json:"data"`
+		Paging struct {
+			Next string `json:"next"`
+		} `json:"paging"`
 	}
 
-	var data response
-	err := w.doQuery("GET", reqUrl, nil, "application/json", &data)
-	if err != nil {
-		w.logger.Error().Err(err).Msg("mal: Failed to get manga collection")
-		return nil, err
+	ret := make([]*MangaListEntry, 0)
+	for reqUrl != "" {
+		var data response
+		err := w.doQuery("GET", reqUrl, nil, "application/json", &data)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("mal: Failed to get manga collection")
+			return nil, err
+		}
+		ret = append(ret, data.Data...)
+		reqUrl = data.Paging.Next
 	}
 
-	w.logger.Info().Msg("mal: Fetched manga collection")
+	w.logger.Info().Int("count", len(ret)).Msg("mal: Fetched manga collection")
 
-	return data.Data, nil
+	return ret, nil
 }
 
 type MangaListProgressParams struct {
@@ -137,6 +151,9 @@ type MangaListStatusParams struct {
 	IsRereading     *bool
 	NumChaptersRead *int
 	Score           *int
+	StartDate       *string // e.g. "2024-01-31", empty to remove the date
+	FinishDate      *string // e.g. "2024-01-31", empty to remove the date
+	NumTimesReread  *int
 }
 
 func (w *Wrapper) UpdateMangaListStatus(opts *MangaListStatusParams, mId int) error {
@@ -158,6 +175,15 @@ func (w *Wrapper) UpdateMangaListStatus(opts *MangaListStatusParams, mId int) er
 	if opts.Score != nil {
 		urlData.Set("score", fmt.Sprintf("%d", *opts.Score))
 	}
+	if opts.StartDate != nil {
+		urlData.Set("start_date", *opts.StartDate)
+	}
+	if opts.FinishDate != nil {
+		urlData.Set("finish_date", *opts.FinishDate)
+	}
+	if opts.NumTimesReread != nil {
+		urlData.Set("num_times_reread", fmt.Sprintf("%d", *opts.NumTimesReread))
+	}
 	encodedData := urlData.Encode()
 
 	err := w.doMutation("PATCH", reqUrl, encodedData)
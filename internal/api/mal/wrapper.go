@@ -139,7 +139,7 @@ func VerifyMALAuth(malInfo *models.Mal, db *db.Database, logger *zerolog.Logger)
 	// Save
 	updatedMalInfo := models.Mal{
 		BaseModel: models.BaseModel{
-			ID:        1,
+			ID:        malInfo.ID,
 			UpdatedAt: time.Now(),
 		},
 		Username:       "",
@@ -92,3 +92,107 @@ func (i *ReducedAnimeListItem) GetTvdbID() (int, bool) {
 		return 0, false
 	}
 }
+
+type (
+	// AnimeListResponse is the response from the anime list API.
+	// It maps the IDs of the anime across the trackers.
+	AnimeListResponse struct {
+		items            []*AnimeListItem
+		itemsByMalID     map[int]*AnimeListItem
+		itemsByKitsuID   map[int]*AnimeListItem
+		itemsByAnilistID map[int]*AnimeListItem
+		Count            int
+	}
+	AnimeListItem struct {
+		AnilistID int    `json:"anilist_id,omitempty"`
+		MalID     int    `json:"mal_id,omitempty"`
+		KitsuID   int    `json:"kitsu_id,omitempty"`
+		AnidbID   int    `json:"anidb_id,omitempty"`
+		Type      string `json:"type,omitempty"`
+	}
+)
+
+func GetAnimeLists() (resp *AnimeListResponse, err error) {
+	client := http.Client{}
+
+	req, err := http.NewRequest("GET", "https://raw.githubusercontent.com/Fribb/anime-lists/master/anime-list-mini.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var items []*AnimeListItem
+	if err := json.NewDecoder(res.Body).Decode(&items); err != nil {
+		return nil, err
+	}
+
+	return NewAnimeListResponse(items), nil
+}
+
+func NewAnimeListResponse(items []*AnimeListItem) *AnimeListResponse {
+	ret := &AnimeListResponse{
+		items:            items,
+		itemsByMalID:     make(map[int]*AnimeListItem),
+		itemsByKitsuID:   make(map[int]*AnimeListItem),
+		itemsByAnilistID: make(map[int]*AnimeListItem),
+		Count:            len(items),
+	}
+	for _, item := range items {
+		if item.AnilistID == 0 {
+			continue
+		}
+		ret.itemsByAnilistID[item.AnilistID] = item
+		if item.MalID != 0 {
+			ret.itemsByMalID[item.MalID] = item
+		}
+		if item.KitsuID != 0 {
+			ret.itemsByKitsuID[item.KitsuID] = item
+		}
+	}
+	return ret
+}
+
+func (i *AnimeListResponse) GetItems() []*AnimeListItem {
+	return i.items
+}
+
+// FindAnilistIDFromMalID will return the AniList ID for the given MAL ID.
+func (i *AnimeListResponse) FindAnilistIDFromMalID(malID int) (anilistID int, ok bool) {
+	if i == nil {
+		return 0, false
+	}
+	item, ok := i.itemsByMalID[malID]
+	if !ok {
+		return 0, false
+	}
+	return item.AnilistID, true
+}
+
+// FindAnilistIDFromKitsuID will return the AniList ID for the given Kitsu ID.
+func (i *AnimeListResponse) FindAnilistIDFromKitsuID(kitsuID int) (anilistID int, ok bool) {
+	if i == nil {
+		return 0, false
+	}
+	item, ok := i.itemsByKitsuID[kitsuID]
+	if !ok {
+		return 0, false
+	}
+	return item.AnilistID, true
+}
+
+// FindMalIDFromAnilistID will return the MAL ID for the given AniList ID.
+func (i *AnimeListResponse) FindMalIDFromAnilistID(anilistID int) (malID int, ok bool) {
+	if i == nil {
+		return 0, false
+	}
+	item, ok := i.itemsByAnilistID[anilistID]
+	if !ok || item.MalID == 0 {
+		return 0, false
+	}
+	return item.MalID, true
+}
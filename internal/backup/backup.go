@@ -0,0 +1,301 @@
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/glebarez/sqlite"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FormatVersion is the version of the archive format.
+	// It should be incremented when the layout of the archive changes.
+	FormatVersion = 1
+
+	// MaxUploadSize is the maximum size of an uploaded archive.
+	MaxUploadSize = 512 * 1024 * 1024
+
+	ManifestFileName = "manifest.json"
+	FilePrefix       = "seanime-backup-"
+	FileExtension    = ".zip"
+)
+
+// Types of the files stored in an archive
+const (
+	FileTypeDatabase        = "database"         // Main database (settings, local files, auto downloader, playlists, mappings, theme...)
+	FileTypeOfflineDatabase = "offline_database" // Database of the offline mode
+	FileTypeFileCache       = "filecache"        // Watch history (continuity)
+	FileTypeExtension       = "extension"        // Installed extensions
+)
+
+// Paths of the files in the archive
+const (
+	archiveDatabasePath        = "database/seanime.db"
+	archiveOfflineDatabasePath = "database/local.db"
+	archiveFileCacheDir        = "filecache/"
+	archiveExtensionsDir       = "extensions/"
+)
+
+var (
+	ErrInvalidArchive     = errors.New("backup: invalid archive")
+	ErrUnsupportedVersion = errors.New("backup: archive was created by a newer version of Seanime")
+	ErrBackupNotFound     = errors.New("backup: backup not found")
+)
+
+type (
+	// Manifest describes the content of an archive.
+	Manifest struct {
+		FormatVersion int             `json:"formatVersion"`
+		AppVersion    string          `json:"appVersion"`
+		CreatedAt     time.Time       `json:"createdAt"`
+		Files         []*ManifestFile `json:"files"`
+	}
+
+	ManifestFile struct {
+		Path   string `json:"path"`
+		Type   string `json:"type"`
+		Size   int64  `json:"size"`
+		Sha256 string `json:"sha256"`
+	}
+
+	// Paths are the locations of the data included in a backup.
+	Paths struct {
+		DataDir       string // App data directory, contains the database
+		DatabaseName  string // Name of the database file, without extension
+		CacheDir      string // File cache directory
+		OfflineDir    string // Offline mode directory, contains local.db
+		ExtensionsDir string // Extensions directory
+	}
+
+	Manager struct {
+		logger     *zerolog.Logger
+		database   *db.Database
+		paths      *Paths
+		appVersion string
+		settings   *models.BackupSettings
+		mu         sync.Mutex
+	}
+
+	NewManagerOptions struct {
+		Logger     *zerolog.Logger
+		Database   *db.Database
+		Paths      *Paths
+		AppVersion string
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	return &Manager{
+		logger:     opts.Logger,
+		database:   opts.Database,
+		paths:      opts.Paths,
+		appVersion: opts.AppVersion,
+		settings:   &models.BackupSettings{},
+	}
+}
+
+func (p *Paths) databasePath() string {
+	return filepath.Join(p.DataDir, p.DatabaseName+".db")
+}
+
+func (p *Paths) offlineDatabasePath() string {
+	return filepath.Join(p.OfflineDir, "local.db")
+}
+
+// isBackedUpCacheFile returns true if the file cache file should be included in backups.
+// Only the watch history buckets are included, other buckets can be fetched again.
+func isBackedUpCacheFile(name string) bool {
+	return strings.HasPrefix(name, "watch_history") && strings.HasSuffix(name, ".cache")
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Export writes an archive containing the current state to w.
+// The encryption key is not included, credentials can only be read back with the same key (see RestoreResult).
+func (m *Manager) Export(w io.Writer) (*Manifest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tmpDir, err := os.MkdirTemp("", "seanime-backup-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		AppVersion:    m.appVersion,
+		CreatedAt:     time.Now(),
+		Files:         make([]*ManifestFile, 0),
+	}
+
+	zw := zip.NewWriter(w)
+
+	// Main database
+	// VACUUM INTO creates a consistent copy while the database is in use
+	dbSnapshot := filepath.Join(tmpDir, "seanime.db")
+	if err := m.database.Gorm().Exec("VACUUM INTO ?", dbSnapshot).Error; err != nil {
+		return nil, fmt.Errorf("backup: failed to snapshot database: %w", err)
+	}
+	if err := addFile(zw, manifest, dbSnapshot, archiveDatabasePath, FileTypeDatabase); err != nil {
+		return nil, err
+	}
+
+	// Offline database
+	if _, err := os.Stat(m.paths.offlineDatabasePath()); err == nil {
+		offlineSnapshot := filepath.Join(tmpDir, "local.db")
+		if err := snapshotSqlite(m.paths.offlineDatabasePath(), offlineSnapshot); err != nil {
+			return nil, fmt.Errorf("backup: failed to snapshot offline database: %w", err)
+		}
+		if err := addFile(zw, manifest, offlineSnapshot, archiveOfflineDatabasePath, FileTypeOfflineDatabase); err != nil {
+			return nil, err
+		}
+	}
+
+	// Watch history
+	if entries, err := os.ReadDir(m.paths.CacheDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || !isBackedUpCacheFile(entry.Name()) {
+				continue
+			}
+			err := addFile(zw, manifest, filepath.Join(m.paths.CacheDir, entry.Name()), archiveFileCacheDir+entry.Name(), FileTypeFileCache)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Extensions
+	if m.paths.ExtensionsDir != "" {
+		err := filepath.WalkDir(m.paths.ExtensionsDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !d.Type().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(m.paths.ExtensionsDir, path)
+			if err != nil {
+				return err
+			}
+			return addFile(zw, manifest, path, archiveExtensionsDir+filepath.ToSlash(rel), FileTypeExtension)
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	// Write the manifest last so that it contains the checksums
+	mw, err := zw.Create(ManifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewEncoder(mw).Encode(manifest); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	m.logger.Info().Int("files", len(manifest.Files)).Msg("backup: Archive created")
+
+	return manifest, nil
+}
+
+// ExportToFile writes an archive to the given path.
+// If the path is a directory, the archive is created in it with a timestamped name.
+func (m *Manager) ExportToFile(path string) (string, *Manifest, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, newBackupFileName(time.Now()))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", nil, err
+	}
+
+	// Write to a temporary file first so that an interrupted backup does not leave a corrupted archive
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	manifest, err := m.Export(f)
+	_ = f.Close()
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return "", nil, err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", nil, err
+	}
+
+	return path, manifest, nil
+}
+
+func newBackupFileName(t time.Time) string {
+	return FilePrefix + t.Format("2006-01-02_15-04-05") + FileExtension
+}
+
+// addFile copies the file to the archive and adds it to the manifest.
+func addFile(zw *zip.Writer, manifest *Manifest, srcPath string, archivePath string, fileType string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	w, err := zw.Create(archivePath)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, h), src)
+	if err != nil {
+		return err
+	}
+
+	manifest.Files = append(manifest.Files, &ManifestFile{
+		Path:   archivePath,
+		Type:   fileType,
+		Size:   size,
+		Sha256: hex.EncodeToString(h.Sum(nil)),
+	})
+	return nil
+}
+
+// snapshotSqlite creates a consistent copy of a SQLite database that may be in use.
+func snapshotSqlite(srcPath string, destPath string) error {
+	conn, err := gorm.Open(sqlite.Open(srcPath), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	return conn.Exec("VACUUM INTO ?", destPath).Error
+}
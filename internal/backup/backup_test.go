@@ -0,0 +1,233 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"seanime/internal/database/encryption"
+	"seanime/internal/database/models"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) (*Manager, *Paths) {
+	logger := util.NewLogger()
+	root := t.TempDir()
+
+	paths := &Paths{
+		DataDir:       root,
+		DatabaseName:  "seanime",
+		CacheDir:      filepath.Join(root, "cache"),
+		OfflineDir:    filepath.Join(root, "offline"),
+		ExtensionsDir: filepath.Join(root, "extensions"),
+	}
+	require.NoError(t, os.MkdirAll(paths.CacheDir, 0755))
+	require.NoError(t, os.MkdirAll(paths.ExtensionsDir, 0755))
+
+	m := NewManager(&NewManagerOptions{
+		Logger:     logger,
+		Database:   testdb.Open(t, paths.DataDir, paths.DatabaseName),
+		Paths:      paths,
+		AppVersion: "test",
+	})
+	return m, paths
+}
+
+func TestExportAndRestore(t *testing.T) {
+	m, paths := newTestManager(t)
+
+	_, err := m.database.UpsertTheme(&models.Theme{BaseModel: models.BaseModel{ID: 1}, AnimeEntryScreenLayout: "stacked"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(paths.CacheDir, "watch_history.cache"), []byte(`{"1":{}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.CacheDir, "anilist.cache"), []byte(`{}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.ExtensionsDir, "ext.json"), []byte(`{"id":"ext"}`), 0644))
+
+	var buf bytes.Buffer
+	manifest, err := m.Export(&buf)
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, manifest.FormatVersion)
+
+	types := make(map[string]int)
+	for _, f := range manifest.Files {
+		types[f.Type]++
+	}
+	require.Equal(t, 1, types[FileTypeDatabase])
+	require.Equal(t, 1, types[FileTypeFileCache]) // Other cache buckets are not included
+	require.Equal(t, 1, types[FileTypeExtension])
+
+	// Change the data after the backup
+	require.NoError(t, os.WriteFile(filepath.Join(paths.CacheDir, "watch_history.cache"), []byte(`{}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.CacheDir, "watch_history_2.cache"), []byte(`{}`), 0644))
+	require.NoError(t, os.Remove(filepath.Join(paths.ExtensionsDir, "ext.json")))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.ExtensionsDir, "new.json"), []byte(`{"id":"new"}`), 0644))
+
+	_, err = m.Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.True(t, m.HasPendingRestore())
+
+	require.NoError(t, ApplyPendingRestore(paths, m.logger))
+	require.False(t, m.HasPendingRestore())
+
+	content, err := os.ReadFile(filepath.Join(paths.CacheDir, "watch_history.cache"))
+	require.NoError(t, err)
+	require.Equal(t, `{"1":{}}`, string(content))
+	require.FileExists(t, filepath.Join(paths.ExtensionsDir, "ext.json"))
+	require.FileExists(t, filepath.Join(paths.DataDir, "seanime.db.pre-restore"))
+
+	// Files that are not in the archive are removed
+	require.NoFileExists(t, filepath.Join(paths.CacheDir, "watch_history_2.cache"))
+	require.NoFileExists(t, filepath.Join(paths.ExtensionsDir, "new.json"))
+	require.FileExists(t, filepath.Join(paths.CacheDir, "anilist.cache"))
+	require.NoDirExists(t, paths.ExtensionsDir+preRestoreSuffix)
+	require.NoDirExists(t, paths.CacheDir+preRestoreSuffix)
+
+	// The restored database can be opened
+	restored := testdb.Open(t, paths.DataDir, paths.DatabaseName)
+	var theme models.Theme
+	require.NoError(t, restored.Gorm().First(&theme, 1).Error)
+	require.Equal(t, "stacked", theme.AnimeEntryScreenLayout)
+}
+
+func TestRestoreWithAnotherKey(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	setKey := func() {
+		key := make([]byte, 32)
+		_, err := rand.Read(key)
+		require.NoError(t, err)
+		require.NoError(t, encryption.SetKey(key))
+	}
+
+	setKey()
+	require.NoError(t, m.database.Gorm().Create(&models.Account{BaseModel: models.BaseModel{ID: 1}, Username: "user", Token: "token"}).Error)
+
+	var buf bytes.Buffer
+	_, err := m.Export(&buf)
+	require.NoError(t, err)
+
+	// Same key
+	res, err := m.Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Empty(t, res.UndecryptableCredentials)
+
+	// The archive was created on another machine
+	setKey()
+	res, err = m.Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, []string{"accounts.token"}, res.UndecryptableCredentials)
+}
+
+func TestRestoreInvalidArchive(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "missing manifest",
+			files: map[string]string{archiveDatabasePath: "data"},
+		},
+		{
+			name: "newer format",
+			files: map[string]string{
+				ManifestFileName: `{"formatVersion":99,"files":[{"path":"database/seanime.db","type":"database"}]}`,
+			},
+		},
+		{
+			name: "path traversal",
+			files: map[string]string{
+				ManifestFileName: `{"formatVersion":1,"files":[{"path":"database/seanime.db","type":"database"},{"path":"extensions/../../evil","type":"extension"}]}`,
+			},
+		},
+		{
+			name: "checksum mismatch",
+			files: map[string]string{
+				ManifestFileName:    `{"formatVersion":1,"files":[{"path":"database/seanime.db","type":"database","size":4,"sha256":"00"}]}`,
+				archiveDatabasePath: "data",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			for name, content := range tt.files {
+				w, err := zw.Create(name)
+				require.NoError(t, err)
+				_, err = w.Write([]byte(content))
+				require.NoError(t, err)
+			}
+			require.NoError(t, zw.Close())
+
+			_, err := m.Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.Error(t, err)
+			require.False(t, m.HasPendingRestore())
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.SetSettings(&models.BackupSettings{Enabled: true, Interval: 1, MaxBackups: 2})
+
+	dir := m.GetBackupDir()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for i := 1; i <= 3; i++ {
+		createdAt := time.Now().Add(-time.Duration(i) * 24 * time.Hour)
+		path := filepath.Join(dir, newBackupFileName(createdAt))
+		require.NoError(t, os.WriteFile(path, []byte{}, 0644))
+		require.NoError(t, os.Chtimes(path, createdAt, createdAt))
+	}
+
+	// The existing backups are older than the interval
+	require.NoError(t, m.RunScheduledBackup())
+
+	backups, err := m.ListBackups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	require.WithinDuration(t, time.Now(), backups[0].CreatedAt, time.Minute)
+}
+
+func TestApplyPendingRestoreRollback(t *testing.T) {
+	m, paths := newTestManager(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(paths.CacheDir, "watch_history.cache"), []byte(`{"1":{}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.ExtensionsDir, "ext.json"), []byte(`{"id":"ext"}`), 0644))
+
+	var buf bytes.Buffer
+	_, err := m.Export(&buf)
+	require.NoError(t, err)
+
+	// Change the data after the backup
+	require.NoError(t, os.WriteFile(filepath.Join(paths.CacheDir, "watch_history.cache"), []byte(`{}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.ExtensionsDir, "new.json"), []byte(`{"id":"new"}`), 0644))
+	currentDatabase, err := os.ReadFile(paths.databasePath())
+	require.NoError(t, err)
+
+	_, err = m.Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	// The last file of the archive can't be moved
+	require.NoError(t, os.Remove(filepath.Join(paths.DataDir, RestoreDirName, "extensions", "ext.json")))
+
+	require.Error(t, ApplyPendingRestore(paths, m.logger))
+
+	// The current files are put back and the restore is kept
+	require.True(t, m.HasPendingRestore())
+	content, err := os.ReadFile(filepath.Join(paths.CacheDir, "watch_history.cache"))
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(content))
+	require.FileExists(t, filepath.Join(paths.ExtensionsDir, "new.json"))
+	database, err := os.ReadFile(paths.databasePath())
+	require.NoError(t, err)
+	require.Equal(t, currentDatabase, database)
+	require.NoFileExists(t, paths.databasePath()+preRestoreSuffix)
+	require.FileExists(t, filepath.Join(paths.DataDir, RestoreDirName, "filecache", "watch_history.cache"))
+}
@@ -0,0 +1,518 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/glebarez/sqlite"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormlogger "gorm.io/gorm/logger"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"seanime/internal/database/encryption"
+	"strings"
+)
+
+// RestoreDirName is the directory in the data directory where a restored archive is staged.
+// The files are moved in place the next time Seanime starts, before the database is opened.
+const RestoreDirName = "restore"
+
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// RestoreResult describes a staged restore.
+type RestoreResult struct {
+	Manifest *Manifest `json:"manifest"`
+	// Encrypted credentials of the archive that cannot be decrypted with the current encryption key, as "table.column".
+	// This happens when the archive was created with another key, e.g. on another machine.
+	// These credentials will be read as empty values and need to be entered again after the restore,
+	// unless the key file (or SEANIME_ENCRYPTION_KEY) of the previous installation is used.
+	UndecryptableCredentials []string `json:"undecryptableCredentials"`
+}
+
+// ReadManifest reads and validates the manifest of an archive.
+// It does not check the content of the files, see Restore.
+func ReadManifest(zr *zip.Reader) (*Manifest, error) {
+	var manifestFile *zip.File
+	for _, f := range zr.File {
+		if f.Name == ManifestFileName {
+			manifestFile = f
+			break
+		}
+	}
+	if manifestFile == nil {
+		return nil, fmt.Errorf("%w: missing manifest", ErrInvalidArchive)
+	}
+
+	rc, err := manifestFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest", ErrInvalidArchive)
+	}
+
+	if manifest.FormatVersion < 1 {
+		return nil, fmt.Errorf("%w: invalid format version", ErrInvalidArchive)
+	}
+	if manifest.FormatVersion > FormatVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	hasDatabase := false
+	for _, file := range manifest.Files {
+		if !isValidArchivePath(file) {
+			return nil, fmt.Errorf("%w: invalid path %q", ErrInvalidArchive, file.Path)
+		}
+		if file.Type == FileTypeDatabase {
+			hasDatabase = true
+		}
+	}
+	if !hasDatabase {
+		return nil, fmt.Errorf("%w: missing database", ErrInvalidArchive)
+	}
+
+	return &manifest, nil
+}
+
+// isValidArchivePath returns true if the file path matches its type and does not escape its directory.
+func isValidArchivePath(file *ManifestFile) bool {
+	if file.Path == "" || strings.Contains(file.Path, "\\") || path.Clean(file.Path) != file.Path || strings.HasPrefix(file.Path, "/") {
+		return false
+	}
+	for _, part := range strings.Split(file.Path, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+
+	switch file.Type {
+	case FileTypeDatabase:
+		return file.Path == archiveDatabasePath
+	case FileTypeOfflineDatabase:
+		return file.Path == archiveOfflineDatabasePath
+	case FileTypeFileCache:
+		name := strings.TrimPrefix(file.Path, archiveFileCacheDir)
+		return strings.HasPrefix(file.Path, archiveFileCacheDir) && !strings.Contains(name, "/") && isBackedUpCacheFile(name)
+	case FileTypeExtension:
+		return strings.HasPrefix(file.Path, archiveExtensionsDir) && len(file.Path) > len(archiveExtensionsDir)
+	}
+	return false
+}
+
+// Restore validates the archive and stages its content.
+// The restored data replaces the current data the next time Seanime starts.
+// The result lists the credentials that cannot be decrypted with the current encryption key.
+func (m *Manager) Restore(r io.ReaderAt, size int64) (*RestoreResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+
+	manifest, err := ReadManifest(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveFiles := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		archiveFiles[f.Name] = f
+	}
+
+	restoreDir := filepath.Join(m.paths.DataDir, RestoreDirName)
+	_ = os.RemoveAll(restoreDir)
+
+	// Extract and verify each file
+	for _, file := range manifest.Files {
+		f, ok := archiveFiles[file.Path]
+		if !ok {
+			_ = os.RemoveAll(restoreDir)
+			return nil, fmt.Errorf("%w: missing file %q", ErrInvalidArchive, file.Path)
+		}
+		if err := extractFile(f, file, filepath.Join(restoreDir, filepath.FromSlash(file.Path))); err != nil {
+			_ = os.RemoveAll(restoreDir)
+			return nil, err
+		}
+	}
+
+	// The manifest is written last, it marks the restore as complete
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		_ = os.RemoveAll(restoreDir)
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(restoreDir, ManifestFileName), data, 0644); err != nil {
+		_ = os.RemoveAll(restoreDir)
+		return nil, err
+	}
+
+	undecryptable, err := findUndecryptableCredentials(filepath.Join(restoreDir, filepath.FromSlash(archiveDatabasePath)))
+	if err != nil {
+		_ = os.RemoveAll(restoreDir)
+		return nil, err
+	}
+	if len(undecryptable) > 0 {
+		m.logger.Warn().Strs("credentials", undecryptable).Msg("backup: The archive was created with another encryption key, some credentials will need to be entered again")
+	}
+
+	m.logger.Info().Int("files", len(manifest.Files)).Str("createdAt", manifest.CreatedAt.String()).Msg("backup: Restore staged, it will be applied on restart")
+
+	return &RestoreResult{
+		Manifest:                 manifest,
+		UndecryptableCredentials: undecryptable,
+	}, nil
+}
+
+// RestoreFromBackup restores one of the backups from the backup directory.
+func (m *Manager) RestoreFromBackup(name string) (*RestoreResult, error) {
+	backupPath, err := m.getBackupPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Restore(f, info.Size())
+}
+
+// HasPendingRestore returns true if a restore will be applied on the next start.
+func (m *Manager) HasPendingRestore() bool {
+	_, err := os.Stat(filepath.Join(m.paths.DataDir, RestoreDirName, ManifestFileName))
+	return err == nil
+}
+
+func extractFile(f *zip.File, file *ManifestFile, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dest, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	h := sha256.New()
+	var header bytes.Buffer
+	n, err := io.Copy(io.MultiWriter(dest, h, &limitedBuffer{buf: &header, max: len(sqliteHeader)}), rc)
+	if err != nil {
+		return err
+	}
+
+	if n != file.Size || hex.EncodeToString(h.Sum(nil)) != file.Sha256 {
+		return fmt.Errorf("%w: checksum mismatch for %q", ErrInvalidArchive, file.Path)
+	}
+
+	if (file.Type == FileTypeDatabase || file.Type == FileTypeOfflineDatabase) && !bytes.Equal(header.Bytes(), sqliteHeader) {
+		return fmt.Errorf("%w: %q is not a SQLite database", ErrInvalidArchive, file.Path)
+	}
+
+	return nil
+}
+
+// findUndecryptableCredentials returns the encrypted columns of the database that contain values
+// which cannot be decrypted with the current key, as "table.column".
+func findUndecryptableCredentials(dbPath string) ([]string, error) {
+	conn, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return nil, err
+	}
+	defer sqlDB.Close()
+
+	var tables []string
+	if err := conn.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'").Scan(&tables).Error; err != nil {
+		return nil, err
+	}
+
+	ret := make([]string, 0)
+	for _, table := range tables {
+		columns, err := conn.Migrator().ColumnTypes(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, column := range columns {
+			var values []string
+			err := conn.Table(table).
+				Where(clause.Like{Column: clause.Column{Name: column.Name()}, Value: encryption.Prefix + "%"}).
+				Distinct().
+				Pluck(column.Name(), &values).Error
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				if _, err := encryption.Decrypt(value); err != nil {
+					ret = append(ret, table+"."+column.Name())
+					break
+				}
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// limitedBuffer keeps the first bytes written to it.
+type limitedBuffer struct {
+	buf *bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.max - b.buf.Len(); remaining > 0 {
+		if len(p) < remaining {
+			remaining = len(p)
+		}
+		b.buf.Write(p[:remaining])
+	}
+	return len(p), nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// preRestoreSuffix is appended to the paths of the current files while the restored ones are moved in.
+const preRestoreSuffix = ".pre-restore"
+
+// ApplyPendingRestore moves the files of a staged restore in place.
+// It should be called before the database and the file cacher are opened.
+// The restore is all-or-nothing, the current files are put back and the staged restore is kept if a file can't be moved.
+// The extensions and the watch history that are not in the archive are removed.
+// The current database is kept next to the restored one with a ".pre-restore" suffix.
+func ApplyPendingRestore(paths *Paths, logger *zerolog.Logger) error {
+	restoreDir := filepath.Join(paths.DataDir, RestoreDirName)
+
+	data, err := os.ReadFile(filepath.Join(restoreDir, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Remove incomplete restores
+			_ = os.RemoveAll(restoreDir)
+			return nil
+		}
+		return err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		_ = os.RemoveAll(restoreDir)
+		return fmt.Errorf("%w: invalid manifest", ErrInvalidArchive)
+	}
+
+	logger.Info().Str("createdAt", manifest.CreatedAt.String()).Msg("backup: Applying restore")
+
+	tx := &restoreTx{}
+	if err := tx.apply(paths, restoreDir, &manifest); err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			logger.Error().Err(rollbackErr).Msg("backup: Failed to put back the files replaced by the restore")
+		}
+		_ = os.Remove(paths.CacheDir + preRestoreSuffix)
+		return err
+	}
+
+	_ = os.RemoveAll(restoreDir)
+	tx.cleanup()
+	_ = os.Remove(paths.CacheDir + preRestoreSuffix)
+
+	logger.Info().Int("files", len(manifest.Files)).Msg("backup: Restore applied")
+
+	return nil
+}
+
+type (
+	// restoreTx records the file operations of a restore so that they can be undone.
+	restoreTx struct {
+		// Current files moved aside
+		replaced []movedPath
+		// Files moved from the staged restore
+		restored []movedPath
+	}
+
+	movedPath struct {
+		from string
+		to   string
+		// keep is true if the path moved aside is kept after the restore
+		keep bool
+	}
+)
+
+func (tx *restoreTx) apply(paths *Paths, restoreDir string, manifest *Manifest) error {
+	hasType := func(fileType string) bool {
+		for _, file := range manifest.Files {
+			if file.Type == fileType {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Move the current files aside
+	if hasType(FileTypeDatabase) {
+		if err := tx.moveAsideDatabase(paths.databasePath()); err != nil {
+			return err
+		}
+	}
+	if hasType(FileTypeOfflineDatabase) {
+		if err := tx.moveAsideDatabase(paths.offlineDatabasePath()); err != nil {
+			return err
+		}
+	}
+	if paths.ExtensionsDir != "" {
+		if err := tx.moveAside(paths.ExtensionsDir, paths.ExtensionsDir+preRestoreSuffix, false); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(paths.ExtensionsDir, 0755); err != nil {
+			return err
+		}
+	}
+	if entries, err := os.ReadDir(paths.CacheDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || !isBackedUpCacheFile(entry.Name()) {
+				continue
+			}
+			aside := filepath.Join(paths.CacheDir+preRestoreSuffix, entry.Name())
+			if err := tx.moveAside(filepath.Join(paths.CacheDir, entry.Name()), aside, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Move the restored files in
+	for _, file := range manifest.Files {
+		if !isValidArchivePath(file) {
+			continue
+		}
+
+		var dest string
+		switch file.Type {
+		case FileTypeDatabase:
+			dest = paths.databasePath()
+		case FileTypeOfflineDatabase:
+			dest = paths.offlineDatabasePath()
+		case FileTypeFileCache:
+			dest = filepath.Join(paths.CacheDir, strings.TrimPrefix(file.Path, archiveFileCacheDir))
+		case FileTypeExtension:
+			dest = filepath.Join(paths.ExtensionsDir, filepath.FromSlash(strings.TrimPrefix(file.Path, archiveExtensionsDir)))
+		}
+
+		src := filepath.Join(restoreDir, filepath.FromSlash(file.Path))
+		if err := moveFile(src, dest); err != nil {
+			return fmt.Errorf("backup: failed to restore %q: %w", file.Path, err)
+		}
+		tx.restored = append(tx.restored, movedPath{from: src, to: dest})
+	}
+
+	return nil
+}
+
+// moveAsideDatabase moves the SQLite database and its journal files aside.
+// The journal files are renamed so that the database can still be opened.
+func (tx *restoreTx) moveAsideDatabase(dbPath string) error {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := tx.moveAside(dbPath+suffix, dbPath+preRestoreSuffix+suffix, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveAside renames the file or directory if it exists, replacing the destination.
+func (tx *restoreTx) moveAside(from string, to string, keep bool) error {
+	if _, err := os.Stat(from); err != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(to); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return err
+	}
+	tx.replaced = append(tx.replaced, movedPath{from: from, to: to, keep: keep})
+	return nil
+}
+
+// rollback moves the restored files back to the staged restore and puts the current files back.
+func (tx *restoreTx) rollback() error {
+	var ret error
+	for i := len(tx.restored) - 1; i >= 0; i-- {
+		if err := moveFile(tx.restored[i].to, tx.restored[i].from); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	for i := len(tx.replaced) - 1; i >= 0; i-- {
+		m := tx.replaced[i]
+		// Remove what was created in place of the file, e.g. the directories of the restored extensions
+		_ = os.RemoveAll(m.from)
+		if err := os.Rename(m.to, m.from); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
+
+// cleanup removes the files that were moved aside, except the ones that are kept.
+func (tx *restoreTx) cleanup() {
+	for _, m := range tx.replaced {
+		if !m.keep {
+			_ = os.RemoveAll(m.to)
+		}
+	}
+}
+
+// moveFile renames the file, falling back to a copy if the destination is on another device.
+func moveFile(src string, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,180 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"seanime/internal/database/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultInterval   = 24 // hours
+	DefaultMaxBackups = 7
+)
+
+type BackupFile struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SetSettings sets the settings of the scheduled backups.
+func (m *Manager) SetSettings(settings *models.BackupSettings) {
+	if settings == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings = settings
+}
+
+// GetBackupDir returns the directory where backups are written.
+func (m *Manager) GetBackupDir() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.settings.Dir != "" {
+		return m.settings.Dir
+	}
+	return filepath.Join(m.paths.DataDir, "backups")
+}
+
+// CreateBackup writes a new archive to the backup directory and deletes the oldest backups.
+func (m *Manager) CreateBackup() (*BackupFile, error) {
+	backupPath, _, err := m.ExportToFile(filepath.Join(m.GetBackupDir(), newBackupFileName(time.Now())))
+	if err != nil {
+		m.logger.Error().Err(err).Msg("backup: Failed to create backup")
+		return nil, err
+	}
+
+	m.rotate()
+
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackupFile{
+		Name:      info.Name(),
+		Size:      info.Size(),
+		CreatedAt: info.ModTime(),
+	}, nil
+}
+
+// ListBackups returns the backups in the backup directory, newest first.
+func (m *Manager) ListBackups() ([]*BackupFile, error) {
+	ret := make([]*BackupFile, 0)
+
+	entries, err := os.ReadDir(m.GetBackupDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ret, nil
+		}
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackupFileName(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		ret = append(ret, &BackupFile{
+			Name:      entry.Name(),
+			Size:      info.Size(),
+			CreatedAt: info.ModTime(),
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.After(ret[j].CreatedAt)
+	})
+
+	return ret, nil
+}
+
+// GetBackupPath returns the path of a backup in the backup directory.
+func (m *Manager) GetBackupPath(name string) (string, error) {
+	return m.getBackupPath(name)
+}
+
+// DeleteBackup deletes a backup from the backup directory.
+func (m *Manager) DeleteBackup(name string) error {
+	backupPath, err := m.getBackupPath(name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(backupPath)
+}
+
+func (m *Manager) getBackupPath(name string) (string, error) {
+	if name != filepath.Base(name) || !isBackupFileName(name) {
+		return "", ErrBackupNotFound
+	}
+	backupPath := filepath.Join(m.GetBackupDir(), name)
+	if _, err := os.Stat(backupPath); err != nil {
+		return "", ErrBackupNotFound
+	}
+	return backupPath, nil
+}
+
+func isBackupFileName(name string) bool {
+	return strings.HasPrefix(name, FilePrefix) && strings.HasSuffix(name, FileExtension)
+}
+
+// rotate deletes the oldest backups so that at most MaxBackups are kept.
+func (m *Manager) rotate() {
+	m.mu.Lock()
+	maxBackups := m.settings.MaxBackups
+	m.mu.Unlock()
+
+	if maxBackups <= 0 {
+		return
+	}
+
+	backups, err := m.ListBackups()
+	if err != nil || len(backups) <= maxBackups {
+		return
+	}
+
+	for _, b := range backups[maxBackups:] {
+		if err := os.Remove(filepath.Join(m.GetBackupDir(), b.Name)); err != nil {
+			m.logger.Warn().Err(err).Str("name", b.Name).Msg("backup: Failed to delete old backup")
+			continue
+		}
+		m.logger.Debug().Str("name", b.Name).Msg("backup: Deleted old backup")
+	}
+}
+
+// RunScheduledBackup creates a backup if scheduled backups are enabled and the last one is older than the interval.
+// It is called periodically by the scheduler.
+func (m *Manager) RunScheduledBackup() error {
+	m.mu.Lock()
+	enabled := m.settings.Enabled
+	interval := m.settings.Interval
+	m.mu.Unlock()
+
+	if !enabled {
+		return nil
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	backups, err := m.ListBackups()
+	if err != nil {
+		m.logger.Error().Err(err).Msg("backup: Failed to list backups")
+		return err
+	}
+
+	if len(backups) > 0 && time.Since(backups[0].CreatedAt) < time.Duration(interval)*time.Hour {
+		return nil
+	}
+
+	m.logger.Info().Msg("backup: Creating scheduled backup")
+	_, err = m.CreateBackup()
+	return err
+}
@@ -0,0 +1,273 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/library/anime"
+	"seanime/internal/util/result"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	RangeWeek  Range = "week"
+	RangeMonth Range = "month"
+)
+
+const (
+	AutoDownloaderStatusNone      AutoDownloaderStatus = "none"      // No rule for the anime
+	AutoDownloaderStatusDisabled  AutoDownloaderStatus = "disabled"  // The rule or the auto downloader is disabled
+	AutoDownloaderStatusSkipped   AutoDownloaderStatus = "skipped"   // The rule doesn't download this episode
+	AutoDownloaderStatusScheduled AutoDownloaderStatus = "scheduled" // The rule will download this episode
+)
+
+var ErrInvalidRange = errors.New("calendar: range should be 'week' or 'month'")
+
+type (
+	Range                string
+	AutoDownloaderStatus string
+
+	// Calendar builds the airing calendar of the anime the user is watching or planning to watch.
+	Calendar struct {
+		logger *zerolog.Logger
+		// Airing schedules keyed by range and media IDs
+		scheduleCache *result.Cache[string, []*anilist.AiringEpisode]
+		// fetchAiringSchedules is replaced in tests
+		fetchAiringSchedules func(mediaIds []int, from int64, to int64) ([]*anilist.AiringEpisode, error)
+	}
+
+	NewCalendarOptions struct {
+		Logger *zerolog.Logger
+	}
+
+	GetOptions struct {
+		AnimeCollection       *anilist.AnimeCollection
+		LocalFiles            []*anime.LocalFile
+		AutoDownloaderRules   []*anime.AutoDownloaderRule
+		AutoDownloaderEnabled bool
+		Start                 time.Time
+		End                   time.Time
+	}
+
+	// View holds the episodes airing between Start and End, grouped by day.
+	View struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+		Days  []*Day    `json:"days"`
+	}
+
+	Day struct {
+		Date  string  `json:"date"` // YYYY-MM-DD, in the timezone of the range
+		Items []*Item `json:"items"`
+	}
+
+	Item struct {
+		MediaId    int                     `json:"mediaId"`
+		Media      *anilist.BaseAnime      `json:"media"`
+		ListStatus anilist.MediaListStatus `json:"listStatus"`
+		Progress   int                     `json:"progress"`
+		Episode    int                     `json:"episode"`
+		AiringAt   int64                   `json:"airingAt"` // Unix timestamp
+		Aired      bool                    `json:"aired"`
+		// Whether the episode is in the library
+		Downloaded           bool                 `json:"downloaded"`
+		AutoDownloaderStatus AutoDownloaderStatus `json:"autoDownloaderStatus"`
+		AutoDownloaderRuleId uint                 `json:"autoDownloaderRuleId,omitempty"`
+	}
+)
+
+func NewCalendar(opts *NewCalendarOptions) *Calendar {
+	ret := &Calendar{
+		logger:        opts.Logger,
+		scheduleCache: result.NewCache[string, []*anilist.AiringEpisode](),
+	}
+	ret.fetchAiringSchedules = func(mediaIds []int, from int64, to int64) ([]*anilist.AiringEpisode, error) {
+		return anilist.FetchAiringSchedules(mediaIds, from, to, ret.logger)
+	}
+	return ret
+}
+
+// Bounds returns the week (starting on Monday) or the month containing the date, in the timezone of the date.
+func Bounds(r Range, date time.Time) (start time.Time, end time.Time, err error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	switch r {
+	case RangeWeek:
+		// time.Sunday is 0
+		start = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7), nil
+	case RangeMonth:
+		start = day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, 0), nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidRange
+}
+
+// Get returns the episodes of the current and planned anime airing between opts.Start and opts.End.
+func (c *Calendar) Get(opts *GetOptions) (*View, error) {
+	items, err := c.GetItems(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &View{
+		Start: opts.Start,
+		End:   opts.End,
+		Days:  make([]*Day, 0),
+	}
+	for day := opts.Start; day.Before(opts.End); day = day.AddDate(0, 0, 1) {
+		ret.Days = append(ret.Days, &Day{
+			Date:  day.Format(time.DateOnly),
+			Items: make([]*Item, 0),
+		})
+	}
+	for _, item := range items {
+		date := time.Unix(item.AiringAt, 0).In(opts.Start.Location()).Format(time.DateOnly)
+		if day, found := lo.Find(ret.Days, func(d *Day) bool { return d.Date == date }); found {
+			day.Items = append(day.Items, item)
+		}
+	}
+
+	return ret, nil
+}
+
+// GetItems returns the episodes airing between opts.Start and opts.End, sorted by airing time.
+func (c *Calendar) GetItems(opts *GetOptions) ([]*Item, error) {
+	entries := make(map[int]*anilist.AnimeListEntry)
+	for _, list := range opts.AnimeCollection.GetMediaListCollection().GetLists() {
+		if list.GetStatus() == nil {
+			continue
+		}
+		switch *list.GetStatus() {
+		case anilist.MediaListStatusCurrent, anilist.MediaListStatusRepeating, anilist.MediaListStatusPlanning:
+		default:
+			continue
+		}
+		for _, entry := range list.GetEntries() {
+			if entry.GetMedia() != nil && mayAirAfter(entry.GetMedia(), opts.Start) {
+				entries[entry.GetMedia().GetID()] = entry
+			}
+		}
+	}
+
+	mediaIds := lo.Keys(entries)
+	slices.Sort(mediaIds)
+
+	episodes, err := c.getAiringSchedules(mediaIds, opts.Start, opts.End)
+	if err != nil {
+		// e.g. offline, fall back to the next episodes known by the collection
+		c.logger.Warn().Err(err).Msg("calendar: Failed to fetch airing schedules")
+		episodes = make([]*anilist.AiringEpisode, 0)
+		for _, id := range mediaIds {
+			next := entries[id].GetMedia().GetNextAiringEpisode()
+			if next == nil || int64(next.AiringAt) < opts.Start.Unix() || int64(next.AiringAt) >= opts.End.Unix() {
+				continue
+			}
+			episodes = append(episodes, &anilist.AiringEpisode{MediaID: id, Episode: next.Episode, AiringAt: int64(next.AiringAt)})
+		}
+	}
+
+	now := time.Now().Unix()
+	ret := make([]*Item, 0, len(episodes))
+	for _, ep := range episodes {
+		entry, found := entries[ep.MediaID]
+		if !found {
+			continue
+		}
+		item := &Item{
+			MediaId:    ep.MediaID,
+			Media:      entry.GetMedia(),
+			ListStatus: lo.FromPtr(entry.GetStatus()),
+			Progress:   lo.FromPtr(entry.GetProgress()),
+			Episode:    ep.Episode,
+			AiringAt:   ep.AiringAt,
+			Aired:      ep.AiringAt <= now,
+			Downloaded: isDownloaded(opts.LocalFiles, ep.MediaID, ep.Episode),
+		}
+		item.AutoDownloaderStatus, item.AutoDownloaderRuleId = getAutoDownloaderStatus(opts, item)
+		ret = append(ret, item)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].AiringAt != ret[j].AiringAt {
+			return ret[i].AiringAt < ret[j].AiringAt
+		}
+		return ret[i].MediaId < ret[j].MediaId
+	})
+
+	return ret, nil
+}
+
+func (c *Calendar) getAiringSchedules(mediaIds []int, start time.Time, end time.Time) ([]*anilist.AiringEpisode, error) {
+	key := fmt.Sprintf("%d-%d-%v", start.Unix(), end.Unix(), mediaIds)
+	return c.scheduleCache.GetOrSet(key, func() ([]*anilist.AiringEpisode, error) {
+		// airingAt_greater and airingAt_lesser are exclusive
+		return c.fetchAiringSchedules(mediaIds, start.Unix()-1, end.Unix())
+	})
+}
+
+// mayAirAfter returns false if the anime finished airing before the date.
+func mayAirAfter(media *anilist.BaseAnime, date time.Time) bool {
+	if media.GetStatus() == nil {
+		return true
+	}
+	switch *media.GetStatus() {
+	case anilist.MediaStatusCancelled:
+		return false
+	case anilist.MediaStatusFinished:
+		endDate := media.GetEndDate()
+		if endDate == nil || endDate.GetYear() == nil {
+			return false
+		}
+		year, month := *endDate.GetYear(), lo.FromPtr(endDate.GetMonth())
+		return year > date.Year() || (year == date.Year() && (month == 0 || month >= int(date.Month())))
+	}
+	return true
+}
+
+func isDownloaded(lfs []*anime.LocalFile, mediaId int, episode int) bool {
+	return lo.ContainsBy(lfs, func(lf *anime.LocalFile) bool {
+		return lf.MediaId == mediaId && lf.GetType() == anime.LocalFileTypeMain && lf.GetEpisodeNumber() == episode
+	})
+}
+
+// getAutoDownloaderStatus checks whether the auto downloader will pick up the episode.
+// It follows the episode checks of the auto downloader, the torrents still have to match the rule.
+func getAutoDownloaderStatus(opts *GetOptions, item *Item) (AutoDownloaderStatus, uint) {
+	rules := lo.Filter(opts.AutoDownloaderRules, func(rule *anime.AutoDownloaderRule, _ int) bool {
+		return rule.MediaId == item.MediaId
+	})
+	if len(rules) == 0 {
+		return AutoDownloaderStatusNone, 0
+	}
+
+	if !opts.AutoDownloaderEnabled {
+		return AutoDownloaderStatusDisabled, rules[0].DbID
+	}
+
+	var enabledRule *anime.AutoDownloaderRule
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		enabledRule = rule
+		switch rule.EpisodeType {
+		case anime.AutoDownloaderRuleEpisodeRecent:
+			if item.Progress <= item.Episode {
+				return AutoDownloaderStatusScheduled, rule.DbID
+			}
+		case anime.AutoDownloaderRuleEpisodeSelected:
+			if slices.Contains(rule.EpisodeNumbers, item.Episode) {
+				return AutoDownloaderStatusScheduled, rule.DbID
+			}
+		}
+	}
+
+	if enabledRule == nil {
+		return AutoDownloaderStatusDisabled, rules[0].DbID
+	}
+	return AutoDownloaderStatusSkipped, enabledRule.DbID
+}
@@ -0,0 +1,189 @@
+package calendar
+
+import (
+	"errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/library/anime"
+	"seanime/internal/util"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getTestAnimeCollection(nextAiringAt int) *anilist.AnimeCollection {
+	return &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: []*anilist.AnimeCollection_MediaListCollection_Lists{
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+					Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+						{
+							Status:   lo.ToPtr(anilist.MediaListStatusCurrent),
+							Progress: lo.ToPtr(3),
+							Media: &anilist.BaseAnime{
+								ID:                1,
+								Status:            lo.ToPtr(anilist.MediaStatusReleasing),
+								Title:             &anilist.BaseAnime_Title{UserPreferred: lo.ToPtr("Frieren, Beyond Journey's End")},
+								NextAiringEpisode: &anilist.BaseAnime_NextAiringEpisode{AiringAt: nextAiringAt, Episode: 5},
+							},
+						},
+						// Finished airing
+						{
+							Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+							Media: &anilist.BaseAnime{
+								ID:      2,
+								Status:  lo.ToPtr(anilist.MediaStatusFinished),
+								EndDate: &anilist.BaseAnime_EndDate{Year: lo.ToPtr(2020), Month: lo.ToPtr(1)},
+							},
+						},
+					},
+				},
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusPlanning),
+					Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+						{
+							Status: lo.ToPtr(anilist.MediaListStatusPlanning),
+							Media: &anilist.BaseAnime{
+								ID:     3,
+								Status: lo.ToPtr(anilist.MediaStatusNotYetReleased),
+								Title:  &anilist.BaseAnime_Title{UserPreferred: lo.ToPtr("Planned")},
+							},
+						},
+					},
+				},
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusDropped),
+					Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+						{
+							Status: lo.ToPtr(anilist.MediaListStatusDropped),
+							Media:  &anilist.BaseAnime{ID: 4, Status: lo.ToPtr(anilist.MediaStatusReleasing)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBounds(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	// Thursday
+	date := time.Date(2024, time.February, 29, 15, 0, 0, 0, loc)
+
+	start, end, err := Bounds(RangeWeek, date)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 26, 0, 0, 0, 0, loc), start)
+	require.Equal(t, time.Date(2024, time.March, 4, 0, 0, 0, 0, loc), end)
+
+	start, end, err = Bounds(RangeMonth, date)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, loc), start)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, loc), end)
+
+	_, _, err = Bounds("year", date)
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func TestCalendar_Get(t *testing.T) {
+	start, end, err := Bounds(RangeWeek, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	monday := start.Unix()
+	day := int64(24 * 60 * 60)
+
+	c := NewCalendar(&NewCalendarOptions{Logger: util.NewLogger()})
+	var fetchedIds []int
+	c.fetchAiringSchedules = func(mediaIds []int, from int64, to int64) ([]*anilist.AiringEpisode, error) {
+		fetchedIds = mediaIds
+		return []*anilist.AiringEpisode{
+			{MediaID: 1, Episode: 5, AiringAt: monday + 3*day},
+			{MediaID: 3, Episode: 1, AiringAt: monday + 6*day},
+			{MediaID: 1, Episode: 4, AiringAt: monday + 10},
+		}, nil
+	}
+
+	view, err := c.Get(&GetOptions{
+		AnimeCollection: getTestAnimeCollection(0),
+		LocalFiles: []*anime.LocalFile{
+			{MediaId: 1, Metadata: &anime.LocalFileMetadata{Episode: 4, Type: anime.LocalFileTypeMain}},
+		},
+		AutoDownloaderRules: []*anime.AutoDownloaderRule{
+			{DbID: 7, Enabled: true, MediaId: 1, EpisodeType: anime.AutoDownloaderRuleEpisodeRecent},
+			{DbID: 8, Enabled: false, MediaId: 3, EpisodeType: anime.AutoDownloaderRuleEpisodeRecent},
+		},
+		AutoDownloaderEnabled: true,
+		Start:                 start,
+		End:                   end,
+	})
+	require.NoError(t, err)
+
+	// Finished and dropped anime are left out
+	require.Equal(t, []int{1, 3}, fetchedIds)
+
+	require.Len(t, view.Days, 7)
+	require.Equal(t, "2024-02-26", view.Days[0].Date)
+
+	require.Len(t, view.Days[0].Items, 1)
+	require.Equal(t, 4, view.Days[0].Items[0].Episode)
+	require.True(t, view.Days[0].Items[0].Downloaded)
+	require.True(t, view.Days[0].Items[0].Aired)
+
+	require.Len(t, view.Days[3].Items, 1)
+	item := view.Days[3].Items[0]
+	require.Equal(t, 5, item.Episode)
+	require.False(t, item.Downloaded)
+	require.Equal(t, AutoDownloaderStatusScheduled, item.AutoDownloaderStatus)
+	require.Equal(t, uint(7), item.AutoDownloaderRuleId)
+
+	require.Len(t, view.Days[6].Items, 1)
+	require.Equal(t, anilist.MediaListStatusPlanning, view.Days[6].Items[0].ListStatus)
+	require.Equal(t, AutoDownloaderStatusDisabled, view.Days[6].Items[0].AutoDownloaderStatus)
+}
+
+func TestCalendar_GetItemsOffline(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(7 * 24 * time.Hour)
+
+	c := NewCalendar(&NewCalendarOptions{Logger: util.NewLogger()})
+	c.fetchAiringSchedules = func(mediaIds []int, from int64, to int64) ([]*anilist.AiringEpisode, error) {
+		return nil, errors.New("offline")
+	}
+
+	// The next episode known by the collection is used
+	items, err := c.GetItems(&GetOptions{
+		AnimeCollection: getTestAnimeCollection(int(start.Add(time.Hour).Unix())),
+		Start:           start,
+		End:             end,
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, 5, items[0].Episode)
+	require.False(t, items[0].Aired)
+	require.Equal(t, AutoDownloaderStatusNone, items[0].AutoDownloaderStatus)
+}
+
+func TestToICS(t *testing.T) {
+	items := []*Item{
+		{
+			MediaId:    1,
+			Media:      getTestAnimeCollection(0).MediaListCollection.Lists[0].Entries[0].Media,
+			ListStatus: anilist.MediaListStatusCurrent,
+			Episode:    5,
+			AiringAt:   time.Date(2024, time.February, 29, 15, 0, 0, 0, time.UTC).Unix(),
+		},
+	}
+
+	ics := ToICS("Seanime", items)
+	require.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	require.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	require.Contains(t, ics, "UID:seanime-1-5@seanime\r\n")
+	require.Contains(t, ics, "DTSTART:20240229T150000Z\r\n")
+	require.Contains(t, ics, "DTEND:20240229T152400Z\r\n")
+	require.Contains(t, ics, `SUMMARY:Frieren\, Beyond Journey's End - Episode 5`)
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+}
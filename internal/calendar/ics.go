@@ -0,0 +1,99 @@
+package calendar
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	// defaultEpisodeDuration is used when the duration of the anime is unknown
+	defaultEpisodeDuration = 24 * time.Minute
+)
+
+// ToICS returns the items as an iCalendar (RFC 5545) feed.
+func ToICS(name string, items []*Item) string {
+	var b strings.Builder
+
+	now := time.Now().UTC().Format(icsTimeFormat)
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Seanime//Airing calendar//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(name))
+	// Hint for clients that support it
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT6H")
+
+	for _, item := range items {
+		start := time.Unix(item.AiringAt, 0).UTC()
+		duration := defaultEpisodeDuration
+		if d := lo.FromPtr(item.Media.GetDuration()); d > 0 {
+			duration = time.Duration(d) * time.Minute
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:seanime-%d-%d@seanime", item.MediaId, item.Episode))
+		writeICSLine(&b, "DTSTAMP:"+now)
+		writeICSLine(&b, "DTSTART:"+start.Format(icsTimeFormat))
+		writeICSLine(&b, "DTEND:"+start.Add(duration).Format(icsTimeFormat))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(fmt.Sprintf("%s - Episode %d", item.Media.GetPreferredTitle(), item.Episode)))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(getICSDescription(item)))
+		if url := lo.FromPtr(item.Media.GetSiteURL()); url != "" {
+			writeICSLine(&b, "URL:"+url)
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	return b.String()
+}
+
+func getICSDescription(item *Item) string {
+	lines := []string{fmt.Sprintf("List status: %s", strings.ToLower(string(item.ListStatus)))}
+	if item.Downloaded {
+		lines = append(lines, "In the library")
+	}
+	switch item.AutoDownloaderStatus {
+	case AutoDownloaderStatusScheduled:
+		lines = append(lines, "Will be downloaded by the auto downloader")
+	case AutoDownloaderStatusDisabled:
+		lines = append(lines, "Auto downloader rule disabled")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeICSLine writes the content line, folded at 75 octets.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		// Don't split multi-byte characters
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+		// The leading space counts towards the limit
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
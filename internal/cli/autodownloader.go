@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"net/http"
+)
+
+func init() {
+	register(&command{
+		name:        "autodownloader run",
+		description: "Check for new episodes and download them according to the Auto Downloader rules",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			if ctx.IsRemote() {
+				// The running instance checks for new episodes in the background
+				if err := ctx.Client().Do(http.MethodPost, "/auto-downloader/run", nil, nil); err != nil {
+					return err
+				}
+				return ctx.print(map[string]bool{"started": true}, func() {
+					ctx.printf("Auto Downloader started\n")
+				})
+			}
+
+			app := ctx.newApp()
+			defer app.Cleanup()
+
+			if app.Settings == nil || app.Settings.AutoDownloader == nil || !app.Settings.AutoDownloader.Enabled {
+				return errors.New("the Auto Downloader is not enabled")
+			}
+
+			app.AutoDownloader.RunNow()
+
+			return ctx.print(map[string]bool{"done": true}, func() {
+				ctx.printf("Auto Downloader done\n")
+			})
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"seanime/internal/backup"
+	"seanime/internal/constants"
+	"seanime/internal/util"
+)
+
+type BackupResult struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+func init() {
+	var output string
+
+	register(&command{
+		name:        "backup",
+		description: "Create a backup archive",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&output, "o", "", "File or directory where the archive is written, defaults to the backup directory")
+		},
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			res := &BackupResult{}
+
+			if ctx.IsRemote() {
+				file := &backup.BackupFile{}
+				if err := ctx.Client().Do(http.MethodPost, "/backups", nil, file); err != nil {
+					return err
+				}
+				res.Path = file.Name
+				res.Size = file.Size
+
+				// Download the archive created by the server
+				if output != "" {
+					dest := output
+					if info, err := os.Stat(output); err == nil && info.IsDir() {
+						dest = filepath.Join(output, file.Name)
+					}
+					if err := ctx.Client().Download("/backups/download/"+url.PathEscape(file.Name), dest); err != nil {
+						return err
+					}
+					res.Path = dest
+				}
+			} else {
+				cfg, database, err := ctx.openDatabase()
+				if err != nil {
+					return err
+				}
+
+				backupManager := backup.NewManager(&backup.NewManagerOptions{
+					Logger:     util.NewLogger(),
+					Database:   database,
+					Paths:      cfg.GetBackupPaths(),
+					AppVersion: constants.Version,
+				})
+
+				if output == "" {
+					if settings, found := database.GetBackupSettings(); found {
+						backupManager.SetSettings(settings)
+					}
+					file, err := backupManager.CreateBackup()
+					if err != nil {
+						return err
+					}
+					res.Path = filepath.Join(backupManager.GetBackupDir(), file.Name)
+					res.Size = file.Size
+				} else {
+					path, _, err := backupManager.ExportToFile(output)
+					if err != nil {
+						return err
+					}
+					res.Path = path
+					if info, err := os.Stat(path); err == nil {
+						res.Size = info.Size()
+					}
+				}
+			}
+
+			return ctx.print(res, func() {
+				ctx.printf("Backup created: %s\n", res.Path)
+			})
+		},
+	})
+}
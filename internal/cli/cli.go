@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/rs/zerolog"
+	"io"
+	"os"
+	"seanime/internal/util"
+	"sort"
+	"strings"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK          = 0
+	ExitError       = 1 // The command failed
+	ExitUsage       = 2 // Invalid command, flags or arguments
+	ExitUnavailable = 3 // The server could not be reached
+)
+
+var (
+	ErrUsage       = errors.New("invalid usage")
+	ErrUnavailable = errors.New("server unavailable")
+)
+
+type (
+	// Context is passed to the commands.
+	Context struct {
+		DataDir   string
+		JSON      bool   // Print the output as JSON
+		ServerURL string // If set, the command is sent to a running instance
+		Token     string // Server password token used with ServerURL
+		Verbose   bool
+		Stdout    io.Writer
+		Stderr    io.Writer
+		client    *Client
+	}
+
+	command struct {
+		name        string
+		args        string
+		description string
+		flags       func(fs *flag.FlagSet)
+		run         func(ctx *Context, fs *flag.FlagSet) error
+	}
+)
+
+var commands = make(map[string]*command)
+
+func register(cmd *command) {
+	commands[cmd.name] = cmd
+}
+
+// IsCommand returns true if the argument is the name of a command.
+// It is used to tell commands apart from the server's flags.
+func IsCommand(arg string) bool {
+	for name := range commands {
+		if name == arg || strings.HasPrefix(name, arg+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the command and returns the exit code.
+// dataDir is the value of the "-datadir" flag, it can be empty.
+func Run(dataDir string, args []string) int {
+	ctx := &Context{
+		DataDir:   dataDir,
+		ServerURL: os.Getenv("SEANIME_SERVER_URL"),
+		Token:     os.Getenv("SEANIME_TOKEN"),
+		Stdout:    os.Stdout,
+		Stderr:    os.Stderr,
+	}
+	return ctx.run(args)
+}
+
+func (ctx *Context) run(args []string) int {
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		ctx.printUsage()
+		return ExitUsage
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(ctx.Stderr)
+	fs.BoolVar(&ctx.JSON, "json", false, "Print the output as JSON")
+	fs.StringVar(&ctx.ServerURL, "server", ctx.ServerURL, "URL of a running instance, e.g. http://127.0.0.1:43211 (env: SEANIME_SERVER_URL)")
+	fs.StringVar(&ctx.Token, "token", ctx.Token, "Token used to access a password-protected instance (env: SEANIME_TOKEN)")
+	fs.BoolVar(&ctx.Verbose, "verbose", false, "Print the logs")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(ctx.Stderr, "Usage:\n  seanime %s [flags] %s\n\n%s\n\nFlags:\n", cmd.name, cmd.args, cmd.description)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(rest); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	// Logs are printed to stderr so that the output can be parsed
+	util.SetLogConsoleOutput(ctx.Stderr)
+	if !ctx.Verbose {
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+
+	if err := cmd.run(ctx, fs); err != nil {
+		switch {
+		case errors.Is(err, ErrUsage):
+			_, _ = fmt.Fprintf(ctx.Stderr, "Error: %v\n\n", err)
+			fs.Usage()
+			return ExitUsage
+		case errors.Is(err, ErrUnavailable):
+			_, _ = fmt.Fprintf(ctx.Stderr, "Error: %v\n", err)
+			return ExitUnavailable
+		default:
+			_, _ = fmt.Fprintf(ctx.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+	}
+
+	return ExitOK
+}
+
+// findCommand returns the command matching the first one or two arguments and the remaining arguments.
+func findCommand(args []string) (*command, []string) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:]
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[1:]
+		}
+	}
+	return nil, nil
+}
+
+func (ctx *Context) printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, _ = fmt.Fprintf(ctx.Stderr, "Usage:\n  seanime [-datadir dir] <command> [flags] [arguments]\n\nCommands:\n")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(ctx.Stderr, "  %-24s %s\n", name, commands[name].description)
+	}
+	_, _ = fmt.Fprintf(ctx.Stderr, "\nCommands run against the data directory, or against a running instance with -server.\n")
+	_, _ = fmt.Fprintf(ctx.Stderr, "Run 'seanime <command> -h' for the flags of a command.\n")
+}
+
+// IsRemote returns true if the command should be sent to a running instance.
+func (ctx *Context) IsRemote() bool {
+	return ctx.ServerURL != ""
+}
+
+// Client returns the client used to talk to the running instance.
+func (ctx *Context) Client() *Client {
+	if ctx.client == nil {
+		ctx.client = NewClient(ctx.ServerURL, ctx.Token)
+	}
+	return ctx.client
+}
+
+func usageError(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrUsage, fmt.Sprintf(format, a...))
+}
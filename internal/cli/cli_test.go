@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"seanime/internal/database/models"
+	"seanime/internal/library/anime"
+	"testing"
+)
+
+func newTestContext(serverURL string) (*Context, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	return &Context{
+		ServerURL: serverURL,
+		Stdout:    stdout,
+		Stderr:    &bytes.Buffer{},
+	}, stdout
+}
+
+func TestSettingValue(t *testing.T) {
+	settings := &models.Settings{
+		Library: &models.LibrarySettings{
+			LibraryPath: "/anime",
+		},
+	}
+
+	value, err := getSettingValue(settings, "library.libraryPath")
+	require.NoError(t, err)
+	require.Equal(t, "/anime", value)
+
+	require.NoError(t, setSettingValue(settings, "library.autoScan", "true"))
+	require.True(t, settings.Library.AutoScan)
+
+	require.NoError(t, setSettingValue(settings, "library.libraryPaths", `["/movies"]`))
+	require.Equal(t, models.LibraryPaths{"/movies"}, settings.Library.LibraryPaths)
+
+	require.ErrorIs(t, setSettingValue(settings, "library.autoScan", "maybe"), ErrUsage)
+	require.Error(t, setSettingValue(settings, "library.unknown", "1"))
+	require.Error(t, setSettingValue(settings, "library", "1"))
+}
+
+func TestMatchLocalFilePaths(t *testing.T) {
+	lfs := []*anime.LocalFile{
+		{Path: "/anime/Show/01.mkv"},
+		{Path: "/anime/Show/02.mkv"},
+		{Path: "/anime/Show 2/01.mkv"},
+	}
+
+	require.Equal(t, []string{"/anime/Show/01.mkv", "/anime/Show/02.mkv"}, matchLocalFilePaths(lfs, []string{"/anime/Show"}))
+	require.Equal(t, []string{"/anime/Show 2/01.mkv"}, matchLocalFilePaths(lfs, []string{"/anime/show 2/01.mkv"}))
+
+	applyLocalFileAction(lfs, []string{"/anime/Show/01.mkv"}, "ignore")
+	require.True(t, lfs[0].Ignored)
+	require.False(t, lfs[1].Ignored)
+}
+
+func TestRemoteCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/library/local-files":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []*anime.LocalFile{
+					{Path: "/anime/Show/01.mkv", MediaId: 1},
+					{Path: "/anime/Show/02.mkv"},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": "not found"})
+		}
+	}))
+	defer server.Close()
+
+	ctx, stdout := newTestContext(server.URL)
+	ctx.Token = "token"
+	require.Equal(t, ExitOK, ctx.run([]string{"localfiles", "list", "-json", "-unmatched"}))
+
+	var lfs []*anime.LocalFile
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &lfs))
+	require.Len(t, lfs, 1)
+	require.Equal(t, "/anime/Show/02.mkv", lfs[0].Path)
+
+	// Errors returned by the server
+	ctx, _ = newTestContext(server.URL)
+	ctx.Token = "token"
+	require.Equal(t, ExitError, ctx.run([]string{"extensions", "list"}))
+
+	// Missing token
+	ctx, _ = newTestContext(server.URL)
+	require.Equal(t, ExitError, ctx.run([]string{"status"}))
+}
+
+func TestExitCodes(t *testing.T) {
+	ctx, _ := newTestContext("http://127.0.0.1:1")
+	require.Equal(t, ExitUnavailable, ctx.run([]string{"status"}))
+
+	ctx, _ = newTestContext("http://127.0.0.1:1")
+	require.Equal(t, ExitUsage, ctx.run([]string{"unknown"}))
+
+	ctx, _ = newTestContext("http://127.0.0.1:1")
+	require.Equal(t, ExitUsage, ctx.run([]string{"localfiles", "lock"}))
+
+	ctx, _ = newTestContext("http://127.0.0.1:1")
+	require.Equal(t, ExitUsage, ctx.run([]string{"scan", "-unknown-flag"}))
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Client sends requests to the API of a running instance.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		httpClient: &http.Client{
+			// Scans can take a while
+			Timeout: 30 * time.Minute,
+		},
+	}
+}
+
+type apiResponse struct {
+	Error string          `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// Do sends a request to the API and decodes the data of the response into out.
+// path is relative to /api/v1.
+func (c *Client) Do(method string, path string, body interface{}, out interface{}) error {
+	res, err := c.send(method, path, body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var apiRes apiResponse
+	if err := json.NewDecoder(res.Body).Decode(&apiRes); err != nil {
+		return fmt.Errorf("invalid response from server (%s)", res.Status)
+	}
+	if apiRes.Error != "" {
+		return errors.New(apiRes.Error)
+	}
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("request failed (%s)", res.Status)
+	}
+
+	if out != nil && len(apiRes.Data) > 0 {
+		return json.Unmarshal(apiRes.Data, out)
+	}
+	return nil
+}
+
+// Download sends a GET request to the API and writes the response body to a file.
+func (c *Client) Download(path string, dest string) error {
+	res, err := c.send(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		var apiRes apiResponse
+		if err := json.NewDecoder(res.Body).Decode(&apiRes); err == nil && apiRes.Error != "" {
+			return errors.New(apiRes.Error)
+		}
+		return fmt.Errorf("request failed (%s)", res.Status)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, res.Body)
+	return err
+}
+
+func (c *Client) send(method string, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+"/api/v1"+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUsage, err.Error())
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err.Error())
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		_ = res.Body.Close()
+		return nil, errors.New("unauthorized, set a valid token with -token")
+	}
+
+	return res, nil
+}
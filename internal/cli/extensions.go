@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"seanime/internal/extension"
+	"seanime/internal/extension_repo"
+	"sort"
+)
+
+func init() {
+	register(&command{
+		name:        "extensions list",
+		description: "List the installed extensions",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			var extensions []*extension.Extension
+
+			if ctx.IsRemote() {
+				if err := ctx.Client().Do(http.MethodGet, "/extensions/list", nil, &extensions); err != nil {
+					return err
+				}
+			} else {
+				repo, err := ctx.newExtensionRepository()
+				if err != nil {
+					return err
+				}
+				extensions = repo.ListExtensionData()
+			}
+
+			sort.Slice(extensions, func(i, j int) bool {
+				return extensions[i].ID < extensions[j].ID
+			})
+
+			return ctx.print(extensions, func() {
+				tw := ctx.newTable()
+				_, _ = fmt.Fprintln(tw, "ID\tNAME\tVERSION\tTYPE\tLANGUAGE")
+				for _, ext := range extensions {
+					_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", ext.ID, ext.Name, ext.Version, ext.Type, ext.Language)
+				}
+				_ = tw.Flush()
+			})
+		},
+	})
+
+	register(&command{
+		name:        "extensions install",
+		args:        "<manifest-uri>",
+		description: "Install or update an extension from its manifest URI",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			if fs.NArg() != 1 {
+				return usageError("expected one manifest URI")
+			}
+
+			res := &extension_repo.ExtensionInstallResponse{}
+
+			if ctx.IsRemote() {
+				body := map[string]string{"manifestUri": fs.Arg(0)}
+				if err := ctx.Client().Do(http.MethodPost, "/extensions/external/install", body, res); err != nil {
+					return err
+				}
+			} else {
+				repo, err := ctx.newExtensionRepository()
+				if err != nil {
+					return err
+				}
+				res, err = repo.InstallExternalExtension(fs.Arg(0))
+				if err != nil {
+					return err
+				}
+			}
+
+			return ctx.print(res, func() {
+				ctx.printf("%s\n", res.Message)
+			})
+		},
+	})
+
+	register(&command{
+		name:        "extensions uninstall",
+		args:        "<id>",
+		description: "Uninstall an extension",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			if fs.NArg() != 1 {
+				return usageError("expected one extension ID")
+			}
+
+			if ctx.IsRemote() {
+				body := map[string]string{"id": fs.Arg(0)}
+				if err := ctx.Client().Do(http.MethodPost, "/extensions/external/uninstall", body, nil); err != nil {
+					return err
+				}
+			} else {
+				repo, err := ctx.newExtensionRepository()
+				if err != nil {
+					return err
+				}
+				if err := repo.UninstallExternalExtension(fs.Arg(0)); err != nil {
+					return err
+				}
+			}
+
+			return ctx.print(map[string]string{"id": fs.Arg(0)}, func() {
+				ctx.printf("Uninstalled %s\n", fs.Arg(0))
+			})
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"seanime/internal/core"
+	"seanime/internal/database/db"
+	"seanime/internal/events"
+	"seanime/internal/extension_repo"
+	"seanime/internal/util"
+	"seanime/internal/util/filecache"
+)
+
+// openDatabase opens the database of the data directory without initializing the other modules.
+func (ctx *Context) openDatabase() (*core.Config, *db.Database, error) {
+	return core.OpenDatabase(&core.ConfigOptions{DataDir: ctx.DataDir}, util.NewLogger())
+}
+
+// newApp initializes all the modules without starting the server.
+// It is used by commands that need the library or torrent modules.
+func (ctx *Context) newApp() *core.App {
+	return core.NewApp(&core.ConfigOptions{DataDir: ctx.DataDir}, nil)
+}
+
+// newExtensionRepository loads the external extensions without initializing the other modules.
+func (ctx *Context) newExtensionRepository() (*extension_repo.Repository, error) {
+	logger := util.NewLogger()
+
+	cfg, err := core.NewConfig(&core.ConfigOptions{DataDir: ctx.DataDir}, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	fileCacher, err := filecache.NewCacher(cfg.Cache.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := extension_repo.NewRepository(&extension_repo.NewRepositoryOptions{
+		Logger:         logger,
+		ExtensionDir:   cfg.Extensions.Dir,
+		WSEventManager: events.NewWSEventManager(logger),
+		FileCacher:     fileCacher,
+	})
+	repo.ReloadExternalExtensions()
+
+	return repo, nil
+}
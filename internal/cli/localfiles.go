@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"net/http"
+	"seanime/internal/database/db_bridge"
+	"seanime/internal/library/anime"
+	"strings"
+)
+
+func init() {
+	var unmatched, locked, ignored bool
+
+	register(&command{
+		name:        "localfiles list",
+		description: "List the local files",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&unmatched, "unmatched", false, "Only list unmatched files")
+			fs.BoolVar(&locked, "locked", false, "Only list locked files")
+			fs.BoolVar(&ignored, "ignored", false, "Only list ignored files")
+		},
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			lfs, err := ctx.getLocalFiles()
+			if err != nil {
+				return err
+			}
+
+			filtered := make([]*anime.LocalFile, 0, len(lfs))
+			for _, lf := range lfs {
+				if unmatched && (lf.MediaId != 0 || lf.Ignored) {
+					continue
+				}
+				if locked && !lf.Locked {
+					continue
+				}
+				if ignored && !lf.Ignored {
+					continue
+				}
+				filtered = append(filtered, lf)
+			}
+
+			return ctx.print(filtered, func() {
+				tw := ctx.newTable()
+				_, _ = fmt.Fprintln(tw, "MEDIA\tEPISODE\tSTATUS\tPATH")
+				for _, lf := range filtered {
+					_, _ = fmt.Fprintf(tw, "%d\t%d\t%s\t%s\n", lf.MediaId, lf.GetEpisodeNumber(), localFileStatus(lf), lf.Path)
+				}
+				_ = tw.Flush()
+			})
+		},
+	})
+
+	registerLocalFileAction("lock", "Lock local files so that they are not rescanned")
+	registerLocalFileAction("unlock", "Unlock local files")
+	registerLocalFileAction("ignore", "Ignore local files so that they are not matched")
+	registerLocalFileAction("unignore", "Stop ignoring local files")
+}
+
+var errNoMatchingLocalFiles = errors.New("no local files match the given paths")
+
+func registerLocalFileAction(action string, description string) {
+	register(&command{
+		name:        "localfiles " + action,
+		args:        "<path>...",
+		description: description + ". Paths can be files or directories.",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			if fs.NArg() == 0 {
+				return usageError("no path provided")
+			}
+
+			var paths []string
+
+			if ctx.IsRemote() {
+				lfs, err := ctx.getLocalFiles()
+				if err != nil {
+					return err
+				}
+				paths = matchLocalFilePaths(lfs, fs.Args())
+				if len(paths) == 0 {
+					return errNoMatchingLocalFiles
+				}
+				body := map[string]interface{}{
+					"paths":  paths,
+					"action": action,
+				}
+				if err := ctx.Client().Do(http.MethodPatch, "/library/local-files", body, nil); err != nil {
+					return err
+				}
+			} else {
+				_, database, err := ctx.openDatabase()
+				if err != nil {
+					return err
+				}
+				lfs, lfsId, err := db_bridge.GetLocalFiles(database)
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				paths = matchLocalFilePaths(lfs, fs.Args())
+				if len(paths) == 0 {
+					return errNoMatchingLocalFiles
+				}
+				applyLocalFileAction(lfs, paths, action)
+				if _, err := db_bridge.SaveLocalFiles(database, lfsId, lfs); err != nil {
+					return err
+				}
+			}
+
+			return ctx.print(paths, func() {
+				ctx.printf("Updated %d files\n", len(paths))
+			})
+		},
+	})
+}
+
+func (ctx *Context) getLocalFiles() ([]*anime.LocalFile, error) {
+	if ctx.IsRemote() {
+		var lfs []*anime.LocalFile
+		err := ctx.Client().Do(http.MethodGet, "/library/local-files", nil, &lfs)
+		return lfs, err
+	}
+
+	_, database, err := ctx.openDatabase()
+	if err != nil {
+		return nil, err
+	}
+	lfs, _, err := db_bridge.GetLocalFiles(database)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The library has not been scanned yet
+		return []*anime.LocalFile{}, nil
+	}
+	return lfs, err
+}
+
+// matchLocalFilePaths returns the paths of the local files that match the given file or directory paths.
+func matchLocalFilePaths(lfs []*anime.LocalFile, args []string) []string {
+	ret := make([]string, 0)
+	for _, lf := range lfs {
+		for _, arg := range args {
+			if lf.HasSamePath(arg) || lf.IsInDir(strings.TrimSuffix(arg, "/")+"/") {
+				ret = append(ret, lf.Path)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// applyLocalFileAction updates the local files with the given paths.
+// It follows the behavior of the local files update route.
+func applyLocalFileAction(lfs []*anime.LocalFile, paths []string, action string) {
+	for _, lf := range lfs {
+		found := false
+		for _, path := range paths {
+			if lf.HasSamePath(path) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		switch action {
+		case "lock":
+			lf.Locked = true
+		case "unlock":
+			lf.Locked = false
+		case "ignore":
+			lf.MediaId = 0
+			lf.Ignored = true
+			lf.Locked = false
+		case "unignore":
+			lf.Ignored = false
+			lf.Locked = false
+		}
+	}
+}
+
+func localFileStatus(lf *anime.LocalFile) string {
+	switch {
+	case lf.Ignored:
+		return "ignored"
+	case lf.Locked:
+		return "locked"
+	case lf.MediaId == 0:
+		return "unmatched"
+	}
+	return "matched"
+}
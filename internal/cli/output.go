@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/goccy/go-json"
+	"text/tabwriter"
+)
+
+// printJSON prints the value as indented JSON.
+func (ctx *Context) printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(ctx.Stdout, string(data))
+	return err
+}
+
+func (ctx *Context) printf(format string, a ...interface{}) {
+	_, _ = fmt.Fprintf(ctx.Stdout, format, a...)
+}
+
+// newTable returns a writer that aligns tab-separated columns, it should be flushed after writing.
+func (ctx *Context) newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(ctx.Stdout, 0, 4, 2, ' ', 0)
+}
+
+// print prints the value as JSON if -json is set, or calls printText.
+func (ctx *Context) print(v interface{}, printText func()) error {
+	if ctx.JSON {
+		return ctx.printJSON(v)
+	}
+	printText()
+	return nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"flag"
+	"net/http"
+	"seanime/internal/core"
+	"seanime/internal/library/anime"
+)
+
+type ScanResult struct {
+	Total     int `json:"total"`
+	Matched   int `json:"matched"`
+	Unmatched int `json:"unmatched"`
+	Locked    int `json:"locked"`
+	Ignored   int `json:"ignored"`
+}
+
+func init() {
+	var enhanced, skipLocked, skipIgnored bool
+
+	register(&command{
+		name:        "scan",
+		description: "Scan the library and save the local files",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&enhanced, "enhanced", false, "Use enhanced scanning")
+			fs.BoolVar(&skipLocked, "skip-locked", true, "Do not rescan locked files")
+			fs.BoolVar(&skipIgnored, "skip-ignored", true, "Do not rescan ignored files")
+		},
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			var lfs []*anime.LocalFile
+
+			if ctx.IsRemote() {
+				body := map[string]interface{}{
+					"enhanced":         enhanced,
+					"skipLockedFiles":  skipLocked,
+					"skipIgnoredFiles": skipIgnored,
+				}
+				if err := ctx.Client().Do(http.MethodPost, "/library/scan", body, &lfs); err != nil {
+					return err
+				}
+			} else {
+				app := ctx.newApp()
+				defer app.Cleanup()
+
+				var err error
+				lfs, err = app.ScanLibrary(&core.ScanLibraryOptions{
+					Enhanced:         enhanced,
+					SkipLockedFiles:  skipLocked,
+					SkipIgnoredFiles: skipIgnored,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			res := newScanResult(lfs)
+			return ctx.print(res, func() {
+				ctx.printf("Scanned %d files: %d matched, %d unmatched, %d locked, %d ignored\n", res.Total, res.Matched, res.Unmatched, res.Locked, res.Ignored)
+			})
+		},
+	})
+}
+
+func newScanResult(lfs []*anime.LocalFile) *ScanResult {
+	res := &ScanResult{Total: len(lfs)}
+	for _, lf := range lfs {
+		switch {
+		case lf.Ignored:
+			res.Ignored++
+		case lf.MediaId == 0:
+			res.Unmatched++
+		default:
+			res.Matched++
+		}
+		if lf.Locked {
+			res.Locked++
+		}
+	}
+	return res
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/goccy/go-json"
+	"net/http"
+	"seanime/internal/database/models"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register(&command{
+		name:        "settings get",
+		args:        "[key]",
+		description: "Print the settings, or the value of a key such as 'library.libraryPath'",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			if fs.NArg() > 1 {
+				return usageError("expected at most one key")
+			}
+
+			settings, err := ctx.getSettings()
+			if err != nil {
+				return err
+			}
+
+			var value interface{} = settings.Redacted()
+			if fs.NArg() == 1 {
+				value, err = getSettingValue(value, fs.Arg(0))
+				if err != nil {
+					return err
+				}
+			}
+
+			if ctx.JSON {
+				return ctx.printJSON(value)
+			}
+			switch v := value.(type) {
+			case map[string]interface{}, []interface{}:
+				return ctx.printJSON(v)
+			default:
+				ctx.printf("%v\n", v)
+			}
+			return nil
+		},
+	})
+
+	register(&command{
+		name:        "settings set",
+		args:        "<key> <value>",
+		description: "Set the value of a key such as 'library.autoScan'. Arrays and objects are set with JSON values.",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			if fs.NArg() != 2 {
+				return usageError("expected a key and a value")
+			}
+			key, rawValue := fs.Arg(0), fs.Arg(1)
+
+			settings, err := ctx.getSettings()
+			if err != nil {
+				return err
+			}
+
+			if err := setSettingValue(settings, key, rawValue); err != nil {
+				return err
+			}
+
+			if ctx.IsRemote() {
+				if strings.HasPrefix(key, "autoDownloader.") {
+					err = ctx.Client().Do(http.MethodPatch, "/settings/auto-downloader", settings.AutoDownloader, nil)
+				} else if strings.HasPrefix(key, "listSync.") {
+					err = errors.New("list sync settings cannot be set on a running instance")
+				} else {
+					// Redacted credentials are kept by the server
+					err = ctx.Client().Do(http.MethodPatch, "/settings", settings, nil)
+				}
+				if err != nil {
+					return err
+				}
+			} else {
+				_, database, err := ctx.openDatabase()
+				if err != nil {
+					return err
+				}
+				if _, err := database.UpsertSettings(settings); err != nil {
+					return err
+				}
+			}
+
+			value, _ := getSettingValue(settings.Redacted(), key)
+			return ctx.print(map[string]interface{}{key: value}, func() {
+				ctx.printf("%s = %v\n", key, value)
+				if !ctx.IsRemote() {
+					ctx.printf("Restart Seanime for the change to take effect\n")
+				}
+			})
+		},
+	})
+}
+
+func (ctx *Context) getSettings() (*models.Settings, error) {
+	if ctx.IsRemote() {
+		settings := &models.Settings{}
+		err := ctx.Client().Do(http.MethodGet, "/settings", nil, settings)
+		return settings, err
+	}
+
+	_, database, err := ctx.openDatabase()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := database.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.ID == 0 {
+		return nil, errors.New("settings not found, finish the setup first")
+	}
+	return settings, nil
+}
+
+// toMap converts the value to a JSON object.
+func toMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// getSettingValue returns the value of a dot-separated key, using the JSON names of the settings.
+func getSettingValue(settings interface{}, key string) (interface{}, error) {
+	m, err := toMap(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	var current interface{} = m
+	for _, part := range strings.Split(key, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unknown setting %q", key)
+		}
+		current, ok = obj[part]
+		if !ok {
+			return nil, fmt.Errorf("unknown setting %q", key)
+		}
+	}
+	return current, nil
+}
+
+// setSettingValue sets the value of a dot-separated key.
+// The raw value is parsed according to the type of the current value.
+func setSettingValue(settings *models.Settings, key string, rawValue string) error {
+	m, err := toMap(settings)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(key, ".")
+	parent := m
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := parent[part].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unknown setting %q", key)
+		}
+		parent = next
+	}
+
+	last := parts[len(parts)-1]
+	current, ok := parent[last]
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	if _, isObject := current.(map[string]interface{}); isObject {
+		return fmt.Errorf("%q is a section, set one of its keys instead", key)
+	}
+
+	var value interface{}
+	switch current.(type) {
+	case string:
+		value = rawValue
+	case bool:
+		value, err = strconv.ParseBool(rawValue)
+	case float64:
+		value, err = strconv.ParseFloat(rawValue, 64)
+	default:
+		err = json.Unmarshal([]byte(rawValue), &value)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: invalid value for %q", ErrUsage, key)
+	}
+	parent[last] = value
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, settings)
+}
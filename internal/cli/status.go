@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"seanime/internal/constants"
+	"seanime/internal/database/db_bridge"
+	"seanime/internal/database/models"
+	"seanime/internal/library/anime"
+)
+
+type StatusResult struct {
+	Version     string `json:"version"`
+	Server      string `json:"server,omitempty"`  // URL of the running instance
+	DataDir     string `json:"dataDir,omitempty"` // Only set when reading the data directory
+	Offline     bool   `json:"offline"`
+	Username    string `json:"username"`
+	LibraryPath string `json:"libraryPath"`
+	LocalFiles  int    `json:"localFiles"`
+	Unmatched   int    `json:"unmatched"`
+}
+
+func init() {
+	register(&command{
+		name:        "status",
+		description: "Print the status of the server or data directory",
+		run: func(ctx *Context, fs *flag.FlagSet) error {
+			res := &StatusResult{}
+			var lfs []*anime.LocalFile
+
+			if ctx.IsRemote() {
+				var status struct {
+					Version   string           `json:"version"`
+					IsOffline bool             `json:"isOffline"`
+					User      *anime.User      `json:"user"`
+					Settings  *models.Settings `json:"settings"`
+				}
+				if err := ctx.Client().Do(http.MethodGet, "/status", nil, &status); err != nil {
+					return err
+				}
+				if err := ctx.Client().Do(http.MethodGet, "/library/local-files", nil, &lfs); err != nil {
+					return err
+				}
+
+				res.Version = status.Version
+				res.Server = ctx.ServerURL
+				res.Offline = status.IsOffline
+				if status.User != nil && status.User.Viewer != nil {
+					res.Username = status.User.Viewer.Name
+				}
+				if status.Settings != nil && status.Settings.Library != nil {
+					res.LibraryPath = status.Settings.Library.LibraryPath
+				}
+			} else {
+				cfg, database, err := ctx.openDatabase()
+				if err != nil {
+					return err
+				}
+
+				res.Version = constants.Version
+				res.DataDir = cfg.Data.AppDataDir
+				res.Offline = cfg.Server.Offline
+				if acc, err := database.GetAccount(); err == nil && acc != nil {
+					res.Username = acc.Username
+				}
+				if settings, err := database.GetSettings(); err == nil && settings.Library != nil {
+					res.LibraryPath = settings.Library.LibraryPath
+				}
+				lfs, _, _ = db_bridge.GetLocalFiles(database)
+			}
+
+			res.LocalFiles = len(lfs)
+			for _, lf := range lfs {
+				if lf.MediaId == 0 && !lf.Ignored {
+					res.Unmatched++
+				}
+			}
+
+			return ctx.print(res, func() {
+				tw := ctx.newTable()
+				_, _ = fmt.Fprintf(tw, "Version:\t%s\n", res.Version)
+				if res.Server != "" {
+					_, _ = fmt.Fprintf(tw, "Server:\t%s\n", res.Server)
+				}
+				if res.DataDir != "" {
+					_, _ = fmt.Fprintf(tw, "Data directory:\t%s\n", res.DataDir)
+				}
+				_, _ = fmt.Fprintf(tw, "Offline:\t%t\n", res.Offline)
+				_, _ = fmt.Fprintf(tw, "AniList user:\t%s\n", valueOrNone(res.Username))
+				_, _ = fmt.Fprintf(tw, "Library:\t%s\n", valueOrNone(res.LibraryPath))
+				_, _ = fmt.Fprintf(tw, "Local files:\t%d (%d unmatched)\n", res.LocalFiles, res.Unmatched)
+				_ = tw.Flush()
+			})
+		},
+	})
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
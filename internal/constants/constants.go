@@ -9,5 +9,7 @@ const (
 	GcTime               = time.Minute * 30
 	ConfigFileName       = "config.toml"
 	MalClientId          = "51cb4294feb400f3ddc66a30f9b9a00f"
+	KitsuClientId        = "dd031b32d2f56c990b1425efe6c42ad847e7fe3ab46bf1299f05ecd856bdb7dd"
+	KitsuClientSecret    = "54d7307928f63414defd96399fc31ba847961ceaecef3a5fd93144e960c0e151"
 	DiscordApplicationId = "1224777421941899285"
 )
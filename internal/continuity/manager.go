@@ -49,12 +49,18 @@ type (
 		FileCacher *filecache.Cacher
 		Logger     *zerolog.Logger
 		Database   *db.Database
+		// Name of the bucket storing the watch history, defaults to WatchHistoryBucketName
+		BucketName string
 	}
 )
 
 // NewManager creates a new Manager, it should be initialized once.
 func NewManager(opts *NewManagerOptions) *Manager {
-	watchHistoryFileCacheBucket := filecache.NewBucket(WatchHistoryBucketName, time.Hour*24*99999)
+	bucketName := WatchHistoryBucketName
+	if opts.BucketName != "" {
+		bucketName = opts.BucketName
+	}
+	watchHistoryFileCacheBucket := filecache.NewBucket(bucketName, time.Hour*24*99999)
 
 	ret := &Manager{
 		fileCacher:                  opts.FileCacher,
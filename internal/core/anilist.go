@@ -28,8 +28,9 @@ func (a *App) GetAccount() (*models.Account, error) {
 // UpdateAnilistClientToken will update the Anilist Client Wrapper token.
 // This function should be called when a user logs in
 func (a *App) UpdateAnilistClientToken(token string) {
-	a.AnilistClient = anilist.NewAnilistClient(token)
+	a.AnilistClient = anilist.NewCachedAnilistClient(anilist.NewAnilistClient(token), a.FileCacher)
 	a.AnilistPlatform.SetAnilistClient(a.AnilistClient) // Update Anilist Client Wrapper in Platform
+	a.ListSyncManager.SetAnilistClient(a.AnilistClient)
 }
 
 // GetAnimeCollection returns the user's Anilist collection if it in the cache, otherwise it queries Anilist for the user's collection.
@@ -6,10 +6,13 @@ import (
 	"runtime"
 	"seanime/internal/api/anilist"
 	"seanime/internal/api/metadata"
+	"seanime/internal/backup"
+	"seanime/internal/calendar"
 	"seanime/internal/constants"
 	"seanime/internal/continuity"
 	"seanime/internal/database/db"
 	"seanime/internal/database/db_bridge"
+	"seanime/internal/database/encryption"
 	"seanime/internal/database/models"
 	"seanime/internal/debrid/client"
 	"seanime/internal/discordrpc/presence"
@@ -22,16 +25,26 @@ import (
 	"seanime/internal/library/fillermanager"
 	"seanime/internal/library/playbackmanager"
 	"seanime/internal/library/scanner"
+	"seanime/internal/listsync"
+	"seanime/internal/listtransfer"
 	"seanime/internal/manga"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/mediaplayers/mpchc"
 	"seanime/internal/mediaplayers/mpv"
 	"seanime/internal/mediaplayers/vlc"
 	"seanime/internal/mediastream"
+	"seanime/internal/notifier"
 	"seanime/internal/onlinestream"
 	"seanime/internal/platforms/anilist_platform"
+	"seanime/internal/platforms/kitsu_platform"
 	"seanime/internal/platforms/local_platform"
+	"seanime/internal/platforms/mal_platform"
+	"seanime/internal/platforms/mutation_queue"
 	"seanime/internal/platforms/platform"
+	"seanime/internal/profile"
+	"seanime/internal/recommendation"
+	"seanime/internal/scheduler"
+	"seanime/internal/server_auth"
 	sync2 "seanime/internal/sync"
 	"seanime/internal/torrent_clients/torrent_client"
 	"seanime/internal/torrents/torrent"
@@ -39,6 +52,8 @@ import (
 	"seanime/internal/updater"
 	"seanime/internal/util"
 	"seanime/internal/util/filecache"
+	"seanime/internal/watchstats"
+	"seanime/internal/webhook"
 	"sync"
 )
 
@@ -55,6 +70,13 @@ type (
 		AnilistPlatform               platform.Platform
 		LocalPlatform                 platform.Platform
 		SyncManager                   sync2.Manager
+		ListSyncManager               *listsync.Manager
+		MutationQueue                 *mutation_queue.Manager
+		ListImporter                  *listtransfer.Importer
+		ListExporter                  *listtransfer.Exporter
+		Calendar                      *calendar.Calendar
+		RecommendationEngine          *recommendation.Engine
+		WatchStats                    *watchstats.Manager
 		FillerManager                 *fillermanager.FillerManager
 		WSEventManager                *events.WSEventManager
 		AutoDownloader                *autodownloader.AutoDownloader
@@ -88,6 +110,12 @@ type (
 			Debrid        *models.DebridSettings
 		} // Struct for other settings sent to client
 		SelfUpdater        *updater.SelfUpdater
+		ServerAuthManager  *server_auth.Manager
+		ProfileManager     *profile.Manager
+		BackupManager      *backup.Manager
+		Scheduler          *scheduler.Scheduler // Jobs are registered in cron.RunJobs
+		WebhookManager     *webhook.Manager
+		NotificationInbox  *notifier.Inbox
 		TotalLibrarySize   uint64 // Initialized in modules.go
 		LibraryDir         string
 		animeCollection    *anilist.AnimeCollection
@@ -97,6 +125,9 @@ type (
 		account            *models.Account
 		previousVersion    string
 		moduleMu           sync.Mutex
+		trackingPlatform   string                   // Platform used to track the lists, chosen on startup
+		profileSessions    map[uint]*ProfileSession // Sessions of non-default profiles
+		profileSessionsMu  sync.Mutex
 	}
 )
 
@@ -131,6 +162,20 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 	// Print working directory
 	logger.Info().Msgf("app: Working directory: %s", cfg.Data.WorkingDir)
 
+	// Apply a restored backup before the database is opened
+	if err = backup.ApplyPendingRestore(cfg.GetBackupPaths(), logger); err != nil {
+		logger.Error().Err(err).Msgf("app: Failed to apply restored backup")
+	}
+
+	// Load the key used to encrypt the credentials stored in the database
+	encryptionKey, err := encryption.LoadOrCreateKey(cfg.Data.AppDataDir)
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("app: Failed to load encryption key")
+	}
+	if err = encryption.SetKey(encryptionKey); err != nil {
+		logger.Fatal().Err(err).Msgf("app: Invalid encryption key")
+	}
+
 	// Initialize the database
 	database, err := db.NewDatabase(cfg.Data.AppDataDir, cfg.Database.Name, logger)
 	if err != nil {
@@ -149,12 +194,33 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 	database.TrimScanSummaryEntries()   // ran in goroutine
 	database.TrimTorrentstreamHistory() // ran in goroutine
 
+	// Server Auth Manager
+	serverAuthManager := server_auth.NewManager(&server_auth.NewManagerOptions{
+		Logger:         logger,
+		Database:       database,
+		ConfigPassword: cfg.Server.Password,
+	})
+
+	// Backup Manager
+	backupManager := backup.NewManager(&backup.NewManagerOptions{
+		Logger:     logger,
+		Database:   database,
+		Paths:      cfg.GetBackupPaths(),
+		AppVersion: constants.Version,
+	})
+
+	// Profile Manager
+	profileManager, err := profile.NewManager(&profile.NewManagerOptions{
+		Logger:   logger,
+		Database: database,
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("app: Failed to initialize profiles")
+	}
+
 	// Get token from stored account or return empty string
 	anilistToken := database.GetAnilistToken()
 
-	// Anilist Client Wrapper
-	anilistCW := anilist.NewAnilistClient(anilistToken)
-
 	// Websocket Event Manager
 	wsEventManager := events.NewWSEventManager(logger)
 
@@ -164,6 +230,10 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 		logger.Fatal().Err(err).Msgf("app: Failed to initialize file cacher")
 	}
 
+	// Anilist Client Wrapper
+	// Responses of the read queries are cached, requests are rate limited
+	anilistCW := anilist.NewCachedAnilistClient(anilist.NewAnilistClient(anilistToken), fileCacher)
+
 	// Metadata Provider
 	metadataProvider := metadata.NewProvider(&metadata.NewProviderImplOptions{
 		Logger:     logger,
@@ -184,6 +254,51 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 
 	anilistPlatform := anilist_platform.NewAnilistPlatform(anilistCW, logger)
 
+	// Use MyAnimeList or Kitsu to track the lists if the user chose to
+	trackingPlatform := getTrackingPlatform(database)
+	onlinePlatform := newTrackingPlatform(&newTrackingPlatformOptions{
+		Name:             trackingPlatform,
+		ProfileID:        models.DefaultProfileID,
+		AnilistPlatform:  anilistPlatform,
+		AnilistClient:    anilistCW,
+		Database:         database,
+		MetadataProvider: metadataProvider,
+		Logger:           logger,
+	})
+
+	// Mutation Queue
+	// Failed writes to the tracking platform are retried
+	mutationQueue := mutation_queue.NewManager(&mutation_queue.NewManagerOptions{
+		Logger:   logger,
+		Database: database,
+	})
+	onlinePlatform = mutationQueue.WrapPlatform(onlinePlatform, models.DefaultProfileID, trackingPlatform)
+
+	// List Sync Manager
+	// Entries updated through the tracking platform are synchronized between AniList and MyAnimeList
+	listSyncManager := listsync.NewManager(&listsync.NewManagerOptions{
+		Logger:          logger,
+		Database:        database,
+		AnilistPlatform: anilistPlatform,
+	})
+	listSyncSource := listsync.SourceAnilist
+	switch trackingPlatform {
+	case models.TrackingPlatformMal:
+		listSyncSource = listsync.SourceMal
+	case models.TrackingPlatformKitsu:
+		// Kitsu lists are not synchronized
+		listSyncSource = ""
+	}
+	if listSyncSource != "" {
+		onlinePlatform = listSyncManager.WrapPlatform(onlinePlatform, listSyncSource)
+		// Writes that are retried by the mutation queue don't go through the wrapped platform
+		mutationQueue.SetOnRetrySucceeded(func(profileID uint, _ string, mediaID int) {
+			if profileID == models.DefaultProfileID {
+				listSyncManager.OnEntryUpdated(mediaID, listSyncSource)
+			}
+		})
+	}
+
 	// Platforms
 	syncManager, err := sync2.NewManager(&sync2.NewManagerOptions{
 		LocalDir:         cfg.Offline.Dir,
@@ -194,7 +309,7 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 		Database:         database,
 		WSEventManager:   wsEventManager,
 		IsOffline:        cfg.Server.Offline,
-		AnilistPlatform:  anilistPlatform,
+		AnilistPlatform:  onlinePlatform,
 	})
 	if err != nil {
 		logger.Fatal().Err(err).Msgf("app: Failed to initialize sync manager")
@@ -209,7 +324,7 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 		logger.Fatal().Err(err).Msgf("app: Failed to initialize local platform")
 	}
 
-	activePlatform := anilistPlatform
+	activePlatform := onlinePlatform
 	// If offline mode is enabled, use the local platform
 	if cfg.Server.Offline {
 		activePlatform = localPlatform
@@ -241,6 +356,8 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 		AnilistPlatform:               activePlatform,
 		LocalPlatform:                 localPlatform,
 		SyncManager:                   syncManager,
+		ListSyncManager:               listSyncManager,
+		MutationQueue:                 mutationQueue,
 		WSEventManager:                wsEventManager,
 		Logger:                        logger,
 		Version:                       constants.Version,
@@ -260,6 +377,11 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 		MediastreamRepository:         nil, // Initialized in App.initModulesOnce
 		TorrentstreamRepository:       nil, // Initialized in App.initModulesOnce
 		ContinuityManager:             nil, // Initialized in App.initModulesOnce
+		ListImporter:                  nil, // Initialized in App.initModulesOnce
+		ListExporter:                  nil, // Initialized in App.initModulesOnce
+		Calendar:                      nil, // Initialized in App.initModulesOnce
+		RecommendationEngine:          nil, // Initialized in App.initModulesOnce
+		WatchStats:                    nil, // Initialized in App.initModulesOnce
 		DebridClientRepository:        nil, // Initialized in App.initModulesOnce
 		TorrentClientRepository:       nil, // Initialized in App.InitOrRefreshModules
 		MediaPlayerRepository:         nil, // Initialized in App.InitOrRefreshModules
@@ -271,8 +393,13 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 			Torrentstream *models.TorrentstreamSettings
 			Debrid        *models.DebridSettings
 		}{Mediastream: nil, Torrentstream: nil},
-		SelfUpdater: selfupdater,
-		moduleMu:    sync.Mutex{},
+		SelfUpdater:       selfupdater,
+		ServerAuthManager: serverAuthManager,
+		ProfileManager:    profileManager,
+		BackupManager:     backupManager,
+		moduleMu:          sync.Mutex{},
+		trackingPlatform:  trackingPlatform,
+		profileSessions:   make(map[uint]*ProfileSession),
 	}
 
 	// Perform necessary migrations if the version has changed
@@ -309,6 +436,54 @@ func NewApp(configOpts *ConfigOptions, selfupdater *updater.SelfUpdater) *App {
 	return app
 }
 
+type newTrackingPlatformOptions struct {
+	Name             string // See models.TrackingPlatformAnilist
+	ProfileID        uint
+	AnilistPlatform  platform.Platform
+	AnilistClient    anilist.AnilistClient
+	Database         *db.Database
+	MetadataProvider metadata.Provider
+	Logger           *zerolog.Logger
+}
+
+// newTrackingPlatform returns the platform used to track the lists of a profile.
+// MyAnimeList and Kitsu use the account of the profile, the AniList platform is returned as-is.
+func newTrackingPlatform(opts *newTrackingPlatformOptions) platform.Platform {
+	switch opts.Name {
+	case models.TrackingPlatformMal:
+		return mal_platform.NewMalPlatform(&mal_platform.NewMalPlatformOptions{
+			Logger:           opts.Logger,
+			Database:         opts.Database,
+			ProfileID:        opts.ProfileID,
+			AnilistClient:    opts.AnilistClient,
+			MetadataProvider: opts.MetadataProvider,
+		})
+	case models.TrackingPlatformKitsu:
+		return kitsu_platform.NewKitsuPlatform(&kitsu_platform.NewKitsuPlatformOptions{
+			Logger:           opts.Logger,
+			Database:         opts.Database,
+			ProfileID:        opts.ProfileID,
+			AnilistClient:    opts.AnilistClient,
+			MetadataProvider: opts.MetadataProvider,
+		})
+	}
+	return opts.AnilistPlatform
+}
+
+// getTrackingPlatform returns the platform used to track the lists, see models.TrackingPlatformAnilist.
+func getTrackingPlatform(database *db.Database) string {
+	settings, err := database.GetSettings()
+	if err != nil || settings == nil || settings.Library == nil || settings.Library.TrackingPlatform == "" {
+		return models.TrackingPlatformAnilist
+	}
+	return settings.Library.TrackingPlatform
+}
+
+// GetTrackingPlatform returns the platform used to track the lists.
+func (a *App) GetTrackingPlatform() string {
+	return getTrackingPlatform(a.Database)
+}
+
 func (a *App) IsOffline() bool {
 	if a.Config == nil {
 		return false
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"fmt"
+	"seanime/internal/backup"
+	"seanime/internal/constants"
+	"seanime/internal/util"
+)
+
+// RunBackupCommand creates a backup without starting the server.
+// It is used by the "-backup" flag. The destination can be a file or a directory.
+func RunBackupCommand(configOpts *ConfigOptions, dest string) error {
+	logger := util.NewLogger()
+
+	cfg, database, err := OpenDatabase(configOpts, logger)
+	if err != nil {
+		return err
+	}
+
+	backupManager := backup.NewManager(&backup.NewManagerOptions{
+		Logger:     logger,
+		Database:   database,
+		Paths:      cfg.GetBackupPaths(),
+		AppVersion: constants.Version,
+	})
+
+	path, manifest, err := backupManager.ExportToFile(dest)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup created: %s (%d files)\n", path, len(manifest.Files))
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 	"os"
 	"path/filepath"
+	"seanime/internal/backup"
 	"seanime/internal/constants"
 	"seanime/internal/util"
 	"strconv"
@@ -20,6 +21,7 @@ type Config struct {
 		Offline       bool
 		UseBinaryPath bool // Makes $SEANIME_WORKING_DIR point to the binary's directory
 		Systray       bool
+		Password      string // Password required to access the API, overrides the one set in the settings
 	}
 	Database struct {
 		Name string
@@ -104,6 +106,7 @@ func NewConfig(options *ConfigOptions, logger *zerolog.Logger) (*Config, error)
 	viper.SetDefault("server.host", defaultHost)
 	viper.SetDefault("server.port", defaultPort)
 	viper.SetDefault("server.offline", false)
+	viper.SetDefault("server.password", "")
 	// Use the binary's directory as the working directory environment variable on macOS
 	viper.SetDefault("server.useBinaryPath", true)
 	//viper.SetDefault("server.systray", true)
@@ -148,6 +151,10 @@ func NewConfig(options *ConfigOptions, logger *zerolog.Logger) (*Config, error)
 	cfg.Data.AppDataDir = dataDir
 	cfg.Data.WorkingDir = os.Getenv("SEANIME_WORKING_DIR")
 
+	if os.Getenv("SEANIME_SERVER_PASSWORD") != "" {
+		cfg.Server.Password = os.Getenv("SEANIME_SERVER_PASSWORD")
+	}
+
 	// Check validity of the config
 	if err := validateConfig(cfg, logger); err != nil {
 		return nil, err
@@ -175,6 +182,17 @@ func (cfg *Config) GetServerURI(df ...string) string {
 	return pAddr
 }
 
+// GetBackupPaths returns the locations of the data included in backups.
+func (cfg *Config) GetBackupPaths() *backup.Paths {
+	return &backup.Paths{
+		DataDir:       cfg.Data.AppDataDir,
+		DatabaseName:  cfg.Database.Name,
+		CacheDir:      cfg.Cache.Dir,
+		OfflineDir:    cfg.Offline.Dir,
+		ExtensionsDir: cfg.Extensions.Dir,
+	}
+}
+
 func getWorkingDir(useBinaryPath bool) (string, error) {
 	// Get the working directory
 	wd, err := os.Getwd()
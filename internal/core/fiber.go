@@ -20,6 +20,10 @@ func NewFiberApp(app *App, webFS *embed.FS) *fiber.App {
 		JSONEncoder:           json.Marshal,
 		JSONDecoder:           json.Unmarshal,
 		DisableStartupMessage: true,
+		// Bodies are streamed so that larger uploads can be accepted on specific routes, see handlers.InitRoutes.
+		// Bodies smaller than the default limit are still read before the handler is called.
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 	})
 
 	//
@@ -38,6 +42,7 @@ func NewFiberApp(app *App, webFS *embed.FS) *fiber.App {
 			path := c.Path()
 			if strings.HasPrefix(path, "/api") ||
 				strings.HasPrefix(path, "/events") ||
+				strings.HasPrefix(path, "/internal") ||
 				strings.HasPrefix(path, "/assets") ||
 				strings.HasPrefix(path, "/manga-downloads") ||
 				strings.HasPrefix(path, "/offline-assets") {
@@ -74,21 +79,7 @@ func NewFiberApp(app *App, webFS *embed.FS) *fiber.App {
 		Compress: false,
 	})
 
-	// Serve the manga downloads
-	if app.Config.Manga.DownloadDir != "" {
-		app.Logger.Info().Msgf("app: Manga downloads path: %s", app.Config.Manga.DownloadDir)
-		fiberApp.Static("/manga-downloads", app.Config.Manga.DownloadDir, fiber.Static{
-			Index:    "index.html",
-			Compress: false,
-		})
-	}
-
-	// Serve the offline assets
-	app.Logger.Info().Msgf("app: Offline assets path: %s", app.Config.Offline.AssetDir)
-	fiberApp.Static("/offline-assets", app.Config.Offline.AssetDir, fiber.Static{
-		Index:    "index.html",
-		Compress: false,
-	})
+	// DEVNOTE: The manga downloads and offline assets are served in handlers.InitRoutes, behind the server password
 
 	return fiberApp
 }
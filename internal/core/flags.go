@@ -10,6 +10,7 @@ type (
 	SeanimeFlags struct {
 		DataDir string
 		Update  bool
+		Backup  string
 	}
 )
 
@@ -17,19 +18,27 @@ func GetSeanimeFlags() SeanimeFlags {
 	// Help flag
 	flag.Usage = func() {
 		fmt.Printf("Self-hosted, user-friendly, media server for anime and manga enthusiasts.\n\n")
-		fmt.Printf("Usage:\n  seanime [flags]\n\n")
+		fmt.Printf("Usage:\n  seanime [flags]\n  seanime [-datadir dir] <command> [flags] [arguments]\n\n")
 		fmt.Printf("Flags:\n")
 		fmt.Printf("  -datadir, --datadir string")
 		fmt.Printf("   directory that contains all Seanime data\n")
 		fmt.Printf("  -update")
 		fmt.Printf("   update the application\n")
+		fmt.Printf("  -backup string")
+		fmt.Printf("             create a backup archive at the given path and exit\n")
 		fmt.Printf("  -h                           show this help message\n")
+		fmt.Printf("\nCommands:\n")
+		fmt.Printf("  scan, autodownloader run, localfiles list|lock|unlock|ignore|unignore,\n")
+		fmt.Printf("  extensions list|install|uninstall, backup, settings get|set, status\n")
+		fmt.Printf("  Run 'seanime <command> -h' for the flags of a command.\n")
 	}
 	// Parse flags
 	var dataDir string
 	flag.StringVar(&dataDir, "datadir", "", "Directory that contains all Seanime data")
 	var update bool
 	flag.BoolVar(&update, "update", false, "Update the application")
+	var backupPath string
+	flag.StringVar(&backupPath, "backup", "", "Create a backup archive at the given path and exit")
 	//var truewd bool
 	//flag.BoolVar(&truewd, "truewd", false, "Force Seanime to use the binary's directory as the working directory")
 	flag.Parse()
@@ -37,5 +46,6 @@ func GetSeanimeFlags() SeanimeFlags {
 	return SeanimeFlags{
 		DataDir: strings.TrimSpace(dataDir),
 		Update:  update,
+		Backup:  strings.TrimSpace(backupPath),
 	}
 }
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"github.com/rs/zerolog"
+	"seanime/internal/database/db"
+	"seanime/internal/database/encryption"
+)
+
+// OpenDatabase loads the config and opens the database without initializing the other modules.
+// It is used by commands that run without starting the server.
+func OpenDatabase(configOpts *ConfigOptions, logger *zerolog.Logger) (*Config, *db.Database, error) {
+	cfg, err := NewConfig(configOpts, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encryptionKey, err := encryption.LoadOrCreateKey(cfg.Data.AppDataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = encryption.SetKey(encryptionKey); err != nil {
+		return nil, nil, err
+	}
+
+	database, err := db.NewDatabase(cfg.Data.AppDataDir, cfg.Database.Name, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, database, nil
+}
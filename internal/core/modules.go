@@ -4,6 +4,8 @@ import (
 	"github.com/cli/browser"
 	"runtime"
 	"seanime/internal/api/anilist"
+	"seanime/internal/backup"
+	"seanime/internal/calendar"
 	"seanime/internal/continuity"
 	"seanime/internal/database/models"
 	debrid_client "seanime/internal/debrid/client"
@@ -12,6 +14,7 @@ import (
 	"seanime/internal/library/autoscanner"
 	"seanime/internal/library/fillermanager"
 	"seanime/internal/library/playbackmanager"
+	"seanime/internal/listtransfer"
 	"seanime/internal/manga"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/mediaplayers/mpchc"
@@ -19,11 +22,15 @@ import (
 	"seanime/internal/mediaplayers/vlc"
 	"seanime/internal/mediastream"
 	"seanime/internal/notifier"
+	"seanime/internal/recommendation"
+	"seanime/internal/scheduler"
 	"seanime/internal/torrent_clients/qbittorrent"
 	"seanime/internal/torrent_clients/torrent_client"
 	"seanime/internal/torrent_clients/transmission"
 	"seanime/internal/torrents/torrent"
 	"seanime/internal/torrentstream"
+	"seanime/internal/watchstats"
+	"seanime/internal/webhook"
 )
 
 // initModulesOnce will initialize modules that need to persist.
@@ -45,6 +52,105 @@ func (a *App) initModulesOnce() {
 		a.DiscordPresence.Close()
 	})
 
+	// +---------------------+
+	// |       Backups       |
+	// +---------------------+
+
+	a.InitOrRefreshBackupSettings()
+
+	// +---------------------+
+	// |      Scheduler      |
+	// +---------------------+
+
+	a.Scheduler = scheduler.NewScheduler(&scheduler.NewSchedulerOptions{
+		Logger:    a.Logger,
+		Database:  a.Database,
+		IsOffline: a.IsOffline(),
+	})
+	a.AddCleanupFunction(func() {
+		a.Scheduler.Stop()
+	})
+
+	// +---------------------+
+	// |      Webhooks       |
+	// +---------------------+
+
+	a.WebhookManager = webhook.NewManager(&webhook.NewManagerOptions{
+		Logger:   a.Logger,
+		Database: a.Database,
+	})
+	webhook.GlobalManager = a.WebhookManager
+	go a.WebhookManager.ResumePendingDeliveries()
+	a.AddCleanupFunction(func() {
+		a.WebhookManager.Shutdown()
+	})
+
+	// +---------------------+
+	// |   Mutation Queue    |
+	// +---------------------+
+
+	go a.MutationQueue.ResumePendingMutations()
+	a.AddCleanupFunction(func() {
+		a.MutationQueue.Shutdown()
+	})
+
+	// +---------------------+
+	// |  List Import/Export |
+	// +---------------------+
+
+	a.ListImporter = listtransfer.NewImporter(&listtransfer.NewImporterOptions{
+		Logger:           a.Logger,
+		MetadataProvider: a.MetadataProvider,
+		WSEventManager:   a.WSEventManager,
+	})
+
+	a.ListExporter = listtransfer.NewExporter(&listtransfer.NewExporterOptions{
+		Logger:           a.Logger,
+		MetadataProvider: a.MetadataProvider,
+	})
+
+	// +---------------------+
+	// |      Calendar       |
+	// +---------------------+
+
+	a.Calendar = calendar.NewCalendar(&calendar.NewCalendarOptions{
+		Logger: a.Logger,
+	})
+
+	// +---------------------+
+	// |   Recommendations   |
+	// +---------------------+
+
+	a.RecommendationEngine = recommendation.NewEngine(&recommendation.NewEngineOptions{
+		Logger: a.Logger,
+	})
+
+	// +---------------------+
+	// |     Watch Stats     |
+	// +---------------------+
+
+	a.WatchStats = watchstats.NewManager(&watchstats.NewManagerOptions{
+		Logger:   a.Logger,
+		Database: a.Database,
+	})
+	watchstats.GlobalManager = a.WatchStats
+	a.AddCleanupFunction(func() {
+		a.WatchStats.Shutdown()
+	})
+
+	// +---------------------+
+	// |    Notifications    |
+	// +---------------------+
+
+	a.InitOrRefreshNotificationChannels()
+
+	a.NotificationInbox = notifier.NewInbox(&notifier.NewInboxOptions{
+		Logger:         a.Logger,
+		Database:       a.Database,
+		WSEventManager: a.WSEventManager,
+	})
+	notifier.GlobalNotifier.SetInbox(a.NotificationInbox)
+
 	// +---------------------+
 	// |       Filler        |
 	// +---------------------+
@@ -504,6 +610,42 @@ func (a *App) InitOrRefreshDebridSettings() {
 	}
 }
 
+func (a *App) InitOrRefreshBackupSettings() {
+
+	settings, found := a.Database.GetBackupSettings()
+	if !found {
+
+		var err error
+		settings, err = a.Database.UpsertBackupSettings(&models.BackupSettings{
+			BaseModel: models.BaseModel{
+				ID: 1,
+			},
+			Enabled:    false,
+			Interval:   backup.DefaultInterval,
+			MaxBackups: backup.DefaultMaxBackups,
+			Dir:        "",
+		})
+		if err != nil {
+			a.Logger.Error().Err(err).Msg("app: Failed to initialize backup settings")
+			return
+		}
+	}
+
+	a.BackupManager.SetSettings(settings)
+}
+
+// InitOrRefreshNotificationChannels loads the notification channels into the global notifier.
+// This function should be called after the channels are edited.
+func (a *App) InitOrRefreshNotificationChannels() {
+	channels, err := a.Database.GetNotificationChannels()
+	if err != nil {
+		a.Logger.Error().Err(err).Msg("app: Failed to load notification channels")
+		return
+	}
+
+	notifier.GlobalNotifier.SetChannels(channels)
+}
+
 // InitOrRefreshAnilistData will initialize the Anilist anime collection and the account.
 // This function should be called after App.Database is initialized and after settings are updated.
 func (a *App) InitOrRefreshAnilistData() {
@@ -515,6 +657,10 @@ func (a *App) InitOrRefreshAnilistData() {
 	}
 
 	if acc.Token == "" || acc.Username == "" {
+		// The collections can be tracked without an AniList account
+		if a.GetTrackingPlatform() != models.TrackingPlatformAnilist && !a.IsOffline() {
+			a.refreshTrackingPlatformData()
+		}
 		return
 	}
 
@@ -544,6 +690,25 @@ func (a *App) InitOrRefreshAnilistData() {
 	a.Logger.Info().Msg("app: Fetched Anilist data")
 }
 
+// refreshTrackingPlatformData fetches the collections from the tracking platform when there is no AniList account.
+func (a *App) refreshTrackingPlatformData() {
+	a.Logger.Debug().Str("platform", a.GetTrackingPlatform()).Msg("app: Fetching tracking platform data")
+
+	_, err := a.RefreshAnimeCollection()
+	if err != nil {
+		a.Logger.Error().Err(err).Msg("app: Failed to fetch anime collection")
+		return
+	}
+
+	_, err = a.RefreshMangaCollection()
+	if err != nil {
+		a.Logger.Error().Err(err).Msg("app: Failed to fetch manga collection")
+		return
+	}
+
+	a.Logger.Info().Msg("app: Fetched tracking platform data")
+}
+
 func (a *App) performActionsOnce() {
 
 	go func() {
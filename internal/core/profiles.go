@@ -0,0 +1,190 @@
+package core
+
+import (
+	"fmt"
+	"seanime/internal/api/anilist"
+	"seanime/internal/continuity"
+	"seanime/internal/database/models"
+	"seanime/internal/platforms/anilist_platform"
+	"seanime/internal/platforms/platform"
+)
+
+// ProfileSession holds the modules that belong to a profile.
+// The default profile uses the App's modules, other profiles get their own AniList client, tracking platform and watch history.
+type ProfileSession struct {
+	Profile           *models.Profile
+	AnilistClient     anilist.AnilistClient
+	AnilistPlatform   platform.Platform
+	ContinuityManager *continuity.Manager
+	app               *App
+}
+
+// IsDefault returns true if the session belongs to the default profile.
+func (s *ProfileSession) IsDefault() bool {
+	return s.Profile.ID == models.DefaultProfileID
+}
+
+// GetAccount returns the AniList account of the profile.
+func (s *ProfileSession) GetAccount() (*models.Account, error) {
+	if s.IsDefault() {
+		return s.app.GetAccount()
+	}
+	return s.app.Database.GetAccountByID(s.Profile.ID)
+}
+
+func (s *ProfileSession) GetAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
+	return s.AnilistPlatform.GetAnimeCollection(bypassCache)
+}
+
+func (s *ProfileSession) GetRawAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
+	return s.AnilistPlatform.GetRawAnimeCollection(bypassCache)
+}
+
+// RefreshAnimeCollection refreshes the profile's anime collection.
+// For the default profile, it also updates the modules that depend on the collection.
+func (s *ProfileSession) RefreshAnimeCollection() (*anilist.AnimeCollection, error) {
+	if s.IsDefault() {
+		return s.app.RefreshAnimeCollection()
+	}
+	return s.AnilistPlatform.RefreshAnimeCollection()
+}
+
+func (s *ProfileSession) GetMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
+	return s.AnilistPlatform.GetMangaCollection(bypassCache)
+}
+
+func (s *ProfileSession) GetRawMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
+	return s.AnilistPlatform.GetRawMangaCollection(bypassCache)
+}
+
+func (s *ProfileSession) RefreshMangaCollection() (*anilist.MangaCollection, error) {
+	if s.IsDefault() {
+		return s.app.RefreshMangaCollection()
+	}
+	return s.AnilistPlatform.RefreshMangaCollection()
+}
+
+// GetDiscordSettings returns the Discord settings of the profile.
+func (s *ProfileSession) GetDiscordSettings() *models.DiscordSettings {
+	if s.IsDefault() {
+		if s.app.Settings != nil {
+			return s.app.Settings.Discord
+		}
+		return nil
+	}
+	return s.Profile.Discord
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetProfileSession returns the session of the given profile, creating it if it does not exist.
+func (a *App) GetProfileSession(profile *models.Profile) *ProfileSession {
+	if profile.ID == models.DefaultProfileID || a.IsOffline() {
+		return &ProfileSession{
+			Profile:           profile,
+			AnilistClient:     a.AnilistClient,
+			AnilistPlatform:   a.AnilistPlatform,
+			ContinuityManager: a.ContinuityManager,
+			app:               a,
+		}
+	}
+
+	a.profileSessionsMu.Lock()
+	defer a.profileSessionsMu.Unlock()
+
+	if session, found := a.profileSessions[profile.ID]; found {
+		session.Profile = profile
+		session.ContinuityManager.SetSettings(a.ContinuityManager.GetSettings())
+		return session
+	}
+
+	token := ""
+	username := ""
+	if acc, err := a.Database.GetAccountByID(profile.ID); err == nil {
+		token = acc.Token
+		username = acc.Username
+	}
+
+	anilistClient := anilist.NewCachedAnilistClient(anilist.NewAnilistClient(token), a.FileCacher)
+	anilistPlatform := anilist_platform.NewAnilistPlatform(anilistClient, a.Logger)
+	anilistPlatform.SetUsername(username)
+
+	// Same tracking platform as the default profile, with the account of the profile
+	trackingPlatform := newTrackingPlatform(&newTrackingPlatformOptions{
+		Name:             a.trackingPlatform,
+		ProfileID:        profile.ID,
+		AnilistPlatform:  anilistPlatform,
+		AnilistClient:    anilistClient,
+		Database:         a.Database,
+		MetadataProvider: a.MetadataProvider,
+		Logger:           a.Logger,
+	})
+	trackingPlatform = a.MutationQueue.WrapPlatform(trackingPlatform, profile.ID, a.trackingPlatform)
+
+	continuityManager := continuity.NewManager(&continuity.NewManagerOptions{
+		FileCacher: a.FileCacher,
+		Logger:     a.Logger,
+		Database:   a.Database,
+		BucketName: profileWatchHistoryBucketName(profile.ID),
+	})
+	continuityManager.SetSettings(a.ContinuityManager.GetSettings())
+
+	session := &ProfileSession{
+		Profile:           profile,
+		AnilistClient:     anilistClient,
+		AnilistPlatform:   trackingPlatform,
+		ContinuityManager: continuityManager,
+		app:               a,
+	}
+	a.profileSessions[profile.ID] = session
+
+	return session
+}
+
+// RefreshProfileSession discards the session of a profile so that it is recreated with the updated account.
+// This should be called when a profile logs in or out of AniList, or when it is deleted.
+func (a *App) RefreshProfileSession(profileId uint) {
+	a.profileSessionsMu.Lock()
+	defer a.profileSessionsMu.Unlock()
+	delete(a.profileSessions, profileId)
+}
+
+// DeleteProfile deletes a profile, its data and its session.
+func (a *App) DeleteProfile(profileId uint) error {
+	if err := a.ProfileManager.DeleteProfile(profileId); err != nil {
+		return err
+	}
+
+	a.RefreshProfileSession(profileId)
+
+	if err := a.FileCacher.Remove(profileWatchHistoryBucketName(profileId)); err != nil {
+		a.Logger.Warn().Err(err).Uint("id", profileId).Msg("app: Failed to remove the watch history of the deleted profile")
+	}
+
+	return nil
+}
+
+func profileWatchHistoryBucketName(profileId uint) string {
+	return fmt.Sprintf("%s_%d", continuity.WatchHistoryBucketName, profileId)
+}
+
+// UseProfileForPlayback sets the platform and Discord settings of the profile that starts playback.
+// The media player and Discord client are shared, so the last profile to start playback is the one being tracked.
+func (a *App) UseProfileForPlayback(session *ProfileSession) {
+	if a.PlaybackManager != nil {
+		a.PlaybackManager.SetPlatform(session.AnilistPlatform)
+		a.PlaybackManager.SetProfileID(session.Profile.ID)
+		if collection, err := session.GetAnimeCollection(false); err == nil && collection != nil {
+			a.PlaybackManager.SetAnimeCollection(collection)
+		}
+	}
+
+	if a.DiscordPresence != nil {
+		if settings := session.GetDiscordSettings(); settings != nil {
+			a.DiscordPresence.SetSettings(settings)
+		}
+		if acc, err := session.GetAccount(); err == nil && acc != nil {
+			a.DiscordPresence.SetUsername(acc.Username)
+		}
+	}
+}
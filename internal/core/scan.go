@@ -0,0 +1,87 @@
+package core
+
+import (
+	"errors"
+	"seanime/internal/database/db_bridge"
+	"seanime/internal/library/anime"
+	"seanime/internal/library/scanner"
+	"seanime/internal/library/summary"
+)
+
+type ScanLibraryOptions struct {
+	Enhanced         bool
+	SkipLockedFiles  bool
+	SkipIgnoredFiles bool
+}
+
+// ScanLibrary scans the library directories and saves the local files.
+// It returns the saved local files, or an empty slice if no local files were found.
+func (a *App) ScanLibrary(opts *ScanLibraryOptions) ([]*anime.LocalFile, error) {
+
+	// Retrieve the user's library path
+	libraryPath, err := a.Database.GetLibraryPathFromSettings()
+	if err != nil {
+		return nil, err
+	}
+	additionalLibraryPaths, err := a.Database.GetAdditionalLibraryPathsFromSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest local files
+	existingLfs, _, err := db_bridge.GetLocalFiles(a.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	// +---------------------+
+	// |       Scanner       |
+	// +---------------------+
+
+	// Create scan summary logger
+	scanSummaryLogger := summary.NewScanSummaryLogger()
+
+	// Create a new scan logger
+	scanLogger, err := scanner.NewScanLogger(a.Config.Logs.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new scanner
+	sc := scanner.Scanner{
+		DirPath:            libraryPath,
+		OtherDirPaths:      additionalLibraryPaths,
+		Enhanced:           opts.Enhanced,
+		Platform:           a.AnilistPlatform,
+		Logger:             a.Logger,
+		WSEventManager:     a.WSEventManager,
+		ExistingLocalFiles: existingLfs,
+		SkipLockedFiles:    opts.SkipLockedFiles,
+		SkipIgnoredFiles:   opts.SkipIgnoredFiles,
+		ScanSummaryLogger:  scanSummaryLogger,
+		ScanLogger:         scanLogger,
+		MetadataProvider:   a.MetadataProvider,
+	}
+
+	// Scan the library
+	allLfs, err := sc.Scan()
+	if err != nil {
+		if errors.Is(err, scanner.ErrNoLocalFiles) {
+			return []*anime.LocalFile{}, nil
+		}
+		return nil, err
+	}
+
+	// Insert the local files
+	lfs, err := db_bridge.InsertLocalFiles(a.Database, allLfs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the scan summary
+	_ = db_bridge.InsertScanSummary(a.Database, scanSummaryLogger.GenerateSummary())
+
+	go a.AutoDownloader.CleanUpDownloadedItems()
+
+	return lfs, nil
+}
@@ -0,0 +1,9 @@
+package cron
+
+func BackupJob(c *JobCtx) error {
+	if c.App.BackupManager == nil {
+		return nil
+	}
+
+	return c.App.BackupManager.RunScheduledBackup()
+}
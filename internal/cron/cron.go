@@ -2,38 +2,129 @@ package cron
 
 import (
 	"seanime/internal/core"
-	"time"
+	"seanime/internal/scheduler"
+)
+
+const (
+	JobRefreshAnilist      = "anilist-refresh"
+	JobSyncLocalData       = "offline-sync"
+	JobListSync            = "list-sync"
+	JobScanLibrary         = "library-scan"
+	JobAutoDownloader      = "auto-downloader"
+	JobRefetchFillers      = "filler-refetch"
+	JobTrimCaches          = "cache-trim"
+	JobBackup              = "backup"
+	JobCheckForNewReleases = "release-check"
+	JobCheckExtensions     = "extension-update-check"
 )
 
 type JobCtx struct {
 	App *core.App
 }
 
+// RunJobs registers the background jobs and starts the scheduler.
+// The schedule and enabled flag of each job can be changed by the user, see handlers/scheduler.go.
 func RunJobs(app *core.App) {
 
-	// Run the jobs only if the server is online
-	if !app.IsOffline() {
+	ctx := &JobCtx{
+		App: app,
+	}
 
-		ctx := &JobCtx{
-			App: app,
+	jobs := []*scheduler.Job{
+		{
+			Name:            JobRefreshAnilist,
+			Description:     "Refreshes the AniList anime and manga collections",
+			DefaultSchedule: "@every 10m",
+			DefaultEnabled:  true,
+			RequiresOnline:  true,
+			Run:             ctx.run(RefreshAnilistDataJob),
+		},
+		{
+			Name:            JobSyncLocalData,
+			Description:     "Synchronizes the data of the anime and manga saved for offline use",
+			DefaultSchedule: "@every 31m",
+			DefaultEnabled:  true,
+			RequiresOnline:  true,
+			Run:             ctx.run(SyncLocalDataJob),
+		},
+		{
+			Name:            JobListSync,
+			Description:     "Synchronizes the AniList and MyAnimeList lists if automatic list sync is enabled",
+			DefaultSchedule: "@every 1h",
+			DefaultEnabled:  true,
+			RequiresOnline:  true,
+			Run:             ctx.run(ListSyncJob),
+		},
+		{
+			Name:            JobScanLibrary,
+			Description:     "Scans the library for new files, skipping locked and ignored files",
+			DefaultSchedule: "0 */6 * * *",
+			DefaultEnabled:  false,
+			RequiresOnline:  true,
+			Run:             ctx.run(ScanLibraryJob),
+		},
+		{
+			Name:            JobAutoDownloader,
+			Description:     "Checks for new episodes, in addition to the interval set in the auto downloader settings",
+			DefaultSchedule: "@every 1h",
+			DefaultEnabled:  false,
+			RequiresOnline:  true,
+			Run:             ctx.run(AutoDownloaderJob),
+		},
+		{
+			Name:            JobRefetchFillers,
+			Description:     "Re-fetches the filler data of the anime that have it",
+			DefaultSchedule: "0 4 * * 1",
+			DefaultEnabled:  true,
+			RequiresOnline:  true,
+			Run:             ctx.run(RefetchFillersJob),
+		},
+		{
+			Name:            JobTrimCaches,
+			Description:     "Trims the video file cache, scan summaries and torrent stream history",
+			DefaultSchedule: "@every 6h",
+			DefaultEnabled:  true,
+			Run:             ctx.run(TrimCachesJob),
+		},
+		{
+			Name:            JobBackup,
+			Description:     "Creates a backup when scheduled backups are enabled and the last one is older than the backup interval",
+			DefaultSchedule: "@every 15m",
+			DefaultEnabled:  true,
+			Run:             ctx.run(BackupJob),
+		},
+		{
+			Name:            JobCheckExtensions,
+			Description:     "Checks the installed extensions for updates and notifies the webhooks",
+			DefaultSchedule: "@every 6h",
+			DefaultEnabled:  true,
+			RequiresOnline:  true,
+			Run:             ctx.run(CheckExtensionUpdatesJob),
+		},
+		{
+			Name:            JobCheckForNewReleases,
+			Description:     "Checks for new releases of Seanime",
+			DefaultSchedule: "@every 1h",
+			DefaultEnabled:  true,
+			RequiresOnline:  true,
+			Run: func() error {
+				app.Updater.ShouldRefetchReleases()
+				return nil
+			},
+		},
+	}
+
+	for _, job := range jobs {
+		if err := app.Scheduler.Register(job); err != nil {
+			app.Logger.Error().Err(err).Str("job", job.Name).Msg("cron: Failed to register job")
 		}
+	}
 
-		refreshAnilistTicker := time.NewTicker(10 * time.Minute)
-		refreshLocalDataTicker := time.NewTicker(31 * time.Minute)
-		refetchReleaseTicker := time.NewTicker(1 * time.Hour)
-
-		go func() {
-			for {
-				select {
-				case <-refreshAnilistTicker.C:
-					RefreshAnilistDataJob(ctx)
-				case <-refreshLocalDataTicker.C:
-					SyncLocalDataJob(ctx)
-				case <-refetchReleaseTicker.C:
-					app.Updater.ShouldRefetchReleases()
-				}
-			}
-		}()
+	app.Scheduler.Start()
+}
 
+func (c *JobCtx) run(job func(c *JobCtx) error) func() error {
+	return func() error {
+		return job(c)
 	}
 }
@@ -0,0 +1,10 @@
+package cron
+
+func CheckExtensionUpdatesJob(c *JobCtx) error {
+	if c.App.ExtensionRepository == nil {
+		return nil
+	}
+
+	c.App.ExtensionRepository.CheckForUpdates()
+	return nil
+}
@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"seanime/internal/core"
+	"seanime/internal/events"
+)
+
+func ScanLibraryJob(c *JobCtx) error {
+	if c.App.Settings == nil || c.App.Settings.Library == nil || c.App.Settings.Library.LibraryPath == "" {
+		return nil
+	}
+
+	c.App.WSEventManager.SendEvent(events.AutoScanStarted, nil)
+	defer c.App.WSEventManager.SendEvent(events.AutoScanCompleted, nil)
+
+	_, err := c.App.ScanLibrary(&core.ScanLibraryOptions{
+		Enhanced:         false,
+		SkipLockedFiles:  true,
+		SkipIgnoredFiles: true,
+	})
+	return err
+}
+
+func AutoDownloaderJob(c *JobCtx) error {
+	if c.App.AutoDownloader == nil {
+		return nil
+	}
+
+	c.App.AutoDownloader.RunNow()
+	return nil
+}
+
+func RefetchFillersJob(c *JobCtx) error {
+	if c.App.FillerManager == nil {
+		return nil
+	}
+
+	return c.App.FillerManager.RefetchFillerData()
+}
+
+func TrimCachesJob(c *JobCtx) error {
+	c.App.Database.TrimScanSummaryEntries()
+	c.App.Database.TrimTorrentstreamHistory()
+	c.App.Database.TrimLocalFileEntries()
+
+	if c.App.FileCacher == nil {
+		return nil
+	}
+	return c.App.FileCacher.TrimMediastreamVideoFiles()
+}
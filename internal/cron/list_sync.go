@@ -0,0 +1,27 @@
+package cron
+
+import (
+	"errors"
+	"seanime/internal/listsync"
+)
+
+func ListSyncJob(c *JobCtx) error {
+	if !c.App.ListSyncManager.GetSettings().Automatic {
+		return nil
+	}
+
+	res, err := c.App.ListSyncManager.Sync("")
+	if err != nil {
+		// Nothing to synchronize until the user logs in to both platforms
+		if errors.Is(err, listsync.ErrAnilistNotLoggedIn) || errors.Is(err, listsync.ErrMalNotLoggedIn) {
+			return nil
+		}
+		return err
+	}
+
+	if res.Applied == 0 {
+		return nil
+	}
+
+	return RefreshAnilistDataJob(c)
+}
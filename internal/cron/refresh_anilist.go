@@ -4,36 +4,33 @@ import (
 	"seanime/internal/events"
 )
 
-func RefreshAnilistDataJob(c *JobCtx) {
-	defer func() {
-		if r := recover(); r != nil {
-		}
-	}()
-
+func RefreshAnilistDataJob(c *JobCtx) error {
 	if c.App.Settings == nil || c.App.Settings.Library == nil {
-		return
+		return nil
 	}
 
 	// Refresh the Anilist Collection
-	animeCollection, _ := c.App.RefreshAnimeCollection()
+	animeCollection, err := c.App.RefreshAnimeCollection()
+	if err != nil {
+		return err
+	}
 
 	if c.App.Settings.Library.EnableManga {
-		mangaCollection, _ := c.App.RefreshMangaCollection()
+		mangaCollection, err := c.App.RefreshMangaCollection()
+		if err != nil {
+			return err
+		}
 		c.App.WSEventManager.SendEvent(events.RefreshedAnilistMangaCollection, mangaCollection)
 	}
 
 	c.App.WSEventManager.SendEvent(events.RefreshedAnilistAnimeCollection, animeCollection)
+	return nil
 }
 
-func SyncLocalDataJob(c *JobCtx) {
-	defer func() {
-		if r := recover(); r != nil {
-		}
-	}()
-
+func SyncLocalDataJob(c *JobCtx) error {
 	if c.App.Settings == nil || c.App.Settings.Library == nil || !c.App.Settings.Library.AutoSyncOfflineLocalData {
-		return
+		return nil
 	}
 
-	_ = c.App.SyncManager.SynchronizeLocal()
+	return c.App.SyncManager.SynchronizeLocal()
 }
@@ -19,7 +19,9 @@ func (db *Database) UpsertAccount(acc *models.Account) (*models.Account, error)
 		return nil, err
 	}
 
-	accountCache = acc
+	if acc.ID == models.DefaultProfileID {
+		accountCache = acc
+	}
 
 	return acc, nil
 }
@@ -31,7 +33,7 @@ func (db *Database) GetAccount() (*models.Account, error) {
 	}
 
 	var acc models.Account
-	err := db.gormdb.Last(&acc).Error
+	err := db.gormdb.First(&acc, models.DefaultProfileID).Error
 	if err != nil {
 		return nil, err
 	}
@@ -52,3 +54,21 @@ func (db *Database) GetAnilistToken() string {
 	}
 	return acc.Token
 }
+
+// GetAccountByID returns the account of the profile with the given ID.
+func (db *Database) GetAccountByID(id uint) (*models.Account, error) {
+	if id == models.DefaultProfileID {
+		return db.GetAccount()
+	}
+
+	var acc models.Account
+	err := db.gormdb.First(&acc, id).Error
+	if err != nil {
+		return nil, err
+	}
+	if acc.Username == "" || acc.Token == "" || acc.Viewer == nil {
+		return nil, errors.New("account does not exist")
+	}
+
+	return &acc, nil
+}
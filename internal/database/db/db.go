@@ -60,11 +60,18 @@ func NewDatabase(appDataDir, dbName string, logger *zerolog.Logger) (*Database,
 
 	logger.Info().Str("name", fmt.Sprintf("%s.db", dbName)).Msg("db: Database instantiated")
 
-	return &Database{
+	ret := &Database{
 		gormdb:           db,
 		Logger:           logger,
 		CurrMediaFillers: mo.None[map[int]*MediaFillerItem](),
-	}, nil
+	}
+
+	// Encrypt credentials stored in plaintext by previous versions
+	if err := ret.encryptCredentials(); err != nil {
+		logger.Error().Err(err).Msg("db: Failed to encrypt stored credentials")
+	}
+
+	return ret, nil
 }
 
 // MigrateTables performs auto migration on the database
@@ -74,6 +81,7 @@ func migrateTables(db *gorm.DB) error {
 		&models.Settings{},
 		&models.Account{},
 		&models.Mal{},
+		&models.Kitsu{},
 		&models.ScanSummary{},
 		&models.AutoDownloaderRule{},
 		&models.AutoDownloaderItem{},
@@ -89,6 +97,18 @@ func migrateTables(db *gorm.DB) error {
 		&models.OnlinestreamMapping{},
 		&models.DebridSettings{},
 		&models.DebridTorrentItem{},
+		&models.ServerPassword{},
+		&models.AuthDevice{},
+		&models.Profile{},
+		&models.BackupSettings{},
+		&models.ScheduledJobSettings{},
+		&models.ScheduledJobRun{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.NotificationChannel{},
+		&models.NotificationEntry{},
+		&models.PlatformMutation{},
+		&models.WatchSession{},
 		//&models.MangaChapterContainer{},
 	)
 	if err != nil {
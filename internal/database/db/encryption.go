@@ -0,0 +1,60 @@
+package db
+
+import (
+	"fmt"
+	"seanime/internal/database/encryption"
+	"seanime/internal/database/models"
+	"strings"
+)
+
+// encryptCredentials encrypts the credentials that were stored in plaintext by previous versions.
+// Rows are only rewritten if one of their credential columns is not encrypted.
+func (db *Database) encryptCredentials() error {
+	if !encryption.HasKey() {
+		return nil
+	}
+
+	if err := db.encryptRows(&models.Settings{}, &[]*models.Settings{}, "vlc_password", "qbittorrent_password", "transmission_password"); err != nil {
+		return err
+	}
+	if err := db.encryptRows(&models.Account{}, &[]*models.Account{}, "token"); err != nil {
+		return err
+	}
+	if err := db.encryptRows(&models.Mal{}, &[]*models.Mal{}, "access_token", "refresh_token"); err != nil {
+		return err
+	}
+	if err := db.encryptRows(&models.Kitsu{}, &[]*models.Kitsu{}, "access_token", "refresh_token"); err != nil {
+		return err
+	}
+	if err := db.encryptRows(&models.DebridSettings{}, &[]*models.DebridSettings{}, "api_key"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *Database) encryptRows(model interface{}, rows interface{}, columns ...string) error {
+	conditions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		conditions = append(conditions, fmt.Sprintf("(%s != '' AND %s NOT LIKE '%s%%')", column, column, encryption.Prefix))
+	}
+
+	var count int64
+	if err := db.gormdb.Model(model).Where(strings.Join(conditions, " OR ")).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+
+	// The serializer encrypts the values when the rows are saved
+	if err := db.gormdb.Find(rows).Error; err != nil {
+		return err
+	}
+	if err := db.gormdb.Save(rows).Error; err != nil {
+		return err
+	}
+
+	db.Logger.Info().Int64("rows", count).Str("table", fmt.Sprintf("%T", model)).Msg("db: Encrypted stored credentials")
+	return nil
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"seanime/internal/database/models"
+)
+
+// GetKitsuInfoByID returns the Kitsu info of the profile with the given ID.
+func (db *Database) GetKitsuInfoByID(id uint) (*models.Kitsu, error) {
+	var res models.Kitsu
+	err := db.gormdb.First(&res, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("Kitsu not connected")
+	} else if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (db *Database) UpsertKitsuInfo(info *models.Kitsu) (*models.Kitsu, error) {
+	err := db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(info).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (db *Database) DeleteKitsuInfoByID(id uint) error {
+	err := db.gormdb.Delete(&models.Kitsu{}, id).Error
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
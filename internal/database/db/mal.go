@@ -8,9 +8,13 @@ import (
 )
 
 func (db *Database) GetMalInfo() (*models.Mal, error) {
-	// Get the first entry
+	return db.GetMalInfoByID(models.DefaultProfileID)
+}
+
+// GetMalInfoByID returns the MAL info of the profile with the given ID.
+func (db *Database) GetMalInfoByID(id uint) (*models.Mal, error) {
 	var res models.Mal
-	err := db.gormdb.First(&res, 1).Error
+	err := db.gormdb.First(&res, id).Error
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.New("MAL not connected")
 	} else if err != nil {
@@ -41,7 +45,11 @@ func (db *Database) InsertMalInfo(info *models.Mal) (*models.Mal, error) {
 }
 
 func (db *Database) DeleteMalInfo() error {
-	err := db.gormdb.Delete(&models.Mal{}, 1).Error
+	return db.DeleteMalInfoByID(models.DefaultProfileID)
+}
+
+func (db *Database) DeleteMalInfoByID(id uint) error {
+	err := db.gormdb.Delete(&models.Mal{}, id).Error
 
 	if err != nil {
 		return err
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+)
+
+func (db *Database) GetNotificationChannels() ([]*models.NotificationChannel, error) {
+	var res []*models.NotificationChannel
+	err := db.gormdb.Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) GetNotificationChannel(id uint) (*models.NotificationChannel, error) {
+	var res models.NotificationChannel
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (db *Database) InsertNotificationChannel(channel *models.NotificationChannel) (*models.NotificationChannel, error) {
+	err := db.gormdb.Create(channel).Error
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (db *Database) UpdateNotificationChannel(channel *models.NotificationChannel) (*models.NotificationChannel, error) {
+	err := db.gormdb.Save(channel).Error
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (db *Database) DeleteNotificationChannel(id uint) error {
+	return db.gormdb.Delete(&models.NotificationChannel{}, id).Error
+}
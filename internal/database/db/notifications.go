@@ -0,0 +1,91 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+	"time"
+)
+
+// maxNotificationEntries is the number of notifications kept in the inbox.
+const maxNotificationEntries = 1000
+
+type NotificationFilter struct {
+	Kind       string
+	MediaId    int
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+// InsertNotificationEntry adds the notification to the inbox and deletes the oldest notifications.
+func (db *Database) InsertNotificationEntry(entry *models.NotificationEntry) error {
+	err := db.gormdb.Create(entry).Error
+	if err != nil {
+		return err
+	}
+
+	return db.gormdb.Delete(&models.NotificationEntry{},
+		"id NOT IN (SELECT id FROM notification_entries ORDER BY id DESC LIMIT ?)", maxNotificationEntries).Error
+}
+
+// GetNotificationEntries returns the notifications matching the filter, newest first, and the total number of matches.
+func (db *Database) GetNotificationEntries(filter *NotificationFilter) ([]*models.NotificationEntry, int64, error) {
+	query := db.gormdb.Model(&models.NotificationEntry{})
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.MediaId != 0 {
+		query = query.Where("media_id = ?", filter.MediaId)
+	}
+	if filter.UnreadOnly {
+		query = query.Where("read = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var res []*models.NotificationEntry
+	err := query.Order("id DESC").Limit(limit).Offset(filter.Offset).Find(&res).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return res, total, nil
+}
+
+func (db *Database) GetUnreadNotificationCount() (int64, error) {
+	var count int64
+	err := db.gormdb.Model(&models.NotificationEntry{}).Where("read = ?", false).Count(&count).Error
+	return count, err
+}
+
+func (db *Database) MarkNotificationEntriesRead(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.gormdb.Model(&models.NotificationEntry{}).
+		Where("id IN ? AND read = ?", ids, false).
+		Updates(map[string]interface{}{"read": true, "read_at": time.Now()}).Error
+}
+
+func (db *Database) MarkAllNotificationEntriesRead() error {
+	return db.gormdb.Model(&models.NotificationEntry{}).
+		Where("read = ?", false).
+		Updates(map[string]interface{}{"read": true, "read_at": time.Now()}).Error
+}
+
+// DeleteNotificationEntries deletes the notifications created before the given time and returns the number of deleted notifications.
+// If readOnly is true, unread notifications are kept.
+func (db *Database) DeleteNotificationEntries(before time.Time, readOnly bool) (int64, error) {
+	query := db.gormdb.Where("created_at < ?", before)
+	if readOnly {
+		query = query.Where("read = ?", true)
+	}
+	res := query.Delete(&models.NotificationEntry{})
+	return res.RowsAffected, res.Error
+}
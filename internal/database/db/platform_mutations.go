@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"seanime/internal/database/models"
+)
+
+// GetPlatformMutations returns the mutations of a profile, oldest first.
+func (db *Database) GetPlatformMutations(profileId uint) ([]*models.PlatformMutation, error) {
+	var res []*models.PlatformMutation
+	err := db.gormdb.Where("profile_id = ?", profileId).Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPendingPlatformMutations returns the mutations of all profiles that are waiting to be retried.
+func (db *Database) GetPendingPlatformMutations() ([]*models.PlatformMutation, error) {
+	var res []*models.PlatformMutation
+	err := db.gormdb.Where("status = ?", "pending").Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) GetPlatformMutation(id uint) (*models.PlatformMutation, error) {
+	var res models.PlatformMutation
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetPlatformMutationByMediaID returns the mutation of the media, or nil if there is none.
+func (db *Database) GetPlatformMutationByMediaID(profileId uint, platform string, mediaId int) (*models.PlatformMutation, error) {
+	var res models.PlatformMutation
+	err := db.gormdb.Where("profile_id = ? AND platform = ? AND media_id = ?", profileId, platform, mediaId).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (db *Database) SavePlatformMutation(mutation *models.PlatformMutation) error {
+	return db.gormdb.Save(mutation).Error
+}
+
+func (db *Database) DeletePlatformMutation(id uint) error {
+	return db.gormdb.Delete(&models.PlatformMutation{}, id).Error
+}
+
+// DeletePlatformMutationRevision deletes the mutation if no other write was collapsed into it.
+func (db *Database) DeletePlatformMutationRevision(id uint, revision int) error {
+	return db.gormdb.Where("revision = ?", revision).Delete(&models.PlatformMutation{}, id).Error
+}
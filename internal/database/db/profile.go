@@ -0,0 +1,64 @@
+package db
+
+import (
+	"gorm.io/gorm/clause"
+	"seanime/internal/database/models"
+)
+
+func (db *Database) GetProfiles() ([]*models.Profile, error) {
+	var res []*models.Profile
+	err := db.gormdb.Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range res {
+		p.HasPin = p.PinHash != ""
+	}
+	return res, nil
+}
+
+func (db *Database) GetProfile(id uint) (*models.Profile, error) {
+	var res models.Profile
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	res.HasPin = res.PinHash != ""
+	return &res, nil
+}
+
+func (db *Database) UpsertProfile(profile *models.Profile) (*models.Profile, error) {
+	err := db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(profile).Error
+	if err != nil {
+		db.Logger.Error().Err(err).Msg("db: Failed to save profile")
+		return nil, err
+	}
+	profile.HasPin = profile.PinHash != ""
+	return profile, nil
+}
+
+// DeleteProfile deletes the profile and the data that belongs to it.
+func (db *Database) DeleteProfile(id uint) error {
+	if err := db.gormdb.Where("profile_id = ?", id).Delete(&models.PlaylistEntry{}).Error; err != nil {
+		return err
+	}
+	if err := db.gormdb.Delete(&models.Account{}, id).Error; err != nil {
+		return err
+	}
+	if err := db.gormdb.Delete(&models.Mal{}, id).Error; err != nil {
+		return err
+	}
+	if err := db.gormdb.Delete(&models.Kitsu{}, id).Error; err != nil {
+		return err
+	}
+	if err := db.gormdb.Where("profile_id = ?", id).Delete(&models.PlatformMutation{}).Error; err != nil {
+		return err
+	}
+	if err := db.gormdb.Where("profile_id = ?", id).Delete(&models.WatchSession{}).Error; err != nil {
+		return err
+	}
+	return db.gormdb.Delete(&models.Profile{}, id).Error
+}
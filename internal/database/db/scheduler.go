@@ -0,0 +1,62 @@
+package db
+
+import (
+	"gorm.io/gorm/clause"
+	"seanime/internal/database/models"
+)
+
+// maxScheduledJobRuns is the number of runs kept per job.
+const maxScheduledJobRuns = 50
+
+func (db *Database) GetScheduledJobSettings() ([]*models.ScheduledJobSettings, error) {
+	var res []*models.ScheduledJobSettings
+	err := db.gormdb.Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) UpsertScheduledJobSettings(settings *models.ScheduledJobSettings) (*models.ScheduledJobSettings, error) {
+	err := db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "enabled", "schedule"}),
+	}).Create(settings).Error
+	if err != nil {
+		db.Logger.Error().Err(err).Msg("db: Failed to save scheduled job settings")
+		return nil, err
+	}
+	return settings, nil
+}
+
+// InsertScheduledJobRun records a job run and deletes the oldest runs of the job.
+func (db *Database) InsertScheduledJobRun(run *models.ScheduledJobRun) error {
+	err := db.gormdb.Create(run).Error
+	if err != nil {
+		return err
+	}
+
+	return db.gormdb.Delete(&models.ScheduledJobRun{},
+		"name = ? AND id NOT IN (SELECT id FROM scheduled_job_runs WHERE name = ? ORDER BY id DESC LIMIT ?)",
+		run.Name, run.Name, maxScheduledJobRuns).Error
+}
+
+// GetScheduledJobRuns returns the latest runs of a job, newest first.
+func (db *Database) GetScheduledJobRuns(name string, limit int) ([]*models.ScheduledJobRun, error) {
+	var res []*models.ScheduledJobRun
+	err := db.gormdb.Where("name = ?", name).Order("id DESC").Limit(limit).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetLastScheduledJobRuns returns the last run of each job.
+func (db *Database) GetLastScheduledJobRuns() ([]*models.ScheduledJobRun, error) {
+	var res []*models.ScheduledJobRun
+	err := db.gormdb.Where("id IN (SELECT MAX(id) FROM scheduled_job_runs GROUP BY name)").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
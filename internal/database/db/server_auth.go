@@ -0,0 +1,77 @@
+package db
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"seanime/internal/database/models"
+	"time"
+)
+
+// GetServerPasswordHash returns the hash of the server password set from the settings.
+// It returns an empty string if no password has been set.
+func (db *Database) GetServerPasswordHash() string {
+	var res models.ServerPassword
+	err := db.gormdb.First(&res, 1).Error
+	if err != nil {
+		return ""
+	}
+	return res.Hash
+}
+
+func (db *Database) UpsertServerPasswordHash(hash string) error {
+	return db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(&models.ServerPassword{
+		BaseModel: models.BaseModel{
+			ID:        1,
+			UpdatedAt: time.Now(),
+		},
+		Hash: hash,
+	}).Error
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (db *Database) GetAuthDevices() ([]*models.AuthDevice, error) {
+	var res []*models.AuthDevice
+	err := db.gormdb.Order("last_used_at DESC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) GetAuthDeviceByTokenHash(tokenHash string) (*models.AuthDevice, error) {
+	var res models.AuthDevice
+	err := db.gormdb.Where("token_hash = ?", tokenHash).First(&res).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("device not found")
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (db *Database) InsertAuthDevice(device *models.AuthDevice) error {
+	return db.gormdb.Create(device).Error
+}
+
+func (db *Database) UpdateAuthDeviceLastUsed(id uint, ip string, lastUsedAt time.Time) error {
+	return db.gormdb.Model(&models.AuthDevice{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_ip":      ip,
+		"last_used_at": lastUsedAt,
+	}).Error
+}
+
+func (db *Database) DeleteAuthDevice(id uint) error {
+	return db.gormdb.Delete(&models.AuthDevice{}, id).Error
+}
+
+// DeleteAuthDevicesExcept deletes all devices except the one with the given ID.
+// Passing 0 deletes all devices.
+func (db *Database) DeleteAuthDevicesExcept(id uint) error {
+	return db.gormdb.Where("id <> ?", id).Delete(&models.AuthDevice{}).Error
+}
@@ -178,3 +178,36 @@ func (db *Database) GetDebridSettings() (*models.DebridSettings, bool) {
 }
 
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var CurrentBackupSettings *models.BackupSettings
+
+func (db *Database) UpsertBackupSettings(settings *models.BackupSettings) (*models.BackupSettings, error) {
+	err := db.gormdb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(settings).Error
+
+	if err != nil {
+		db.Logger.Error().Err(err).Msg("db: Failed to save backup settings in the database")
+		return nil, err
+	}
+
+	CurrentBackupSettings = settings
+
+	db.Logger.Debug().Msg("db: Backup settings saved")
+	return settings, nil
+}
+
+func (db *Database) GetBackupSettings() (*models.BackupSettings, bool) {
+
+	if CurrentBackupSettings != nil {
+		return CurrentBackupSettings, true
+	}
+
+	var settings models.BackupSettings
+	err := db.gormdb.Where("id = ?", 1).First(&settings).Error
+	if err != nil {
+		return nil, false
+	}
+	return &settings, true
+}
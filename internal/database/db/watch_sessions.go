@@ -0,0 +1,31 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+	"time"
+)
+
+// GetWatchSessions returns the sessions of a profile that started between from and to, oldest first.
+// The times are compared in UTC, which is how the sessions are stored.
+func (db *Database) GetWatchSessions(profileId uint, from time.Time, to time.Time) ([]*models.WatchSession, error) {
+	var res []*models.WatchSession
+	err := db.gormdb.Where("profile_id = ? AND started_at >= ? AND started_at < ?", profileId, from.UTC(), to.UTC()).Order("started_at ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAllWatchSessions returns all the sessions of a profile, oldest first.
+func (db *Database) GetAllWatchSessions(profileId uint) ([]*models.WatchSession, error) {
+	var res []*models.WatchSession
+	err := db.gormdb.Where("profile_id = ?", profileId).Order("started_at ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) SaveWatchSession(session *models.WatchSession) error {
+	return db.gormdb.Save(session).Error
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"seanime/internal/database/models"
+)
+
+// maxWebhookDeliveries is the number of deliveries kept per webhook.
+const maxWebhookDeliveries = 100
+
+func (db *Database) GetWebhooks() ([]*models.Webhook, error) {
+	var res []*models.Webhook
+	err := db.gormdb.Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (db *Database) GetWebhook(id uint) (*models.Webhook, error) {
+	var res models.Webhook
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (db *Database) InsertWebhook(webhook *models.Webhook) (*models.Webhook, error) {
+	err := db.gormdb.Create(webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (db *Database) UpdateWebhook(webhook *models.Webhook) (*models.Webhook, error) {
+	err := db.gormdb.Save(webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes the webhook and its deliveries.
+func (db *Database) DeleteWebhook(id uint) error {
+	err := db.gormdb.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error
+	if err != nil {
+		return err
+	}
+	return db.gormdb.Delete(&models.Webhook{}, id).Error
+}
+
+// InsertWebhookDelivery records a delivery and deletes the oldest deliveries of the webhook.
+func (db *Database) InsertWebhookDelivery(delivery *models.WebhookDelivery) error {
+	err := db.gormdb.Create(delivery).Error
+	if err != nil {
+		return err
+	}
+
+	return db.gormdb.Delete(&models.WebhookDelivery{},
+		"webhook_id = ? AND id NOT IN (SELECT id FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC LIMIT ?)",
+		delivery.WebhookID, delivery.WebhookID, maxWebhookDeliveries).Error
+}
+
+func (db *Database) UpdateWebhookDelivery(delivery *models.WebhookDelivery) error {
+	return db.gormdb.Save(delivery).Error
+}
+
+func (db *Database) GetWebhookDelivery(id uint) (*models.WebhookDelivery, error) {
+	var res models.WebhookDelivery
+	err := db.gormdb.First(&res, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetWebhookDeliveries returns the latest deliveries of a webhook, newest first.
+func (db *Database) GetWebhookDeliveries(webhookId uint, limit int) ([]*models.WebhookDelivery, error) {
+	var res []*models.WebhookDelivery
+	err := db.gormdb.Where("webhook_id = ?", webhookId).Order("id DESC").Limit(limit).Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPendingWebhookDeliveries returns the deliveries that were interrupted before being completed.
+func (db *Database) GetPendingWebhookDeliveries() ([]*models.WebhookDelivery, error) {
+	var res []*models.WebhookDelivery
+	err := db.gormdb.Where("status = ?", "pending").Order("id ASC").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
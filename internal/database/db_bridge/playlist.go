@@ -7,9 +7,9 @@ import (
 	"seanime/internal/library/anime"
 )
 
-func GetPlaylists(db *db.Database) ([]*anime.Playlist, error) {
+func GetPlaylists(db *db.Database, profileId uint) ([]*anime.Playlist, error) {
 	var res []*models.PlaylistEntry
-	err := db.Gorm().Find(&res).Error
+	err := db.Gorm().Where("profile_id = ?", profileId).Find(&res).Error
 	if err != nil {
 		return nil, err
 	}
@@ -27,14 +27,15 @@ func GetPlaylists(db *db.Database) ([]*anime.Playlist, error) {
 	return playlists, nil
 }
 
-func SavePlaylist(db *db.Database, playlist *anime.Playlist) error {
+func SavePlaylist(db *db.Database, profileId uint, playlist *anime.Playlist) error {
 	data, err := json.Marshal(playlist.LocalFiles)
 	if err != nil {
 		return err
 	}
 	playlistEntry := &models.PlaylistEntry{
-		Name:  playlist.Name,
-		Value: data,
+		Name:      playlist.Name,
+		Value:     data,
+		ProfileID: profileId,
 	}
 
 	return db.Gorm().Save(playlistEntry).Error
@@ -44,7 +45,7 @@ func DeletePlaylist(db *db.Database, id uint) error {
 	return db.Gorm().Where("id = ?", id).Delete(&models.PlaylistEntry{}).Error
 }
 
-func UpdatePlaylist(db *db.Database, playlist *anime.Playlist) error {
+func UpdatePlaylist(db *db.Database, profileId uint, playlist *anime.Playlist) error {
 	data, err := json.Marshal(playlist.LocalFiles)
 	if err != nil {
 		return err
@@ -52,7 +53,7 @@ func UpdatePlaylist(db *db.Database, playlist *anime.Playlist) error {
 
 	// Get the playlist entry
 	playlistEntry := &models.PlaylistEntry{}
-	if err := db.Gorm().Where("id = ?", playlist.DbId).First(playlistEntry).Error; err != nil {
+	if err := db.Gorm().Where("id = ? AND profile_id = ?", playlist.DbId, profileId).First(playlistEntry).Error; err != nil {
 		return err
 	}
 
@@ -63,9 +64,9 @@ func UpdatePlaylist(db *db.Database, playlist *anime.Playlist) error {
 	return db.Gorm().Save(playlistEntry).Error
 }
 
-func GetPlaylist(db *db.Database, id uint) (*anime.Playlist, error) {
+func GetPlaylist(db *db.Database, profileId uint, id uint) (*anime.Playlist, error) {
 	playlistEntry := &models.PlaylistEntry{}
-	if err := db.Gorm().Where("id = ?", id).First(playlistEntry).Error; err != nil {
+	if err := db.Gorm().Where("id = ? AND profile_id = ?", id, profileId).First(playlistEntry).Error; err != nil {
 		return nil, err
 	}
 
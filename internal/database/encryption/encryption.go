@@ -0,0 +1,211 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm/schema"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Encrypted values are prefixed so that plaintext values from older versions can still be read.
+const Prefix = "enc:v1:"
+
+const (
+	// KeyFileName is the name of the file storing the encryption key in the data directory.
+	KeyFileName = "seanime.key"
+	// KeyEnvName is the environment variable that can be used to provide the key instead of the key file.
+	KeyEnvName = "SEANIME_ENCRYPTION_KEY"
+)
+
+var (
+	ErrInvalidKey        = errors.New("encryption: key must be 32 bytes")
+	ErrInvalidCiphertext = errors.New("encryption: invalid ciphertext")
+	ErrNoKey             = errors.New("encryption: no key set")
+)
+
+var (
+	gcm cipher.AEAD
+	mu  sync.RWMutex
+)
+
+func init() {
+	schema.RegisterSerializer("encrypted", Serializer{})
+}
+
+// SetKey sets the key used to encrypt and decrypt values.
+// The key must be 32 bytes long (AES-256).
+func SetKey(key []byte) error {
+	if len(key) != 32 {
+		return ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	gcm = aead
+	mu.Unlock()
+	return nil
+}
+
+// HasKey returns true if a key has been set.
+func HasKey() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return gcm != nil
+}
+
+// IsEncrypted returns true if the value was encrypted by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Encrypt encrypts the value with AES-GCM.
+// Empty values are not encrypted.
+func Encrypt(value string) (string, error) {
+	if value == "" || IsEncrypted(value) {
+		return value, nil
+	}
+
+	mu.RLock()
+	aead := gcm
+	mu.RUnlock()
+	if aead == nil {
+		return "", ErrNoKey
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value encrypted by Encrypt.
+// Values that are not encrypted are returned as-is.
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	mu.RLock()
+	aead := gcm
+	mu.RUnlock()
+	if aead == nil {
+		return "", ErrNoKey
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	if len(data) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
+
+// LoadOrCreateKey returns the key from the SEANIME_ENCRYPTION_KEY environment variable (hex encoded),
+// or from the key file in the given directory. The key file is created if it does not exist.
+func LoadOrCreateKey(dir string) ([]byte, error) {
+	if envKey := strings.TrimSpace(os.Getenv(KeyEnvName)); envKey != "" {
+		key, err := hex.DecodeString(envKey)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("encryption: %s must be a 64 character hex string", KeyEnvName)
+		}
+		return key, nil
+	}
+
+	path := filepath.Join(dir, KeyFileName)
+
+	content, err := os.ReadFile(path)
+	if err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("encryption: invalid key file %s", path)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)), 0600); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Serializer is a gorm serializer that encrypts string fields.
+// Fields tagged with `gorm:"serializer:encrypted"` are encrypted when written and decrypted when read.
+// If no key is set, values are stored as-is.
+// Values that cannot be decrypted (e.g. the key changed) are read as empty strings so that the credentials can be entered again.
+type Serializer struct{}
+
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("encryption: unsupported value type %T", dbValue)
+	}
+
+	decrypted, err := Decrypt(value)
+	if err != nil {
+		decrypted = ""
+	}
+
+	field.ReflectValueOf(ctx, dst).SetString(decrypted)
+	return nil
+}
+
+func (Serializer) Value(_ context.Context, _ *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encryption: unsupported field type %T", fieldValue)
+	}
+
+	if !HasKey() {
+		return value, nil
+	}
+
+	return Encrypt(value)
+}
@@ -0,0 +1,78 @@
+package encryption_test
+
+import (
+	"crypto/rand"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/database/db"
+	"seanime/internal/database/encryption"
+	"seanime/internal/database/models"
+	"seanime/internal/util"
+	"testing"
+)
+
+func setTestKey(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	require.NoError(t, encryption.SetKey(key))
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	setTestKey(t)
+
+	encrypted, err := encryption.Encrypt("hunter2")
+	require.NoError(t, err)
+	require.True(t, encryption.IsEncrypted(encrypted))
+	require.NotContains(t, encrypted, "hunter2")
+
+	decrypted, err := encryption.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", decrypted)
+
+	// Plaintext values are returned as-is
+	decrypted, err = encryption.Decrypt("plaintext")
+	require.NoError(t, err)
+	require.Equal(t, "plaintext", decrypted)
+
+	// Empty values are not encrypted
+	encrypted, err = encryption.Encrypt("")
+	require.NoError(t, err)
+	require.Empty(t, encrypted)
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	dir := t.TempDir()
+
+	key, err := encryption.LoadOrCreateKey(dir)
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+
+	// The key is persisted
+	key2, err := encryption.LoadOrCreateKey(dir)
+	require.NoError(t, err)
+	require.Equal(t, key, key2)
+}
+
+func TestSerializer(t *testing.T) {
+	logger := util.NewLogger()
+	dir := t.TempDir()
+
+	// Store a token in plaintext, like previous versions did
+	database, err := db.NewDatabase(dir, "test", logger)
+	require.NoError(t, err)
+	require.NoError(t, database.Gorm().Exec("INSERT INTO accounts (id, username, token) VALUES (2, 'user', 'secret-token')").Error)
+
+	setTestKey(t)
+
+	// Existing credentials are encrypted when the database is opened
+	database, err = db.NewDatabase(dir, "test", logger)
+	require.NoError(t, err)
+
+	var raw string
+	require.NoError(t, database.Gorm().Raw("SELECT token FROM accounts WHERE id = 2").Scan(&raw).Error)
+	require.True(t, encryption.IsEncrypted(raw))
+
+	var acc models.Account
+	require.NoError(t, database.Gorm().First(&acc, 2).Error)
+	require.Equal(t, "secret-token", acc.Token)
+}
@@ -21,7 +21,7 @@ type Token struct {
 type Account struct {
 	BaseModel
 	Username string `gorm:"column:username" json:"username"`
-	Token    string `gorm:"column:token" json:"token"`
+	Token    string `gorm:"column:token;serializer:encrypted" json:"token"`
 	Viewer   []byte `gorm:"column:viewer" json:"viewer"`
 }
 
@@ -78,8 +78,17 @@ type LibrarySettings struct {
 	EnableWatchContinuity    bool         `gorm:"column:enable_watch_continuity" json:"enableWatchContinuity"`
 	LibraryPaths             LibraryPaths `gorm:"column:library_paths;type:text" json:"libraryPaths"`
 	AutoSyncOfflineLocalData bool         `gorm:"column:auto_sync_offline_local_data" json:"autoSyncOfflineLocalData"`
+	// TrackingPlatform is the platform used to track the lists, see TrackingPlatformAnilist.
+	// Changing it requires a restart.
+	TrackingPlatform string `gorm:"column:tracking_platform" json:"trackingPlatform"`
 }
 
+const (
+	TrackingPlatformAnilist = "anilist" // Default
+	TrackingPlatformMal     = "mal"
+	TrackingPlatformKitsu   = "kitsu"
+)
+
 func (o *LibrarySettings) GetLibraryPaths() (ret []string) {
 	ret = make([]string, len(o.LibraryPaths)+1)
 	ret[0] = o.LibraryPath
@@ -114,7 +123,7 @@ type MediaPlayerSettings struct {
 	Default     string `gorm:"column:default_player" json:"defaultPlayer"` // "vlc" or "mpc-hc"
 	Host        string `gorm:"column:player_host" json:"host"`
 	VlcUsername string `gorm:"column:vlc_username" json:"vlcUsername"`
-	VlcPassword string `gorm:"column:vlc_password" json:"vlcPassword"`
+	VlcPassword string `gorm:"column:vlc_password;serializer:encrypted" json:"vlcPassword"`
 	VlcPort     int    `gorm:"column:vlc_port" json:"vlcPort"`
 	VlcPath     string `gorm:"column:vlc_path" json:"vlcPath"`
 	MpcPort     int    `gorm:"column:mpc_port" json:"mpcPort"`
@@ -129,12 +138,12 @@ type TorrentSettings struct {
 	QBittorrentHost      string `gorm:"column:qbittorrent_host" json:"qbittorrentHost"`
 	QBittorrentPort      int    `gorm:"column:qbittorrent_port" json:"qbittorrentPort"`
 	QBittorrentUsername  string `gorm:"column:qbittorrent_username" json:"qbittorrentUsername"`
-	QBittorrentPassword  string `gorm:"column:qbittorrent_password" json:"qbittorrentPassword"`
+	QBittorrentPassword  string `gorm:"column:qbittorrent_password;serializer:encrypted" json:"qbittorrentPassword"`
 	TransmissionPath     string `gorm:"column:transmission_path" json:"transmissionPath"`
 	TransmissionHost     string `gorm:"column:transmission_host" json:"transmissionHost"`
 	TransmissionPort     int    `gorm:"column:transmission_port" json:"transmissionPort"`
 	TransmissionUsername string `gorm:"column:transmission_username" json:"transmissionUsername"`
-	TransmissionPassword string `gorm:"column:transmission_password" json:"transmissionPassword"`
+	TransmissionPassword string `gorm:"column:transmission_password;serializer:encrypted" json:"transmissionPassword"`
 	// v2.1+
 	ShowActiveTorrentCount bool `gorm:"column:show_active_torrent_count" json:"showActiveTorrentCount"`
 	// v2.2+
@@ -168,8 +177,23 @@ type NotificationSettings struct {
 type Mal struct {
 	BaseModel
 	Username       string    `gorm:"column:username" json:"username"`
-	AccessToken    string    `gorm:"column:access_token" json:"accessToken"`
-	RefreshToken   string    `gorm:"column:refresh_token" json:"refreshToken"`
+	AccessToken    string    `gorm:"column:access_token;serializer:encrypted" json:"accessToken"`
+	RefreshToken   string    `gorm:"column:refresh_token;serializer:encrypted" json:"refreshToken"`
+	TokenExpiresAt time.Time `gorm:"column:token_expires_at" json:"tokenExpiresAt"`
+}
+
+// +---------------------+
+// |        Kitsu        |
+// +---------------------+
+
+// Kitsu holds the Kitsu credentials of a profile.
+// The row shares the ID of the profile.
+type Kitsu struct {
+	BaseModel
+	Username       string    `gorm:"column:username" json:"username"`
+	UserID         string    `gorm:"column:user_id" json:"userId"`
+	AccessToken    string    `gorm:"column:access_token;serializer:encrypted" json:"accessToken"`
+	RefreshToken   string    `gorm:"column:refresh_token;serializer:encrypted" json:"refreshToken"`
 	TokenExpiresAt time.Time `gorm:"column:token_expires_at" json:"tokenExpiresAt"`
 }
 
@@ -262,8 +286,9 @@ type Theme struct {
 
 type PlaylistEntry struct {
 	BaseModel
-	Name  string `gorm:"column:name" json:"name"`
-	Value []byte `gorm:"column:value" json:"value"`
+	Name      string `gorm:"column:name" json:"name"`
+	Value     []byte `gorm:"column:value" json:"value"`
+	ProfileID uint   `gorm:"column:profile_id;default:1" json:"profileId"`
 }
 
 // +------------------------+
@@ -380,7 +405,7 @@ type DebridSettings struct {
 	BaseModel
 	Enabled  bool   `gorm:"column:enabled" json:"enabled"`
 	Provider string `gorm:"column:provider" json:"provider"`
-	ApiKey   string `gorm:"column:api_key" json:"apiKey"`
+	ApiKey   string `gorm:"column:api_key;serializer:encrypted" json:"apiKey"`
 	//FallbackToDebridStreamingView bool   `gorm:"column:fallback_to_debrid_streaming_view" json:"fallbackToDebridStreamingView"`
 	IncludeDebridStreamInLibrary bool   `gorm:"column:include_debrid_stream_in_library" json:"includeDebridStreamInLibrary"`
 	StreamAutoSelect             bool   `gorm:"column:stream_auto_select" json:"streamAutoSelect"`
@@ -394,3 +419,245 @@ type DebridTorrentItem struct {
 	Provider      string `gorm:"column:provider" json:"provider"`
 	MediaId       int    `gorm:"column:media_id" json:"mediaId"`
 }
+
+// +---------------------+
+// |     Server Auth     |
+// +---------------------+
+
+// ServerPassword stores the hash of the server password when it is set from the settings.
+// A password defined in the config file takes precedence.
+type ServerPassword struct {
+	BaseModel
+	Hash string `gorm:"column:hash" json:"-"`
+}
+
+// AuthDevice is a device that has logged in to the server.
+// Only the hash of the device's token is stored.
+type AuthDevice struct {
+	BaseModel
+	Name       string          `gorm:"column:name" json:"name"`
+	TokenHash  string          `gorm:"column:token_hash;uniqueIndex" json:"-"`
+	Scope      AuthDeviceScope `gorm:"column:scope" json:"scope"`
+	UserAgent  string          `gorm:"column:user_agent" json:"userAgent"`
+	LastIP     string          `gorm:"column:last_ip" json:"lastIp"`
+	LastUsedAt time.Time       `gorm:"column:last_used_at" json:"lastUsedAt"`
+}
+
+// AuthDeviceScope limits the routes a device's token can access.
+type AuthDeviceScope string
+
+const (
+	// AuthDeviceScopeFull gives access to every route, it is the scope of the devices that log in with the password.
+	AuthDeviceScopeFull AuthDeviceScope = ""
+	// AuthDeviceScopeCalendarFeed only gives access to the iCalendar feed.
+	AuthDeviceScopeCalendarFeed AuthDeviceScope = "calendar_feed"
+)
+
+// +---------------------+
+// |      Profiles       |
+// +---------------------+
+
+// DefaultProfileID is the ID of the profile that is created on first run.
+// Its AniList account, MAL info and Discord settings are the ones stored in Account, Mal and Settings.
+const DefaultProfileID uint = 1
+
+// Profile is a user of the server.
+// The Account and Mal rows of a profile share its ID.
+type Profile struct {
+	BaseModel
+	Name    string `gorm:"column:name" json:"name"`
+	PinHash string `gorm:"column:pin_hash" json:"-"`
+	// Random key used to build the profile's session token, regenerated when the PIN changes
+	SessionKey string `gorm:"column:session_key" json:"-"`
+	// Discord settings of the profile, the default profile uses Settings.Discord
+	Discord *DiscordSettings `gorm:"embedded;embeddedPrefix:discord_" json:"discord"`
+	HasPin  bool             `gorm:"-" json:"hasPin"`
+}
+
+// +---------------------+
+// |       Backups       |
+// +---------------------+
+
+// BackupSettings configures the scheduled backups.
+type BackupSettings struct {
+	BaseModel
+	Enabled bool `gorm:"column:enabled" json:"enabled"`
+	// Hours between two scheduled backups
+	Interval int `gorm:"column:interval" json:"interval"`
+	// Number of backups to keep, older ones are deleted
+	MaxBackups int `gorm:"column:max_backups" json:"maxBackups"`
+	// Directory where backups are written, defaults to "<data dir>/backups"
+	Dir string `gorm:"column:dir" json:"dir"`
+}
+
+// +---------------------+
+// |      Scheduler      |
+// +---------------------+
+
+// ScheduledJobSettings overrides the default schedule of a job.
+type ScheduledJobSettings struct {
+	BaseModel
+	Name    string `gorm:"column:name;uniqueIndex" json:"name"`
+	Enabled bool   `gorm:"column:enabled" json:"enabled"`
+	// Cron expression (e.g. "0 */6 * * *"), descriptor (e.g. "@daily", "@every 1h") or interval (e.g. "30m")
+	Schedule string `gorm:"column:schedule" json:"schedule"`
+}
+
+// ScheduledJobRun is a record of a job run.
+type ScheduledJobRun struct {
+	BaseModel
+	Name string `gorm:"column:name;index" json:"name"`
+	// "schedule" or "manual"
+	TriggeredBy string    `gorm:"column:triggered_by" json:"triggeredBy"`
+	StartedAt   time.Time `gorm:"column:started_at" json:"startedAt"`
+	DurationMs  int64     `gorm:"column:duration_ms" json:"durationMs"`
+	Error       string    `gorm:"column:error" json:"error"`
+}
+
+// +---------------------+
+// |  Platform Mutations |
+// +---------------------+
+
+// PlatformMutation is a write to a tracking platform that is retried until it succeeds.
+// Writes to the same media are collapsed into a single mutation.
+type PlatformMutation struct {
+	BaseModel
+	ProfileID uint `gorm:"column:profile_id;index" json:"profileId"`
+	// "anilist", "mal" or "kitsu"
+	Platform string `gorm:"column:platform" json:"platform"`
+	MediaID  int    `gorm:"column:media_id;index" json:"mediaId"`
+	Payload  string `gorm:"column:payload" json:"payload"`
+	// "pending" or "failed"
+	Status        string     `gorm:"column:status" json:"status"`
+	Attempts      int        `gorm:"column:attempts" json:"attempts"`
+	Error         string     `gorm:"column:error" json:"error"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at" json:"nextAttemptAt"`
+	// Incremented when a write is collapsed into the mutation
+	Revision int `gorm:"column:revision" json:"revision"`
+}
+
+// +---------------------+
+// |     Watch Stats     |
+// +---------------------+
+
+// WatchSession is a period of continuous playback of an episode.
+type WatchSession struct {
+	BaseModel
+	ProfileID uint `gorm:"column:profile_id;index" json:"profileId"`
+	MediaID   int  `gorm:"column:media_id;index" json:"mediaId"`
+	Episode   int  `gorm:"column:episode" json:"episode"`
+	// "local", "torrentstream", "debrid" or "online"
+	Source    string    `gorm:"column:source" json:"source"`
+	StartedAt time.Time `gorm:"column:started_at;index" json:"startedAt"`
+	EndedAt   time.Time `gorm:"column:ended_at" json:"endedAt"`
+	// Time spent watching in seconds, pauses and skipped parts are not counted
+	Duration int `gorm:"column:duration" json:"duration"`
+}
+
+// +---------------------+
+// |      Webhooks       |
+// +---------------------+
+
+// Webhook is a URL that receives the events selected by the user.
+type Webhook struct {
+	BaseModel
+	Name string `gorm:"column:name" json:"name"`
+	URL  string `gorm:"column:url" json:"url"`
+	// Secret used to sign the payloads with HMAC-SHA256
+	Secret  string     `gorm:"column:secret;serializer:encrypted" json:"secret"`
+	Events  StringList `gorm:"column:events;type:text" json:"events"` // Empty means all events
+	Enabled bool       `gorm:"column:enabled" json:"enabled"`
+	// Number of retries after a failed delivery
+	MaxRetries int `gorm:"column:max_retries" json:"maxRetries"`
+	// Seconds before the first retry, doubled after each retry
+	RetryBackoff int `gorm:"column:retry_backoff" json:"retryBackoff"`
+}
+
+type StringList []string
+
+func (o *StringList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = StringList{}
+	case string:
+		*o = strings.Split(v, ",")
+	case []byte:
+		*o = strings.Split(string(v), ",")
+	default:
+		return errors.New("src value cannot cast to string")
+	}
+	if len(*o) == 1 && (*o)[0] == "" {
+		*o = StringList{}
+	}
+	return nil
+}
+func (o StringList) Value() (driver.Value, error) {
+	if len(o) == 0 {
+		return "", nil
+	}
+	return strings.Join(o, ","), nil
+}
+
+// WebhookDelivery is a record of an event sent to a webhook.
+type WebhookDelivery struct {
+	BaseModel
+	WebhookID uint   `gorm:"column:webhook_id;index" json:"webhookId"`
+	Event     string `gorm:"column:event" json:"event"`
+	Payload   string `gorm:"column:payload" json:"payload"`
+	// "pending", "success" or "failed"
+	Status         string     `gorm:"column:status" json:"status"`
+	Attempts       int        `gorm:"column:attempts" json:"attempts"`
+	ResponseStatus int        `gorm:"column:response_status" json:"responseStatus"`
+	Error          string     `gorm:"column:error" json:"error"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at" json:"nextAttemptAt"`
+}
+
+// +---------------------+
+// |    Notifications    |
+// +---------------------+
+
+// NotificationChannel is a service that receives the notifications in addition to the desktop notifications.
+type NotificationChannel struct {
+	BaseModel
+	Name string `gorm:"column:name" json:"name"`
+	// "ntfy", "gotify", "discord", "telegram", "smtp" or "json"
+	Type    string     `gorm:"column:type" json:"type"`
+	Enabled bool       `gorm:"column:enabled" json:"enabled"`
+	Kinds   StringList `gorm:"column:kinds;type:text" json:"kinds"` // Empty means all kinds
+	// Templates of the title and message, e.g. "{{.Kind}}: {{.Message}}"
+	TitleTemplate   string `gorm:"column:title_template" json:"titleTemplate"`
+	MessageTemplate string `gorm:"column:message_template" json:"messageTemplate"`
+	// Maximum number of notifications sent per minute, 0 means no limit
+	RateLimit int `gorm:"column:rate_limit" json:"rateLimit"`
+	// URL of the ntfy topic, Gotify server, Discord webhook or JSON endpoint.
+	// Optional for Telegram, defaults to the Bot API.
+	URL string `gorm:"column:url;serializer:encrypted" json:"url"`
+	// Access token for ntfy, application token for Gotify, bot token for Telegram or bearer token for JSON
+	Token        string     `gorm:"column:token;serializer:encrypted" json:"token"`
+	ChatID       string     `gorm:"column:chat_id" json:"chatId"`     // Telegram
+	Priority     int        `gorm:"column:priority" json:"priority"`  // ntfy and Gotify
+	SmtpHost     string     `gorm:"column:smtp_host" json:"smtpHost"` // SMTP
+	SmtpPort     int        `gorm:"column:smtp_port" json:"smtpPort"` // SMTP, 465 uses implicit TLS
+	SmtpUsername string     `gorm:"column:smtp_username" json:"smtpUsername"`
+	SmtpPassword string     `gorm:"column:smtp_password;serializer:encrypted" json:"smtpPassword"`
+	SmtpFrom     string     `gorm:"column:smtp_from" json:"smtpFrom"`
+	SmtpTo       StringList `gorm:"column:smtp_to;type:text" json:"smtpTo"`
+}
+
+// NotificationEntry is a notification stored in the inbox.
+type NotificationEntry struct {
+	BaseModel
+	Kind    string `gorm:"column:kind;index" json:"kind"`
+	Message string `gorm:"column:message" json:"message"`
+	// AniList ID of the media the notification is about, 0 if none
+	MediaId int                `gorm:"column:media_id;index" json:"mediaId"`
+	Episode int                `gorm:"column:episode" json:"episode"`
+	Links   []NotificationLink `gorm:"column:links;serializer:json" json:"links"`
+	Read    bool               `gorm:"column:read;index" json:"read"`
+	ReadAt  *time.Time         `gorm:"column:read_at" json:"readAt"`
+}
+
+type NotificationLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,105 @@
+package models
+
+// RedactedValue replaces stored credentials in API responses.
+// When a client sends it back, the stored value is kept.
+const RedactedValue = "__REDACTED__"
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+// KeepExisting returns the previous value if the new value is the RedactedValue placeholder.
+func KeepExisting(value string, prev string) string {
+	if value == RedactedValue {
+		return prev
+	}
+	return value
+}
+
+// Redacted returns a copy of the settings with the credentials replaced by RedactedValue.
+func (s *Settings) Redacted() *Settings {
+	if s == nil {
+		return nil
+	}
+
+	ret := *s
+	if s.MediaPlayer != nil {
+		mediaPlayer := *s.MediaPlayer
+		mediaPlayer.VlcPassword = redact(mediaPlayer.VlcPassword)
+		ret.MediaPlayer = &mediaPlayer
+	}
+	if s.Torrent != nil {
+		torrent := *s.Torrent
+		torrent.QBittorrentPassword = redact(torrent.QBittorrentPassword)
+		torrent.TransmissionPassword = redact(torrent.TransmissionPassword)
+		ret.Torrent = &torrent
+	}
+	return &ret
+}
+
+// KeepExistingCredentials replaces the RedactedValue placeholders with the credentials from the previous settings.
+func (s *Settings) KeepExistingCredentials(prev *Settings) {
+	if s == nil || prev == nil {
+		return
+	}
+
+	if s.MediaPlayer != nil && prev.MediaPlayer != nil {
+		s.MediaPlayer.VlcPassword = KeepExisting(s.MediaPlayer.VlcPassword, prev.MediaPlayer.VlcPassword)
+	}
+	if s.Torrent != nil && prev.Torrent != nil {
+		s.Torrent.QBittorrentPassword = KeepExisting(s.Torrent.QBittorrentPassword, prev.Torrent.QBittorrentPassword)
+		s.Torrent.TransmissionPassword = KeepExisting(s.Torrent.TransmissionPassword, prev.Torrent.TransmissionPassword)
+	}
+}
+
+// Redacted returns a copy of the settings with the API key replaced by RedactedValue.
+func (s *DebridSettings) Redacted() *DebridSettings {
+	if s == nil {
+		return nil
+	}
+
+	ret := *s
+	ret.ApiKey = redact(s.ApiKey)
+	return &ret
+}
+
+// Redacted returns a copy of the webhook with the secret replaced by RedactedValue.
+func (w *Webhook) Redacted() *Webhook {
+	if w == nil {
+		return nil
+	}
+
+	ret := *w
+	ret.Secret = redact(ret.Secret)
+	return &ret
+}
+
+// Redacted returns a copy of the channel with the credentials replaced by RedactedValue.
+// The URL is redacted for Discord since it contains the webhook token.
+func (c *NotificationChannel) Redacted() *NotificationChannel {
+	if c == nil {
+		return nil
+	}
+
+	ret := *c
+	ret.Token = redact(ret.Token)
+	ret.SmtpPassword = redact(ret.SmtpPassword)
+	if ret.Type == "discord" {
+		ret.URL = redact(ret.URL)
+	}
+	return &ret
+}
+
+// KeepExistingCredentials replaces the RedactedValue placeholders with the credentials from the previous channel.
+func (c *NotificationChannel) KeepExistingCredentials(prev *NotificationChannel) {
+	if c == nil || prev == nil {
+		return
+	}
+
+	c.URL = KeepExisting(c.URL, prev.URL)
+	c.Token = KeepExisting(c.Token, prev.Token)
+	c.SmtpPassword = KeepExisting(c.SmtpPassword, prev.SmtpPassword)
+}
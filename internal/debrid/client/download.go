@@ -15,6 +15,7 @@ import (
 	"seanime/internal/debrid/debrid"
 	"seanime/internal/events"
 	"seanime/internal/notifier"
+	"seanime/internal/webhook"
 	"time"
 )
 
@@ -262,6 +263,7 @@ func (r *Repository) downloadTorrentItem(tId string, torrentName string, destina
 				r.sendDownloadCancelledEvent(tId)
 				return
 			}
+			r.dispatchDownloadFinished(torrentName, destination)
 			return // Exit early
 		}
 		if err != nil {
@@ -289,6 +291,7 @@ func (r *Repository) downloadTorrentItem(tId string, torrentName string, destina
 
 		r.sendDownloadCompletedEvent(tId)
 		notifier.GlobalNotifier.Notify(notifier.Debrid, fmt.Sprintf("Downloaded %q", torrentName))
+		r.dispatchDownloadFinished(torrentName, destination)
 	}(ctx)
 
 	// Send a starting event
@@ -338,3 +341,10 @@ func getFilenameFromHeaders(url string) (string, error) {
 	}
 	return "", fmt.Errorf("filename not found in Content-Disposition header")
 }
+
+func (r *Repository) dispatchDownloadFinished(torrentName string, destination string) {
+	webhook.GlobalManager.Dispatch(webhook.EventDebridDownloadFinished, &webhook.DebridDownloadFinishedPayload{
+		TorrentName: torrentName,
+		Destination: destination,
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"seanime/internal/events"
 	"seanime/internal/library/playbackmanager"
 	"seanime/internal/util"
+	"seanime/internal/watchstats"
 	"strconv"
 	"time"
 )
@@ -286,6 +287,7 @@ func (s *StreamManager) startStream(opts *StartStreamOptions) (err error) {
 				Payload:   streamUrl,
 				UserAgent: opts.UserAgent,
 				ClientId:  opts.ClientId,
+				Source:    watchstats.SourceDebrid,
 			}, media.ToBaseAnime(), aniDbEpisode)
 			if err != nil {
 				// Failed to start the stream, we'll drop the torrents and stop the server
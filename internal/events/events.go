@@ -45,4 +45,8 @@ const (
 	DebridDownloadProgress = "debrid-download-progress"
 
 	DebridStreamState = "debrid-stream-state"
+
+	NotificationInboxUpdated = "notification-inbox-updated" // The notification inbox has changed, the payload contains the unread count
+
+	ListImportStatus = "list-import-status" // The status of the list import of the profile has changed
 )
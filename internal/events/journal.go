@@ -0,0 +1,199 @@
+package events
+
+import (
+	"github.com/goccy/go-json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultJournalSize is the number of events kept in the journal.
+const DefaultJournalSize = 1000
+
+// journalSubscriberBuffer is the number of events buffered per subscriber.
+// Subscribers that fall behind are closed and should resume from the journal.
+const journalSubscriberBuffer = 256
+
+// coalescedEvents are sent frequently (e.g. every second during playback) and only their latest state matters.
+// Only the latest event of each of these types is kept, so that they do not push the other events out of the journal.
+var coalescedEvents = map[string]struct{}{
+	PlaybackManagerProgressPlaybackState:       {},
+	PlaybackManagerManualTrackingPlaybackState: {},
+	"torrentstream-torrent-status":             {}, // See torrentstream/events.go
+}
+
+type (
+	// Journal keeps the latest broadcast events with increasing sequence numbers,
+	// so that clients can resume from the last event they received.
+	Journal struct {
+		mu          sync.RWMutex
+		entries     []*JournalEntry // Ring buffer
+		start       int             // Index of the oldest entry
+		count       int
+		latest      map[string]*JournalEntry // Latest entry of each coalesced event type
+		seq         uint64
+		subscribers map[uint64]chan *JournalEntry
+		nextSubId   uint64
+	}
+
+	JournalEntry struct {
+		Seq     uint64          `json:"seq"`
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+		Time    time.Time       `json:"time"`
+	}
+)
+
+func NewJournal(size int) *Journal {
+	if size <= 0 {
+		size = DefaultJournalSize
+	}
+	return &Journal{
+		entries:     make([]*JournalEntry, size),
+		latest:      make(map[string]*JournalEntry),
+		subscribers: make(map[uint64]chan *JournalEntry),
+	}
+}
+
+// Append adds the event to the journal and sends it to the subscribers.
+// The payload is marshaled immediately so that later changes to it are not recorded.
+func (j *Journal) Append(t string, payload interface{}) *JournalEntry {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		data = []byte("null")
+	}
+	return j.AppendRaw(t, data)
+}
+
+// AppendRaw is like Append with an already marshaled payload.
+func (j *Journal) AppendRaw(t string, payload json.RawMessage) *JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.seq++
+	entry := &JournalEntry{
+		Seq:     j.seq,
+		Type:    t,
+		Payload: payload,
+		Time:    time.Now(),
+	}
+
+	if _, ok := coalescedEvents[t]; ok {
+		j.latest[t] = entry
+	} else {
+		size := len(j.entries)
+		if j.count < size {
+			j.entries[(j.start+j.count)%size] = entry
+			j.count++
+		} else {
+			j.entries[j.start] = entry
+			j.start = (j.start + 1) % size
+		}
+	}
+
+	for id, ch := range j.subscribers {
+		select {
+		case ch <- entry:
+		default:
+			// The subscriber is too slow, it will resume from the journal
+			close(ch)
+			delete(j.subscribers, id)
+		}
+	}
+
+	return entry
+}
+
+// LastSeq returns the sequence number of the latest event.
+func (j *Journal) LastSeq() uint64 {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.seq
+}
+
+// Since returns the events after the given sequence number, oldest first.
+// If types is not empty, only the matching events are returned, see MatchesType.
+//
+// If the sequence number is greater than the latest one, e.g. because the server restarted,
+// all the events in the journal are returned.
+func (j *Journal) Since(seq uint64, types []string) []*JournalEntry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	if seq > j.seq {
+		seq = 0
+	}
+
+	return j.since(seq, types)
+}
+
+// since returns the entries after the given sequence number, oldest first. mu must be held.
+func (j *Journal) since(seq uint64, types []string) []*JournalEntry {
+	ret := make([]*JournalEntry, 0)
+	for i := 0; i < j.count; i++ {
+		entry := j.entries[(j.start+i)%len(j.entries)]
+		if entry.Seq > seq && MatchesType(entry.Type, types) {
+			ret = append(ret, entry)
+		}
+	}
+
+	if len(j.latest) > 0 {
+		for _, entry := range j.latest {
+			if entry.Seq > seq && MatchesType(entry.Type, types) {
+				ret = append(ret, entry)
+			}
+		}
+		sort.Slice(ret, func(a, b int) bool {
+			return ret[a].Seq < ret[b].Seq
+		})
+	}
+
+	return ret
+}
+
+// Subscribe returns a channel receiving the new events.
+// If resume is true, the events after the given sequence number are returned as the backlog.
+// The channel is closed when the subscriber falls behind or when cancel is called.
+func (j *Journal) Subscribe(resume bool, seq uint64) (backlog []*JournalEntry, ch <-chan *JournalEntry, cancel func()) {
+	// The backlog and the subscription are taken under the same lock so that no event is missed
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	backlog = make([]*JournalEntry, 0)
+	if resume {
+		if seq > j.seq {
+			seq = 0
+		}
+		backlog = j.since(seq, nil)
+	}
+
+	j.nextSubId++
+	id := j.nextSubId
+	c := make(chan *JournalEntry, journalSubscriberBuffer)
+	j.subscribers[id] = c
+
+	cancel = func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if _, ok := j.subscribers[id]; ok {
+			close(c)
+			delete(j.subscribers, id)
+		}
+	}
+
+	return backlog, c, cancel
+}
+
+// MatchesType returns true if the event type matches one of the topics, or if there are no topics.
+// See matchesTopic.
+func MatchesType(t string, topics []string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, topic := range topics {
+		if matchesTopic(t, topic) {
+			return true
+		}
+	}
+	return false
+}
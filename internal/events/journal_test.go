@@ -0,0 +1,88 @@
+package events
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	j := NewJournal(3)
+
+	for i := 1; i <= 5; i++ {
+		j.Append(EventScanProgress, i)
+	}
+	require.EqualValues(t, 5, j.LastSeq())
+
+	// Only the latest 3 events are kept
+	entries := j.Since(0, nil)
+	require.Len(t, entries, 3)
+	require.EqualValues(t, 3, entries[0].Seq)
+	require.Equal(t, "3", string(entries[0].Payload))
+
+	entries = j.Since(4, nil)
+	require.Len(t, entries, 1)
+	require.EqualValues(t, 5, entries[0].Seq)
+
+	// The server restarted, the client's sequence number is ahead
+	require.Len(t, j.Since(100, nil), 3)
+
+	j.Append(EventScanStatus, "done")
+	require.Len(t, j.Since(0, []string{EventScanStatus}), 1)
+	require.Len(t, j.Since(0, []string{"scan-*"}), 3)
+	require.Empty(t, j.Since(0, []string{AutoScanStarted}))
+}
+
+func TestJournalCoalescedEvents(t *testing.T) {
+	j := NewJournal(3)
+
+	j.Append(EventScanStatus, "started")
+	for i := 0; i < 10; i++ {
+		j.Append(PlaybackManagerProgressPlaybackState, i)
+	}
+	j.Append(EventScanStatus, "done")
+
+	// Only the latest playback state is kept, the other events are not pushed out
+	entries := j.Since(0, nil)
+	require.Len(t, entries, 3)
+	require.Equal(t, `"started"`, string(entries[0].Payload))
+	require.Equal(t, PlaybackManagerProgressPlaybackState, entries[1].Type)
+	require.Equal(t, "9", string(entries[1].Payload))
+	require.Equal(t, `"done"`, string(entries[2].Payload))
+
+	require.Len(t, j.Since(entries[1].Seq, nil), 1)
+}
+
+func TestJournalSubscribe(t *testing.T) {
+	j := NewJournal(10)
+	j.Append(EventScanProgress, 1)
+	j.Append(EventScanProgress, 2)
+
+	backlog, ch, cancel := j.Subscribe(true, 1)
+	require.Len(t, backlog, 1)
+	require.EqualValues(t, 2, backlog[0].Seq)
+
+	j.Append(EventScanStatus, "done")
+	entry := <-ch
+	require.EqualValues(t, 3, entry.Seq)
+	require.Equal(t, EventScanStatus, entry.Type)
+
+	cancel()
+	_, ok := <-ch
+	require.False(t, ok)
+	cancel() // No-op
+
+	// New events only
+	backlog, ch, cancel = j.Subscribe(false, 0)
+	defer cancel()
+	require.Empty(t, backlog)
+
+	// Slow subscribers are closed
+	for i := 0; i < journalSubscriberBuffer+1; i++ {
+		j.Append(EventScanProgress, i)
+	}
+	count := 0
+	for range ch {
+		count++
+	}
+	require.Equal(t, journalSubscriberBuffer, count)
+}
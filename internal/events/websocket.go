@@ -2,6 +2,7 @@ package events
 
 import (
 	"github.com/davecgh/go-spew/spew"
+	"github.com/goccy/go-json"
 	"github.com/gofiber/contrib/websocket"
 	"github.com/rs/zerolog"
 	"sync"
@@ -10,6 +11,7 @@ import (
 type WSEventManagerInterface interface {
 	SendEvent(t string, payload interface{})
 	SendEventTo(clientId string, t string, payload interface{})
+	SendEventToProfile(profileId uint, t string, payload interface{})
 }
 
 type (
@@ -20,11 +22,17 @@ type (
 		Conns  []*WSConn
 		Logger *zerolog.Logger
 		mu     sync.Mutex
+		// Journal keeps the broadcast events for the SSE clients
+		Journal *Journal
 	}
 
 	WSConn struct {
 		ID   string
 		Conn *websocket.Conn
+		// ID of the profile used by the client
+		ProfileID uint
+		// Event types the client subscribed to, nil means all events
+		Topics map[string]struct{}
 	}
 
 	WSEvent struct {
@@ -36,12 +44,15 @@ type (
 // NewWSEventManager creates a new WSEventManager instance for App.
 func NewWSEventManager(logger *zerolog.Logger) *WSEventManager {
 	return &WSEventManager{
-		Logger: logger,
-		Conns:  make([]*WSConn, 0),
+		Logger:  logger,
+		Conns:   make([]*WSConn, 0),
+		Journal: NewJournal(DefaultJournalSize),
 	}
 }
 
 func (m *WSEventManager) AddConn(id string, conn *websocket.Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Conns = append(m.Conns, &WSConn{
 		ID:   id,
 		Conn: conn,
@@ -49,6 +60,8 @@ func (m *WSEventManager) AddConn(id string, conn *websocket.Conn) {
 }
 
 func (m *WSEventManager) RemoveConn(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for i, conn := range m.Conns {
 		if conn.ID == id {
 			m.Conns = append(m.Conns[:i], m.Conns[i+1:]...)
@@ -57,8 +70,35 @@ func (m *WSEventManager) RemoveConn(id string) {
 	}
 }
 
-// SendEvent sends a websocket event to the client.
+// SetConnProfileID sets the profile used by the client.
+func (m *WSEventManager) SetConnProfileID(id string, profileId uint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, conn := range m.Conns {
+		if conn.ID == id {
+			conn.ProfileID = profileId
+		}
+	}
+}
+
+// SendEvent sends a websocket event to the clients and records it in the journal.
+// The event is marshaled once for the journal and all the clients.
 func (m *WSEventManager) SendEvent(t string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		// Note: NaN error coming from [progress_tracking.go]
+		return
+	}
+	m.Journal.AppendRaw(t, data)
+
+	message, err := json.Marshal(WSEvent{
+		Type:    t,
+		Payload: json.RawMessage(data),
+	})
+	if err != nil {
+		return
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	// If there's no connection, do nothing
@@ -71,14 +111,10 @@ func (m *WSEventManager) SendEvent(t string, payload interface{}) {
 	}
 
 	for _, conn := range m.Conns {
-		err := conn.Conn.WriteJSON(WSEvent{
-			Type:    t,
-			Payload: payload,
-		})
-		if err != nil {
-			// Note: NaN error coming from [progress_tracking.go]
-			//m.Logger.Err(err).Msg("ws: Failed to send message")
+		if !conn.isSubscribed(t) {
+			continue
 		}
+		_ = conn.Conn.WriteMessage(websocket.TextMessage, message)
 		//m.Logger.Trace().Str("type", t).Msg("ws: Sent message")
 	}
 
@@ -107,3 +143,19 @@ func (m *WSEventManager) SendEventTo(clientId string, t string, payload interfac
 		}
 	}
 }
+
+// SendEventToProfile sends a websocket event to the clients using the specified profile.
+func (m *WSEventManager) SendEventToProfile(profileId uint, t string, payload interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, conn := range m.Conns {
+		if conn.ProfileID == profileId && conn.isSubscribed(t) {
+			m.Logger.Trace().Uint("profile", profileId).Str("type", t).Msg("ws: Sending message")
+			_ = conn.Conn.WriteJSON(WSEvent{
+				Type:    t,
+				Payload: payload,
+			})
+		}
+	}
+}
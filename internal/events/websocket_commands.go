@@ -0,0 +1,145 @@
+package events
+
+import (
+	"github.com/goccy/go-json"
+	"sort"
+	"strings"
+)
+
+// CommandResponse is the event type of the replies to the commands sent by the clients.
+const CommandResponse = "command-response"
+
+const (
+	CommandErrorInvalidRequest = "invalid_request"
+	CommandErrorMethodNotFound = "method_not_found"
+	CommandErrorInvalidParams  = "invalid_params"
+	CommandErrorFailed         = "command_failed"
+)
+
+type (
+	// WSCommand is a request sent by a client over the websocket.
+	//
+	//	e.g., {"id": "1", "method": "events.subscribe", "params": {"topics": ["scan-progress"]}}
+	WSCommand struct {
+		ID     string          `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params,omitempty"`
+	}
+
+	// WSCommandResponse is the payload of the CommandResponse event.
+	// Either Result or Error is set.
+	WSCommandResponse struct {
+		ID     string          `json:"id"`
+		Result interface{}     `json:"result,omitempty"`
+		Error  *WSCommandError `json:"error,omitempty"`
+	}
+
+	WSCommandError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// SendResponse sends the response to the client that sent the command.
+// Responses are not affected by the subscriptions of the client.
+func (m *WSEventManager) SendResponse(clientId string, res *WSCommandResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, conn := range m.Conns {
+		if conn.ID == clientId {
+			_ = conn.Conn.WriteJSON(WSEvent{
+				Type:    CommandResponse,
+				Payload: res,
+			})
+		}
+	}
+}
+
+// Subscribe adds the topics to the subscriptions of the client and returns the resulting subscriptions.
+// A topic is an event type, a prefix ending with "*" (e.g. "playback-manager-*") or "*" for all events.
+// Until a client subscribes to a topic, it receives all events.
+func (m *WSEventManager) Subscribe(clientId string, topics []string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, conn := range m.Conns {
+		if conn.ID == clientId {
+			if conn.Topics == nil {
+				conn.Topics = make(map[string]struct{})
+			}
+			for _, topic := range topics {
+				conn.Topics[topic] = struct{}{}
+			}
+			return conn.subscriptions()
+		}
+	}
+	return []string{}
+}
+
+// Unsubscribe removes the topics from the subscriptions of the client and returns the remaining subscriptions.
+// A client that unsubscribed from all topics receives no events.
+func (m *WSEventManager) Unsubscribe(clientId string, topics []string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, conn := range m.Conns {
+		if conn.ID == clientId {
+			if conn.Topics == nil {
+				conn.Topics = make(map[string]struct{})
+			}
+			for _, topic := range topics {
+				delete(conn.Topics, topic)
+			}
+			return conn.subscriptions()
+		}
+	}
+	return []string{}
+}
+
+// GetSubscriptions returns the subscriptions of the client, "*" if it receives all events.
+func (m *WSEventManager) GetSubscriptions(clientId string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, conn := range m.Conns {
+		if conn.ID == clientId {
+			return conn.subscriptions()
+		}
+	}
+	return []string{}
+}
+
+func (c *WSConn) subscriptions() []string {
+	if c.Topics == nil {
+		return []string{"*"}
+	}
+	ret := make([]string, 0, len(c.Topics))
+	for topic := range c.Topics {
+		ret = append(ret, topic)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// isSubscribed returns true if the client should receive the event type.
+func (c *WSConn) isSubscribed(t string) bool {
+	if c.Topics == nil {
+		return true
+	}
+	for topic := range c.Topics {
+		if matchesTopic(t, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTopic returns true if the event type matches the topic.
+// A topic is an event type, a prefix ending with "*" (e.g. "playback-manager-*") or "*" for all events.
+func matchesTopic(t string, topic string) bool {
+	if strings.HasSuffix(topic, "*") {
+		return strings.HasPrefix(t, strings.TrimSuffix(topic, "*"))
+	}
+	return t == topic
+}
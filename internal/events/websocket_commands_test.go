@@ -0,0 +1,33 @@
+package events
+
+import (
+	"github.com/stretchr/testify/require"
+	"seanime/internal/util"
+	"testing"
+)
+
+func TestSubscriptions(t *testing.T) {
+	m := NewWSEventManager(util.NewLogger())
+	m.Conns = append(m.Conns, &WSConn{ID: "1"})
+	conn := m.Conns[0]
+
+	// All events until the client subscribes
+	require.Equal(t, []string{"*"}, m.GetSubscriptions("1"))
+	require.True(t, conn.isSubscribed(EventScanProgress))
+
+	topics := m.Subscribe("1", []string{EventScanProgress, "playback-manager-*"})
+	require.Equal(t, []string{"playback-manager-*", EventScanProgress}, topics)
+	require.True(t, conn.isSubscribed(EventScanProgress))
+	require.True(t, conn.isSubscribed(PlaybackManagerProgressUpdated))
+	require.False(t, conn.isSubscribed(EventScanStatus))
+
+	topics = m.Unsubscribe("1", []string{"playback-manager-*"})
+	require.Equal(t, []string{EventScanProgress}, topics)
+	require.False(t, conn.isSubscribed(PlaybackManagerProgressUpdated))
+
+	m.Subscribe("1", []string{"*"})
+	require.True(t, conn.isSubscribed(EventScanStatus))
+
+	// Unknown client
+	require.Empty(t, m.Subscribe("2", []string{EventScanProgress}))
+}
@@ -30,3 +30,7 @@ func (m *MockWSEventManager) SendEvent(t string, payload interface{}) {
 func (m *MockWSEventManager) SendEventTo(clientId string, t string, payload interface{}) {
 	m.Logger.Trace().Any("payload", payload).Str("type", t).Str("clientId", clientId).Msg("ws: Sent message to client")
 }
+
+func (m *MockWSEventManager) SendEventToProfile(profileId uint, t string, payload interface{}) {
+	m.Logger.Trace().Any("payload", payload).Str("type", t).Uint("profile", profileId).Msg("ws: Sent message to profile")
+}
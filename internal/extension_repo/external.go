@@ -11,7 +11,9 @@ import (
 	"path/filepath"
 	"seanime/internal/events"
 	"seanime/internal/extension"
+	"seanime/internal/notifier"
 	"seanime/internal/util"
+	"seanime/internal/webhook"
 	"sync"
 	"time"
 )
@@ -204,6 +206,7 @@ func (r *Repository) checkForUpdates() (ret []UpdateData) {
 				mu.Lock()
 				ret = append(ret, updateData)
 				mu.Unlock()
+				r.dispatchUpdateAvailable(ext, extFromRepo.Version)
 			}
 		}(ext)
 		return true
@@ -216,6 +219,27 @@ func (r *Repository) checkForUpdates() (ret []UpdateData) {
 	return
 }
 
+// CheckForUpdates checks all extensions for updates.
+// It is called periodically by the scheduler so that the webhooks are notified of new versions.
+func (r *Repository) CheckForUpdates() []UpdateData {
+	return r.checkForUpdates()
+}
+
+// dispatchUpdateAvailable sends the update available webhook event once per version.
+func (r *Repository) dispatchUpdateAvailable(ext extension.BaseExtension, version string) {
+	if dispatched, ok := r.dispatchedUpdates.Get(ext.GetID()); ok && dispatched == version {
+		return
+	}
+	r.dispatchedUpdates.Set(ext.GetID(), version)
+
+	webhook.GlobalManager.Dispatch(webhook.EventExtensionUpdateAvailable, &webhook.ExtensionUpdatePayload{
+		ExtensionId:    ext.GetID(),
+		CurrentVersion: ext.GetVersion(),
+		Version:        version,
+	})
+	notifier.GlobalNotifier.Notify(notifier.ExtensionUpdate, fmt.Sprintf("%s %s is available.", ext.GetName(), version))
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // UpdateExtensionCode updates the code of an external application
@@ -6,6 +6,7 @@ import (
 	"github.com/goccy/go-json"
 	"github.com/rs/zerolog"
 	"seanime/internal/extension"
+	"seanime/internal/metrics"
 	"time"
 )
 
@@ -19,6 +20,7 @@ type gojaExtensionImpl struct {
 }
 
 func (g *gojaExtensionImpl) error(err error, msg ...string) error {
+	metrics.ExtensionErrors.WithLabelValues(g.ext.ID).Inc()
 	if len(msg) > 0 {
 		g.logger.Error().Err(err).Str("id", g.ext.ID).Msgf("extensions: %s, %v", msg[0], err)
 		return fmt.Errorf("%s, %v", msg[0], err)
@@ -34,6 +34,8 @@ type (
 		extensionBank *extension.UnifiedBank
 
 		invalidExtensions *result.Map[string, *extension.InvalidExtension]
+		// Versions for which the update webhook event has been sent, by extension ID
+		dispatchedUpdates *result.Map[string, string]
 	}
 
 	AllExtensions struct {
@@ -94,6 +96,7 @@ func NewRepository(opts *NewRepositoryOptions) *Repository {
 		gojaExtensions:    result.NewResultMap[string, GojaExtension](),
 		extensionBank:     extension.NewUnifiedBank(),
 		invalidExtensions: result.NewResultMap[string, *extension.InvalidExtension](),
+		dispatchedUpdates: result.NewResultMap[string, string](),
 		fileCacher:        opts.FileCacher,
 	}
 
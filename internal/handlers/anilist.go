@@ -22,16 +22,18 @@ func HandleGetAnimeCollection(c *RouteCtx) error {
 
 	bypassCache := c.Fiber.Method() == "POST"
 
+	session := c.Profile()
+
 	// Get the user's anilist collection
-	animeCollection, err := c.App.GetAnimeCollection(bypassCache)
+	animeCollection, err := session.GetAnimeCollection(bypassCache)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
 	go func() {
 		if c.App.Settings != nil && c.App.Settings.Library.EnableManga {
-			_, _ = c.App.GetMangaCollection(bypassCache)
-			if bypassCache {
+			_, _ = session.GetMangaCollection(bypassCache)
+			if bypassCache && session.IsDefault() {
 				c.App.WSEventManager.SendEvent(events.RefreshedAnilistMangaCollection, nil)
 			}
 		}
@@ -51,7 +53,7 @@ func HandleGetRawAnimeCollection(c *RouteCtx) error {
 	bypassCache := c.Fiber.Method() == "POST"
 
 	// Get the user's anilist collection
-	animeCollection, err := c.App.GetRawAnimeCollection(bypassCache)
+	animeCollection, err := c.Profile().GetRawAnimeCollection(bypassCache)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -65,6 +67,7 @@ func HandleGetRawAnimeCollection(c *RouteCtx) error {
 //	@desc This is used to edit an entry on AniList.
 //	@desc The "type" field is used to determine if the entry is an anime or manga and refreshes the collection accordingly.
 //	@desc The client should refetch collection-dependent queries after this mutation.
+//	@desc "customLists" replaces the custom lists of the entry, they are left unchanged if it is omitted.
 //	@returns true
 //	@route /api/v1/anilist/list-entry [POST]
 func HandleEditAnilistListEntry(c *RouteCtx) error {
@@ -76,7 +79,9 @@ func HandleEditAnilistListEntry(c *RouteCtx) error {
 		Progress  *int                     `json:"progress"`
 		StartDate *anilist.FuzzyDateInput  `json:"startedAt"`
 		EndDate   *anilist.FuzzyDateInput  `json:"completedAt"`
-		Type      string                   `json:"type"`
+		// Names of the custom lists, nil if unchanged
+		CustomLists []string `json:"customLists"`
+		Type        string   `json:"type"`
 	}
 
 	p := new(body)
@@ -84,13 +89,14 @@ func HandleEditAnilistListEntry(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	err := c.App.AnilistPlatform.UpdateEntry(
+	err := c.Profile().AnilistPlatform.UpdateEntry(
 		*p.MediaId,
 		p.Status,
 		p.Score,
 		p.Progress,
 		p.StartDate,
 		p.EndDate,
+		p.CustomLists,
 	)
 	if err != nil {
 		return c.RespondWithError(err)
@@ -98,12 +104,12 @@ func HandleEditAnilistListEntry(c *RouteCtx) error {
 
 	switch p.Type {
 	case "anime":
-		_, _ = c.App.RefreshAnimeCollection()
+		_, _ = c.Profile().RefreshAnimeCollection()
 	case "manga":
-		_, _ = c.App.RefreshMangaCollection()
+		_, _ = c.Profile().RefreshMangaCollection()
 	default:
-		_, _ = c.App.RefreshAnimeCollection()
-		_, _ = c.App.RefreshMangaCollection()
+		_, _ = c.Profile().RefreshAnimeCollection()
+		_, _ = c.Profile().RefreshMangaCollection()
 	}
 
 	return c.RespondWithData(true)
@@ -132,7 +138,7 @@ func HandleGetAnilistAnimeDetails(c *RouteCtx) error {
 	if details, ok := detailsCache.Get(mId); ok {
 		return c.RespondWithData(details)
 	}
-	details, err := c.App.AnilistPlatform.GetAnimeDetails(mId)
+	details, err := c.Profile().AnilistPlatform.GetAnimeDetails(mId)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -162,7 +168,7 @@ func HandleGetAnilistStudioDetails(c *RouteCtx) error {
 	if details, ok := studioDetailsMap.Get(mId); ok {
 		return c.RespondWithData(details)
 	}
-	details, err := c.App.AnilistPlatform.GetStudioDetails(mId)
+	details, err := c.Profile().AnilistPlatform.GetStudioDetails(mId)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -207,7 +213,7 @@ func HandleDeleteAnilistListEntry(c *RouteCtx) error {
 	switch *p.Type {
 	case "anime":
 		// Get the list entry ID
-		animeCollection, err := c.App.GetAnimeCollection(false)
+		animeCollection, err := c.Profile().GetAnimeCollection(false)
 		if err != nil {
 			return c.RespondWithError(err)
 		}
@@ -219,7 +225,7 @@ func HandleDeleteAnilistListEntry(c *RouteCtx) error {
 		listEntryID = listEntry.ID
 	case "manga":
 		// Get the list entry ID
-		mangaCollection, err := c.App.GetMangaCollection(false)
+		mangaCollection, err := c.Profile().GetMangaCollection(false)
 		if err != nil {
 			return c.RespondWithError(err)
 		}
@@ -232,16 +238,16 @@ func HandleDeleteAnilistListEntry(c *RouteCtx) error {
 	}
 
 	// Delete the list entry
-	err := c.App.AnilistPlatform.DeleteEntry(listEntryID)
+	err := c.Profile().AnilistPlatform.DeleteEntry(listEntryID)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
 	switch *p.Type {
 	case "anime":
-		_, _ = c.App.RefreshAnimeCollection()
+		_, _ = c.Profile().RefreshAnimeCollection()
 	case "manga":
-		_, _ = c.App.RefreshMangaCollection()
+		_, _ = c.Profile().RefreshMangaCollection()
 	}
 
 	return c.RespondWithData(true)
@@ -401,7 +407,7 @@ var anilistMissedSequelsCache = result.NewCache[string, []*anilist.BaseAnime]()
 //	@returns []anilist.BaseAnime
 func HandleAnilistListMissedSequels(c *RouteCtx) error {
 
-	cacheKey := "missed_sequels"
+	cacheKey := fmt.Sprintf("missed_sequels_%d", c.Profile().Profile.ID)
 
 	cached, ok := anilistMissedSequelsCache.Get(cacheKey)
 	if ok {
@@ -409,7 +415,7 @@ func HandleAnilistListMissedSequels(c *RouteCtx) error {
 	}
 
 	// Get complete anime collection
-	animeCollection, err := c.App.AnilistPlatform.GetAnimeCollectionWithRelations()
+	animeCollection, err := c.Profile().AnilistPlatform.GetAnimeCollectionWithRelations()
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -438,20 +444,20 @@ var anilistStatsCache = result.NewCache[int, *anilist.Stats]()
 //	@route /api/v1/anilist/stats [GET]
 //	@returns anilist.Stats
 func HandleGetAniListStats(c *RouteCtx) error {
-	cached, ok := anilistStatsCache.Get(0)
+	cached, ok := anilistStatsCache.Get(int(c.Profile().Profile.ID))
 	if ok {
 		return c.RespondWithData(cached)
 	}
 
 	ret, err := anilist.GetStats(
 		c.Fiber.Context(),
-		c.App.AnilistClient,
+		c.Profile().AnilistClient,
 	)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
-	anilistStatsCache.SetT(0, ret, time.Hour*1)
+	anilistStatsCache.SetT(int(c.Profile().Profile.ID), ret, time.Hour*1)
 
 	return c.RespondWithData(ret)
 }
@@ -19,7 +19,7 @@ import (
 //	@returns anime.LibraryCollection
 func HandleGetLibraryCollection(c *RouteCtx) error {
 
-	animeCollection, err := c.App.GetAnimeCollection(false)
+	animeCollection, err := c.Profile().GetAnimeCollection(false)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -28,16 +28,20 @@ func HandleGetLibraryCollection(c *RouteCtx) error {
 		return c.RespondWithData(&anime.LibraryCollection{})
 	}
 
+	// Includes the custom lists
+	rawAnimeCollection, _ := c.Profile().GetRawAnimeCollection(false)
+
 	lfs, _, err := db_bridge.GetLocalFiles(c.App.Database)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
 	libraryCollection, err := anime.NewLibraryCollection(&anime.NewLibraryCollectionOptions{
-		AnimeCollection:  animeCollection,
-		Platform:         c.App.AnilistPlatform,
-		LocalFiles:       lfs,
-		MetadataProvider: c.App.MetadataProvider,
+		AnimeCollection:    animeCollection,
+		RawAnimeCollection: rawAnimeCollection,
+		Platform:           c.Profile().AnilistPlatform,
+		LocalFiles:         lfs,
+		MetadataProvider:   c.App.MetadataProvider,
 	})
 	if err != nil {
 		return c.RespondWithError(err)
@@ -78,12 +82,12 @@ func HandleAddUnknownMedia(c *RouteCtx) error {
 	}
 
 	// Add non-added media entries to AniList collection
-	if err := c.App.AnilistPlatform.AddMediaToCollection(b.MediaIds); err != nil {
+	if err := c.Profile().AnilistPlatform.AddMediaToCollection(b.MediaIds); err != nil {
 		return c.RespondWithError(errors.New("error: Anilist responded with an error, this is most likely a rate limit issue"))
 	}
 
 	// Bypass the cache
-	animeCollection, err := c.App.GetAnimeCollection(true)
+	animeCollection, err := c.Profile().GetAnimeCollection(true)
 	if err != nil {
 		return c.RespondWithError(errors.New("error: Anilist responded with an error, wait one minute before refreshing"))
 	}
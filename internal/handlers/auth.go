@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/goccy/go-json"
+	"seanime/internal/api/anilist"
 	"seanime/internal/database/models"
 	"seanime/internal/util"
 	"time"
@@ -29,11 +30,19 @@ func HandleLogin(c *RouteCtx) error {
 		return c.Fiber.JSON(NewErrorResponse(err))
 	}
 
+	session := c.Profile()
+
 	// Set a new AniList client by passing to JWT token
-	c.App.UpdateAnilistClientToken(b.Token)
+	var anilistClient anilist.AnilistClient
+	if session.IsDefault() {
+		c.App.UpdateAnilistClientToken(b.Token)
+		anilistClient = c.App.AnilistClient
+	} else {
+		anilistClient = anilist.NewAnilistClient(b.Token)
+	}
 
 	// Get viewer data from AniList
-	getViewer, err := c.App.AnilistClient.GetViewer(context.Background())
+	getViewer, err := anilistClient.GetViewer(context.Background())
 	if err != nil {
 		c.App.Logger.Error().Msg("Could not authenticate to AniList")
 		return c.RespondWithError(err)
@@ -52,7 +61,7 @@ func HandleLogin(c *RouteCtx) error {
 	// Save account data in database
 	_, err = c.App.Database.UpsertAccount(&models.Account{
 		BaseModel: models.BaseModel{
-			ID:        1,
+			ID:        session.Profile.ID,
 			UpdatedAt: time.Now(),
 		},
 		Username: getViewer.Viewer.Name,
@@ -66,6 +75,12 @@ func HandleLogin(c *RouteCtx) error {
 
 	c.App.Logger.Info().Msg("app: Authenticated to AniList as " + getViewer.Viewer.Name)
 
+	// Other profiles only need their session to be recreated
+	if !session.IsDefault() {
+		c.App.RefreshProfileSession(session.Profile.ID)
+		return c.RespondWithData(NewStatus(c))
+	}
+
 	// Create a new status
 	status := NewStatus(c)
 
@@ -94,9 +109,11 @@ func HandleLogin(c *RouteCtx) error {
 //	@returns handlers.Status
 func HandleLogout(c *RouteCtx) error {
 
+	session := c.Profile()
+
 	_, err := c.App.Database.UpsertAccount(&models.Account{
 		BaseModel: models.BaseModel{
-			ID:        1,
+			ID:        session.Profile.ID,
 			UpdatedAt: time.Now(),
 		},
 		Username: "",
@@ -110,6 +127,11 @@ func HandleLogout(c *RouteCtx) error {
 
 	c.App.Logger.Info().Msg("Logged out of AniList")
 
+	if !session.IsDefault() {
+		c.App.RefreshProfileSession(session.Profile.ID)
+		return c.RespondWithData(NewStatus(c))
+	}
+
 	status := NewStatus(c)
 
 	c.App.InitOrRefreshModules()
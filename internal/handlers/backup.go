@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/backup"
+	"seanime/internal/database/models"
+)
+
+// HandleGetBackups
+//
+//	@summary returns the backups stored in the backup directory.
+//	@desc The backups are sorted from newest to oldest.
+//	@route /api/v1/backups [GET]
+//	@returns []backup.BackupFile
+func HandleGetBackups(c *RouteCtx) error {
+	backups, err := c.App.BackupManager.ListBackups()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(backups)
+}
+
+// HandleCreateBackup
+//
+//	@summary creates a backup in the backup directory.
+//	@desc The archive contains the database, the offline database, the watch history and the installed extensions.
+//	@desc The oldest backups are deleted if there are more than the maximum number of backups.
+//	@desc The archive can be downloaded with HandleDownloadBackup.
+//	@route /api/v1/backups [POST]
+//	@returns backup.BackupFile
+func HandleCreateBackup(c *RouteCtx) error {
+	file, err := c.App.BackupManager.CreateBackup()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(file)
+}
+
+// HandleDownloadBackup
+//
+//	@summary downloads a backup archive.
+//	@route /api/v1/backups/download/{name} [GET]
+//	@param name - string - true - "The name of the backup file"
+func HandleDownloadBackup(c *RouteCtx) error {
+	backupPath, err := c.App.BackupManager.GetBackupPath(c.Fiber.Params("name"))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.Fiber.Download(backupPath)
+}
+
+// HandleDeleteBackup
+//
+//	@summary deletes a backup from the backup directory.
+//	@route /api/v1/backups [DELETE]
+//	@returns []backup.BackupFile
+func HandleDeleteBackup(c *RouteCtx) error {
+
+	type body struct {
+		Name string `json:"name"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if err := c.App.BackupManager.DeleteBackup(b.Name); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	backups, err := c.App.BackupManager.ListBackups()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(backups)
+}
+
+// HandleRestoreBackup
+//
+//	@summary restores a backup.
+//	@desc The archive can be uploaded as a multipart form file named "file", or a backup from the backup directory can be selected by name.
+//	@desc The archive is validated and staged, it replaces the current data when Seanime is restarted.
+//	@desc Stored credentials can only be read back if the encryption key is the same as the one used when the backup was created.
+//	@desc 'undecryptableCredentials' lists the credentials that were encrypted with another key, they will be empty after the restore and need to be entered again.
+//	@desc To keep them, copy the key file of the previous installation to the data directory (or set SEANIME_ENCRYPTION_KEY) before restoring.
+//	@route /api/v1/backups/restore [POST]
+//	@returns backup.RestoreResult
+func HandleRestoreBackup(c *RouteCtx) error {
+
+	if fh, err := c.Fiber.FormFile("file"); err == nil {
+		f, err := fh.Open()
+		if err != nil {
+			return c.RespondWithError(err)
+		}
+		defer f.Close()
+
+		res, err := c.App.BackupManager.Restore(f, fh.Size)
+		if err != nil {
+			return c.RespondWithError(err)
+		}
+
+		return c.RespondWithData(res)
+	}
+
+	type body struct {
+		Name string `json:"name"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if b.Name == "" {
+		return c.RespondWithError(errors.New("no backup provided"))
+	}
+
+	res, err := c.App.BackupManager.RestoreFromBackup(b.Name)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(res)
+}
+
+// HandleGetBackupSettings
+//
+//	@summary returns the settings of the scheduled backups.
+//	@route /api/v1/backups/settings [GET]
+//	@returns models.BackupSettings
+func HandleGetBackupSettings(c *RouteCtx) error {
+	settings, found := c.App.Database.GetBackupSettings()
+	if !found {
+		return c.RespondWithError(errors.New("backup settings not found"))
+	}
+
+	return c.RespondWithData(settings)
+}
+
+// HandleSaveBackupSettings
+//
+//	@summary saves the settings of the scheduled backups.
+//	@route /api/v1/backups/settings [PATCH]
+//	@returns models.BackupSettings
+func HandleSaveBackupSettings(c *RouteCtx) error {
+
+	type body struct {
+		Settings models.BackupSettings `json:"settings"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if b.Settings.Interval <= 0 {
+		b.Settings.Interval = backup.DefaultInterval
+	}
+	if b.Settings.MaxBackups < 0 {
+		b.Settings.MaxBackups = 0
+	}
+
+	b.Settings.ID = 1
+	settings, err := c.App.Database.UpsertBackupSettings(&b.Settings)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	c.App.InitOrRefreshBackupSettings()
+
+	return c.RespondWithData(settings)
+}
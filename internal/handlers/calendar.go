@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"fmt"
+	"seanime/internal/calendar"
+	"seanime/internal/database/db_bridge"
+	"time"
+)
+
+// calendarFeedWeeks is the number of weeks in the iCalendar feed, starting from the previous week
+const calendarFeedWeeks = 6
+
+// HandleGetCalendar
+//
+//	@summary returns the airing calendar of the anime being watched or planned.
+//	@desc 'range' is either 'week' (default) or 'month', 'date' (YYYY-MM-DD) is any day in the range and defaults to today.
+//	@desc 'timezone' is an IANA time zone name used to group the episodes by day, the server time zone is used by default.
+//	@desc Each episode is annotated with its local availability and auto downloader rule status.
+//	@route /api/v1/calendar [GET]
+//	@returns calendar.View
+func HandleGetCalendar(c *RouteCtx) error {
+	loc, err := getTimezoneQuery(c)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	date, err := getDateQuery(c, "date", time.Now().In(loc), loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	r := calendar.Range(c.Fiber.Query("range", string(calendar.RangeWeek)))
+	start, end, err := calendar.Bounds(r, date)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	opts, err := getCalendarOptions(c, start, end)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	view, err := c.App.Calendar.Get(opts)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(view)
+}
+
+// HandleGetCalendarFeed
+//
+//	@summary returns the airing calendar as an iCalendar feed.
+//	@desc The feed covers the previous week and the upcoming weeks, calendar apps can subscribe to it.
+//	@desc Calendar apps can't send headers, pass 'token' and 'profile' as query parameters instead.
+//	@desc 'token' must be a calendar feed token created with /api/v1/server-auth/calendar-feed-token, device tokens are not accepted in the URL.
+//	@route /api/v1/calendar/feed.ics [GET]
+//	@returns string
+func HandleGetCalendarFeed(c *RouteCtx) error {
+	start, _, err := calendar.Bounds(calendar.RangeWeek, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	opts, err := getCalendarOptions(c, start, start.AddDate(0, 0, 7*calendarFeedWeeks))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	items, err := c.App.Calendar.GetItems(opts)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	c.Fiber.Set("Content-Type", "text/calendar; charset=utf-8")
+	c.Fiber.Set("Content-Disposition", "inline; filename=seanime.ics")
+
+	return c.Fiber.SendString(calendar.ToICS("Seanime", items))
+}
+
+func getTimezoneQuery(c *RouteCtx) (*time.Location, error) {
+	tz := c.Fiber.Query("timezone")
+	if tz == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(tz)
+}
+
+func getDateQuery(c *RouteCtx, key string, defaultValue time.Time, loc *time.Location) (time.Time, error) {
+	d := c.Fiber.Query(key)
+	if d == "" {
+		return defaultValue, nil
+	}
+	ret, err := time.ParseInLocation(time.DateOnly, d, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s, expected YYYY-MM-DD", key)
+	}
+	return ret, nil
+}
+
+func getCalendarOptions(c *RouteCtx, start time.Time, end time.Time) (*calendar.GetOptions, error) {
+	animeCollection, err := c.Profile().GetAnimeCollection(false)
+	if err != nil {
+		return nil, err
+	}
+
+	lfs, _, err := db_bridge.GetLocalFiles(c.App.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := db_bridge.GetAutoDownloaderRules(c.App.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendar.GetOptions{
+		AnimeCollection:       animeCollection,
+		LocalFiles:            lfs,
+		AutoDownloaderRules:   rules,
+		AutoDownloaderEnabled: c.App.Settings != nil && c.App.Settings.AutoDownloader != nil && c.App.Settings.AutoDownloader.Enabled,
+		Start:                 start,
+		End:                   end,
+	}, nil
+}
@@ -1,12 +1,16 @@
 package handlers
 
-import "seanime/internal/continuity"
+import (
+	"seanime/internal/continuity"
+	"seanime/internal/watchstats"
+)
 
 // HandleUpdateContinuityWatchHistoryItem
 //
 //	@summary Updates watch history item.
 //	@desc This endpoint is used to update a watch history item.
 //	@desc Since this is low priority, we ignore any errors.
+//	@desc The playback position of the online and media streams is also recorded in the watch stats.
 //	@route /api/v1/continuity/item [PATCH]
 //	@returns bool
 func HandleUpdateContinuityWatchHistoryItem(c *RouteCtx) error {
@@ -19,7 +23,15 @@ func HandleUpdateContinuityWatchHistoryItem(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	err := c.App.ContinuityManager.UpdateWatchHistoryItem(&b.Options)
+	// The media player events are recorded by the playback manager
+	switch b.Options.Kind {
+	case continuity.OnlinestreamKind:
+		trackContinuityWatchTime(c, &b.Options, watchstats.SourceOnline)
+	case continuity.MediastreamKind:
+		trackContinuityWatchTime(c, &b.Options, watchstats.SourceLocal)
+	}
+
+	err := c.Profile().ContinuityManager.UpdateWatchHistoryItem(&b.Options)
 	if err != nil {
 		// Ignore the error
 		return c.RespondWithData(false)
@@ -28,6 +40,16 @@ func HandleUpdateContinuityWatchHistoryItem(c *RouteCtx) error {
 	return c.RespondWithData(true)
 }
 
+func trackContinuityWatchTime(c *RouteCtx, opts *continuity.UpdateWatchHistoryItemOptions, source watchstats.Source) {
+	c.App.WatchStats.Track(&watchstats.TrackOptions{
+		ProfileID: c.Profile().Profile.ID,
+		MediaID:   opts.MediaId,
+		Episode:   opts.EpisodeNumber,
+		Source:    source,
+		Position:  opts.CurrentTime,
+	})
+}
+
 // HandleGetContinuityWatchHistoryItem
 //
 //	@summary Returns a watch history item.
@@ -41,14 +63,14 @@ func HandleGetContinuityWatchHistoryItem(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	if !c.App.ContinuityManager.GetSettings().WatchContinuityEnabled {
+	if !c.Profile().ContinuityManager.GetSettings().WatchContinuityEnabled {
 		return c.RespondWithData(&continuity.WatchHistoryItemResponse{
 			Item:  nil,
 			Found: false,
 		})
 	}
 
-	resp := c.App.ContinuityManager.GetWatchHistoryItem(id)
+	resp := c.Profile().ContinuityManager.GetWatchHistoryItem(id)
 	return c.RespondWithData(resp)
 }
 
@@ -59,11 +81,11 @@ func HandleGetContinuityWatchHistoryItem(c *RouteCtx) error {
 //	@route /api/v1/continuity/history [GET]
 //	@returns continuity.WatchHistory
 func HandleGetContinuityWatchHistory(c *RouteCtx) error {
-	if !c.App.ContinuityManager.GetSettings().WatchContinuityEnabled {
+	if !c.Profile().ContinuityManager.GetSettings().WatchContinuityEnabled {
 		ret := make(map[int]*continuity.WatchHistoryItem)
 		return c.RespondWithData(ret)
 	}
 
-	resp := c.App.ContinuityManager.GetWatchHistory()
+	resp := c.Profile().ContinuityManager.GetWatchHistory()
 	return c.RespondWithData(resp)
 }
@@ -23,7 +23,7 @@ func HandleGetDebridSettings(c *RouteCtx) error {
 		return c.RespondWithError(errors.New("debrid settings not found"))
 	}
 
-	return c.RespondWithData(debridSettings)
+	return c.RespondWithData(debridSettings.Redacted())
 }
 
 // HandleSaveDebridSettings
@@ -44,6 +44,11 @@ func HandleSaveDebridSettings(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
+	// Keep the stored API key if the client sent back the redacted placeholder
+	if prev, found := c.App.Database.GetDebridSettings(); found {
+		b.Settings.ApiKey = models.KeepExisting(b.Settings.ApiKey, prev.ApiKey)
+	}
+
 	settings, err := c.App.Database.UpsertDebridSettings(&b.Settings)
 	if err != nil {
 		return c.RespondWithError(err)
@@ -51,7 +56,7 @@ func HandleSaveDebridSettings(c *RouteCtx) error {
 
 	c.App.InitOrRefreshDebridSettings()
 
-	return c.RespondWithData(settings)
+	return c.RespondWithData(settings.Redacted())
 }
 
 // HandleDebridAddTorrents
@@ -22,6 +22,8 @@ func HandleSetDiscordMangaActivity(c *RouteCtx) error {
 		return c.RespondWithData(false)
 	}
 
+	c.App.UseProfileForPlayback(c.Profile())
+
 	c.App.DiscordPresence.SetMangaActivity(&discordrpc_presence.MangaActivity{
 		ID:      b.MediaId,
 		Title:   b.Title,
@@ -94,3 +94,26 @@ func HandleGetDocs(c *RouteCtx) error {
 
 	return c.RespondWithData(groups)
 }
+
+var cachedOpenAPISpec []byte
+
+// HandleGetOpenAPISpec
+//
+//	@summary returns the OpenAPI 3 specification of the API.
+//	@desc The specification is generated by codegen and is not wrapped in the response envelope.
+//	@route /api/v1/internal/openapi.json [GET]
+//	@returns string
+func HandleGetOpenAPISpec(c *RouteCtx) error {
+
+	if len(cachedOpenAPISpec) == 0 {
+		wd, _ := os.Getwd()
+		buf, err := os.ReadFile(filepath.Join(wd, "codegen/generated/openapi.json"))
+		if err != nil {
+			return c.RespondWithError(err)
+		}
+		cachedOpenAPISpec = buf
+	}
+
+	c.Fiber.Set("Content-Type", "application/json")
+	return c.Fiber.Send(cachedOpenAPISpec)
+}
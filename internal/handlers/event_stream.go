@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"bufio"
+	"fmt"
+	"seanime/internal/events"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sseHeartbeatInterval is the interval between the comments sent to keep idle connections open.
+const sseHeartbeatInterval = 15 * time.Second
+
+// HandleGetEventStream
+//
+//	@summary streams the server events using Server-Sent Events.
+//	@desc The "id" of each event is its sequence number in the event journal.
+//	@desc Clients resume from the last event they received with the "Last-Event-ID" header or the "lastEventId" query parameter.
+//	@desc The "types" query parameter is a comma-separated list of event types to receive, e.g. "scan-progress,playback-manager-*".
+//	@desc Only the events broadcast to all clients are streamed. The journal keeps the latest 1000 events.
+//	@route /api/v1/events/stream [GET]
+//	@returns string
+func HandleGetEventStream(c *RouteCtx) error {
+	journal := c.App.WSEventManager.Journal
+
+	types := parseEventTypes(c.Fiber.Query("types"))
+
+	lastEventId := c.Fiber.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Fiber.Query("lastEventId")
+	}
+	resume := false
+	var since uint64
+	if lastEventId != "" {
+		if seq, err := strconv.ParseUint(lastEventId, 10, 64); err == nil {
+			resume = true
+			since = seq
+		}
+	}
+
+	c.Fiber.Set("Content-Type", "text/event-stream")
+	c.Fiber.Set("Cache-Control", "no-cache")
+	c.Fiber.Set("Connection", "keep-alive")
+	c.Fiber.Set("X-Accel-Buffering", "no")
+
+	backlog, ch, cancel := journal.Subscribe(resume, since)
+
+	c.Fiber.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		// Tell the client to wait before reconnecting
+		_, _ = fmt.Fprintf(w, "retry: 3000\n\n")
+
+		for _, entry := range backlog {
+			if events.MatchesType(entry.Type, types) {
+				writeSSEEvent(w, entry)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case entry, ok := <-ch:
+				if !ok {
+					// The client fell behind, it will reconnect with the Last-Event-ID header
+					return
+				}
+				if !events.MatchesType(entry.Type, types) {
+					continue
+				}
+				writeSSEEvent(w, entry)
+			case <-heartbeat.C:
+				_, _ = fmt.Fprintf(w, ": heartbeat\n\n")
+			}
+			// Flush fails when the client disconnects
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// HandleGetEventHistory
+//
+//	@summary returns the events recorded in the event journal after the given sequence number.
+//	@desc If "types" is empty, all events are returned. The journal keeps the latest 1000 events.
+//	@route /api/v1/events/history [POST]
+//	@returns []events.JournalEntry
+func HandleGetEventHistory(c *RouteCtx) error {
+
+	type body struct {
+		Since uint64   `json:"since"`
+		Types []string `json:"types"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(c.App.WSEventManager.Journal.Since(b.Since, b.Types))
+}
+
+func writeSSEEvent(w *bufio.Writer, entry *events.JournalEntry) {
+	_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", entry.Seq, entry.Type, entry.Payload)
+}
+
+func parseEventTypes(s string) []string {
+	ret := make([]string, 0)
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
@@ -200,6 +200,7 @@ func HandleRunExtensionPlaygroundCode(c *RouteCtx) error {
 //
 //	@summary returns the user config definition and current values for the extension with the given ID.
 //	@route /api/v1/extensions/user-config/{id} [GET]
+//	@param id - string - true - "The extension ID"
 //	@returns extension_repo.ExtensionUserConfig
 func HandleGetExtensionUserConfig(c *RouteCtx) error {
 	id := c.Fiber.Params("id", "")
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/api/kitsu"
+	"seanime/internal/database/models"
+	"time"
+)
+
+// HandleKitsuLogin
+//
+//	@summary logs the user in to Kitsu.
+//	@desc This uses the OAuth password grant to fetch the access and refresh tokens.
+//	@desc It will save the info in the database, the password is not stored.
+//	@desc The client should re-fetch the server status after this.
+//	@route /api/v1/kitsu/login [POST]
+//	@returns kitsu.User
+func HandleKitsuLogin(c *RouteCtx) error {
+
+	type body struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if b.Username == "" || b.Password == "" {
+		return c.RespondWithError(errors.New("username and password are required"))
+	}
+
+	ret, err := kitsu.Login(b.Username, b.Password)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	// Get the user the token belongs to
+	user, err := kitsu.NewWrapper(ret.AccessToken, "", c.App.Logger).GetCurrentUser()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	kitsuInfo := models.Kitsu{
+		BaseModel: models.BaseModel{
+			ID:        c.Profile().Profile.ID,
+			UpdatedAt: time.Now(),
+		},
+		Username:       user.Name,
+		UserID:         user.ID,
+		AccessToken:    ret.AccessToken,
+		RefreshToken:   ret.RefreshToken,
+		TokenExpiresAt: time.Now().Add(time.Duration(ret.ExpiresIn) * time.Second),
+	}
+
+	_, err = c.App.Database.UpsertKitsuInfo(&kitsuInfo)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	// Fetch the lists if Kitsu is the tracking platform
+	if c.App.GetTrackingPlatform() == models.TrackingPlatformKitsu && c.Profile().Profile.ID == models.DefaultProfileID {
+		go c.App.InitOrRefreshAnilistData()
+	}
+
+	return c.RespondWithData(user)
+}
+
+// HandleKitsuLogout
+//
+//	@summary logs the user out of Kitsu.
+//	@desc This will delete the Kitsu info from the database, effectively logging the user out.
+//	@desc The client should re-fetch the server status after this.
+//	@route /api/v1/kitsu/logout [POST]
+//	@returns bool
+func HandleKitsuLogout(c *RouteCtx) error {
+
+	err := c.App.Database.DeleteKitsuInfoByID(c.Profile().Profile.ID)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
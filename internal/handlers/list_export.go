@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"seanime/internal/api/anilist"
+	"seanime/internal/listtransfer"
+)
+
+// HandleExportList
+//
+//	@summary exports the collection as a file.
+//	@desc Supported formats are 'mal-xml' (MyAnimeList import file), 'json' and 'csv'.
+//	@desc The 'type' query parameter ('anime' or 'manga') is required for MyAnimeList exports, both collections are exported otherwise.
+//	@desc Entries without a MyAnimeList ID are reported in 'missingMalId' and left out of MyAnimeList exports.
+//	@route /api/v1/list-export [GET]
+//	@returns listtransfer.ExportResult
+func HandleExportList(c *RouteCtx) error {
+	format := listtransfer.Format(c.Fiber.Query("format"))
+	mediaType := listtransfer.MediaType(c.Fiber.Query("type"))
+
+	var animeCollection *anilist.AnimeCollection
+	var mangaCollection *anilist.MangaCollection
+	var err error
+
+	if mediaType != listtransfer.MediaTypeManga {
+		animeCollection, err = c.Profile().GetRawAnimeCollection(false)
+		if err != nil {
+			return c.RespondWithError(err)
+		}
+	}
+	if mediaType != listtransfer.MediaTypeAnime {
+		mangaCollection, err = c.Profile().GetRawMangaCollection(false)
+		if err != nil {
+			return c.RespondWithError(err)
+		}
+	}
+
+	res, err := c.App.ListExporter.Export(&listtransfer.ExportOptions{
+		Format:          format,
+		MediaType:       mediaType,
+		AnimeCollection: animeCollection,
+		MangaCollection: mangaCollection,
+	})
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(res)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"seanime/internal/events"
+	"seanime/internal/listtransfer"
+)
+
+// HandleGetListImportStatus
+//
+//	@summary returns the status of the list import of the current profile.
+//	@desc The preview is set once the entries are matched, until all of them are applied.
+//	@route /api/v1/list-import [GET]
+//	@returns listtransfer.Status
+func HandleGetListImportStatus(c *RouteCtx) error {
+	status, found := c.App.ListImporter.GetStatus(c.Profile().Profile.ID)
+	if !found {
+		return c.RespondWithData(nil)
+	}
+	return c.RespondWithData(status)
+}
+
+// HandleGetListImportPreview
+//
+//	@summary parses an exported list and starts mapping its entries to AniList media.
+//	@desc Supported formats are 'mal-xml' (MyAnimeList export), 'json' and 'csv'.
+//	@desc The entries are mapped in the background, the progress is sent with the 'list-import-status' event.
+//	@desc The preview can be fetched once the state is 'ready'. Nothing is added to the collection, the preview is kept until it is applied.
+//	@route /api/v1/list-import/preview [POST]
+//	@returns listtransfer.Status
+func HandleGetListImportPreview(c *RouteCtx) error {
+
+	type body struct {
+		Format  string `json:"format"`
+		Content string `json:"content"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	res, err := c.App.ListImporter.Preview(&listtransfer.PreviewOptions{
+		ProfileID:     c.Profile().Profile.ID,
+		Format:        listtransfer.Format(b.Format),
+		Data:          []byte(b.Content),
+		AnilistClient: c.Profile().AnilistClient,
+	})
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(res)
+}
+
+// HandleApplyListImport
+//
+//	@summary starts adding the entries of the import preview to the collection.
+//	@desc If 'mediaIds' is empty, all matched entries are added.
+//	@desc Entries already in the collection are skipped unless 'overwrite' is true.
+//	@desc The entries are added to the local collection when offline.
+//	@desc The entries are added in the background, the progress and the result are sent with the 'list-import-status' event.
+//	@desc Entries that could not be added are kept in the preview so that they can be applied again.
+//	@route /api/v1/list-import/apply [POST]
+//	@returns listtransfer.Status
+func HandleApplyListImport(c *RouteCtx) error {
+
+	type body struct {
+		MediaIDs  []int `json:"mediaIds"`
+		Overwrite bool  `json:"overwrite"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	profile := c.Profile()
+
+	res, err := c.App.ListImporter.Apply(&listtransfer.ApplyOptions{
+		ProfileID: profile.Profile.ID,
+		Platform:  profile.AnilistPlatform,
+		MediaIDs:  b.MediaIDs,
+		Overwrite: b.Overwrite,
+		OnApplied: func(_ *listtransfer.ApplyResult) {
+			animeCollection, err := profile.RefreshAnimeCollection()
+			if err == nil && profile.IsDefault() {
+				c.App.WSEventManager.SendEvent(events.RefreshedAnilistAnimeCollection, animeCollection)
+			}
+			mangaCollection, err := profile.RefreshMangaCollection()
+			if err == nil && profile.IsDefault() {
+				c.App.WSEventManager.SendEvent(events.RefreshedAnilistMangaCollection, mangaCollection)
+			}
+		},
+	})
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(res)
+}
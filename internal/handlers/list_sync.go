@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+	"seanime/internal/events"
+	"seanime/internal/listsync"
+	"time"
+)
+
+var errListSyncDefaultProfile = errors.New("list sync is only available for the default profile")
+
+// HandleGetListSyncPreview
+//
+//	@summary returns the changes a list synchronization would make.
+//	@desc The entries are matched by AniList ID, nothing is applied.
+//	@desc The 'origin' query parameter overrides the origin from the settings.
+//	@route /api/v1/list-sync/preview [GET]
+//	@returns listsync.Result
+func HandleGetListSyncPreview(c *RouteCtx) error {
+	if !c.Profile().IsDefault() {
+		return c.RespondWithError(errListSyncDefaultProfile)
+	}
+
+	origin := c.Fiber.Query("origin")
+	if !listsync.IsValidOrigin(origin) {
+		return c.RespondWithError(errors.New("invalid origin"))
+	}
+
+	res, err := c.App.ListSyncManager.Preview(listsync.Origin(origin))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(res)
+}
+
+// HandleRunListSync
+//
+//	@summary synchronizes the AniList and MyAnimeList lists.
+//	@desc Entries missing on a platform are added to it, entries that differ are resolved using the origin.
+//	@desc The origin from the settings is used if 'origin' is empty.
+//	@route /api/v1/list-sync/sync [POST]
+//	@returns listsync.Result
+func HandleRunListSync(c *RouteCtx) error {
+
+	type body struct {
+		Origin string `json:"origin"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if !c.Profile().IsDefault() {
+		return c.RespondWithError(errListSyncDefaultProfile)
+	}
+
+	if !listsync.IsValidOrigin(b.Origin) {
+		return c.RespondWithError(errors.New("invalid origin"))
+	}
+
+	res, err := c.App.ListSyncManager.Sync(listsync.Origin(b.Origin))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if res.Applied > 0 {
+		go func() {
+			animeCollection, err := c.App.RefreshAnimeCollection()
+			if err == nil {
+				c.App.WSEventManager.SendEvent(events.RefreshedAnilistAnimeCollection, animeCollection)
+			}
+			if c.App.Settings != nil && c.App.Settings.Library != nil && c.App.Settings.Library.EnableManga {
+				mangaCollection, err := c.App.RefreshMangaCollection()
+				if err == nil {
+					c.App.WSEventManager.SendEvent(events.RefreshedAnilistMangaCollection, mangaCollection)
+				}
+			}
+		}()
+	}
+
+	return c.RespondWithData(res)
+}
+
+// HandleSaveListSyncSettings
+//
+//	@summary updates the list sync settings.
+//	@desc When 'automatic' is true, entries are synchronized after they are updated and on a schedule.
+//	@route /api/v1/settings/list-sync [PATCH]
+//	@returns bool
+func HandleSaveListSyncSettings(c *RouteCtx) error {
+
+	type body struct {
+		Automatic bool   `json:"automatic"`
+		Origin    string `json:"origin"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if !listsync.IsValidOrigin(b.Origin) {
+		return c.RespondWithError(errors.New("invalid origin"))
+	}
+
+	currSettings, err := c.App.Database.GetSettings()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	currSettings.ListSync = &models.ListSyncSettings{
+		Automatic: b.Automatic,
+		Origin:    b.Origin,
+	}
+	currSettings.BaseModel = models.BaseModel{
+		ID:        1,
+		UpdatedAt: time.Now(),
+	}
+
+	_, err = c.App.Database.UpsertSettings(currSettings)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
@@ -73,7 +73,7 @@ func HandleMALAuth(c *RouteCtx) error {
 	// Save
 	malInfo := models.Mal{
 		BaseModel: models.BaseModel{
-			ID:        1,
+			ID:        c.Profile().Profile.ID,
 			UpdatedAt: time.Now(),
 		},
 		Username:       "",
@@ -87,6 +87,11 @@ func HandleMALAuth(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
+	// Fetch the lists if MyAnimeList is the tracking platform
+	if c.App.GetTrackingPlatform() == models.TrackingPlatformMal && c.Profile().Profile.ID == models.DefaultProfileID {
+		go c.App.InitOrRefreshAnilistData()
+	}
+
 	return c.RespondWithData(ret)
 }
 
@@ -114,7 +119,7 @@ func HandleEditMALListEntryProgress(c *RouteCtx) error {
 	}
 
 	// Get MAL info
-	_malInfo, err := c.App.Database.GetMalInfo()
+	_malInfo, err := c.App.Database.GetMalInfoByID(c.Profile().Profile.ID)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -152,7 +157,7 @@ func HandleEditMALListEntryProgress(c *RouteCtx) error {
 //	@returns bool
 func HandleMALLogout(c *RouteCtx) error {
 
-	err := c.App.Database.DeleteMalInfo()
+	err := c.App.Database.DeleteMalInfoByID(c.Profile().Profile.ID)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -30,7 +30,7 @@ func HandleGetAnilistMangaCollection(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	collection, err := c.App.GetMangaCollection(b.BypassCache)
+	collection, err := c.Profile().GetMangaCollection(b.BypassCache)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -48,7 +48,7 @@ func HandleGetRawAnilistMangaCollection(c *RouteCtx) error {
 	bypassCache := c.Fiber.Method() == "POST"
 
 	// Get the user's anilist collection
-	mangaCollection, err := c.App.GetRawMangaCollection(bypassCache)
+	mangaCollection, err := c.Profile().GetRawMangaCollection(bypassCache)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -66,14 +66,14 @@ func HandleGetRawAnilistMangaCollection(c *RouteCtx) error {
 //	@returns manga.Collection
 func HandleGetMangaCollection(c *RouteCtx) error {
 
-	animeCollection, err := c.App.GetMangaCollection(false)
+	animeCollection, err := c.Profile().GetMangaCollection(false)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
 	collection, err := manga.NewCollection(&manga.NewCollectionOptions{
 		MangaCollection: animeCollection,
-		Platform:        c.App.AnilistPlatform,
+		Platform:        c.Profile().AnilistPlatform,
 	})
 	if err != nil {
 		return c.RespondWithError(err)
@@ -96,7 +96,7 @@ func HandleGetMangaEntry(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	animeCollection, err := c.App.GetMangaCollection(false)
+	animeCollection, err := c.Profile().GetMangaCollection(false)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -105,7 +105,7 @@ func HandleGetMangaEntry(c *RouteCtx) error {
 		MediaId:         id,
 		Logger:          c.App.Logger,
 		FileCacher:      c.App.FileCacher,
-		Platform:        c.App.AnilistPlatform,
+		Platform:        c.Profile().AnilistPlatform,
 		MangaCollection: animeCollection,
 	})
 	if err != nil {
@@ -137,7 +137,7 @@ func HandleGetMangaEntryDetails(c *RouteCtx) error {
 		return c.RespondWithData(detailsMedia)
 	}
 
-	details, err := c.App.AnilistPlatform.GetMangaDetails(id)
+	details, err := c.Profile().AnilistPlatform.GetMangaDetails(id)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -197,7 +197,7 @@ func HandleGetMangaEntryChapters(c *RouteCtx) error {
 	baseManga, found := baseMangaCache.Get(b.MediaId)
 	if !found {
 		var err error
-		baseManga, err = c.App.AnilistPlatform.GetManga(b.MediaId)
+		baseManga, err = c.Profile().AnilistPlatform.GetManga(b.MediaId)
 		if err != nil {
 			return c.RespondWithError(err)
 		}
@@ -265,7 +265,7 @@ func HandleGetMangaEntryDownloadedChapters(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	mangaCollection, err := c.App.GetMangaCollection(false)
+	mangaCollection, err := c.Profile().GetMangaCollection(false)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -384,7 +384,7 @@ func HandleUpdateMangaProgress(c *RouteCtx) error {
 	}
 
 	// Update the progress on AniList
-	err := c.App.AnilistPlatform.UpdateEntryProgress(
+	err := c.Profile().AnilistPlatform.UpdateEntryProgress(
 		b.MediaId,
 		b.ChapterNumber,
 		&b.TotalChapters,
@@ -393,7 +393,7 @@ func HandleUpdateMangaProgress(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	_, _ = c.App.RefreshMangaCollection() // Refresh the AniList collection
+	_, _ = c.Profile().RefreshMangaCollection() // Refresh the AniList collection
 
 	return c.RespondWithData(true)
 }
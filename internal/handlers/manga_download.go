@@ -4,6 +4,7 @@ import (
 	"seanime/internal/events"
 	"seanime/internal/manga"
 	"seanime/internal/manga/downloader"
+	"seanime/internal/metrics"
 	"time"
 )
 
@@ -130,6 +131,7 @@ func HandleClearAllChapterDownloadQueue(c *RouteCtx) error {
 	if err != nil {
 		return c.RespondWithError(err)
 	}
+	metrics.MangaDownloadQueueDepth.Set(0)
 
 	c.App.WSEventManager.SendEvent(events.ChapterDownloadQueueUpdated, nil)
 
@@ -192,7 +194,7 @@ func HandleDeleteMangaDownloadedChapters(c *RouteCtx) error {
 //	@returns []manga.DownloadListItem
 func HandleGetMangaDownloadsList(c *RouteCtx) error {
 
-	mangaCollection, err := c.App.GetMangaCollection(false)
+	mangaCollection, err := c.Profile().GetMangaCollection(false)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
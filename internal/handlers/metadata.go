@@ -25,7 +25,7 @@ func HandlePopulateTVDBEpisodes(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	media, err := c.App.AnilistPlatform.GetAnime(b.MediaId)
+	media, err := c.Profile().AnilistPlatform.GetAnime(b.MediaId)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -64,7 +64,7 @@ func HandleEmptyTVDBEpisodes(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	media, err := c.App.AnilistPlatform.GetAnime(b.MediaId)
+	media, err := c.Profile().AnilistPlatform.GetAnime(b.MediaId)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -100,7 +100,7 @@ func HandlePopulateFillerData(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
-	animeCollection, err := c.App.GetAnimeCollection(false)
+	animeCollection, err := c.Profile().GetAnimeCollection(false)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -108,7 +108,7 @@ func HandlePopulateFillerData(c *RouteCtx) error {
 	media, found := animeCollection.FindAnime(b.MediaId)
 	if !found {
 		// Fetch media
-		media, err = c.App.AnilistPlatform.GetAnime(b.MediaId)
+		media, err = c.Profile().AnilistPlatform.GetAnime(b.MediaId)
 		if err != nil {
 			return c.RespondWithError(err)
 		}
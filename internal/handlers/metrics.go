@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"seanime/internal/metrics"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metricsMiddleware records the latency of each request.
+// Requests are labeled with the route pattern (e.g. "/api/v1/library/anime-entry/:id") to keep the cardinality low.
+func metricsMiddleware(c *fiber.Ctx) error {
+	// Long-lived websocket connections and the metrics endpoint itself are not recorded
+	if strings.HasPrefix(c.Path(), "/events") || strings.HasPrefix(c.Path(), "/internal/metrics") {
+		return c.Next()
+	}
+
+	start := time.Now()
+	err := c.Next()
+
+	status := c.Response().StatusCode()
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		status = fiberErr.Code
+	} else if err != nil {
+		status = fiber.StatusInternalServerError
+	}
+
+	metrics.HTTPRequestDuration.
+		WithLabelValues(c.Method(), c.Route().Path, strconv.Itoa(status)).
+		Observe(time.Since(start).Seconds())
+
+	return err
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/database/models"
+	"seanime/internal/notifier"
+)
+
+// HandleGetNotificationChannels
+//
+//	@summary returns the notification channels.
+//	@desc The tokens and passwords are redacted.
+//	@route /api/v1/notification-channels [GET]
+//	@returns []models.NotificationChannel
+func HandleGetNotificationChannels(c *RouteCtx) error {
+	channels, err := c.App.Database.GetNotificationChannels()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	ret := make([]*models.NotificationChannel, 0, len(channels))
+	for _, ch := range channels {
+		ret = append(ret, ch.Redacted())
+	}
+
+	return c.RespondWithData(ret)
+}
+
+// HandleGetNotificationKinds
+//
+//	@summary returns the notification kinds a channel can receive.
+//	@route /api/v1/notification-channels/kinds [GET]
+//	@returns []notifier.Notification
+func HandleGetNotificationKinds(c *RouteCtx) error {
+	return c.RespondWithData(notifier.Notifications)
+}
+
+// HandleCreateNotificationChannel
+//
+//	@summary creates a notification channel.
+//	@desc If no kinds are selected, the channel receives all notifications.
+//	@desc The title and message templates use Go template syntax with the fields "Kind", "Message" and "Time".
+//	@route /api/v1/notification-channels [POST]
+//	@returns models.NotificationChannel
+func HandleCreateNotificationChannel(c *RouteCtx) error {
+
+	var b models.NotificationChannel
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+	b.ID = 0
+
+	if err := notifier.ValidateChannel(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	ch, err := c.App.Database.InsertNotificationChannel(&b)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	c.App.InitOrRefreshNotificationChannels()
+
+	return c.RespondWithData(ch.Redacted())
+}
+
+// HandleUpdateNotificationChannel
+//
+//	@summary updates a notification channel.
+//	@desc The stored credentials are kept if the redacted values are sent back.
+//	@route /api/v1/notification-channels/{id} [PATCH]
+//	@param id - int - true - "The ID of the notification channel"
+//	@returns models.NotificationChannel
+func HandleUpdateNotificationChannel(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	prev, err := c.App.Database.GetNotificationChannel(uint(id))
+	if err != nil {
+		return c.RespondWithError(errors.New("notification channel not found"))
+	}
+
+	var b models.NotificationChannel
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+	b.ID = prev.ID
+	b.CreatedAt = prev.CreatedAt
+	b.KeepExistingCredentials(prev)
+
+	if err := notifier.ValidateChannel(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	ch, err := c.App.Database.UpdateNotificationChannel(&b)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	c.App.InitOrRefreshNotificationChannels()
+
+	return c.RespondWithData(ch.Redacted())
+}
+
+// HandleDeleteNotificationChannel
+//
+//	@summary deletes a notification channel.
+//	@route /api/v1/notification-channels/{id} [DELETE]
+//	@param id - int - true - "The ID of the notification channel"
+//	@returns bool
+func HandleDeleteNotificationChannel(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if err := c.App.Database.DeleteNotificationChannel(uint(id)); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	c.App.InitOrRefreshNotificationChannels()
+
+	return c.RespondWithData(true)
+}
+
+// HandleTestNotificationChannel
+//
+//	@summary sends a test notification to a channel.
+//	@desc The request waits for the channel to respond and returns its error, if any. The rate limit of the channel is ignored.
+//	@route /api/v1/notification-channels/{id}/test [POST]
+//	@param id - int - true - "The ID of the notification channel"
+//	@returns bool
+func HandleTestNotificationChannel(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	ch, err := c.App.Database.GetNotificationChannel(uint(id))
+	if err != nil {
+		return c.RespondWithError(errors.New("notification channel not found"))
+	}
+
+	if err := notifier.GlobalNotifier.SendTest(ch); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"time"
+)
+
+type NotificationInbox struct {
+	Notifications []*models.NotificationEntry `json:"notifications"`
+	Total         int64                       `json:"total"`
+	UnreadCount   int64                       `json:"unreadCount"`
+}
+
+// HandleGetNotifications
+//
+//	@summary returns the notifications stored in the inbox.
+//	@desc The notifications are sorted from newest to oldest.
+//	@desc "total" is the number of notifications matching the filter, "unreadCount" is the number of unread notifications in the inbox.
+//	@route /api/v1/notifications/list [POST]
+//	@returns handlers.NotificationInbox
+func HandleGetNotifications(c *RouteCtx) error {
+
+	type body struct {
+		Kind       string `json:"kind"`
+		MediaId    int    `json:"mediaId"`
+		UnreadOnly bool   `json:"unreadOnly"`
+		Limit      int    `json:"limit"`
+		Offset     int    `json:"offset"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	notifications, total, err := c.App.NotificationInbox.List(&db.NotificationFilter{
+		Kind:       b.Kind,
+		MediaId:    b.MediaId,
+		UnreadOnly: b.UnreadOnly,
+		Limit:      b.Limit,
+		Offset:     b.Offset,
+	})
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	unreadCount, err := c.App.NotificationInbox.UnreadCount()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(&NotificationInbox{
+		Notifications: notifications,
+		Total:         total,
+		UnreadCount:   unreadCount,
+	})
+}
+
+// HandleGetUnreadNotificationCount
+//
+//	@summary returns the number of unread notifications.
+//	@route /api/v1/notifications/unread-count [GET]
+//	@returns int
+func HandleGetUnreadNotificationCount(c *RouteCtx) error {
+	count, err := c.App.NotificationInbox.UnreadCount()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(count)
+}
+
+// HandleMarkNotificationsRead
+//
+//	@summary marks the given notifications as read.
+//	@desc The new unread count is sent to the clients.
+//	@route /api/v1/notifications/read [POST]
+//	@returns bool
+func HandleMarkNotificationsRead(c *RouteCtx) error {
+
+	type body struct {
+		Ids []uint `json:"ids"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if err := c.App.NotificationInbox.MarkRead(b.Ids); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
+
+// HandleMarkAllNotificationsRead
+//
+//	@summary marks all notifications as read.
+//	@desc The new unread count is sent to the clients.
+//	@route /api/v1/notifications/read-all [POST]
+//	@returns bool
+func HandleMarkAllNotificationsRead(c *RouteCtx) error {
+	if err := c.App.NotificationInbox.MarkAllRead(); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
+
+// HandlePruneNotifications
+//
+//	@summary deletes the notifications older than the given number of days.
+//	@desc If "readOnly" is true, unread notifications are kept. A value of 0 days deletes all matching notifications.
+//	@desc Returns the number of deleted notifications.
+//	@route /api/v1/notifications/prune [POST]
+//	@returns int
+func HandlePruneNotifications(c *RouteCtx) error {
+
+	type body struct {
+		OlderThanDays int  `json:"olderThanDays"`
+		ReadOnly      bool `json:"readOnly"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if b.OlderThanDays < 0 {
+		b.OlderThanDays = 0
+	}
+
+	count, err := c.App.NotificationInbox.Prune(time.Duration(b.OlderThanDays)*24*time.Hour, b.ReadOnly)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(count)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+// HandleGetPlatformMutations
+//
+//	@summary returns the queued writes to the tracking platform.
+//	@desc Writes that failed are retried with a backoff, writes to the same media are collapsed.
+//	@desc Mutations that are still failing after the maximum number of attempts have the status "failed".
+//	@route /api/v1/platform-mutations [GET]
+//	@returns []models.PlatformMutation
+func HandleGetPlatformMutations(c *RouteCtx) error {
+	mutations, err := c.App.MutationQueue.GetMutations(c.Profile().Profile.ID)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(mutations)
+}
+
+// HandleRetryPlatformMutation
+//
+//	@summary sends a queued write to the tracking platform right away.
+//	@desc The attempts are reset. If it fails again, it is retried with a backoff.
+//	@route /api/v1/platform-mutations/{id}/retry [POST]
+//	@param id - int - true - "The ID of the mutation"
+//	@returns bool
+func HandleRetryPlatformMutation(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	err = c.App.MutationQueue.Retry(c.Profile().Profile.ID, uint(id))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
+
+// HandleDiscardPlatformMutation
+//
+//	@summary deletes a queued write without sending it.
+//	@route /api/v1/platform-mutations/{id} [DELETE]
+//	@param id - int - true - "The ID of the mutation"
+//	@returns bool
+func HandleDiscardPlatformMutation(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	err = c.App.MutationQueue.Discard(c.Profile().Profile.ID, uint(id))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
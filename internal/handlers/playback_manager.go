@@ -21,6 +21,8 @@ func HandlePlaybackPlayVideo(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
+	c.App.UseProfileForPlayback(c.Profile())
+
 	err := c.App.PlaybackManager.StartPlayingUsingMediaPlayer(&playbackmanager.StartPlayingOptions{
 		Payload:   b.Path,
 		UserAgent: c.Fiber.Get("User-Agent"),
@@ -43,6 +45,8 @@ func HandlePlaybackPlayVideo(c *RouteCtx) error {
 //	@returns bool
 func HandlePlaybackPlayRandomVideo(c *RouteCtx) error {
 
+	c.App.UseProfileForPlayback(c.Profile())
+
 	err := c.App.PlaybackManager.StartRandomVideo(&playbackmanager.StartRandomVideoOptions{
 		UserAgent: c.Fiber.Get("User-Agent"),
 		ClientId:  "",
@@ -138,11 +142,13 @@ func HandlePlaybackStartPlaylist(c *RouteCtx) error {
 	}
 
 	// Get playlist
-	playlist, err := db_bridge.GetPlaylist(c.App.Database, b.DbId)
+	playlist, err := db_bridge.GetPlaylist(c.App.Database, c.Profile().Profile.ID, b.DbId)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
+	c.App.UseProfileForPlayback(c.Profile())
+
 	err = c.App.PlaybackManager.StartPlaylist(playlist)
 	if err != nil {
 		return c.RespondWithError(err)
@@ -204,6 +210,8 @@ func HandlePlaybackStartManualTracking(c *RouteCtx) error {
 		return c.RespondWithError(err)
 	}
 
+	c.App.UseProfileForPlayback(c.Profile())
+
 	err := c.App.PlaybackManager.StartManualProgressTracking(&playbackmanager.StartManualProgressTrackingOptions{
 		ClientId:      b.ClientId,
 		MediaId:       b.MediaId,
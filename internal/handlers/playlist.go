@@ -49,7 +49,7 @@ func HandleCreatePlaylist(c *RouteCtx) error {
 	playlist.SetLocalFiles(lfs)
 
 	// Save the playlist
-	if err := db_bridge.SavePlaylist(c.App.Database, playlist); err != nil {
+	if err := db_bridge.SavePlaylist(c.App.Database, c.Profile().Profile.ID, playlist); err != nil {
 		return c.RespondWithError(err)
 	}
 
@@ -63,7 +63,7 @@ func HandleCreatePlaylist(c *RouteCtx) error {
 //	@returns []anime.Playlist
 func HandleGetPlaylists(c *RouteCtx) error {
 
-	playlists, err := db_bridge.GetPlaylists(c.App.Database)
+	playlists, err := db_bridge.GetPlaylists(c.App.Database, c.Profile().Profile.ID)
 	if err != nil {
 		return c.RespondWithError(err)
 	}
@@ -116,7 +116,7 @@ func HandleUpdatePlaylist(c *RouteCtx) error {
 	playlist.SetLocalFiles(lfs)
 
 	// Save the playlist
-	if err := db_bridge.UpdatePlaylist(c.App.Database, playlist); err != nil {
+	if err := db_bridge.UpdatePlaylist(c.App.Database, c.Profile().Profile.ID, playlist); err != nil {
 		return c.RespondWithError(err)
 	}
 
@@ -140,6 +140,11 @@ func HandleDeletePlaylist(c *RouteCtx) error {
 
 	}
 
+	// Make sure the playlist belongs to the profile
+	if _, err := db_bridge.GetPlaylist(c.App.Database, c.Profile().Profile.ID, b.DbId); err != nil {
+		return c.RespondWithError(err)
+	}
+
 	if err := db_bridge.DeletePlaylist(c.App.Database, b.DbId); err != nil {
 		return c.RespondWithError(err)
 	}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"seanime/internal/core"
+	"seanime/internal/database/models"
+	"seanime/internal/profile"
+	"strings"
+	"time"
+)
+
+const profileCookieName = "Seanime-Profile"
+
+// newProfileMiddleware creates a middleware that resolves the profile making the request.
+// The profile token can be sent with the "X-Seanime-Profile" header, the "Seanime-Profile" cookie or the "profile" query parameter.
+// Requests without a profile token use the default profile.
+func newProfileMiddleware(app *core.App) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p, err := app.ProfileManager.Resolve(getProfileToken(c))
+		if err != nil {
+			// Allow the client to list and select profiles
+			path := c.Path()
+			if strings.HasPrefix(path, "/api/v1/profiles") || strings.HasPrefix(path, "/api/v1/server-auth") {
+				return c.Next()
+			}
+			return c.Status(fiber.StatusForbidden).JSON(NewErrorResponse(err))
+		}
+
+		c.Locals("profileSession", app.GetProfileSession(p))
+		return c.Next()
+	}
+}
+
+func getProfileToken(c *fiber.Ctx) string {
+	if token := c.Get("X-Seanime-Profile"); token != "" {
+		return token
+	}
+	if token := c.Cookies(profileCookieName); token != "" {
+		return token
+	}
+	return c.Query("profile")
+}
+
+// Profile returns the session of the profile that made the request.
+func (c *RouteCtx) Profile() *core.ProfileSession {
+	if session, ok := c.Fiber.Locals("profileSession").(*core.ProfileSession); ok && session != nil {
+		return session
+	}
+	p, _ := c.App.ProfileManager.GetProfile(models.DefaultProfileID)
+	return c.App.GetProfileSession(p)
+}
+
+//----------------------------------------------------------------------------------------------------------------------
+
+// HandleGetProfiles
+//
+//	@summary returns all the profiles.
+//	@desc This route is accessible without selecting a profile so that the client can display the profile picker.
+//	@route /api/v1/profiles [GET]
+//	@returns []models.Profile
+func HandleGetProfiles(c *RouteCtx) error {
+	return c.RespondWithData(c.App.ProfileManager.GetProfiles())
+}
+
+// HandleGetCurrentProfile
+//
+//	@summary returns the profile making the request.
+//	@route /api/v1/profiles/current [GET]
+//	@returns models.Profile
+func HandleGetCurrentProfile(c *RouteCtx) error {
+	session, ok := c.Fiber.Locals("profileSession").(*core.ProfileSession)
+	if !ok || session == nil {
+		return c.Fiber.Status(fiber.StatusForbidden).JSON(NewErrorResponse(profile.ErrPinRequired))
+	}
+	return c.RespondWithData(session.Profile)
+}
+
+// HandleCreateProfile
+//
+//	@summary creates a new profile.
+//	@desc The PIN is optional.
+//	@route /api/v1/profiles [POST]
+//	@returns models.Profile
+func HandleCreateProfile(c *RouteCtx) error {
+
+	type body struct {
+		Name string `json:"name"`
+		Pin  string `json:"pin"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	p, err := c.App.ProfileManager.CreateProfile(b.Name, b.Pin)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(p)
+}
+
+// HandleUpdateProfile
+//
+//	@summary updates the current profile.
+//	@desc The name and Discord settings are only updated if they are provided.
+//	@desc The Discord settings are ignored for the default profile, which uses the ones from the settings.
+//	@route /api/v1/profiles [PATCH]
+//	@returns models.Profile
+func HandleUpdateProfile(c *RouteCtx) error {
+
+	type body struct {
+		Name    *string                 `json:"name"`
+		Discord *models.DiscordSettings `json:"discord"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	session, ok := c.Fiber.Locals("profileSession").(*core.ProfileSession)
+	if !ok || session == nil {
+		return c.Fiber.Status(fiber.StatusForbidden).JSON(NewErrorResponse(profile.ErrPinRequired))
+	}
+
+	p, err := c.App.ProfileManager.UpdateProfile(session.Profile.ID, b.Name, b.Discord)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(p)
+}
+
+// HandleSetProfilePin
+//
+//	@summary sets or removes the PIN of the current profile.
+//	@desc An empty PIN removes it. Other clients using the profile will need to select it again.
+//	@desc The new profile token is returned and set as a cookie.
+//	@route /api/v1/profiles/pin [PATCH]
+//	@returns handlers.ProfileSelection
+func HandleSetProfilePin(c *RouteCtx) error {
+
+	type body struct {
+		CurrentPin string `json:"currentPin"`
+		Pin        string `json:"pin"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	session, ok := c.Fiber.Locals("profileSession").(*core.ProfileSession)
+	if !ok || session == nil {
+		return c.Fiber.Status(fiber.StatusForbidden).JSON(NewErrorResponse(profile.ErrPinRequired))
+	}
+
+	p, err := c.App.ProfileManager.SetPin(session.Profile.ID, b.CurrentPin, b.Pin, c.Fiber.IP())
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return respondWithProfileSelection(c, p, profile.GetToken(p))
+}
+
+// HandleDeleteProfile
+//
+//	@summary deletes a profile and its data.
+//	@desc The PIN of the profile is required if it has one.
+//	@desc The default profile cannot be deleted.
+//	@route /api/v1/profiles [DELETE]
+//	@returns []models.Profile
+func HandleDeleteProfile(c *RouteCtx) error {
+
+	type body struct {
+		ID  uint   `json:"id"`
+		Pin string `json:"pin"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if _, err := c.App.ProfileManager.Select(b.ID, b.Pin, c.Fiber.IP()); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if err := c.App.DeleteProfile(b.ID); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(c.App.ProfileManager.GetProfiles())
+}
+
+type ProfileSelection struct {
+	Token   string          `json:"token"`
+	Profile *models.Profile `json:"profile"`
+}
+
+// HandleSelectProfile
+//
+//	@summary selects the profile used by the client.
+//	@desc The PIN is required if the profile has one.
+//	@desc It returns a token that should be sent with each request, the token is also set as a cookie.
+//	@route /api/v1/profiles/select [POST]
+//	@returns handlers.ProfileSelection
+func HandleSelectProfile(c *RouteCtx) error {
+
+	type body struct {
+		ID  uint   `json:"id"`
+		Pin string `json:"pin"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	token, err := c.App.ProfileManager.Select(b.ID, b.Pin, c.Fiber.IP())
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	p, err := c.App.ProfileManager.GetProfile(b.ID)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return respondWithProfileSelection(c, p, token)
+}
+
+func respondWithProfileSelection(c *RouteCtx, p *models.Profile, token string) error {
+	c.Fiber.Cookie(&fiber.Cookie{
+		Name:     profileCookieName,
+		Value:    token,
+		Path:     "/",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+		Expires:  time.Now().AddDate(1, 0, 0),
+	})
+
+	return c.RespondWithData(&ProfileSelection{
+		Token:   token,
+		Profile: p,
+	})
+}
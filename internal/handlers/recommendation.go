@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/recommendation"
+	"strconv"
+	"strings"
+)
+
+// HandleGetRecommendations
+//
+//	@summary returns anime or manga recommendations based on the collection.
+//	@desc Candidates are the AniList recommendations and follow-ups of the completed and high-scored entries, and the planned entries.
+//	@desc They are scored against the genres, tags and studios of these entries. Each recommendation comes with the reasons behind it.
+//	@desc 'type' is either 'anime' (default) or 'manga'. 'format', 'season' and 'seasonYear' filter the results, e.g. 'TV', 'WINTER' and '2024'.
+//	@desc The ranking is cached for a few hours, 'refresh=true' computes it again.
+//	@route /api/v1/recommendations [GET]
+//	@returns []recommendation.Recommendation
+func HandleGetRecommendations(c *RouteCtx) error {
+	opts := &recommendation.GetOptions{
+		CacheKey:     strconv.Itoa(int(c.Profile().Profile.ID)),
+		Refresh:      c.Fiber.QueryBool("refresh"),
+		IncludeAdult: c.App.Settings != nil && c.App.Settings.Anilist != nil && c.App.Settings.Anilist.EnableAdultContent,
+		Limit:        c.Fiber.QueryInt("limit"),
+	}
+
+	if format := c.Fiber.Query("format"); format != "" {
+		opts.Format = lo.ToPtr(anilist.MediaFormat(strings.ToUpper(format)))
+		if !opts.Format.IsValid() {
+			return c.RespondWithError(errors.New("invalid format"))
+		}
+	}
+	if season := c.Fiber.Query("season"); season != "" {
+		opts.Season = lo.ToPtr(anilist.MediaSeason(strings.ToUpper(season)))
+		if !opts.Season.IsValid() {
+			return c.RespondWithError(errors.New("invalid season"))
+		}
+	}
+	if year := c.Fiber.QueryInt("seasonYear"); year > 0 {
+		opts.SeasonYear = &year
+	}
+
+	var err error
+	switch c.Fiber.Query("type", "anime") {
+	case "anime":
+		opts.MediaType = anilist.MediaTypeAnime
+		opts.AnimeCollection, err = c.Profile().GetAnimeCollection(false)
+	case "manga":
+		opts.MediaType = anilist.MediaTypeManga
+		opts.MangaCollection, err = c.Profile().GetMangaCollection(false)
+	default:
+		return c.RespondWithError(errors.New("type should be 'anime' or 'manga'"))
+	}
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	ret, err := c.App.RecommendationEngine.Get(opts)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(ret)
+}
@@ -4,11 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"io"
 	"runtime"
+	"seanime/internal/backup"
 	"seanime/internal/core"
+	"seanime/internal/metrics"
 	"seanime/internal/util"
 	"seanime/internal/util/fiberlogger"
 	util2 "seanime/internal/util/proxies"
@@ -20,9 +24,43 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 
 	fiberApp.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Seanime-Token, X-Seanime-Profile",
 	}))
 
+	// Limit the size of request bodies.
+	// Bodies are streamed so that backup archives can be uploaded, the default limit is enforced here for every other route.
+	fiberApp.Use(func(c *fiber.Ctx) error {
+		contentLength := c.Request().Header.ContentLength()
+
+		if c.Path() == "/api/v1/backups/restore" {
+			if contentLength > backup.MaxUploadSize {
+				return fiber.ErrRequestEntityTooLarge
+			}
+			// Archives are only accepted with a known length
+			if contentLength == -1 {
+				return fiber.ErrLengthRequired
+			}
+			return c.Next()
+		}
+
+		if contentLength > fiber.DefaultBodyLimit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+		// Chunked bodies have no known length, read them up to the limit
+		if contentLength == -1 {
+			body, err := io.ReadAll(io.LimitReader(c.Context().RequestBodyStream(), fiber.DefaultBodyLimit+1))
+			if err != nil {
+				return fiber.ErrBadRequest
+			}
+			if len(body) > fiber.DefaultBodyLimit {
+				return fiber.ErrRequestEntityTooLarge
+			}
+			c.Request().SetBody(body)
+		}
+
+		return c.Next()
+	})
+
 	// Set up a custom logger for fiber.
 	// This is not instantiated in `core.NewFiberApp` because we do not want to log requests for the static file server.
 	fiberLogger := fiberlogger.New(fiberlogger.Config{
@@ -42,6 +80,9 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 	})
 	fiberApp.Use(fiberLogger)
 
+	// Record the latency of each route
+	fiberApp.Use(metricsMiddleware)
+
 	fiberApp.Use(func(c *fiber.Ctx) error {
 		// Check if the client has a UUID cookie
 		cookie := c.Cookies("Seanime-Client-Id")
@@ -73,6 +114,14 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 	api := fiberApp.Group("/api")
 	v1 := api.Group("/v1")
 
+	// Server password protection
+	serverAuthMiddleware := newServerAuthMiddleware(app)
+	v1.Use(serverAuthMiddleware)
+
+	// Resolve the profile making the request
+	profileMiddleware := newProfileMiddleware(app)
+	v1.Use(profileMiddleware)
+
 	//if app.IsOffline() {
 	//	v1.Use(func(c *fiber.Ctx) error {
 	//		uriS := strings.Split(c.Request().URI().String(), "v1")
@@ -107,6 +156,7 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 	//}))
 
 	v1.Get("/internal/docs", makeHandler(app, HandleGetDocs))
+	v1.Get("/internal/openapi.json", makeHandler(app, HandleGetOpenAPISpec))
 
 	// Image Proxy
 	imageProxy := &util2.ImageProxy{}
@@ -126,11 +176,30 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 	v1.Post("/auth/login", makeHandler(app, HandleLogin))
 	v1.Post("/auth/logout", makeHandler(app, HandleLogout))
 
+	// Server Auth
+	v1.Get("/server-auth/status", makeHandler(app, HandleGetServerAuthStatus))
+	v1.Post("/server-auth/login", makeHandler(app, HandleServerAuthLogin))
+	v1.Post("/server-auth/logout", makeHandler(app, HandleServerAuthLogout))
+	v1.Get("/server-auth/devices", makeHandler(app, HandleGetServerAuthDevices))
+	v1.Delete("/server-auth/device", makeHandler(app, HandleRevokeServerAuthDevice))
+	v1.Post("/server-auth/calendar-feed-token", makeHandler(app, HandleCreateServerAuthCalendarFeedToken))
+	v1.Patch("/server-auth/password", makeHandler(app, HandleSetServerPassword))
+
+	// Profiles
+	v1.Get("/profiles", makeHandler(app, HandleGetProfiles))
+	v1.Post("/profiles", makeHandler(app, HandleCreateProfile))
+	v1.Patch("/profiles", makeHandler(app, HandleUpdateProfile))
+	v1.Delete("/profiles", makeHandler(app, HandleDeleteProfile))
+	v1.Get("/profiles/current", makeHandler(app, HandleGetCurrentProfile))
+	v1.Post("/profiles/select", makeHandler(app, HandleSelectProfile))
+	v1.Patch("/profiles/pin", makeHandler(app, HandleSetProfilePin))
+
 	// Settings
 	v1.Get("/settings", makeHandler(app, HandleGetSettings))
 	v1.Patch("/settings", makeHandler(app, HandleSaveSettings))
 	v1.Post("/start", makeHandler(app, HandleGettingStarted))
 	v1.Patch("/settings/auto-downloader", makeHandler(app, HandleSaveAutoDownloaderSettings))
+	v1.Patch("/settings/list-sync", makeHandler(app, HandleSaveListSyncSettings))
 
 	// Auto Downloader
 	v1.Post("/auto-downloader/run", makeHandler(app, HandleRunAutoDownloader))
@@ -189,6 +258,69 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 
 	v1.Post("/mal/logout", makeHandler(app, HandleMALLogout))
 
+	//
+	// Kitsu
+	//
+
+	v1.Post("/kitsu/login", makeHandler(app, HandleKitsuLogin))
+
+	v1.Post("/kitsu/logout", makeHandler(app, HandleKitsuLogout))
+
+	//
+	// List Sync
+	//
+
+	v1.Get("/list-sync/preview", makeHandler(app, HandleGetListSyncPreview))
+
+	v1.Post("/list-sync/sync", makeHandler(app, HandleRunListSync))
+
+	//
+	// List Import
+	//
+
+	v1.Get("/list-import", makeHandler(app, HandleGetListImportStatus))
+
+	v1.Post("/list-import/preview", makeHandler(app, HandleGetListImportPreview))
+
+	v1.Post("/list-import/apply", makeHandler(app, HandleApplyListImport))
+
+	//
+	// List Export
+	//
+
+	v1.Get("/list-export", makeHandler(app, HandleExportList))
+
+	//
+	// Calendar
+	//
+
+	v1.Get("/calendar", makeHandler(app, HandleGetCalendar))
+
+	v1.Get("/calendar/feed.ics", makeHandler(app, HandleGetCalendarFeed))
+
+	//
+	// Recommendations
+	//
+
+	v1.Get("/recommendations", makeHandler(app, HandleGetRecommendations))
+
+	//
+	// Watch Stats
+	//
+
+	v1.Get("/watch-stats/activity", makeHandler(app, HandleGetWatchStatsActivity))
+	v1.Get("/watch-stats/streaks", makeHandler(app, HandleGetWatchStatsStreaks))
+	v1.Get("/watch-stats/top", makeHandler(app, HandleGetWatchStatsTop))
+	v1.Get("/watch-stats/year/:year", makeHandler(app, HandleGetWatchStatsYearSummary))
+
+	//
+	// Platform Mutations
+	//
+
+	v1.Get("/platform-mutations", makeHandler(app, HandleGetPlatformMutations))
+	v1.Post("/platform-mutations/:id/retry", makeHandler(app, HandleRetryPlatformMutation))
+	v1.Delete("/platform-mutations/:id", makeHandler(app, HandleDiscardPlatformMutation))
+
 	//
 	// Library
 	//
@@ -440,16 +572,103 @@ func InitRoutes(app *core.App, fiberApp *fiber.App) {
 	v1.Post("/debrid/stream/start", makeHandler(app, HandleDebridStartStream))
 	v1.Post("/debrid/stream/cancel", makeHandler(app, HandleDebridCancelStream))
 
+	//
+	// Backups
+	//
+
+	v1.Get("/backups", makeHandler(app, HandleGetBackups))
+	v1.Post("/backups", makeHandler(app, HandleCreateBackup))
+	v1.Delete("/backups", makeHandler(app, HandleDeleteBackup))
+	v1.Get("/backups/download/:name", makeHandler(app, HandleDownloadBackup))
+	v1.Post("/backups/restore", makeHandler(app, HandleRestoreBackup))
+	v1.Get("/backups/settings", makeHandler(app, HandleGetBackupSettings))
+	v1.Patch("/backups/settings", makeHandler(app, HandleSaveBackupSettings))
+
+	//
+	// Scheduler
+	//
+
+	v1.Get("/scheduler/jobs", makeHandler(app, HandleGetScheduledJobs))
+	v1.Patch("/scheduler/jobs/:name", makeHandler(app, HandleUpdateScheduledJob))
+	v1.Post("/scheduler/jobs/:name/run", makeHandler(app, HandleRunScheduledJob))
+	v1.Get("/scheduler/jobs/:name/runs", makeHandler(app, HandleGetScheduledJobRuns))
+
+	//
+	// Webhooks
+	//
+
+	v1.Get("/webhooks", makeHandler(app, HandleGetWebhooks))
+	v1.Post("/webhooks", makeHandler(app, HandleCreateWebhook))
+	v1.Get("/webhooks/events", makeHandler(app, HandleGetWebhookEvents))
+	v1.Post("/webhooks/deliveries/:id/redeliver", makeHandler(app, HandleRedeliverWebhook))
+	v1.Patch("/webhooks/:id", makeHandler(app, HandleUpdateWebhook))
+	v1.Delete("/webhooks/:id", makeHandler(app, HandleDeleteWebhook))
+	v1.Post("/webhooks/:id/test", makeHandler(app, HandleTestWebhook))
+	v1.Get("/webhooks/:id/deliveries", makeHandler(app, HandleGetWebhookDeliveries))
+
+	//
+	// Event stream
+	//
+
+	v1.Get("/events/stream", makeHandler(app, HandleGetEventStream))
+	v1.Post("/events/history", makeHandler(app, HandleGetEventHistory))
+
+	//
+	// Notifications
+	//
+
+	v1.Post("/notifications/list", makeHandler(app, HandleGetNotifications))
+	v1.Get("/notifications/unread-count", makeHandler(app, HandleGetUnreadNotificationCount))
+	v1.Post("/notifications/read", makeHandler(app, HandleMarkNotificationsRead))
+	v1.Post("/notifications/read-all", makeHandler(app, HandleMarkAllNotificationsRead))
+	v1.Post("/notifications/prune", makeHandler(app, HandlePruneNotifications))
+
+	//
+	// Notification channels
+	//
+
+	v1.Get("/notification-channels", makeHandler(app, HandleGetNotificationChannels))
+	v1.Post("/notification-channels", makeHandler(app, HandleCreateNotificationChannel))
+	v1.Get("/notification-channels/kinds", makeHandler(app, HandleGetNotificationKinds))
+	v1.Patch("/notification-channels/:id", makeHandler(app, HandleUpdateNotificationChannel))
+	v1.Delete("/notification-channels/:id", makeHandler(app, HandleDeleteNotificationChannel))
+	v1.Post("/notification-channels/:id/test", makeHandler(app, HandleTestNotificationChannel))
+
 	//
 	// Websocket
 	//
 
-	fiberApp.Use("/events", websocketUpgradeMiddleware)
+	fiberApp.Use("/events", serverAuthMiddleware, profileMiddleware, websocketUpgradeMiddleware)
 	// Create a new websocket event handler.
 	// This will be used to send real-time events to the client.
 	// It also attaches the websocket connection to the app instance, so it is available to other handlers.
 	fiberApp.Get("/events", newWebSocketEventHandler(app))
 
+	//
+	// Manga downloads & offline assets
+	//
+
+	// These are registered here instead of core.NewFiberApp so that they are protected by the server password
+	if app.Config.Manga.DownloadDir != "" {
+		app.Logger.Info().Msgf("app: Manga downloads path: %s", app.Config.Manga.DownloadDir)
+		fiberApp.Group("/manga-downloads", serverAuthMiddleware).Static("/", app.Config.Manga.DownloadDir, fiber.Static{
+			Index:    "index.html",
+			Compress: false,
+		})
+	}
+
+	app.Logger.Info().Msgf("app: Offline assets path: %s", app.Config.Offline.AssetDir)
+	fiberApp.Group("/offline-assets", serverAuthMiddleware).Static("/", app.Config.Offline.AssetDir, fiber.Static{
+		Index:    "index.html",
+		Compress: false,
+	})
+
+	//
+	// Metrics
+	//
+
+	fiberApp.Get("/internal/metrics", serverAuthMiddleware, adaptor.HTTPHandler(metrics.Handler()))
+
 }
 
 //----------------------------------------------------------------------------------------------------------------------
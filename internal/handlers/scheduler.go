@@ -0,0 +1,72 @@
+package handlers
+
+// HandleGetScheduledJobs
+//
+//	@summary returns the background jobs.
+//	@desc Each job contains its schedule, its last run and its next run.
+//	@desc The next run is not set if the job is disabled or cannot run in offline mode.
+//	@route /api/v1/scheduler/jobs [GET]
+//	@returns []scheduler.JobStatus
+func HandleGetScheduledJobs(c *RouteCtx) error {
+	return c.RespondWithData(c.App.Scheduler.ListJobs())
+}
+
+// HandleUpdateScheduledJob
+//
+//	@summary updates the schedule of a background job.
+//	@desc The schedule can be a cron expression (e.g. "0 */6 * * *"), a descriptor (e.g. "@daily", "@every 1h") or an interval (e.g. "30m").
+//	@desc An empty schedule resets the job to its default schedule.
+//	@route /api/v1/scheduler/jobs/{name} [PATCH]
+//	@param name - string - true - "The name of the job"
+//	@returns scheduler.JobStatus
+func HandleUpdateScheduledJob(c *RouteCtx) error {
+
+	type body struct {
+		Enabled  bool   `json:"enabled"`
+		Schedule string `json:"schedule"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	status, err := c.App.Scheduler.UpdateJob(c.Fiber.Params("name"), b.Enabled, b.Schedule)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(status)
+}
+
+// HandleRunScheduledJob
+//
+//	@summary runs a background job immediately.
+//	@desc The job runs in the background, even if it is disabled.
+//	@desc The client should re-fetch the jobs to see the result of the run.
+//	@route /api/v1/scheduler/jobs/{name}/run [POST]
+//	@param name - string - true - "The name of the job"
+//	@returns bool
+func HandleRunScheduledJob(c *RouteCtx) error {
+	if err := c.App.Scheduler.Trigger(c.Fiber.Params("name")); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
+
+// HandleGetScheduledJobRuns
+//
+//	@summary returns the latest runs of a background job.
+//	@desc The runs are sorted from newest to oldest.
+//	@route /api/v1/scheduler/jobs/{name}/runs [GET]
+//	@param name - string - true - "The name of the job"
+//	@returns []models.ScheduledJobRun
+func HandleGetScheduledJobRuns(c *RouteCtx) error {
+	runs, err := c.App.Scheduler.GetJobRuns(c.Fiber.Params("name"), 50)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(runs)
+}
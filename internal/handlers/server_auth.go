@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"seanime/internal/core"
+	"seanime/internal/database/models"
+	"seanime/internal/server_auth"
+	"strings"
+	"time"
+)
+
+const serverAuthCookieName = "Seanime-Token"
+
+var ErrUnauthorized = errors.New("unauthorized")
+
+// serverAuthQueryTokenRoutes are the routes that accept the token as a query parameter because their clients cannot set headers,
+// along with the scope that the token must have.
+// Only tokens limited to the calendar feed can be put in its URL since calendar apps store and sync it.
+var serverAuthQueryTokenRoutes = map[string]models.AuthDeviceScope{
+	"/events":                   models.AuthDeviceScopeFull,
+	"/api/v1/events/stream":     models.AuthDeviceScopeFull,
+	"/api/v1/calendar/feed.ics": models.AuthDeviceScopeCalendarFeed,
+}
+
+// serverAuthScopeRoutes are the only routes that the tokens with a limited scope can access.
+var serverAuthScopeRoutes = map[models.AuthDeviceScope]string{
+	models.AuthDeviceScopeCalendarFeed: "/api/v1/calendar/feed.ics",
+}
+
+// newServerAuthMiddleware creates a middleware that rejects requests without a valid device token when a server password is set.
+// The token can be sent with the "Authorization: Bearer <token>" header, the "X-Seanime-Token" header, the "Seanime-Token" cookie
+// or, on the routes in serverAuthQueryTokenRoutes, the "token" query parameter.
+func newServerAuthMiddleware(app *core.App) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !app.ServerAuthManager.IsEnabled() {
+			return c.Next()
+		}
+
+		// Routes that are needed to log in
+		path := strings.TrimSuffix(c.Path(), "/")
+		if path == "/api/v1/server-auth/login" || path == "/api/v1/server-auth/status" {
+			return c.Next()
+		}
+
+		token, fromQuery := getServerAuthToken(c)
+		device, ok := app.ServerAuthManager.Authenticate(token, c.IP())
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(NewErrorResponse(ErrUnauthorized))
+		}
+
+		if device.Scope != models.AuthDeviceScopeFull && serverAuthScopeRoutes[device.Scope] != path {
+			return c.Status(fiber.StatusUnauthorized).JSON(NewErrorResponse(ErrUnauthorized))
+		}
+		if fromQuery && serverAuthQueryTokenRoutes[path] != device.Scope {
+			return c.Status(fiber.StatusUnauthorized).JSON(NewErrorResponse(ErrUnauthorized))
+		}
+
+		c.Locals("authDevice", device)
+		return c.Next()
+	}
+}
+
+func getServerAuthToken(c *fiber.Ctx) (string, bool) {
+	if auth := c.Get(fiber.HeaderAuthorization); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer ")), false
+	}
+	if token := c.Get("X-Seanime-Token"); token != "" {
+		return token, false
+	}
+	if token := c.Cookies(serverAuthCookieName); token != "" {
+		return token, false
+	}
+	if _, ok := serverAuthQueryTokenRoutes[strings.TrimSuffix(c.Path(), "/")]; ok {
+		return c.Query("token"), true
+	}
+	return "", false
+}
+
+func getServerAuthDevice(c *RouteCtx) (*models.AuthDevice, bool) {
+	device, ok := c.Fiber.Locals("authDevice").(*models.AuthDevice)
+	return device, ok && device != nil
+}
+
+//----------------------------------------------------------------------------------------------------------------------
+
+type ServerAuthStatus struct {
+	// Whether a server password is set
+	Enabled bool `json:"enabled"`
+	// Whether the client is authenticated
+	Authenticated  bool                       `json:"authenticated"`
+	PasswordSource server_auth.PasswordSource `json:"passwordSource"`
+	DeviceID       uint                       `json:"deviceId,omitempty"`
+	// Only set when the password is set for the first time, the current device is logged in with this token
+	Token string `json:"token,omitempty"`
+}
+
+// HandleGetServerAuthStatus
+//
+//	@summary returns the server authentication status.
+//	@desc This route is accessible without a token so that the client knows if it needs to log in.
+//	@route /api/v1/server-auth/status [GET]
+//	@returns handlers.ServerAuthStatus
+func HandleGetServerAuthStatus(c *RouteCtx) error {
+	ret := &ServerAuthStatus{
+		Enabled:        c.App.ServerAuthManager.IsEnabled(),
+		PasswordSource: c.App.ServerAuthManager.GetPasswordSource(),
+	}
+
+	if !ret.Enabled {
+		ret.Authenticated = true
+		return c.RespondWithData(ret)
+	}
+
+	token, _ := getServerAuthToken(c.Fiber)
+	if device, ok := c.App.ServerAuthManager.Authenticate(token, c.Fiber.IP()); ok && device.Scope == models.AuthDeviceScopeFull {
+		ret.Authenticated = true
+		ret.DeviceID = device.ID
+	}
+
+	return c.RespondWithData(ret)
+}
+
+type ServerAuthLoginResponse struct {
+	Token  string             `json:"token"`
+	Device *models.AuthDevice `json:"device"`
+}
+
+// HandleServerAuthLogin
+//
+//	@summary logs in a device using the server password.
+//	@desc It returns a token that should be sent with each request.
+//	@desc The token is also set as a cookie so that the web interface does not need to handle it.
+//	@route /api/v1/server-auth/login [POST]
+//	@returns handlers.ServerAuthLoginResponse
+func HandleServerAuthLogin(c *RouteCtx) error {
+
+	type body struct {
+		Password   string `json:"password"`
+		DeviceName string `json:"deviceName"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	device, token, err := c.App.ServerAuthManager.Login(&server_auth.LoginOptions{
+		Password:   b.Password,
+		DeviceName: b.DeviceName,
+		UserAgent:  c.Fiber.Get(fiber.HeaderUserAgent),
+		IP:         c.Fiber.IP(),
+	})
+	if err != nil {
+		if errors.Is(err, server_auth.ErrInvalidPassword) || errors.Is(err, server_auth.ErrTooManyAttempts) {
+			return c.Fiber.Status(fiber.StatusUnauthorized).JSON(NewErrorResponse(err))
+		}
+		return c.RespondWithError(err)
+	}
+
+	setServerAuthCookie(c, token)
+
+	return c.RespondWithData(&ServerAuthLoginResponse{
+		Token:  token,
+		Device: device,
+	})
+}
+
+func setServerAuthCookie(c *RouteCtx, token string) {
+	c.Fiber.Cookie(&fiber.Cookie{
+		Name:     serverAuthCookieName,
+		Value:    token,
+		Path:     "/",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+		Expires:  time.Now().AddDate(1, 0, 0),
+	})
+}
+
+// HandleServerAuthLogout
+//
+//	@summary logs out the current device.
+//	@desc The device's token is revoked and the cookie is cleared.
+//	@route /api/v1/server-auth/logout [POST]
+//	@returns bool
+func HandleServerAuthLogout(c *RouteCtx) error {
+
+	if device, ok := getServerAuthDevice(c); ok {
+		if err := c.App.ServerAuthManager.RevokeDevice(device.ID); err != nil {
+			return c.RespondWithError(err)
+		}
+	}
+
+	c.Fiber.ClearCookie(serverAuthCookieName)
+
+	return c.RespondWithData(true)
+}
+
+// HandleGetServerAuthDevices
+//
+//	@summary returns the devices that are logged in.
+//	@route /api/v1/server-auth/devices [GET]
+//	@returns []models.AuthDevice
+func HandleGetServerAuthDevices(c *RouteCtx) error {
+	devices, err := c.App.ServerAuthManager.GetDevices()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+	return c.RespondWithData(devices)
+}
+
+// HandleRevokeServerAuthDevice
+//
+//	@summary revokes the token of a device.
+//	@desc The device will need to log in again.
+//	@route /api/v1/server-auth/device [DELETE]
+//	@returns []models.AuthDevice
+func HandleRevokeServerAuthDevice(c *RouteCtx) error {
+
+	type body struct {
+		ID uint `json:"id"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if err := c.App.ServerAuthManager.RevokeDevice(b.ID); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	devices, err := c.App.ServerAuthManager.GetDevices()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+	return c.RespondWithData(devices)
+}
+
+type ServerAuthCalendarFeedTokenResponse struct {
+	Token  string             `json:"token"`
+	Device *models.AuthDevice `json:"device"`
+}
+
+// HandleCreateServerAuthCalendarFeedToken
+//
+//	@summary creates a token that can only be used to access the calendar feed.
+//	@desc The token is meant to be added to the feed's URL as the 'token' query parameter, it does not give access to any other route.
+//	@desc It is listed with the devices and can be revoked the same way.
+//	@route /api/v1/server-auth/calendar-feed-token [POST]
+//	@returns handlers.ServerAuthCalendarFeedTokenResponse
+func HandleCreateServerAuthCalendarFeedToken(c *RouteCtx) error {
+
+	type body struct {
+		Name string `json:"name"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	device, token, err := c.App.ServerAuthManager.CreateCalendarFeedToken(b.Name)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(&ServerAuthCalendarFeedTokenResponse{
+		Token:  token,
+		Device: device,
+	})
+}
+
+// HandleSetServerPassword
+//
+//	@summary sets or removes the server password.
+//	@desc An empty password disables password protection.
+//	@desc All other devices are logged out when the password changes.
+//	@desc When the password is set for the first time, the current device is logged in and its token is returned.
+//	@desc This will fail if the password is defined in the config file.
+//	@route /api/v1/server-auth/password [PATCH]
+//	@returns handlers.ServerAuthStatus
+func HandleSetServerPassword(c *RouteCtx) error {
+
+	type body struct {
+		CurrentPassword string `json:"currentPassword"`
+		NewPassword     string `json:"newPassword"`
+		// Name of the current device when the password is set for the first time
+		DeviceName string `json:"deviceName"`
+	}
+
+	var b body
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	wasEnabled := c.App.ServerAuthManager.IsEnabled()
+
+	if err := c.App.ServerAuthManager.SetPassword(b.CurrentPassword, b.NewPassword); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	// Log out all other devices
+	var currentID uint
+	if device, ok := getServerAuthDevice(c); ok {
+		currentID = device.ID
+	}
+	if err := c.App.ServerAuthManager.RevokeAllDevicesExcept(currentID); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	// First password, the client has no token yet.
+	// Log it in so that it is not locked out by the password it just set.
+	if !wasEnabled && b.NewPassword != "" {
+		device, token, err := c.App.ServerAuthManager.Login(&server_auth.LoginOptions{
+			Password:   b.NewPassword,
+			DeviceName: b.DeviceName,
+			UserAgent:  c.Fiber.Get(fiber.HeaderUserAgent),
+			IP:         c.Fiber.IP(),
+		})
+		if err != nil {
+			return c.RespondWithError(err)
+		}
+
+		setServerAuthCookie(c, token)
+
+		return c.RespondWithData(&ServerAuthStatus{
+			Enabled:        true,
+			Authenticated:  true,
+			PasswordSource: c.App.ServerAuthManager.GetPasswordSource(),
+			DeviceID:       device.ID,
+			Token:          token,
+		})
+	}
+
+	return HandleGetServerAuthStatus(c)
+}
@@ -27,7 +27,7 @@ func HandleGetSettings(c *RouteCtx) error {
 		return c.RespondWithError(errors.New(runtime.GOOS))
 	}
 
-	return c.RespondWithData(settings)
+	return c.RespondWithData(settings.Redacted())
 }
 
 // HandleGettingStarted
@@ -64,7 +64,7 @@ func HandleGettingStarted(c *RouteCtx) error {
 	}
 	b.Library.LibraryPath = filepath.ToSlash(b.Library.LibraryPath)
 
-	settings, err := c.App.Database.UpsertSettings(&models.Settings{
+	newSettings := &models.Settings{
 		BaseModel: models.BaseModel{
 			ID:        1,
 			UpdatedAt: time.Now(),
@@ -83,7 +83,12 @@ func HandleGettingStarted(c *RouteCtx) error {
 			DownloadAutomatically: true,
 			EnableEnhancedQueries: true,
 		},
-	})
+	}
+	if prevSettings, err := c.App.Database.GetSettings(); err == nil {
+		newSettings.KeepExistingCredentials(prevSettings)
+	}
+
+	settings, err := c.App.Database.UpsertSettings(newSettings)
 
 	if err != nil {
 		return c.RespondWithError(err)
@@ -119,14 +124,14 @@ func HandleGettingStarted(c *RouteCtx) error {
 			if found {
 				prev.Enabled = true
 				prev.Provider = b.DebridProvider
-				prev.ApiKey = b.DebridApiKey
+				prev.ApiKey = models.KeepExisting(b.DebridApiKey, prev.ApiKey)
 				//prev.IncludeDebridStreamInLibrary = true
 				_, _ = c.App.Database.UpsertDebridSettings(prev)
 			}
 		}()
 	}
 
-	c.App.WSEventManager.SendEvent("settings", settings)
+	c.App.WSEventManager.SendEvent("settings", settings.Redacted())
 
 	status := NewStatus(c)
 
@@ -200,13 +205,26 @@ func HandleSaveSettings(c *RouteCtx) error {
 	if err == nil && prevSettings.AutoDownloader != nil {
 		autoDownloaderSettings = *prevSettings.AutoDownloader
 	}
+	listSyncSettings := models.ListSyncSettings{}
+	if err == nil && prevSettings.ListSync != nil {
+		listSyncSettings = *prevSettings.ListSync
+	}
+	// Keep the tracking platform if the client didn't send it
+	if b.Library.TrackingPlatform == "" && err == nil && prevSettings.Library != nil {
+		b.Library.TrackingPlatform = prevSettings.Library.TrackingPlatform
+	}
+	switch b.Library.TrackingPlatform {
+	case "", models.TrackingPlatformAnilist, models.TrackingPlatformMal, models.TrackingPlatformKitsu:
+	default:
+		return c.RespondWithError(errors.New("invalid tracking platform"))
+	}
 	// Disable auto-downloader if the torrent provider is set to none
 	if b.Library.TorrentProvider == torrent.ProviderNone && autoDownloaderSettings.Enabled {
 		c.App.Logger.Debug().Msg("app: Disabling auto-downloader because the torrent provider is set to none")
 		autoDownloaderSettings.Enabled = false
 	}
 
-	settings, err := c.App.Database.UpsertSettings(&models.Settings{
+	newSettings := &models.Settings{
 		BaseModel: models.BaseModel{
 			ID:        1,
 			UpdatedAt: time.Now(),
@@ -218,14 +236,21 @@ func HandleSaveSettings(c *RouteCtx) error {
 		Manga:          &b.Manga,
 		Discord:        &b.Discord,
 		Notifications:  &b.Notifications,
+		ListSync:       &listSyncSettings,
 		AutoDownloader: &autoDownloaderSettings,
-	})
+	}
+	// Keep the stored credentials if the client sent back the redacted placeholders
+	if prevSettings != nil {
+		newSettings.KeepExistingCredentials(prevSettings)
+	}
+
+	settings, err := c.App.Database.UpsertSettings(newSettings)
 
 	if err != nil {
 		return c.RespondWithError(err)
 	}
 
-	c.App.WSEventManager.SendEvent("settings", settings)
+	c.App.WSEventManager.SendEvent("settings", settings.Redacted())
 
 	status := NewStatus(c)
 
@@ -21,6 +21,7 @@ type Status struct {
 	ClientPlatform  string           `json:"clientPlatform"`
 	ClientUserAgent string           `json:"clientUserAgent"`
 	User            *anime.User      `json:"user"`
+	Profile         *models.Profile  `json:"profile"`
 	Settings        *models.Settings `json:"settings"`
 	Mal             *models.Mal      `json:"mal"`
 	Version         string           `json:"version"`
@@ -45,7 +46,9 @@ func NewStatus(c *RouteCtx) *Status {
 	var theme *models.Theme
 	//var mal *models.Mal
 
-	if dbAcc, _ = c.App.Database.GetAccount(); dbAcc != nil {
+	session := c.Profile()
+
+	if dbAcc, _ = c.App.Database.GetAccountByID(session.Profile.ID); dbAcc != nil {
 		user, _ = anime.NewUser(dbAcc)
 		if user != nil {
 			user.Token = "HIDDEN"
@@ -72,14 +75,15 @@ func NewStatus(c *RouteCtx) *Status {
 		ClientPlatform:  clientInfo.Platform,
 		ClientUserAgent: c.Fiber.Get("User-Agent"),
 		User:            user,
-		Settings:        settings,
+		Profile:         session.Profile,
+		Settings:        settings.Redacted(),
 		//Mal:                   mal,
 		Version:               c.App.Version,
 		ThemeSettings:         theme,
 		IsOffline:             c.App.Config.Server.Offline,
 		MediastreamSettings:   c.App.SecondarySettings.Mediastream,
 		TorrentstreamSettings: c.App.SecondarySettings.Torrentstream,
-		DebridSettings:        c.App.SecondarySettings.Debrid,
+		DebridSettings:        c.App.SecondarySettings.Debrid.Redacted(),
 		AnilistClientID:       c.App.Config.Anilist.ClientID,
 		Updating:              false,
 		//FeatureFlags:          c.App.FeatureFlags,
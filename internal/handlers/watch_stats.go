@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"seanime/internal/watchstats"
+	"time"
+)
+
+// HandleGetWatchStatsActivity
+//
+//	@summary returns the time watched per day or week.
+//	@desc 'interval' is either 'day' (default) or 'week', weeks start on Monday.
+//	@desc 'from' and 'to' (YYYY-MM-DD) are the first and last days included, the last 30 days or 12 weeks are returned by default.
+//	@desc 'timezone' is an IANA time zone name used to group the sessions by day, the server time zone is used by default.
+//	@route /api/v1/watch-stats/activity [GET]
+//	@returns watchstats.Activity
+func HandleGetWatchStatsActivity(c *RouteCtx) error {
+	loc, err := getTimezoneQuery(c)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	interval := watchstats.Interval(c.Fiber.Query("interval", string(watchstats.IntervalDay)))
+
+	now := time.Now().In(loc)
+	to, err := getDateQuery(c, "to", time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc), loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+	defaultFrom := to.AddDate(0, 0, -29)
+	if interval == watchstats.IntervalWeek {
+		defaultFrom = to.AddDate(0, 0, -7*11)
+	}
+	from, err := getDateQuery(c, "from", defaultFrom, loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	activity, err := c.App.WatchStats.GetActivity(&watchstats.ActivityOptions{
+		ProfileID: c.Profile().Profile.ID,
+		Interval:  interval,
+		From:      from,
+		To:        to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(activity)
+}
+
+// HandleGetWatchStatsStreaks
+//
+//	@summary returns the current and longest watching streaks.
+//	@desc A streak is a run of consecutive days with at least one playback session.
+//	@desc 'timezone' is an IANA time zone name, the server time zone is used by default.
+//	@route /api/v1/watch-stats/streaks [GET]
+//	@returns watchstats.Streaks
+func HandleGetWatchStatsStreaks(c *RouteCtx) error {
+	loc, err := getTimezoneQuery(c)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	streaks, err := c.App.WatchStats.GetStreaks(c.Profile().Profile.ID, loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(streaks)
+}
+
+// HandleGetWatchStatsTop
+//
+//	@summary returns the most-watched anime, genres and studios.
+//	@desc 'from' and 'to' (YYYY-MM-DD) are the first and last days included, all the sessions are used by default.
+//	@desc 'limit' is the number of items in each list, 10 by default.
+//	@route /api/v1/watch-stats/top [GET]
+//	@returns watchstats.Top
+func HandleGetWatchStatsTop(c *RouteCtx) error {
+	loc, err := getTimezoneQuery(c)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	from, err := getDateQuery(c, "from", time.Time{}, loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+	to, err := getDateQuery(c, "to", time.Time{}, loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	top, err := c.App.WatchStats.GetTop(&watchstats.TopOptions{
+		ProfileID: c.Profile().Profile.ID,
+		From:      from,
+		To:        to,
+		Limit:     c.Fiber.QueryInt("limit"),
+	})
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(top)
+}
+
+// HandleGetWatchStatsYearSummary
+//
+//	@summary returns the year-in-review report.
+//	@desc The report contains the time watched per month and per source, the busiest day, the longest streak and the most-watched anime, genres and studios.
+//	@desc 'timezone' is an IANA time zone name, the server time zone is used by default.
+//	@route /api/v1/watch-stats/year/{year} [GET]
+//	@param year - int - true - "The year, e.g. 2024"
+//	@returns watchstats.YearSummary
+func HandleGetWatchStatsYearSummary(c *RouteCtx) error {
+	year, err := c.Fiber.ParamsInt("year")
+	if err != nil || year < 1970 || year > 9999 {
+		return c.RespondWithError(errors.New("invalid year"))
+	}
+
+	loc, err := getTimezoneQuery(c)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	summary, err := c.App.WatchStats.GetYearSummary(c.Profile().Profile.ID, year, loc)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(summary)
+}
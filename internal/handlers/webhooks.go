@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"seanime/internal/database/models"
+	"seanime/internal/webhook"
+)
+
+// HandleGetWebhooks
+//
+//	@summary returns the webhooks.
+//	@desc The secrets are redacted.
+//	@route /api/v1/webhooks [GET]
+//	@returns []models.Webhook
+func HandleGetWebhooks(c *RouteCtx) error {
+	webhooks, err := c.App.WebhookManager.GetWebhooks()
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	ret := make([]*models.Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		ret = append(ret, w.Redacted())
+	}
+
+	return c.RespondWithData(ret)
+}
+
+// HandleGetWebhookEvents
+//
+//	@summary returns the events a webhook can subscribe to.
+//	@route /api/v1/webhooks/events [GET]
+//	@returns []webhook.Event
+func HandleGetWebhookEvents(c *RouteCtx) error {
+	return c.RespondWithData(webhook.Events)
+}
+
+// HandleCreateWebhook
+//
+//	@summary creates a webhook.
+//	@desc If no events are selected, the webhook receives all events.
+//	@desc The payloads are signed with HMAC-SHA256 using the secret, the signature is sent in the "X-Seanime-Signature" header.
+//	@route /api/v1/webhooks [POST]
+//	@returns models.Webhook
+func HandleCreateWebhook(c *RouteCtx) error {
+
+	var b models.Webhook
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	w, err := c.App.WebhookManager.CreateWebhook(&b)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(w.Redacted())
+}
+
+// HandleUpdateWebhook
+//
+//	@summary updates a webhook.
+//	@desc The stored secret is kept if the redacted secret is sent back.
+//	@route /api/v1/webhooks/{id} [PATCH]
+//	@param id - int - true - "The ID of the webhook"
+//	@returns models.Webhook
+func HandleUpdateWebhook(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	var b models.Webhook
+	if err := c.Fiber.BodyParser(&b); err != nil {
+		return c.RespondWithError(err)
+	}
+	b.ID = uint(id)
+
+	w, err := c.App.WebhookManager.UpdateWebhook(&b)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(w.Redacted())
+}
+
+// HandleDeleteWebhook
+//
+//	@summary deletes a webhook and its delivery log.
+//	@route /api/v1/webhooks/{id} [DELETE]
+//	@param id - int - true - "The ID of the webhook"
+//	@returns bool
+func HandleDeleteWebhook(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	if err := c.App.WebhookManager.DeleteWebhook(uint(id)); err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(true)
+}
+
+// HandleTestWebhook
+//
+//	@summary sends a ping event to a webhook.
+//	@desc The request waits for the response of the webhook. Failed test deliveries are not retried.
+//	@route /api/v1/webhooks/{id}/test [POST]
+//	@param id - int - true - "The ID of the webhook"
+//	@returns models.WebhookDelivery
+func HandleTestWebhook(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	delivery, err := c.App.WebhookManager.Test(uint(id))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(delivery)
+}
+
+// HandleGetWebhookDeliveries
+//
+//	@summary returns the delivery log of a webhook.
+//	@desc The deliveries are sorted from newest to oldest.
+//	@route /api/v1/webhooks/{id}/deliveries [GET]
+//	@param id - int - true - "The ID of the webhook"
+//	@returns []models.WebhookDelivery
+func HandleGetWebhookDeliveries(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	deliveries, err := c.App.WebhookManager.GetDeliveries(uint(id), 100)
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(deliveries)
+}
+
+// HandleRedeliverWebhook
+//
+//	@summary sends the payload of a previous delivery again.
+//	@desc A new delivery is added to the log. The client should re-fetch the deliveries to see the result.
+//	@route /api/v1/webhooks/deliveries/{id}/redeliver [POST]
+//	@param id - int - true - "The ID of the delivery"
+//	@returns models.WebhookDelivery
+func HandleRedeliverWebhook(c *RouteCtx) error {
+	id, err := c.Fiber.ParamsInt("id")
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	delivery, err := c.App.WebhookManager.Redeliver(uint(id))
+	if err != nil {
+		return c.RespondWithError(err)
+	}
+
+	return c.RespondWithData(delivery)
+}
@@ -6,7 +6,8 @@ import (
 	"seanime/internal/core"
 )
 
-// newWebSocketEventHandler creates a new websocket handler for real-time event communication
+// newWebSocketEventHandler creates a new websocket handler for real-time event communication.
+// The clients can send commands over the connection, see wsCommands.
 func newWebSocketEventHandler(app *core.App) fiber.Handler {
 	return websocket.New(func(c *websocket.Conn) {
 
@@ -16,8 +17,18 @@ func newWebSocketEventHandler(app *core.App) fiber.Handler {
 		id := c.Locals("id").(string)
 
 		app.WSEventManager.AddConn(id, c)
+		session, ok := c.Locals("profileSession").(*core.ProfileSession)
+		if ok && session != nil {
+			app.WSEventManager.SetConnProfileID(id, session.Profile.ID)
+		}
 		app.Logger.Debug().Str("id", id).Msg("ws: Client connected")
 
+		commandCtx := &wsCommandCtx{
+			App:      app,
+			ClientId: id,
+			Session:  session,
+		}
+
 		var (
 			_   int
 			msg []byte
@@ -34,12 +45,10 @@ func newWebSocketEventHandler(app *core.App) fiber.Handler {
 				}
 				break
 			}
-			app.Logger.Debug().Msgf("ws: message received: %+v", msg)
+			app.Logger.Trace().Str("id", id).Msgf("ws: Message received: %s", msg)
 
-			if err = c.WriteJSON(msg); err != nil {
-				app.Logger.Err(err).Msg("ws: Failed to send message")
-				break
-			}
+			// Messages are commands, the response is sent as a "command-response" event
+			handleWebSocketCommand(commandCtx, msg)
 		}
 	})
 }
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"seanime/internal/core"
+	"seanime/internal/events"
+	"seanime/internal/library/playbackmanager"
+	"seanime/internal/util"
+)
+
+type (
+	// wsCommandCtx is passed to the websocket command handlers.
+	wsCommandCtx struct {
+		App      *core.App
+		ClientId string
+		// Profile session of the client, nil if profiles are not used
+		Session *core.ProfileSession
+	}
+
+	wsCommandHandler func(c *wsCommandCtx, params json.RawMessage) (interface{}, error)
+
+	wsInvalidParamsError struct {
+		err error
+	}
+)
+
+func (e *wsInvalidParamsError) Error() string {
+	return fmt.Sprintf("invalid params: %v", e.err)
+}
+
+// parseParams decodes the params of a command into v.
+func parseParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return &wsInvalidParamsError{err: errors.New("missing params")}
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &wsInvalidParamsError{err: err}
+	}
+	return nil
+}
+
+// wsCommands are the methods the clients can call over the websocket.
+var wsCommands = map[string]wsCommandHandler{
+	"ping": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		return "pong", nil
+	},
+
+	//
+	// Subscriptions
+	//
+
+	"events.subscribe": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Topics []string `json:"topics"`
+		}
+		if err := parseParams(params, &p); err != nil {
+			return nil, err
+		}
+		return c.App.WSEventManager.Subscribe(c.ClientId, p.Topics), nil
+	},
+	"events.unsubscribe": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Topics []string `json:"topics"`
+		}
+		if err := parseParams(params, &p); err != nil {
+			return nil, err
+		}
+		return c.App.WSEventManager.Unsubscribe(c.ClientId, p.Topics), nil
+	},
+	"events.subscriptions": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		return c.App.WSEventManager.GetSubscriptions(c.ClientId), nil
+	},
+
+	//
+	// Playback
+	//
+
+	"playback.play": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Path string `json:"path"`
+		}
+		if err := parseParams(params, &p); err != nil {
+			return nil, err
+		}
+		if c.Session != nil {
+			c.App.UseProfileForPlayback(c.Session)
+		}
+		err := c.App.PlaybackManager.StartPlayingUsingMediaPlayer(&playbackmanager.StartPlayingOptions{
+			Payload:  p.Path,
+			ClientId: c.ClientId,
+		})
+		return true, err
+	},
+	"playback.playRandom": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		if c.Session != nil {
+			c.App.UseProfileForPlayback(c.Session)
+		}
+		err := c.App.PlaybackManager.StartRandomVideo(&playbackmanager.StartRandomVideoOptions{
+			ClientId: c.ClientId,
+		})
+		return true, err
+	},
+	"playback.nextEpisode": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		return true, c.App.PlaybackManager.PlayNextEpisode()
+	},
+	"playback.playlistNext": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		return true, c.App.PlaybackManager.RequestNextPlaylistFile()
+	},
+	"playback.cancelPlaylist": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		return true, c.App.PlaybackManager.CancelCurrentPlaylist()
+	},
+	"playback.syncProgress": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		if err := c.App.PlaybackManager.SyncCurrentProgress(); err != nil {
+			return nil, err
+		}
+		mId, _ := c.App.PlaybackManager.GetCurrentMediaID()
+		return mId, nil
+	},
+	"playback.cancelManualTracking": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		c.App.PlaybackManager.CancelManualProgressTracking()
+		return true, nil
+	},
+	"playback.status": func(c *wsCommandCtx, params json.RawMessage) (interface{}, error) {
+		if c.App.MediaPlayerRepository == nil {
+			return nil, errors.New("media player is not initialized")
+		}
+		return c.App.MediaPlayerRepository.GetStatus(), nil
+	},
+}
+
+// handleWebSocketCommand runs the command sent by the client and sends the response.
+// Commands are run in goroutines so that long-running commands do not block the connection.
+func handleWebSocketCommand(c *wsCommandCtx, msg []byte) {
+	var cmd events.WSCommand
+	if err := json.Unmarshal(msg, &cmd); err != nil || cmd.Method == "" {
+		c.App.WSEventManager.SendResponse(c.ClientId, &events.WSCommandResponse{
+			ID:    cmd.ID,
+			Error: &events.WSCommandError{Code: events.CommandErrorInvalidRequest, Message: "invalid command"},
+		})
+		return
+	}
+
+	handler, ok := wsCommands[cmd.Method]
+	if !ok {
+		c.App.WSEventManager.SendResponse(c.ClientId, &events.WSCommandResponse{
+			ID:    cmd.ID,
+			Error: &events.WSCommandError{Code: events.CommandErrorMethodNotFound, Message: fmt.Sprintf("unknown method %q", cmd.Method)},
+		})
+		return
+	}
+
+	go func() {
+		defer util.HandlePanicInModuleThen("handlers/handleWebSocketCommand", func() {
+			c.App.WSEventManager.SendResponse(c.ClientId, &events.WSCommandResponse{
+				ID:    cmd.ID,
+				Error: &events.WSCommandError{Code: events.CommandErrorFailed, Message: "command panicked"},
+			})
+		})
+
+		result, err := handler(c, cmd.Params)
+		if err != nil {
+			code := events.CommandErrorFailed
+			var paramsErr *wsInvalidParamsError
+			if errors.As(err, &paramsErr) {
+				code = events.CommandErrorInvalidParams
+			}
+			c.App.WSEventManager.SendResponse(c.ClientId, &events.WSCommandResponse{
+				ID:    cmd.ID,
+				Error: &events.WSCommandError{Code: code, Message: err.Error()},
+			})
+			return
+		}
+
+		c.App.WSEventManager.SendResponse(c.ClientId, &events.WSCommandResponse{
+			ID:     cmd.ID,
+			Result: result,
+		})
+	}()
+}
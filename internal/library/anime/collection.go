@@ -24,14 +24,15 @@ type (
 	//  - IgnoredLocalFiles: a list of ignored local files. (DEVNOTE: Unused for now)
 	//  - UnknownGroups: a list of UnknownGroup instances. Group of files whose media is not in the user's AniList "Resolve unknown media" feature.
 	LibraryCollection struct {
-		ContinueWatchingList []*Episode               `json:"continueWatchingList"`
-		Lists                []*LibraryCollectionList `json:"lists"`
-		UnmatchedLocalFiles  []*LocalFile             `json:"unmatchedLocalFiles"`
-		UnmatchedGroups      []*UnmatchedGroup        `json:"unmatchedGroups"`
-		IgnoredLocalFiles    []*LocalFile             `json:"ignoredLocalFiles"`
-		UnknownGroups        []*UnknownGroup          `json:"unknownGroups"`
-		Stats                *LibraryCollectionStats  `json:"stats"`
-		Stream               *StreamCollection        `json:"stream,omitempty"` // Hydrated by the route handler
+		ContinueWatchingList []*Episode                     `json:"continueWatchingList"`
+		Lists                []*LibraryCollectionList       `json:"lists"`
+		CustomLists          []*LibraryCollectionCustomList `json:"customLists"` // AniList custom lists, their entries are also in Lists
+		UnmatchedLocalFiles  []*LocalFile                   `json:"unmatchedLocalFiles"`
+		UnmatchedGroups      []*UnmatchedGroup              `json:"unmatchedGroups"`
+		IgnoredLocalFiles    []*LocalFile                   `json:"ignoredLocalFiles"`
+		UnknownGroups        []*UnknownGroup                `json:"unknownGroups"`
+		Stats                *LibraryCollectionStats        `json:"stats"`
+		Stream               *StreamCollection              `json:"stream,omitempty"` // Hydrated by the route handler
 	}
 
 	StreamCollection struct {
@@ -57,6 +58,12 @@ type (
 		Entries []*LibraryCollectionEntry `json:"entries"`
 	}
 
+	// LibraryCollectionCustomList holds the entries of an AniList custom list, e.g. "Rewatch with friends".
+	LibraryCollectionCustomList struct {
+		Name    string                    `json:"name"`
+		Entries []*LibraryCollectionEntry `json:"entries"`
+	}
+
 	// LibraryCollectionEntry holds the data for a single entry in a LibraryCollectionList.
 	// It is a slimmed down version of Entry. It holds the media, media id, library data, and list data.
 	LibraryCollectionEntry struct {
@@ -83,10 +90,12 @@ type (
 type (
 	// NewLibraryCollectionOptions is a struct that holds the data needed for creating a new LibraryCollection.
 	NewLibraryCollectionOptions struct {
-		AnimeCollection  *anilist.AnimeCollection
-		LocalFiles       []*LocalFile
-		Platform         platform.Platform
-		MetadataProvider metadata.Provider
+		AnimeCollection *anilist.AnimeCollection
+		// RawAnimeCollection includes the custom lists, they are left out if it is nil
+		RawAnimeCollection *anilist.AnimeCollection
+		LocalFiles         []*LocalFile
+		Platform           platform.Platform
+		MetadataProvider   metadata.Provider
 	}
 )
 
@@ -106,6 +115,8 @@ func NewLibraryCollection(opts *NewLibraryCollectionOptions) (lc *LibraryCollect
 		aniLists,
 	)
 
+	lc.hydrateCustomLists(opts.RawAnimeCollection)
+
 	lc.hydrateStats(opts.LocalFiles)
 
 	// Add Continue Watching list
@@ -277,6 +288,39 @@ func (lc *LibraryCollection) hydrateCollectionLists(
 
 //----------------------------------------------------------------------------------------------------------------------
 
+// hydrateCustomLists groups the entries of the collection lists by custom list.
+func (lc *LibraryCollection) hydrateCustomLists(rawAnimeCollection *anilist.AnimeCollection) {
+	lc.CustomLists = make([]*LibraryCollectionCustomList, 0)
+
+	entries := make(map[int]*LibraryCollectionEntry)
+	for _, list := range lc.Lists {
+		for _, entry := range list.Entries {
+			entries[entry.MediaId] = entry
+		}
+	}
+
+	for _, list := range rawAnimeCollection.GetMediaListCollection().GetLists() {
+		if !list.IsCustom() || list.GetName() == nil {
+			continue
+		}
+		customList := &LibraryCollectionCustomList{
+			Name:    *list.GetName(),
+			Entries: make([]*LibraryCollectionEntry, 0),
+		}
+		for _, e := range list.GetEntries() {
+			if entry, found := entries[e.GetMedia().GetID()]; found {
+				customList.Entries = append(customList.Entries, entry)
+			}
+		}
+		sort.Slice(customList.Entries, func(i, j int) bool {
+			return customList.Entries[i].Media.GetTitleSafe() < customList.Entries[j].Media.GetTitleSafe()
+		})
+		lc.CustomLists = append(lc.CustomLists, customList)
+	}
+}
+
+//----------------------------------------------------------------------------------------------------------------------
+
 func (lc *LibraryCollection) hydrateStats(lfs []*LocalFile) {
 	stats := &LibraryCollectionStats{
 		TotalFiles:    len(lfs),
@@ -91,3 +91,71 @@ func TestNewLibraryCollection(t *testing.T) {
 	}
 
 }
+
+func TestLibraryCollection_HydrateCustomLists(t *testing.T) {
+	entry := func(id int, title string) *LibraryCollectionEntry {
+		return &LibraryCollectionEntry{
+			MediaId: id,
+			Media:   &anilist.BaseAnime{ID: id, Title: &anilist.BaseAnime_Title{English: lo.ToPtr(title)}},
+		}
+	}
+	customList := func(name string, ids ...int) *anilist.AnimeCollection_MediaListCollection_Lists {
+		return &anilist.AnimeCollection_MediaListCollection_Lists{
+			Name:         lo.ToPtr(name),
+			IsCustomList: lo.ToPtr(true),
+			Entries: lo.Map(ids, func(id int, _ int) *anilist.AnimeListEntry {
+				return &anilist.AnimeListEntry{Media: &anilist.BaseAnime{ID: id}}
+			}),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		lists    []*anilist.AnimeCollection_MediaListCollection_Lists
+		expected map[string][]int
+	}{
+		{
+			name:     "no custom lists",
+			lists:    []*anilist.AnimeCollection_MediaListCollection_Lists{{Status: lo.ToPtr(anilist.MediaListStatusCurrent)}},
+			expected: map[string][]int{},
+		},
+		{
+			name:     "grouped and sorted by title",
+			lists:    []*anilist.AnimeCollection_MediaListCollection_Lists{customList("Favorites", 1, 2, 3), customList("Rewatch", 2)},
+			expected: map[string][]int{"Favorites": {2, 3, 1}, "Rewatch": {2}},
+		},
+		{
+			name:     "entries not in the library are left out",
+			lists:    []*anilist.AnimeCollection_MediaListCollection_Lists{customList("Favorites", 1, 4), customList("Empty", 5)},
+			expected: map[string][]int{"Favorites": {1}, "Empty": {}},
+		},
+		{
+			name:     "lists without a name are left out",
+			lists:    []*anilist.AnimeCollection_MediaListCollection_Lists{{IsCustomList: lo.ToPtr(true)}},
+			expected: map[string][]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &LibraryCollection{
+				Lists: []*LibraryCollectionList{
+					{Status: anilist.MediaListStatusCurrent, Entries: []*LibraryCollectionEntry{entry(1, "Frieren"), entry(2, "Bocchi")}},
+					{Status: anilist.MediaListStatusPlanning, Entries: []*LibraryCollectionEntry{entry(3, "Dandadan")}},
+				},
+			}
+
+			lc.hydrateCustomLists(&anilist.AnimeCollection{
+				MediaListCollection: &anilist.AnimeCollection_MediaListCollection{Lists: tt.lists},
+			})
+
+			ret := make(map[string][]int)
+			for _, list := range lc.CustomLists {
+				ret[list.Name] = lo.Map(list.Entries, func(e *LibraryCollectionEntry, _ int) int {
+					return e.MediaId
+				})
+			}
+			assert.Equal(t, tt.expected, ret)
+		})
+	}
+}
@@ -18,11 +18,13 @@ import (
 	"seanime/internal/debrid/debrid"
 	"seanime/internal/events"
 	"seanime/internal/library/anime"
+	metrics2 "seanime/internal/metrics"
 	"seanime/internal/notifier"
 	"seanime/internal/torrent_clients/torrent_client"
 	"seanime/internal/torrents/torrent"
 	"seanime/internal/util"
 	"seanime/internal/util/comparison"
+	"seanime/internal/webhook"
 	"sort"
 	"strings"
 	"sync"
@@ -169,6 +171,17 @@ func (ad *AutoDownloader) Run() {
 	}()
 }
 
+// RunNow checks for new episodes and returns once it is done.
+// Unlike Run, it does not go through the auto downloader loop.
+func (ad *AutoDownloader) RunNow() {
+	defer util.HandlePanicInModuleThen("autodownloader/RunNow", func() {})
+
+	if ad == nil {
+		return
+	}
+	ad.checkForNewEpisodes()
+}
+
 // CleanUpDownloadedItems will clean up downloaded items from the database.
 // This should be run after a scan is completed.
 func (ad *AutoDownloader) CleanUpDownloadedItems() {
@@ -242,6 +255,8 @@ func (ad *AutoDownloader) checkForNewEpisodes() {
 	}
 	ad.mu.Unlock()
 
+	metrics2.AutoDownloaderRuns.Inc()
+
 	torrents := make([]*NormalizedTorrent, 0)
 
 	// Get rules from the database
@@ -329,6 +344,8 @@ func (ad *AutoDownloader) checkForNewEpisodes() {
 				}
 			}
 
+			metrics2.AutoDownloaderMatches.Add(float64(len(torrentsToDownload)))
+
 			// Download the torrent if there's only one
 			if len(torrentsToDownload) == 1 {
 				t := torrentsToDownload[0]
@@ -390,12 +407,12 @@ func (ad *AutoDownloader) checkForNewEpisodes() {
 
 	if downloaded > 0 {
 		if ad.settings.DownloadAutomatically {
-			notifier.GlobalNotifier.Notify(
+			notifier.GlobalNotifier.Push(
 				notifier.AutoDownloader,
 				fmt.Sprintf("%d %s %s been downloaded.", downloaded, util.Pluralize(downloaded, "episode", "episodes"), util.Pluralize(downloaded, "has", "have")),
 			)
 		} else {
-			notifier.GlobalNotifier.Notify(
+			notifier.GlobalNotifier.Push(
 				notifier.AutoDownloader,
 				fmt.Sprintf("%d %s %s been added to the queue.", downloaded, util.Pluralize(downloaded, "episode", "episodes"), util.Pluralize(downloaded, "has", "have")),
 			)
@@ -541,6 +558,7 @@ func (ad *AutoDownloader) downloadTorrent(t *NormalizedTorrent, rule *anime.Auto
 	}
 
 	ad.logger.Info().Str("name", t.Name).Msg("autodownloader: Added torrent")
+	metrics2.AutoDownloaderDownloads.Inc()
 	ad.wsEventManager.SendEvent(events.AutoDownloaderItemAdded, t.Name)
 
 	// Add the torrent to the database
@@ -556,6 +574,29 @@ func (ad *AutoDownloader) downloadTorrent(t *NormalizedTorrent, rule *anime.Auto
 	}
 	_ = ad.database.InsertAutoDownloaderItem(item)
 
+	webhook.GlobalManager.Dispatch(webhook.EventAutoDownloaderTorrentAdded, &webhook.TorrentAddedPayload{
+		MediaId:     rule.MediaId,
+		Episode:     episode,
+		TorrentName: t.Name,
+		Hash:        t.InfoHash,
+		Downloaded:  downloaded,
+	})
+
+	// Each torrent is stored in the inbox, the summary is pushed after the run
+	inboxMessage := fmt.Sprintf("%s has been added to the queue.", t.Name)
+	if downloaded {
+		inboxMessage = fmt.Sprintf("%s has been downloaded.", t.Name)
+	}
+	links := make([]models.NotificationLink, 0)
+	if t.Link != "" {
+		links = append(links, models.NotificationLink{Label: "Torrent", URL: t.Link})
+	}
+	notifier.GlobalNotifier.Record(notifier.AutoDownloader, inboxMessage, &notifier.Details{
+		MediaId: rule.MediaId,
+		Episode: episode,
+		Links:   links,
+	})
+
 	return true
 }
 
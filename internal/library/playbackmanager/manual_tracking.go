@@ -50,7 +50,7 @@ func (pm *PlaybackManager) StartManualProgressTracking(opts *StartManualProgress
 
 	// Get the media
 	// - Find the media in the collection
-	animeCollection, err := pm.platform.GetAnimeCollection(false)
+	animeCollection, err := pm.getPlatform().GetAnimeCollection(false)
 	if err != nil {
 		return err
 	}
@@ -65,7 +65,7 @@ func (pm *PlaybackManager) StartManualProgressTracking(opts *StartManualProgress
 		media = listEntry.Media
 	} else {
 		// Fetch the media from AniList
-		media, err = pm.platform.GetAnime(opts.MediaId)
+		media, err = pm.getPlatform().GetAnime(opts.MediaId)
 	}
 	if media == nil {
 		pm.Logger.Error().Msg("playback manager: Media not found for manual tracking")
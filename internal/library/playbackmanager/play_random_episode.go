@@ -20,7 +20,7 @@ func (pm *PlaybackManager) StartRandomVideo(opts *StartRandomVideoOptions) error
 		return err
 	}
 
-	animeCollection, err := pm.platform.GetAnimeCollection(false)
+	animeCollection, err := pm.getPlatform().GetAnimeCollection(false)
 	if err != nil {
 		return err
 	}
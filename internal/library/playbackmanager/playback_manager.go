@@ -10,12 +10,14 @@ import (
 	"seanime/internal/continuity"
 	"seanime/internal/database/db"
 	"seanime/internal/database/db_bridge"
+	"seanime/internal/database/models"
 	"seanime/internal/discordrpc/presence"
 	"seanime/internal/events"
 	"seanime/internal/library/anime"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/platforms/platform"
 	"seanime/internal/util"
+	"seanime/internal/watchstats"
 	"sync"
 )
 
@@ -50,7 +52,8 @@ type (
 		discordPresence            *discordrpc_presence.Presence     // DiscordPresence is used to update the user's Discord presence
 		mediaPlayerRepoSubscriber  *mediaplayer.RepositorySubscriber // Used to listen for media player events
 		wsEventManager             events.WSEventManagerInterface
-		platform                   platform.Platform
+		platform                   platform.Platform // Guarded by platformMu, use getPlatform
+		platformMu                 sync.RWMutex
 		refreshAnimeCollectionFunc func() // This function is called to refresh the AniList collection
 		mu                         sync.Mutex
 		eventMu                    sync.Mutex
@@ -85,6 +88,8 @@ type (
 		currentStreamEpisode mo.Option[*anime.Episode]
 		// The current media being streamed, set in [StartStreamingUsingMediaPlayer]
 		currentStreamMedia mo.Option[*anilist.BaseAnime]
+		// The source of the current stream, set in [StartStreamingUsingMediaPlayer]
+		currentStreamSource watchstats.Source
 
 		// \/ Manual progress tracking (non-integrated external player)
 		manualTrackingCtx           context.Context
@@ -97,6 +102,9 @@ type (
 
 		isOffline       bool
 		animeCollection mo.Option[*anilist.AnimeCollection]
+		// ID of the profile that started the playback, used for the watch stats.
+		// Guarded by eventMu.
+		profileId uint
 	}
 
 	PlaybackStateType string
@@ -154,6 +162,7 @@ func New(opts *NewPlaybackManagerOptions) *PlaybackManager {
 		currentLocalFileWrapperEntry:   mo.None[*anime.LocalFileWrapperEntry](),
 		currentMediaListEntry:          mo.None[*anilist.AnimeListEntry](),
 		continuityManager:              opts.ContinuityManager,
+		profileId:                      models.DefaultProfileID,
 	}
 
 	pm.playlistHub = newPlaylistHub(pm)
@@ -177,6 +186,28 @@ func (pm *PlaybackManager) SetAnimeCollection(ac *anilist.AnimeCollection) {
 	pm.animeCollection = mo.Some(ac)
 }
 
+// SetPlatform sets the platform used to fetch the collection and update the progress.
+// This is called when a profile starts playback.
+func (pm *PlaybackManager) SetPlatform(p platform.Platform) {
+	pm.platformMu.Lock()
+	defer pm.platformMu.Unlock()
+	pm.platform = p
+}
+
+func (pm *PlaybackManager) getPlatform() platform.Platform {
+	pm.platformMu.RLock()
+	defer pm.platformMu.RUnlock()
+	return pm.platform
+}
+
+// SetProfileID sets the profile the watch time is recorded for.
+// This is called when a profile starts playback.
+func (pm *PlaybackManager) SetProfileID(id uint) {
+	pm.eventMu.Lock()
+	defer pm.eventMu.Unlock()
+	pm.profileId = id
+}
+
 func (pm *PlaybackManager) SetSettings(s *Settings) {
 	pm.settings = s
 }
@@ -217,6 +248,7 @@ type StartPlayingOptions struct {
 	Payload   string // url or path
 	UserAgent string
 	ClientId  string
+	Source    watchstats.Source // Where the stream comes from, recorded in the watch stats
 }
 
 func (pm *PlaybackManager) StartPlayingUsingMediaPlayer(opts *StartPlayingOptions) error {
@@ -269,6 +301,7 @@ func (pm *PlaybackManager) StartStreamingUsingMediaPlayer(windowTitle string, op
 	}
 
 	pm.currentStreamMedia = mo.Some(media)
+	pm.currentStreamSource = opts.Source
 
 	episodeNumber := 0
 
@@ -485,7 +518,7 @@ func (pm *PlaybackManager) checkOrLoadAnimeCollection() (err error) {
 
 	if pm.animeCollection.IsAbsent() {
 		// If the anime collection is not present, we retrieve it from the platform
-		collection, err := pm.platform.GetAnimeCollection(false)
+		collection, err := pm.getPlatform().GetAnimeCollection(false)
 		if err != nil {
 			return err
 		}
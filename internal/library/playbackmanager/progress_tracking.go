@@ -11,6 +11,8 @@ import (
 	"seanime/internal/library/anime"
 	"seanime/internal/mediaplayers/mediaplayer"
 	"seanime/internal/util"
+	"seanime/internal/watchstats"
+	"seanime/internal/webhook"
 )
 
 var (
@@ -72,6 +74,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 					Filepath:      pm.currentLocalFile.MustGet().GetPath(),
 				})
 
+				// ------- Watch stats ------- //
+				pm.trackWatchTime(status)
+
 				// ------- Playlist ------- //
 				go pm.playlistHub.onVideoStart(pm.currentMediaListEntry.MustGet(), pm.currentLocalFile.MustGet(), _ps)
 
@@ -107,6 +112,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 				// Push the video playback state to the history
 				pm.historyMap[status.Filename] = _ps
 
+				// ------- Watch stats ------- //
+				pm.trackWatchTime(status)
+
 				// ------- Playlist ------- //
 				if pm.currentMediaListEntry.IsPresent() && pm.currentLocalFile.IsPresent() {
 					go pm.playlistHub.onVideoCompleted(pm.currentMediaListEntry.MustGet(), pm.currentLocalFile.MustGet(), _ps)
@@ -133,6 +141,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 					pm.continuityManager.UpdateExternalPlayerEpisodeWatchHistoryItem(pm.currentMediaPlaybackStatus.CurrentTimeInSeconds, pm.currentMediaPlaybackStatus.DurationInSeconds)
 				}
 
+				// ------- Watch stats ------- //
+				pm.endWatchSession(watchstats.SourceLocal)
+
 				// ------- Playlist ------- //
 				go pm.playlistHub.onTrackingStopped()
 
@@ -157,6 +168,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 				// Send the playback state to the client
 				pm.wsEventManager.SendEvent(events.PlaybackManagerProgressPlaybackState, _ps)
 
+				// ------- Watch stats ------- //
+				pm.trackWatchTime(status)
+
 				// ------- Playlist ------- //
 				if pm.currentMediaListEntry.IsPresent() && pm.currentLocalFile.IsPresent() {
 					go pm.playlistHub.onPlaybackStatus(pm.currentMediaListEntry.MustGet(), pm.currentLocalFile.MustGet(), _ps)
@@ -207,6 +221,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 					Filepath:      "",
 				})
 
+				// ------- Watch stats ------- //
+				pm.trackWatchTime(status)
+
 				// ------- Discord ------- //
 				if pm.discordPresence != nil && !pm.isOffline {
 					go pm.discordPresence.SetAnimeActivity(&discordrpc_presence.AnimeActivity{
@@ -238,6 +255,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 				// Send the playback state to the client
 				pm.wsEventManager.SendEvent(events.PlaybackManagerProgressPlaybackState, _ps)
 
+				// ------- Watch stats ------- //
+				pm.trackWatchTime(status)
+
 				pm.eventMu.Unlock()
 			case status := <-pm.mediaPlayerRepoSubscriber.StreamingVideoCompletedCh:
 				pm.eventMu.Lock()
@@ -264,6 +284,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 				// Push the video playback state to the history
 				pm.historyMap[status.Filename] = _ps
 
+				// ------- Watch stats ------- //
+				pm.trackWatchTime(status)
+
 				pm.eventMu.Unlock()
 			case reason := <-pm.mediaPlayerRepoSubscriber.StreamingTrackingStoppedCh:
 				pm.eventMu.Lock()
@@ -279,6 +302,9 @@ func (pm *PlaybackManager) listenToMediaPlayerEvents(ctx context.Context) {
 				pm.Logger.Debug().Msg("playback manager: Received tracking stopped event")
 				pm.wsEventManager.SendEvent(events.PlaybackManagerProgressTrackingStopped, reason)
 
+				// ------- Watch stats ------- //
+				pm.endWatchSession(pm.currentStreamSource)
+
 				// ------- Discord ------- //
 				if pm.discordPresence != nil && !pm.isOffline {
 					go pm.discordPresence.Close()
@@ -496,7 +522,7 @@ func (pm *PlaybackManager) updateProgress() (err error) {
 	}
 
 	// Update the progress on AniList
-	err = pm.platform.UpdateEntryProgress(
+	err = pm.getPlatform().UpdateEntryProgress(
 		mediaId,
 		epNum,
 		&totalEpisodes,
@@ -510,5 +536,11 @@ func (pm *PlaybackManager) updateProgress() (err error) {
 
 	pm.Logger.Info().Msg("playback manager: Updated progress on AniList")
 
+	webhook.GlobalManager.Dispatch(webhook.EventEpisodeWatched, &webhook.EpisodeWatchedPayload{
+		MediaId:       mediaId,
+		Episode:       epNum,
+		TotalEpisodes: totalEpisodes,
+	})
+
 	return nil
 }
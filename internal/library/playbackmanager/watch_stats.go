@@ -0,0 +1,45 @@
+package playbackmanager
+
+import (
+	"seanime/internal/mediaplayers/mediaplayer"
+	"seanime/internal/watchstats"
+)
+
+// trackWatchTime records the playback position of the current video in the watch stats.
+// It should be called with eventMu held.
+func (pm *PlaybackManager) trackWatchTime(status *mediaplayer.PlaybackStatus) {
+	if status == nil || !status.Playing {
+		return
+	}
+
+	opts := &watchstats.TrackOptions{
+		ProfileID: pm.profileId,
+		Position:  status.CurrentTimeInSeconds,
+	}
+
+	switch pm.currentPlaybackType {
+	case LocalFilePlayback:
+		if pm.currentMediaListEntry.IsAbsent() || pm.currentLocalFile.IsAbsent() || pm.currentLocalFileWrapperEntry.IsAbsent() {
+			return
+		}
+		opts.MediaID = pm.currentMediaListEntry.MustGet().GetMedia().GetID()
+		opts.Episode = pm.currentLocalFileWrapperEntry.MustGet().GetProgressNumber(pm.currentLocalFile.MustGet())
+		opts.Source = watchstats.SourceLocal
+	case StreamPlayback:
+		if pm.currentStreamMedia.IsAbsent() || pm.currentStreamEpisode.IsAbsent() {
+			return
+		}
+		opts.MediaID = pm.currentStreamMedia.MustGet().GetID()
+		opts.Episode = pm.currentStreamEpisode.MustGet().GetProgressNumber()
+		opts.Source = pm.currentStreamSource
+	default:
+		return
+	}
+
+	watchstats.GlobalManager.Track(opts)
+}
+
+// endWatchSession ends the watch stats session of the current video, e.g. when the player is closed.
+func (pm *PlaybackManager) endWatchSession(source watchstats.Source) {
+	watchstats.GlobalManager.End(pm.profileId, source)
+}
@@ -11,11 +11,14 @@ import (
 	"seanime/internal/library/anime"
 	"seanime/internal/library/filesystem"
 	"seanime/internal/library/summary"
+	"seanime/internal/metrics"
 	"seanime/internal/platforms/platform"
 	"seanime/internal/util"
 	"seanime/internal/util/limiter"
+	"seanime/internal/webhook"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Scanner struct {
@@ -37,6 +40,8 @@ type Scanner struct {
 func (scn *Scanner) Scan() (lfs []*anime.LocalFile, err error) {
 	defer util.HandlePanicWithError(&err)
 
+	scanStart := time.Now()
+
 	scn.WSEventManager.SendEvent(events.EventScanProgress, 0)
 	scn.WSEventManager.SendEvent(events.EventScanStatus, "Retrieving local files...")
 
@@ -315,5 +320,53 @@ func (scn *Scanner) Scan() (lfs []*anime.LocalFile, err error) {
 			Msg("Scan completed")
 	}
 
+	recordScanMetrics(localFiles, len(skippedLfs), time.Since(scanStart))
+	scn.dispatchScanCompleted(localFiles)
+
 	return localFiles, nil
 }
+
+// dispatchScanCompleted sends the scan completed webhook event if new files were found.
+func (scn *Scanner) dispatchScanCompleted(lfs []*anime.LocalFile) {
+	existing := make(map[string]struct{}, len(scn.ExistingLocalFiles))
+	for _, lf := range scn.ExistingLocalFiles {
+		existing[lf.GetNormalizedPath()] = struct{}{}
+	}
+
+	newFiles := make([]string, 0)
+	for _, lf := range lfs {
+		if _, ok := existing[lf.GetNormalizedPath()]; !ok {
+			newFiles = append(newFiles, lf.Path)
+		}
+	}
+	if len(newFiles) == 0 {
+		return
+	}
+
+	webhook.GlobalManager.Dispatch(webhook.EventScanCompleted, &webhook.ScanCompletedPayload{
+		NewFiles:   newFiles,
+		TotalFiles: len(lfs),
+	})
+}
+
+// recordScanMetrics reports the duration of the scan and the number of files by status.
+func recordScanMetrics(lfs []*anime.LocalFile, skipped int, duration time.Duration) {
+	matched, unmatched, ignored := 0, 0, 0
+	for _, lf := range lfs {
+		switch {
+		case lf.Ignored:
+			ignored++
+		case lf.MediaId == 0:
+			unmatched++
+		default:
+			matched++
+		}
+	}
+
+	metrics.ScanDuration.Observe(duration.Seconds())
+	metrics.ScanFiles.WithLabelValues("total").Set(float64(len(lfs)))
+	metrics.ScanFiles.WithLabelValues("matched").Set(float64(matched))
+	metrics.ScanFiles.WithLabelValues("unmatched").Set(float64(unmatched))
+	metrics.ScanFiles.WithLabelValues("ignored").Set(float64(ignored))
+	metrics.ScanFiles.WithLabelValues("skipped").Set(float64(skipped))
+}
@@ -0,0 +1,198 @@
+package listsync
+
+import (
+	"seanime/internal/api/anilist"
+	"seanime/internal/platforms/mal_platform"
+	"slices"
+	"time"
+)
+
+type (
+	// Origin decides which platform wins when an entry differs on both platforms.
+	Origin string
+	// Source is a platform the lists are synchronized between.
+	Source    string
+	MediaType string
+
+	// Entry is the state of a list entry on one of the platforms.
+	Entry struct {
+		Status      anilist.MediaListStatus `json:"status"`
+		Progress    int                     `json:"progress"`
+		Score       int                     `json:"score"` // 0-100
+		StartedAt   *anilist.FuzzyDateInput `json:"startedAt,omitempty"`
+		CompletedAt *anilist.FuzzyDateInput `json:"completedAt,omitempty"`
+		UpdatedAt   time.Time               `json:"updatedAt"`
+	}
+
+	// Diff is an entry that will be created or updated on the target platform.
+	Diff struct {
+		MediaID   int       `json:"mediaId"`
+		MalID     int       `json:"malId"`
+		MediaType MediaType `json:"mediaType"`
+		Title     string    `json:"title"`
+		// Target is the platform that will be updated
+		Target Source `json:"target"`
+		// Fields are the fields that differ, empty if the entry is missing on the target
+		Fields  []string `json:"fields"`
+		Anilist *Entry   `json:"anilist,omitempty"`
+		Mal     *Entry   `json:"mal,omitempty"`
+	}
+
+	// listEntry is an entry keyed by its AniList media ID.
+	listEntry struct {
+		*Entry
+		MediaID   int
+		MalID     int
+		MediaType MediaType
+		Title     string
+	}
+)
+
+const (
+	OriginAnilist Origin = "anilist" // Default
+	OriginMal     Origin = "mal"
+	OriginLatest  Origin = "latest" // The latest change wins
+
+	SourceAnilist Source = "anilist"
+	SourceMal     Source = "mal"
+
+	MediaTypeAnime MediaType = "anime"
+	MediaTypeManga MediaType = "manga"
+
+	FieldStatus      = "status"
+	FieldProgress    = "progress"
+	FieldScore       = "score"
+	FieldStartedAt   = "startedAt"
+	FieldCompletedAt = "completedAt"
+)
+
+// IsValidOrigin returns true if the origin is empty (default) or known.
+func IsValidOrigin(origin string) bool {
+	switch Origin(origin) {
+	case "", OriginAnilist, OriginMal, OriginLatest:
+		return true
+	}
+	return false
+}
+
+// computeDiffs compares the entries of both platforms, keyed by AniList media ID.
+// Entries missing on a platform are added to it, entries are never removed.
+// Entries that differ are written to the platform that loses according to the origin.
+func computeDiffs(anilistEntries map[int]*listEntry, malEntries map[int]*listEntry, origin Origin) []*Diff {
+	ret := make([]*Diff, 0)
+
+	for mediaID, a := range anilistEntries {
+		m, ok := malEntries[mediaID]
+		if !ok {
+			// Cannot add the entry to MAL without a MAL ID
+			if a.MalID == 0 {
+				continue
+			}
+			ret = append(ret, &Diff{
+				MediaID:   mediaID,
+				MalID:     a.MalID,
+				MediaType: a.MediaType,
+				Title:     a.Title,
+				Target:    SourceMal,
+				Fields:    []string{},
+				Anilist:   a.Entry,
+			})
+			continue
+		}
+
+		target := resolveTarget(a.Entry, m.Entry, origin)
+		var fields []string
+		if target == SourceMal {
+			fields = changedFields(a.Entry, m.Entry)
+		} else {
+			fields = changedFields(m.Entry, a.Entry)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		ret = append(ret, &Diff{
+			MediaID:   mediaID,
+			MalID:     m.MalID,
+			MediaType: a.MediaType,
+			Title:     a.Title,
+			Target:    target,
+			Fields:    fields,
+			Anilist:   a.Entry,
+			Mal:       m.Entry,
+		})
+	}
+
+	for mediaID, m := range malEntries {
+		if _, ok := anilistEntries[mediaID]; ok {
+			continue
+		}
+		ret = append(ret, &Diff{
+			MediaID:   mediaID,
+			MalID:     m.MalID,
+			MediaType: m.MediaType,
+			Title:     m.Title,
+			Target:    SourceAnilist,
+			Fields:    []string{},
+			Mal:       m.Entry,
+		})
+	}
+
+	slices.SortFunc(ret, func(a, b *Diff) int {
+		if a.MediaType != b.MediaType {
+			if a.MediaType < b.MediaType {
+				return -1
+			}
+			return 1
+		}
+		return a.MediaID - b.MediaID
+	})
+
+	return ret
+}
+
+// resolveTarget returns the platform that should be updated when the entry exists on both platforms.
+func resolveTarget(a *Entry, m *Entry, origin Origin) Source {
+	switch origin {
+	case OriginMal:
+		return SourceAnilist
+	case OriginLatest:
+		if m.UpdatedAt.After(a.UpdatedAt) {
+			return SourceAnilist
+		}
+		return SourceMal
+	default:
+		return SourceMal
+	}
+}
+
+// changedFields returns the fields of the target entry that differ from the source entry.
+// Scores are compared on MAL's 10 point scale and dates are only compared if the source has one,
+// so that the entries don't keep differing because of the precision of the platforms.
+func changedFields(source *Entry, target *Entry) []string {
+	ret := make([]string, 0)
+	if source.Status != target.Status {
+		ret = append(ret, FieldStatus)
+	}
+	if source.Progress != target.Progress {
+		ret = append(ret, FieldProgress)
+	}
+	if mal_platform.ToMalScore(source.Score) != mal_platform.ToMalScore(target.Score) {
+		ret = append(ret, FieldScore)
+	}
+	if source.StartedAt != nil && mal_platform.FormatMalDate(source.StartedAt) != mal_platform.FormatMalDate(target.StartedAt) {
+		ret = append(ret, FieldStartedAt)
+	}
+	if source.CompletedAt != nil && mal_platform.FormatMalDate(source.CompletedAt) != mal_platform.FormatMalDate(target.CompletedAt) {
+		ret = append(ret, FieldCompletedAt)
+	}
+	return ret
+}
+
+// source returns the entry that will be written to the target platform.
+func (d *Diff) source() *Entry {
+	if d.Target == SourceMal {
+		return d.Anilist
+	}
+	return d.Mal
+}
@@ -0,0 +1,124 @@
+package listsync
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"testing"
+	"time"
+)
+
+func TestComputeDiffs(t *testing.T) {
+	now := time.Now()
+
+	newEntry := func(mediaID int, malID int, entry *Entry) *listEntry {
+		return &listEntry{Entry: entry, MediaID: mediaID, MalID: malID, MediaType: MediaTypeAnime}
+	}
+
+	anilistEntries := map[int]*listEntry{
+		// Same entry, scores and dates only differ in precision
+		1: newEntry(1, 101, &Entry{
+			Status:    anilist.MediaListStatusCompleted,
+			Progress:  12,
+			Score:     85,
+			StartedAt: &anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(1)},
+			UpdatedAt: now,
+		}),
+		// Differs, MAL was updated later
+		2: newEntry(2, 102, &Entry{
+			Status:    anilist.MediaListStatusCurrent,
+			Progress:  3,
+			UpdatedAt: now.Add(-time.Hour),
+		}),
+		// Missing on MAL
+		3: newEntry(3, 103, &Entry{Status: anilist.MediaListStatusPlanning}),
+		// Missing on MAL, but no MAL ID
+		4: newEntry(4, 0, &Entry{Status: anilist.MediaListStatusPlanning}),
+	}
+
+	malEntries := map[int]*listEntry{
+		1: newEntry(1, 101, &Entry{
+			Status:    anilist.MediaListStatusCompleted,
+			Progress:  12,
+			Score:     90,
+			StartedAt: &anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(1), Day: lo.ToPtr(1)},
+			UpdatedAt: now,
+		}),
+		2: newEntry(2, 102, &Entry{
+			Status:    anilist.MediaListStatusCurrent,
+			Progress:  5,
+			Score:     70,
+			UpdatedAt: now,
+		}),
+		// Missing on AniList
+		5: newEntry(5, 105, &Entry{Status: anilist.MediaListStatusDropped}),
+	}
+
+	tests := []struct {
+		name     string
+		origin   Origin
+		expected map[int]Source
+		fields   []string
+	}{
+		{
+			name:     "AniList origin",
+			origin:   OriginAnilist,
+			expected: map[int]Source{2: SourceMal, 3: SourceMal, 5: SourceAnilist},
+			fields:   []string{FieldProgress, FieldScore},
+		},
+		{
+			name:     "MAL origin",
+			origin:   OriginMal,
+			expected: map[int]Source{2: SourceAnilist, 3: SourceMal, 5: SourceAnilist},
+			fields:   []string{FieldProgress, FieldScore},
+		},
+		{
+			name:     "Latest change wins",
+			origin:   OriginLatest,
+			expected: map[int]Source{2: SourceAnilist, 3: SourceMal, 5: SourceAnilist},
+			fields:   []string{FieldProgress, FieldScore},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := computeDiffs(anilistEntries, malEntries, tt.origin)
+
+			require.Len(t, diffs, len(tt.expected))
+			for _, diff := range diffs {
+				target, ok := tt.expected[diff.MediaID]
+				require.True(t, ok, "unexpected diff for %d", diff.MediaID)
+				require.Equal(t, target, diff.Target)
+
+				switch diff.MediaID {
+				case 2:
+					require.Equal(t, tt.fields, diff.Fields)
+				case 3:
+					require.Empty(t, diff.Fields)
+					require.Equal(t, 103, diff.MalID)
+					require.Equal(t, anilistEntries[3].Entry, diff.source())
+				case 5:
+					require.Empty(t, diff.Fields)
+					require.Equal(t, malEntries[5].Entry, diff.source())
+				}
+			}
+		})
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	source := &Entry{Status: anilist.MediaListStatusCompleted, Progress: 12, Score: 80}
+	target := &Entry{
+		Status:      anilist.MediaListStatusCompleted,
+		Progress:    12,
+		Score:       78,
+		CompletedAt: &anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(2), Day: lo.ToPtr(1)},
+	}
+
+	// The source has no completion date, the target's is kept
+	require.Empty(t, changedFields(source, target))
+
+	source.CompletedAt = &anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(2), Day: lo.ToPtr(2)}
+	source.Status = anilist.MediaListStatusRepeating
+	require.Equal(t, []string{FieldStatus, FieldCompletedAt}, changedFields(source, target))
+}
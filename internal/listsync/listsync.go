@@ -0,0 +1,436 @@
+package listsync
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mal"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/platforms/mal_platform"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util/limiter"
+	"sync"
+	"time"
+)
+
+var (
+	ErrAnilistNotLoggedIn = errors.New("list sync: Not logged in to AniList")
+	ErrMalNotLoggedIn     = errors.New("list sync: Not logged in to MyAnimeList")
+)
+
+type (
+	// Manager synchronizes the AniList and MyAnimeList lists of the default profile.
+	// The entries are matched by AniList ID, MAL entries are mapped using the MAL IDs known by AniList.
+	Manager struct {
+		logger          *zerolog.Logger
+		db              *db.Database
+		anilistPlatform platform.Platform
+		mu              sync.Mutex // Only one synchronization at a time
+		// Entries updated through the wrapped platforms, waiting to be synchronized
+		pending      map[Source]map[int]struct{}
+		pendingTimer *time.Timer
+		pendingMu    sync.Mutex
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+		// AnilistPlatform is used to read and update the AniList lists.
+		// It should not be wrapped by WrapPlatform.
+		AnilistPlatform platform.Platform
+	}
+
+	// Result is the outcome of a synchronization or a preview.
+	Result struct {
+		Origin Origin  `json:"origin"`
+		DryRun bool    `json:"dryRun"`
+		Diffs  []*Diff `json:"diffs"`
+		// Unmapped are the titles of the MAL entries that couldn't be found on AniList
+		Unmapped []string `json:"unmapped"`
+		Applied  int      `json:"applied"`
+		Errors   []string `json:"errors"`
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	return &Manager{
+		logger:          opts.Logger,
+		db:              opts.Database,
+		anilistPlatform: opts.AnilistPlatform,
+		pending:         make(map[Source]map[int]struct{}),
+	}
+}
+
+// SetAnilistClient updates the client used to update the AniList lists after the user logs in.
+func (m *Manager) SetAnilistClient(client anilist.AnilistClient) {
+	m.anilistPlatform.SetAnilistClient(client)
+}
+
+// GetSettings returns the list sync settings.
+func (m *Manager) GetSettings() *models.ListSyncSettings {
+	settings, err := m.db.GetSettings()
+	if err != nil || settings == nil || settings.ListSync == nil {
+		return &models.ListSyncSettings{}
+	}
+	return settings.ListSync
+}
+
+// Preview returns the changes a synchronization would make without applying them.
+// The origin from the settings is used if origin is empty.
+func (m *Manager) Preview(origin Origin) (*Result, error) {
+	return m.run(origin, true, nil)
+}
+
+// Sync synchronizes the lists.
+// The origin from the settings is used if origin is empty.
+func (m *Manager) Sync(origin Origin) (*Result, error) {
+	return m.run(origin, false, nil)
+}
+
+// SyncEntries copies the entries of the media from the source platform to the other platform.
+func (m *Manager) SyncEntries(mediaIDs []int, source Source) (*Result, error) {
+	origin := OriginAnilist
+	if source == SourceMal {
+		origin = OriginMal
+	}
+	return m.run(origin, false, mediaIDs)
+}
+
+// run computes the diffs and applies them unless dryRun is true.
+// If mediaIDs is not nil, only the entries of these media are synchronized.
+func (m *Manager) run(origin Origin, dryRun bool, mediaIDs []int) (*Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if origin == "" {
+		origin = Origin(m.GetSettings().Origin)
+		if origin == "" {
+			origin = OriginAnilist
+		}
+	}
+	if !IsValidOrigin(string(origin)) {
+		return nil, fmt.Errorf("list sync: Invalid origin %q", origin)
+	}
+
+	acc, err := m.db.GetAccount()
+	if err != nil || acc == nil || acc.Token == "" || acc.Username == "" {
+		return nil, ErrAnilistNotLoggedIn
+	}
+	m.anilistPlatform.SetUsername(acc.Username)
+
+	wrapper, err := m.getMalWrapper()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Result{
+		Origin:   origin,
+		DryRun:   dryRun,
+		Diffs:    make([]*Diff, 0),
+		Unmapped: make([]string, 0),
+		Errors:   make([]string, 0),
+	}
+
+	mediaTypes := []MediaType{MediaTypeAnime}
+	if settings, err := m.db.GetSettings(); err == nil && settings.Library != nil && settings.Library.EnableManga {
+		mediaTypes = append(mediaTypes, MediaTypeManga)
+	}
+
+	for _, mediaType := range mediaTypes {
+		anilistEntries, err := m.getAnilistEntries(mediaType, acc)
+		if err != nil {
+			return nil, err
+		}
+		malEntries, unmapped, err := m.getMalEntries(wrapper, mediaType, anilistEntries)
+		if err != nil {
+			return nil, err
+		}
+		ret.Unmapped = append(ret.Unmapped, unmapped...)
+
+		if mediaIDs != nil {
+			anilistEntries = lo.PickByKeys(anilistEntries, mediaIDs)
+			malEntries = lo.PickByKeys(malEntries, mediaIDs)
+		}
+
+		ret.Diffs = append(ret.Diffs, computeDiffs(anilistEntries, malEntries, origin)...)
+	}
+
+	if dryRun || len(ret.Diffs) == 0 {
+		return ret, nil
+	}
+
+	rateLimiter := limiter.NewLimiter(1*time.Second, 1) // 1 request per second
+	for _, diff := range ret.Diffs {
+		rateLimiter.Wait()
+		if err := m.apply(wrapper, diff); err != nil {
+			m.logger.Error().Err(err).Int("mediaId", diff.MediaID).Str("target", string(diff.Target)).Msg("list sync: Failed to update entry")
+			ret.Errors = append(ret.Errors, fmt.Sprintf("%s: %s", diff.Title, err.Error()))
+			continue
+		}
+		ret.Applied++
+	}
+
+	m.logger.Info().Int("applied", ret.Applied).Int("errors", len(ret.Errors)).Str("origin", string(origin)).Msg("list sync: Synchronized lists")
+
+	return ret, nil
+}
+
+// apply writes the source entry of the diff to the target platform.
+func (m *Manager) apply(wrapper *mal.Wrapper, diff *Diff) error {
+	source := diff.source()
+
+	if diff.Target == SourceAnilist {
+		return m.anilistPlatform.UpdateEntry(diff.MediaID, &source.Status, &source.Score, &source.Progress, source.StartedAt, source.CompletedAt, nil)
+	}
+
+	status, isRepeating := mal_platform.ToMalStatus(source.Status, diff.MediaType == MediaTypeManga)
+	score := mal_platform.ToMalScore(source.Score)
+	var startDate, finishDate *string
+	if source.StartedAt != nil {
+		startDate = lo.ToPtr(mal_platform.FormatMalDate(source.StartedAt))
+	}
+	if source.CompletedAt != nil {
+		finishDate = lo.ToPtr(mal_platform.FormatMalDate(source.CompletedAt))
+	}
+
+	if diff.MediaType == MediaTypeManga {
+		return wrapper.UpdateMangaListStatus(&mal.MangaListStatusParams{
+			Status:          &status,
+			IsRereading:     &isRepeating,
+			NumChaptersRead: &source.Progress,
+			Score:           &score,
+			StartDate:       startDate,
+			FinishDate:      finishDate,
+		}, diff.MalID)
+	}
+	return wrapper.UpdateAnimeListStatus(&mal.AnimeListStatusParams{
+		Status:             &status,
+		IsRewatching:       &isRepeating,
+		NumEpisodesWatched: &source.Progress,
+		Score:              &score,
+		StartDate:          startDate,
+		FinishDate:         finishDate,
+	}, diff.MalID)
+}
+
+func (m *Manager) getMalWrapper() (*mal.Wrapper, error) {
+	malInfo, err := m.db.GetMalInfoByID(models.DefaultProfileID)
+	if err != nil || malInfo == nil || malInfo.AccessToken == "" {
+		return nil, ErrMalNotLoggedIn
+	}
+
+	malInfo, err = mal.VerifyMALAuth(malInfo, m.db, m.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return mal.NewWrapper(malInfo.AccessToken, m.logger), nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getAnilistEntries returns the AniList entries keyed by media ID.
+func (m *Manager) getAnilistEntries(mediaType MediaType, acc *models.Account) (map[int]*listEntry, error) {
+	ret := make(map[int]*listEntry)
+
+	anilistType := "ANIME"
+	if mediaType == MediaTypeManga {
+		anilistType = "MANGA"
+	}
+	updatedAt, err := anilist.FetchMediaListUpdatedAt(acc.Username, anilistType, acc.Token, m.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if mediaType == MediaTypeAnime {
+		collection, err := m.anilistPlatform.GetRawAnimeCollection(true)
+		if err != nil {
+			return nil, err
+		}
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			// Skip custom lists, their entries are also in the status lists
+			if list.GetStatus() == nil {
+				continue
+			}
+			for _, e := range list.GetEntries() {
+				if e.GetMedia() == nil || e.GetStatus() == nil {
+					continue
+				}
+				entry := &listEntry{
+					Entry: &Entry{
+						Status:    *e.GetStatus(),
+						Progress:  lo.FromPtr(e.GetProgress()),
+						Score:     int(lo.FromPtr(e.GetScore())),
+						UpdatedAt: updatedAt[e.GetMedia().GetID()],
+					},
+					MediaID:   e.GetMedia().GetID(),
+					MalID:     lo.FromPtr(e.GetMedia().GetIDMal()),
+					MediaType: mediaType,
+					Title:     e.GetMedia().GetTitleSafe(),
+				}
+				if date := e.GetStartedAt(); date != nil && date.GetYear() != nil {
+					entry.StartedAt = &anilist.FuzzyDateInput{Year: date.Year, Month: date.Month, Day: date.Day}
+				}
+				if date := e.GetCompletedAt(); date != nil && date.GetYear() != nil {
+					entry.CompletedAt = &anilist.FuzzyDateInput{Year: date.Year, Month: date.Month, Day: date.Day}
+				}
+				ret[entry.MediaID] = entry
+			}
+		}
+		return ret, nil
+	}
+
+	collection, err := m.anilistPlatform.GetRawMangaCollection(true)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		if list.GetStatus() == nil {
+			continue
+		}
+		for _, e := range list.GetEntries() {
+			if e.GetMedia() == nil || e.GetStatus() == nil {
+				continue
+			}
+			entry := &listEntry{
+				Entry: &Entry{
+					Status:    *e.GetStatus(),
+					Progress:  lo.FromPtr(e.GetProgress()),
+					Score:     int(lo.FromPtr(e.GetScore())),
+					UpdatedAt: updatedAt[e.GetMedia().GetID()],
+				},
+				MediaID:   e.GetMedia().GetID(),
+				MalID:     lo.FromPtr(e.GetMedia().GetIDMal()),
+				MediaType: mediaType,
+				Title:     e.GetMedia().GetTitleSafe(),
+			}
+			if date := e.GetStartedAt(); date != nil && date.GetYear() != nil {
+				entry.StartedAt = &anilist.FuzzyDateInput{Year: date.Year, Month: date.Month, Day: date.Day}
+			}
+			if date := e.GetCompletedAt(); date != nil && date.GetYear() != nil {
+				entry.CompletedAt = &anilist.FuzzyDateInput{Year: date.Year, Month: date.Month, Day: date.Day}
+			}
+			ret[entry.MediaID] = entry
+		}
+	}
+	return ret, nil
+}
+
+// getMalEntries returns the MAL entries keyed by AniList media ID and the titles of the entries that couldn't be mapped.
+// The MAL IDs of the AniList entries are used first, the others are looked up on AniList.
+func (m *Manager) getMalEntries(wrapper *mal.Wrapper, mediaType MediaType, anilistEntries map[int]*listEntry) (map[int]*listEntry, []string, error) {
+	ret := make(map[int]*listEntry)
+	unmapped := make([]string, 0)
+
+	anilistIDs := make(map[int]int) // MAL ID -> AniList ID
+	for _, e := range anilistEntries {
+		if e.MalID != 0 {
+			anilistIDs[e.MalID] = e.MediaID
+		}
+	}
+
+	type malEntry struct {
+		malID int
+		title string
+		entry *Entry
+	}
+	entries := make([]*malEntry, 0)
+
+	if mediaType == MediaTypeAnime {
+		collection, err := wrapper.GetAnimeCollection()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range collection {
+			ls := e.ListStatus
+			entries = append(entries, &malEntry{
+				malID: e.Node.ID,
+				title: e.Node.Title,
+				entry: &Entry{
+					Status:      mal_platform.ToAnilistStatus(ls.Status, ls.IsRewatching),
+					Progress:    ls.NumEpisodesWatched,
+					Score:       int(mal_platform.ToAnilistScore(ls.Score)),
+					StartedAt:   mal_platform.ParseMalDate(ls.StartDate),
+					CompletedAt: mal_platform.ParseMalDate(ls.FinishDate),
+					UpdatedAt:   parseMalTime(ls.UpdatedAt),
+				},
+			})
+		}
+	} else {
+		collection, err := wrapper.GetMangaCollection()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range collection {
+			ls := e.ListStatus
+			entries = append(entries, &malEntry{
+				malID: e.Node.ID,
+				title: e.Node.Title,
+				entry: &Entry{
+					Status:      mal_platform.ToAnilistStatus(ls.Status, ls.IsRereading),
+					Progress:    ls.NumChaptersRead,
+					Score:       int(mal_platform.ToAnilistScore(ls.Score)),
+					StartedAt:   mal_platform.ParseMalDate(ls.StartDate),
+					CompletedAt: mal_platform.ParseMalDate(ls.FinishDate),
+					UpdatedAt:   parseMalTime(ls.UpdatedAt),
+				},
+			})
+		}
+	}
+
+	// Look up the entries that are not on the AniList lists
+	missing := make([]int, 0)
+	for _, e := range entries {
+		if _, ok := anilistIDs[e.malID]; !ok {
+			missing = append(missing, e.malID)
+		}
+	}
+	if len(missing) > 0 {
+		if mediaType == MediaTypeAnime {
+			media, err := anilist.FetchBaseAnimeByMalIDs(missing, m.logger)
+			if err != nil {
+				return nil, nil, err
+			}
+			for malID, media := range media {
+				anilistIDs[malID] = media.GetID()
+			}
+		} else {
+			media, err := anilist.FetchBaseMangaByMalIDs(missing, m.logger)
+			if err != nil {
+				return nil, nil, err
+			}
+			for malID, media := range media {
+				anilistIDs[malID] = media.GetID()
+			}
+		}
+	}
+
+	for _, e := range entries {
+		mediaID, ok := anilistIDs[e.malID]
+		if !ok {
+			unmapped = append(unmapped, e.title)
+			continue
+		}
+		ret[mediaID] = &listEntry{
+			Entry:     e.entry,
+			MediaID:   mediaID,
+			MalID:     e.malID,
+			MediaType: mediaType,
+			Title:     e.title,
+		}
+	}
+
+	return ret, unmapped, nil
+}
+
+// parseMalTime parses the update time of a MAL entry, e.g. "2024-01-31T12:00:00+00:00".
+func parseMalTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
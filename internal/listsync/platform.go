@@ -0,0 +1,88 @@
+package listsync
+
+import (
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/platforms/platform"
+	"time"
+)
+
+// trackedPlatform synchronizes the entries to the other platform after they are updated,
+// if automatic list sync is enabled.
+type trackedPlatform struct {
+	platform.Platform
+	manager *Manager
+	source  Source
+}
+
+// WrapPlatform returns a platform that synchronizes the entries updated through p.
+// source is the platform p writes to.
+func (m *Manager) WrapPlatform(p platform.Platform, source Source) platform.Platform {
+	return &trackedPlatform{
+		Platform: p,
+		manager:  m,
+		source:   source,
+	}
+}
+
+func (tp *trackedPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error {
+	err := tp.Platform.UpdateEntry(mediaID, status, scoreRaw, progress, startedAt, completedAt, customLists)
+	if err == nil {
+		tp.manager.OnEntryUpdated(mediaID, tp.source)
+	}
+	return err
+}
+
+func (tp *trackedPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpisodes *int) error {
+	err := tp.Platform.UpdateEntryProgress(mediaID, progress, totalEpisodes)
+	if err == nil {
+		tp.manager.OnEntryUpdated(mediaID, tp.source)
+	}
+	return err
+}
+
+// entrySyncDelay is how long the updated entries are collected before being synchronized.
+// A synchronization fetches the whole lists of both platforms, so a bulk import or consecutive progress updates
+// should result in a single synchronization.
+const entrySyncDelay = 10 * time.Second
+
+// OnEntryUpdated schedules the synchronization of the entry if automatic list sync is enabled.
+// The timer restarts on each update, the pending entries are synchronized together once the updates stop.
+func (m *Manager) OnEntryUpdated(mediaID int, source Source) {
+	if !m.GetSettings().Automatic {
+		return
+	}
+
+	m.pendingMu.Lock()
+	defer m.pendingMu.Unlock()
+
+	if _, ok := m.pending[source]; !ok {
+		m.pending[source] = make(map[int]struct{})
+	}
+	m.pending[source][mediaID] = struct{}{}
+
+	if m.pendingTimer == nil {
+		m.pendingTimer = time.AfterFunc(entrySyncDelay, m.syncPendingEntries)
+	} else {
+		m.pendingTimer.Reset(entrySyncDelay)
+	}
+}
+
+// syncPendingEntries synchronizes the entries collected by OnEntryUpdated.
+func (m *Manager) syncPendingEntries() {
+	m.pendingMu.Lock()
+	pending := m.pending
+	m.pending = make(map[Source]map[int]struct{})
+	m.pendingTimer = nil
+	m.pendingMu.Unlock()
+
+	for source, ids := range pending {
+		mediaIDs := lo.Keys(ids)
+		res, err := m.SyncEntries(mediaIDs, source)
+		if err != nil {
+			m.logger.Warn().Err(err).Ints("mediaIds", mediaIDs).Msg("list sync: Failed to synchronize entries")
+			continue
+		}
+		m.logger.Debug().Ints("mediaIds", mediaIDs).Int("applied", res.Applied).Msg("list sync: Synchronized entries")
+	}
+}
@@ -0,0 +1,124 @@
+package listtransfer
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"math"
+	"seanime/internal/api/anilist"
+	"strconv"
+	"strings"
+)
+
+type (
+	MediaType string
+	Format    string
+
+	// Entry is a list entry in the generic JSON and CSV formats.
+	Entry struct {
+		MediaType MediaType               `json:"type"`
+		AnilistID int                     `json:"anilistId,omitempty"`
+		MalID     int                     `json:"malId,omitempty"`
+		KitsuID   int                     `json:"kitsuId,omitempty"`
+		Title     string                  `json:"title"`
+		Status    anilist.MediaListStatus `json:"status"`
+		// Episodes watched or chapters read
+		Progress int `json:"progress"`
+		// Score out of 10, 0 if not scored
+		Score float64 `json:"score"`
+		// YYYY-MM-DD, unknown parts are 00
+		StartedAt   string `json:"startedAt,omitempty"`
+		CompletedAt string `json:"completedAt,omitempty"`
+		// Number of times the media was rewatched or reread
+		Repeat int `json:"repeat"`
+	}
+)
+
+const (
+	MediaTypeAnime MediaType = "anime"
+	MediaTypeManga MediaType = "manga"
+
+	FormatMalXML Format = "mal-xml"
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+)
+
+// csvColumns are the columns of the generic CSV format, matching the JSON keys of Entry.
+var csvColumns = []string{"type", "anilistId", "malId", "kitsuId", "title", "status", "progress", "score", "startedAt", "completedAt", "repeat"}
+
+// ParseStatus converts the statuses used by AniList, MyAnimeList and Kitsu.
+//
+//	e.g., "CURRENT", "Watching", "plan_to_read", "on-hold", "planned"
+func ParseStatus(status string) (anilist.MediaListStatus, bool) {
+	s := strings.ToLower(status)
+	s = strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s)
+
+	switch s {
+	case "current", "watching", "reading":
+		return anilist.MediaListStatusCurrent, true
+	case "planning", "plantowatch", "plantoread", "planned":
+		return anilist.MediaListStatusPlanning, true
+	case "completed":
+		return anilist.MediaListStatusCompleted, true
+	case "paused", "onhold":
+		return anilist.MediaListStatusPaused, true
+	case "dropped":
+		return anilist.MediaListStatusDropped, true
+	case "repeating", "rewatching", "rereading":
+		return anilist.MediaListStatusRepeating, true
+	}
+	return "", false
+}
+
+// ParseDate parses a YYYY-MM-DD date, unknown parts can be 00 or omitted.
+// Returns nil if the year is unknown.
+func ParseDate(date string) *anilist.FuzzyDateInput {
+	parts := strings.Split(strings.TrimSpace(date), "-")
+	if len(parts) == 0 || len(parts) > 3 {
+		return nil
+	}
+
+	values := make([]int, 0, 3)
+	for _, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v <= 0 {
+			break
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	ret := &anilist.FuzzyDateInput{Year: lo.ToPtr(values[0])}
+	if len(values) > 1 && values[1] <= 12 {
+		ret.Month = lo.ToPtr(values[1])
+		if len(values) > 2 && values[2] <= 31 {
+			ret.Day = lo.ToPtr(values[2])
+		}
+	}
+	return ret
+}
+
+// FormatDate formats the date as YYYY-MM-DD, unknown parts are 00.
+// Returns an empty string if the year is unknown.
+func FormatDate(year, month, day *int) string {
+	if year == nil || *year == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", *year, lo.FromPtr(month), lo.FromPtr(day))
+}
+
+// scoreRaw converts the score out of 10 to AniList's 100 point score.
+func (e *Entry) scoreRaw() *int {
+	if e.Score <= 0 {
+		return nil
+	}
+	return lo.ToPtr(int(math.Round(math.Min(e.Score, 10) * 10)))
+}
+
+func (e *Entry) mediaType() MediaType {
+	if e.MediaType == MediaTypeManga {
+		return MediaTypeManga
+	}
+	return MediaTypeAnime
+}
@@ -0,0 +1,361 @@
+package listtransfer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mal"
+	"seanime/internal/api/metadata"
+	"seanime/internal/platforms/mal_platform"
+	"strconv"
+	"time"
+)
+
+var ErrMalExportMediaType = errors.New("list transfer: MyAnimeList exports contain either anime or manga")
+
+type (
+	// Exporter converts the collections to the formats read by Parse.
+	Exporter struct {
+		logger           *zerolog.Logger
+		metadataProvider metadata.Provider
+	}
+
+	NewExporterOptions struct {
+		Logger           *zerolog.Logger
+		MetadataProvider metadata.Provider
+	}
+
+	ExportOptions struct {
+		Format Format
+		// MediaType is required for MyAnimeList exports, both collections are exported in the other formats if empty
+		MediaType       MediaType
+		AnimeCollection *anilist.AnimeCollection
+		MangaCollection *anilist.MangaCollection
+	}
+
+	ExportResult struct {
+		Format   Format `json:"format"`
+		Filename string `json:"filename"`
+		Content  string `json:"content"`
+		// Number of entries in the export
+		Exported int `json:"exported"`
+		// Entries without a MyAnimeList ID, they are left out of MyAnimeList exports
+		MissingMalID []*Entry `json:"missingMalId"`
+	}
+)
+
+func NewExporter(opts *NewExporterOptions) *Exporter {
+	return &Exporter{
+		logger:           opts.Logger,
+		metadataProvider: opts.MetadataProvider,
+	}
+}
+
+// Export converts the collections to the given format.
+func (e *Exporter) Export(opts *ExportOptions) (*ExportResult, error) {
+	if opts.Format == FormatMalXML && opts.MediaType != MediaTypeAnime && opts.MediaType != MediaTypeManga {
+		return nil, ErrMalExportMediaType
+	}
+
+	entries := make([]*exportEntry, 0)
+	if opts.MediaType != MediaTypeManga && opts.AnimeCollection != nil {
+		entries = append(entries, e.fromAnimeCollection(opts.AnimeCollection)...)
+	}
+	if opts.MediaType != MediaTypeAnime && opts.MangaCollection != nil {
+		entries = append(entries, fromMangaCollection(opts.MangaCollection)...)
+	}
+
+	ret := &ExportResult{
+		Format:       opts.Format,
+		MissingMalID: make([]*Entry, 0),
+	}
+	for _, entry := range entries {
+		if entry.MalID == 0 {
+			ret.MissingMalID = append(ret.MissingMalID, entry.Entry)
+		}
+	}
+
+	var data []byte
+	var err error
+	var ext string
+
+	switch opts.Format {
+	case FormatMalXML:
+		entries = lo.Filter(entries, func(entry *exportEntry, _ int) bool { return entry.MalID > 0 })
+		data, err = toMalXML(opts.MediaType, entries)
+		ext = "xml"
+	case FormatJSON:
+		data, err = json.MarshalIndent(lo.Map(entries, func(entry *exportEntry, _ int) *Entry { return entry.Entry }), "", "  ")
+		ext = "json"
+	case FormatCSV:
+		data, err = toCSV(entries)
+		ext = "csv"
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name := "seanime"
+	if opts.MediaType != "" {
+		name += "_" + string(opts.MediaType)
+	}
+	ret.Filename = fmt.Sprintf("%s_list_%s.%s", name, time.Now().Format("2006-01-02"), ext)
+	ret.Content = string(data)
+	ret.Exported = len(entries)
+
+	if len(ret.MissingMalID) > 0 {
+		e.logger.Debug().Int("count", len(ret.MissingMalID)).Msg("list transfer: Exported entries without a MyAnimeList ID")
+	}
+
+	return ret, nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// exportEntry is an Entry with the details used by the MyAnimeList format.
+type exportEntry struct {
+	*Entry
+	MalFormat string
+	Total     int
+	Volumes   int
+}
+
+func (e *Exporter) fromAnimeCollection(collection *anilist.AnimeCollection) []*exportEntry {
+	ret := make([]*exportEntry, 0)
+	added := make(map[int]struct{})
+
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		// Entries of custom lists are also in the status lists
+		if list.GetIsCustomList() != nil && *list.GetIsCustomList() {
+			continue
+		}
+		for _, entry := range list.GetEntries() {
+			media := entry.GetMedia()
+			if media == nil || entry.GetStatus() == nil {
+				continue
+			}
+			if _, found := added[media.GetID()]; found {
+				continue
+			}
+			added[media.GetID()] = struct{}{}
+
+			ret = append(ret, &exportEntry{
+				Entry: &Entry{
+					MediaType:   MediaTypeAnime,
+					AnilistID:   media.GetID(),
+					MalID:       e.getAnimeMalID(media),
+					Title:       media.GetTitleSafe(),
+					Status:      *entry.GetStatus(),
+					Progress:    lo.FromPtr(entry.GetProgress()),
+					Score:       lo.FromPtr(entry.GetScore()) / 10,
+					StartedAt:   FormatDate(entry.GetStartedAt().GetYear(), entry.GetStartedAt().GetMonth(), entry.GetStartedAt().GetDay()),
+					CompletedAt: FormatDate(entry.GetCompletedAt().GetYear(), entry.GetCompletedAt().GetMonth(), entry.GetCompletedAt().GetDay()),
+					Repeat:      lo.FromPtr(entry.GetRepeat()),
+				},
+				MalFormat: toMalAnimeType(media.GetFormat()),
+				Total:     lo.FromPtr(media.GetEpisodes()),
+			})
+		}
+	}
+
+	return ret
+}
+
+// getAnimeMalID returns the MyAnimeList ID of the anime, using the metadata mappings when AniList doesn't have it.
+func (e *Exporter) getAnimeMalID(media *anilist.BaseAnime) int {
+	if id := lo.FromPtr(media.GetIDMal()); id > 0 {
+		return id
+	}
+	if e.metadataProvider == nil {
+		return 0
+	}
+	res, err := e.metadataProvider.GetAnimeMetadata(metadata.AnilistPlatform, media.GetID())
+	if err != nil || res == nil || res.GetMappings() == nil {
+		return 0
+	}
+	return res.GetMappings().MalId
+}
+
+func fromMangaCollection(collection *anilist.MangaCollection) []*exportEntry {
+	ret := make([]*exportEntry, 0)
+	added := make(map[int]struct{})
+
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		if list.GetIsCustomList() != nil && *list.GetIsCustomList() {
+			continue
+		}
+		for _, entry := range list.GetEntries() {
+			media := entry.GetMedia()
+			if media == nil || entry.GetStatus() == nil {
+				continue
+			}
+			if _, found := added[media.GetID()]; found {
+				continue
+			}
+			added[media.GetID()] = struct{}{}
+
+			ret = append(ret, &exportEntry{
+				Entry: &Entry{
+					MediaType:   MediaTypeManga,
+					AnilistID:   media.GetID(),
+					MalID:       lo.FromPtr(media.GetIDMal()),
+					Title:       media.GetTitleSafe(),
+					Status:      *entry.GetStatus(),
+					Progress:    lo.FromPtr(entry.GetProgress()),
+					Score:       lo.FromPtr(entry.GetScore()) / 10,
+					StartedAt:   FormatDate(entry.GetStartedAt().GetYear(), entry.GetStartedAt().GetMonth(), entry.GetStartedAt().GetDay()),
+					CompletedAt: FormatDate(entry.GetCompletedAt().GetYear(), entry.GetCompletedAt().GetMonth(), entry.GetCompletedAt().GetDay()),
+					Repeat:      lo.FromPtr(entry.GetRepeat()),
+				},
+				Total:   lo.FromPtr(media.GetChapters()),
+				Volumes: lo.FromPtr(media.GetVolumes()),
+			})
+		}
+	}
+
+	return ret
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func toMalXML(mediaType MediaType, entries []*exportEntry) ([]byte, error) {
+	export := &malExport{}
+
+	if mediaType == MediaTypeAnime {
+		export.Info = malInfo{ExportType: 1, TotalAnime: len(entries)}
+		for _, e := range entries {
+			status, rewatching := mal_platform.ToMalStatus(e.Status, false)
+			export.Anime = append(export.Anime, &malAnime{
+				ID:              strconv.Itoa(e.MalID),
+				Title:           e.Title,
+				Type:            e.MalFormat,
+				Episodes:        strconv.Itoa(e.Total),
+				WatchedEpisodes: strconv.Itoa(e.Progress),
+				StartDate:       toMalDate(e.StartedAt),
+				FinishDate:      toMalDate(e.CompletedAt),
+				Score:           strconv.Itoa(mal_platform.ToMalScore(lo.FromPtr(e.scoreRaw()))),
+				Status:          malStatusNames[status],
+				TimesWatched:    strconv.Itoa(e.Repeat),
+				Rewatching:      toMalBool(rewatching),
+				UpdateOnImport:  "1",
+			})
+		}
+	} else {
+		export.Info = malInfo{ExportType: 2, TotalManga: len(entries)}
+		for _, e := range entries {
+			status, rereading := mal_platform.ToMalStatus(e.Status, true)
+			export.Manga = append(export.Manga, &malManga{
+				ID:             strconv.Itoa(e.MalID),
+				Title:          e.Title,
+				Volumes:        strconv.Itoa(e.Volumes),
+				Chapters:       strconv.Itoa(e.Total),
+				ReadVolumes:    "0",
+				ReadChapters:   strconv.Itoa(e.Progress),
+				StartDate:      toMalDate(e.StartedAt),
+				FinishDate:     toMalDate(e.CompletedAt),
+				Score:          strconv.Itoa(mal_platform.ToMalScore(lo.FromPtr(e.scoreRaw()))),
+				Status:         malStatusNames[status],
+				TimesRead:      strconv.Itoa(e.Repeat),
+				Rereading:      toMalBool(rereading),
+				UpdateOnImport: "1",
+			})
+		}
+	}
+
+	data, err := xml.MarshalIndent(export, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// malStatusNames are the statuses as written in MyAnimeList exports.
+var malStatusNames = map[mal.MediaListStatus]string{
+	mal.MediaListStatusWatching:    "Watching",
+	mal.MediaListStatusReading:     "Reading",
+	mal.MediaListStatusCompleted:   "Completed",
+	mal.MediaListStatusOnHold:      "On-Hold",
+	mal.MediaListStatusDropped:     "Dropped",
+	mal.MediaListStatusPlanToWatch: "Plan to Watch",
+	mal.MediaListStatusPlanToRead:  "Plan to Read",
+}
+
+func toMalAnimeType(format *anilist.MediaFormat) string {
+	if format == nil {
+		return "Unknown"
+	}
+	switch *format {
+	case anilist.MediaFormatTv, anilist.MediaFormatTvShort:
+		return "TV"
+	case anilist.MediaFormatMovie:
+		return "Movie"
+	case anilist.MediaFormatOva:
+		return "OVA"
+	case anilist.MediaFormatOna:
+		return "ONA"
+	case anilist.MediaFormatSpecial:
+		return "Special"
+	case anilist.MediaFormatMusic:
+		return "Music"
+	}
+	return "Unknown"
+}
+
+// toMalDate returns the date as written in MyAnimeList exports, 0000-00-00 if unknown.
+func toMalDate(date string) string {
+	if ret := mal_platform.FormatMalDate(ParseDate(date)); ret != "" {
+		return ret
+	}
+	return "0000-00-00"
+}
+
+func toMalBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func toCSV(entries []*exportEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(csvColumns); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		record := []string{
+			string(e.MediaType),
+			strconv.Itoa(e.AnilistID),
+			strconv.Itoa(e.MalID),
+			strconv.Itoa(e.KitsuID),
+			e.Title,
+			string(e.Status),
+			strconv.Itoa(e.Progress),
+			strconv.FormatFloat(e.Score, 'f', -1, 64),
+			e.StartedAt,
+			e.CompletedAt,
+			strconv.Itoa(e.Repeat),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package listtransfer
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/util"
+	"strings"
+	"testing"
+)
+
+func getTestAnimeCollection() *anilist.AnimeCollection {
+	return &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: []*anilist.AnimeCollection_MediaListCollection_Lists{
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusCompleted),
+					Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+						{
+							Status:      lo.ToPtr(anilist.MediaListStatusCompleted),
+							Progress:    lo.ToPtr(26),
+							Score:       lo.ToPtr(85.0),
+							Repeat:      lo.ToPtr(2),
+							StartedAt:   &anilist.AnimeCollection_MediaListCollection_Lists_Entries_StartedAt{Year: lo.ToPtr(2020), Month: lo.ToPtr(3)},
+							CompletedAt: &anilist.AnimeCollection_MediaListCollection_Lists_Entries_CompletedAt{Year: lo.ToPtr(2020), Month: lo.ToPtr(4), Day: lo.ToPtr(12)},
+							Media: &anilist.BaseAnime{
+								ID:       1,
+								IDMal:    lo.ToPtr(1),
+								Format:   lo.ToPtr(anilist.MediaFormatTv),
+								Episodes: lo.ToPtr(26),
+								Title:    &anilist.BaseAnime_Title{Romaji: lo.ToPtr("Cowboy Bebop")},
+							},
+						},
+					},
+				},
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+					Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+						{
+							Status:   lo.ToPtr(anilist.MediaListStatusRepeating),
+							Progress: lo.ToPtr(3),
+							Media: &anilist.BaseAnime{
+								ID:    2,
+								IDMal: lo.ToPtr(2),
+								Title: &anilist.BaseAnime_Title{Romaji: lo.ToPtr("Trigun")},
+							},
+						},
+						// No MyAnimeList ID
+						{
+							Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+							Media: &anilist.BaseAnime{
+								ID:    3,
+								Title: &anilist.BaseAnime_Title{Romaji: lo.ToPtr("Unknown")},
+							},
+						},
+					},
+				},
+				{
+					IsCustomList: lo.ToPtr(true),
+					Entries: []*anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+						{
+							Status: lo.ToPtr(anilist.MediaListStatusCompleted),
+							Media:  &anilist.BaseAnime{ID: 1, IDMal: lo.ToPtr(1)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestExportMalXML(t *testing.T) {
+	exporter := NewExporter(&NewExporterOptions{Logger: util.NewLogger()})
+
+	res, err := exporter.Export(&ExportOptions{
+		Format:          FormatMalXML,
+		MediaType:       MediaTypeAnime,
+		AnimeCollection: getTestAnimeCollection(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Exported)
+	require.Len(t, res.MissingMalID, 1)
+	require.Equal(t, 3, res.MissingMalID[0].AnilistID)
+	require.True(t, strings.HasSuffix(res.Filename, ".xml"))
+	require.Contains(t, res.Content, "<my_status>Completed</my_status>")
+	require.Contains(t, res.Content, "<series_type>TV</series_type>")
+	// Entries being rewatched are completed on MyAnimeList
+	require.NotContains(t, res.Content, "<my_status>Watching</my_status>")
+	require.Contains(t, res.Content, "<my_rewatching>1</my_rewatching>")
+	// MyAnimeList only has complete dates
+	require.Contains(t, res.Content, "<my_start_date>2020-03-01</my_start_date>")
+
+	// The export can be imported back
+	entries, err := Parse(FormatMalXML, []byte(res.Content))
+	require.NoError(t, err)
+	require.Equal(t, []*Entry{
+		{MediaType: MediaTypeAnime, MalID: 1, Title: "Cowboy Bebop", Status: anilist.MediaListStatusCompleted, Progress: 26, Score: 9, StartedAt: "2020-03-01", CompletedAt: "2020-04-12", Repeat: 2},
+		{MediaType: MediaTypeAnime, MalID: 2, Title: "Trigun", Status: anilist.MediaListStatusRepeating, Progress: 3},
+	}, entries)
+
+	_, err = exporter.Export(&ExportOptions{Format: FormatMalXML, AnimeCollection: getTestAnimeCollection()})
+	require.ErrorIs(t, err, ErrMalExportMediaType)
+}
+
+func TestExportJSONAndCSV(t *testing.T) {
+	exporter := NewExporter(&NewExporterOptions{Logger: util.NewLogger()})
+
+	for _, format := range []Format{FormatJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			res, err := exporter.Export(&ExportOptions{
+				Format:          format,
+				AnimeCollection: getTestAnimeCollection(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 3, res.Exported)
+
+			entries, err := Parse(format, []byte(res.Content))
+			require.NoError(t, err)
+			require.Len(t, entries, 3)
+			require.Equal(t, &Entry{
+				MediaType:   MediaTypeAnime,
+				AnilistID:   1,
+				MalID:       1,
+				Title:       "Cowboy Bebop",
+				Status:      anilist.MediaListStatusCompleted,
+				Progress:    26,
+				Score:       8.5,
+				StartedAt:   "2020-03-00",
+				CompletedAt: "2020-04-12",
+				Repeat:      2,
+			}, entries[0])
+		})
+	}
+}
@@ -0,0 +1,552 @@
+package listtransfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mappings"
+	"seanime/internal/api/metadata"
+	"seanime/internal/events"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util"
+	"seanime/internal/util/limiter"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoPreview     = errors.New("list transfer: No import to apply, upload a list first")
+	ErrImportRunning = errors.New("list transfer: An import is already running")
+)
+
+const (
+	MatchedByAnilist = "anilist"
+	MatchedByMal     = "mal"
+	MatchedByKitsu   = "kitsu"
+	MatchedByTitle   = "title"
+)
+
+const (
+	// StateMatching means the entries of the uploaded list are being mapped to AniList media
+	StateMatching = "matching"
+	// StateReady means the preview can be applied
+	StateReady = "ready"
+	// StateApplying means the entries are being added to the collection
+	StateApplying = "applying"
+	// StateApplied means all the entries of the preview were added to the collection
+	StateApplied = "applied"
+	// StateFailed means the matching failed, the error is set
+	StateFailed = "failed"
+)
+
+// applyInterval is the delay between two writes to the platform.
+var applyInterval = time.Second
+
+type (
+	// Importer maps the entries of exported lists to AniList media and adds them to the collection.
+	// The import is done in two steps, Preview parses the list and maps its entries, the result is kept until it is applied.
+	// Both steps run in the background, the clients of the profile are notified of the progress with events.ListImportStatus.
+	Importer struct {
+		logger           *zerolog.Logger
+		metadataProvider metadata.Provider
+		wsEventManager   events.WSEventManagerInterface
+		mu               sync.Mutex
+		statuses         map[uint]*Status // Import of each profile
+		animeLists       *mappings.AnimeListResponse
+		animeListsAt     time.Time
+	}
+
+	NewImporterOptions struct {
+		Logger           *zerolog.Logger
+		MetadataProvider metadata.Provider
+		WSEventManager   events.WSEventManagerInterface
+	}
+
+	// Status is the state of the import of a profile.
+	Status struct {
+		State string `json:"state"`
+		// Number of entries matched or applied so far, out of Total
+		Progress int `json:"progress"`
+		Total    int `json:"total"`
+		// Preview is set once the entries are matched, it is omitted from the events.
+		// The entries that are applied are removed from it.
+		Preview *Preview `json:"preview,omitempty"`
+		// Result is the result of the last apply
+		Result *ApplyResult `json:"result,omitempty"`
+		Error  string       `json:"error,omitempty"`
+	}
+
+	// Preview is the result of the mapping of an exported list.
+	Preview struct {
+		Format    Format          `json:"format"`
+		Matched   []*MatchedEntry `json:"matched"`
+		Unmatched []*Entry        `json:"unmatched"`
+	}
+
+	MatchedEntry struct {
+		Entry   *Entry `json:"entry"`
+		MediaID int    `json:"mediaId"`
+		// How the entry was mapped to the media, "anilist", "mal", "kitsu" or "title"
+		MatchedBy  string `json:"matchedBy"`
+		Title      string `json:"title"`
+		CoverImage string `json:"coverImage"`
+		// Total number of episodes or chapters, 0 if unknown
+		Total int `json:"total"`
+	}
+
+	PreviewOptions struct {
+		ProfileID uint
+		Format    Format
+		Data      []byte
+		// AnilistClient is used to search the entries that only have a title
+		AnilistClient anilist.AnilistClient
+	}
+
+	ApplyOptions struct {
+		ProfileID uint
+		Platform  platform.Platform
+		// MediaIDs are the entries to import, all matched entries are imported if empty
+		MediaIDs []int
+		// Overwrite replaces the entries that are already in the collection
+		Overwrite bool
+		// OnApplied is called in the background once the entries are added, if there are any
+		OnApplied func(res *ApplyResult)
+	}
+
+	ApplyResult struct {
+		Applied int      `json:"applied"`
+		Skipped int      `json:"skipped"`
+		Errors  []string `json:"errors"`
+	}
+)
+
+func NewImporter(opts *NewImporterOptions) *Importer {
+	return &Importer{
+		logger:           opts.Logger,
+		metadataProvider: opts.MetadataProvider,
+		wsEventManager:   opts.WSEventManager,
+		statuses:         make(map[uint]*Status),
+	}
+}
+
+// GetStatus returns the status of the import of the profile.
+func (i *Importer) GetStatus(profileID uint) (*Status, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	ret, found := i.statuses[profileID]
+	if !found {
+		return nil, false
+	}
+	cpy := *ret
+	return &cpy, true
+}
+
+// isRunning returns true if the profile has an import being matched or applied. mu must be held.
+func (i *Importer) isRunning(profileID uint) bool {
+	status, found := i.statuses[profileID]
+	return found && (status.State == StateMatching || status.State == StateApplying)
+}
+
+// update modifies the status of the profile and sends it to the clients of the profile.
+func (i *Importer) update(profileID uint, f func(status *Status)) {
+	i.mu.Lock()
+	status := i.statuses[profileID]
+	f(status)
+	payload := *status
+	payload.Preview = nil
+	i.mu.Unlock()
+
+	if i.wsEventManager != nil {
+		i.wsEventManager.SendEventToProfile(profileID, events.ListImportStatus, &payload)
+	}
+}
+
+// Preview parses the list and maps its entries to AniList media in the background.
+// The previous preview of the profile is replaced.
+func (i *Importer) Preview(opts *PreviewOptions) (*Status, error) {
+	entries, err := Parse(opts.Format, opts.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	if i.isRunning(opts.ProfileID) {
+		i.mu.Unlock()
+		return nil, ErrImportRunning
+	}
+	status := &Status{
+		State: StateMatching,
+		Total: len(entries),
+	}
+	i.statuses[opts.ProfileID] = status
+	ret := *status
+	i.mu.Unlock()
+
+	go i.match(opts, entries)
+
+	return &ret, nil
+}
+
+func (i *Importer) match(opts *PreviewOptions, entries []*Entry) {
+	defer util.HandlePanicInModuleThen("listtransfer/match", func() {
+		i.update(opts.ProfileID, func(status *Status) {
+			status.State = StateFailed
+			status.Error = "An unexpected error occurred while matching the entries"
+		})
+	})
+
+	preview := &Preview{
+		Format:    opts.Format,
+		Matched:   make([]*MatchedEntry, 0),
+		Unmatched: make([]*Entry, 0),
+	}
+
+	onProgress := func(n int) {
+		i.update(opts.ProfileID, func(status *Status) {
+			status.Progress += n
+		})
+	}
+
+	anime := lo.Filter(entries, func(e *Entry, _ int) bool { return e.mediaType() == MediaTypeAnime })
+	manga := lo.Filter(entries, func(e *Entry, _ int) bool { return e.mediaType() == MediaTypeManga })
+
+	if len(anime) > 0 {
+		matched, unmatched := i.matchAnime(anime, opts.AnilistClient, onProgress)
+		preview.Matched = append(preview.Matched, matched...)
+		preview.Unmatched = append(preview.Unmatched, unmatched...)
+	}
+	if len(manga) > 0 {
+		matched, unmatched := i.matchManga(manga, opts.AnilistClient, onProgress)
+		preview.Matched = append(preview.Matched, matched...)
+		preview.Unmatched = append(preview.Unmatched, unmatched...)
+	}
+
+	i.logger.Debug().Int("matched", len(preview.Matched)).Int("unmatched", len(preview.Unmatched)).Msg("list transfer: Mapped imported entries")
+
+	i.update(opts.ProfileID, func(status *Status) {
+		status.State = StateReady
+		status.Progress = status.Total
+		status.Preview = preview
+	})
+}
+
+// Apply adds the matched entries of the preview to the collection through the platform in the background.
+// The applied entries are removed from the preview, the preview is kept until all of them are applied.
+// The number of rewatches is not imported since the platforms can't update it.
+func (i *Importer) Apply(opts *ApplyOptions) (*Status, error) {
+	i.mu.Lock()
+	status, found := i.statuses[opts.ProfileID]
+	if !found || status.Preview == nil {
+		i.mu.Unlock()
+		return nil, ErrNoPreview
+	}
+	if i.isRunning(opts.ProfileID) {
+		i.mu.Unlock()
+		return nil, ErrImportRunning
+	}
+
+	selected := make(map[int]struct{})
+	for _, id := range opts.MediaIDs {
+		selected[id] = struct{}{}
+	}
+	toApply := lo.Filter(status.Preview.Matched, func(m *MatchedEntry, _ int) bool {
+		_, ok := selected[m.MediaID]
+		return len(selected) == 0 || ok
+	})
+
+	status.State = StateApplying
+	status.Progress = 0
+	status.Total = len(toApply)
+	status.Result = nil
+	status.Error = ""
+	ret := *status
+	i.mu.Unlock()
+
+	go i.apply(opts, toApply)
+
+	return &ret, nil
+}
+
+func (i *Importer) apply(opts *ApplyOptions, toApply []*MatchedEntry) {
+	ret := &ApplyResult{Errors: make([]string, 0)}
+	// Entries that don't need to be applied again
+	done := make(map[int]struct{})
+
+	defer func() {
+		i.update(opts.ProfileID, func(status *Status) {
+			status.Result = ret
+			// The preview is copied since it might be read by GetStatus callers
+			preview := *status.Preview
+			preview.Matched = lo.Filter(preview.Matched, func(m *MatchedEntry, _ int) bool {
+				_, ok := done[m.MediaID]
+				return !ok
+			})
+			status.Preview = &preview
+			status.State = StateReady
+			if len(preview.Matched) == 0 {
+				status.State = StateApplied
+				status.Preview = nil
+			}
+		})
+
+		i.logger.Info().Int("applied", ret.Applied).Int("skipped", ret.Skipped).Int("errors", len(ret.Errors)).Msg("list transfer: Imported entries")
+
+		if ret.Applied > 0 && opts.OnApplied != nil {
+			opts.OnApplied(ret)
+		}
+	}()
+	defer util.HandlePanicInModuleThen("listtransfer/apply", func() {
+		ret.Errors = append(ret.Errors, "An unexpected error occurred while adding the entries")
+	})
+
+	existing := make(map[int]struct{})
+	if !opts.Overwrite {
+		if collection, err := opts.Platform.GetRawAnimeCollection(false); err == nil && collection != nil {
+			for _, list := range collection.GetMediaListCollection().GetLists() {
+				for _, entry := range list.GetEntries() {
+					existing[entry.GetMedia().GetID()] = struct{}{}
+				}
+			}
+		}
+		if collection, err := opts.Platform.GetRawMangaCollection(false); err == nil && collection != nil {
+			for _, list := range collection.GetMediaListCollection().GetLists() {
+				for _, entry := range list.GetEntries() {
+					existing[entry.GetMedia().GetID()] = struct{}{}
+				}
+			}
+		}
+	}
+
+	rateLimiter := limiter.NewLimiter(applyInterval, 1)
+
+	for _, m := range toApply {
+		if _, ok := existing[m.MediaID]; ok {
+			ret.Skipped++
+			done[m.MediaID] = struct{}{}
+			i.update(opts.ProfileID, func(status *Status) { status.Progress++ })
+			continue
+		}
+
+		progress := m.Entry.Progress
+		if m.Total > 0 && progress > m.Total {
+			progress = m.Total
+		}
+
+		rateLimiter.Wait()
+		err := opts.Platform.UpdateEntry(
+			m.MediaID,
+			lo.ToPtr(m.Entry.Status),
+			m.Entry.scoreRaw(),
+			&progress,
+			ParseDate(m.Entry.StartedAt),
+			ParseDate(m.Entry.CompletedAt),
+			nil,
+		)
+		if err != nil {
+			ret.Errors = append(ret.Errors, fmt.Sprintf("%s: %s", m.Title, err.Error()))
+		} else {
+			ret.Applied++
+			done[m.MediaID] = struct{}{}
+		}
+		i.update(opts.ProfileID, func(status *Status) { status.Progress++ })
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getAnimeLists returns the ID mappings, they are refreshed daily.
+func (i *Importer) getAnimeLists() *mappings.AnimeListResponse {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.animeLists != nil && time.Since(i.animeListsAt) < 24*time.Hour {
+		return i.animeLists
+	}
+
+	res, err := mappings.GetAnimeLists()
+	if err != nil {
+		i.logger.Warn().Err(err).Msg("list transfer: Failed to fetch the anime ID mappings")
+		return i.animeLists
+	}
+	i.animeLists = res
+	i.animeListsAt = time.Now()
+	return res
+}
+
+// matchAnime maps the entries using their AniList ID, then the MAL and Kitsu IDs, then their title.
+// onProgress is called with the number of entries that were mapped or couldn't be.
+func (i *Importer) matchAnime(entries []*Entry, client anilist.AnilistClient, onProgress func(n int)) (matched []*MatchedEntry, unmatched []*Entry) {
+	ids := make([]int, len(entries))
+	matchedBy := make([]string, len(entries))
+
+	animeLists := i.getAnimeLists()
+	malIdsToFetch := make([]int, 0)
+
+	for idx, e := range entries {
+		switch {
+		case e.AnilistID > 0:
+			ids[idx], matchedBy[idx] = e.AnilistID, MatchedByAnilist
+		case e.MalID > 0:
+			if id, ok := animeLists.FindAnilistIDFromMalID(e.MalID); ok {
+				ids[idx], matchedBy[idx] = id, MatchedByMal
+			} else if id := i.findAnilistIDFromMetadata(e.MalID); id > 0 {
+				ids[idx], matchedBy[idx] = id, MatchedByMal
+			} else {
+				malIdsToFetch = append(malIdsToFetch, e.MalID)
+			}
+		case e.KitsuID > 0:
+			if id, ok := animeLists.FindAnilistIDFromKitsuID(e.KitsuID); ok {
+				ids[idx], matchedBy[idx] = id, MatchedByKitsu
+			}
+		}
+	}
+
+	// Ask AniList for the MAL IDs that are not in the mappings
+	if len(malIdsToFetch) > 0 {
+		if res, err := anilist.FetchBaseAnimeByMalIDs(malIdsToFetch, i.logger); err == nil {
+			for idx, e := range entries {
+				if ids[idx] == 0 && e.MalID > 0 {
+					if media, ok := res[e.MalID]; ok {
+						ids[idx], matchedBy[idx] = media.GetID(), MatchedByMal
+					}
+				}
+			}
+		}
+	}
+
+	// Search the remaining entries by title
+	onProgress(len(entries) - countTitleSearches(entries, ids, client))
+	for idx, e := range entries {
+		if needsTitleSearch(e, ids[idx], client) {
+			res, err := client.ListAnime(context.Background(), lo.ToPtr(1), lo.ToPtr(e.Title), lo.ToPtr(1), []*anilist.MediaSort{lo.ToPtr(anilist.MediaSortSearchMatch)}, nil, nil, nil, nil, nil, nil, nil)
+			if err == nil && len(res.GetPage().GetMedia()) > 0 {
+				ids[idx], matchedBy[idx] = res.GetPage().GetMedia()[0].GetID(), MatchedByTitle
+			}
+			onProgress(1)
+		}
+	}
+
+	media, err := anilist.FetchBaseAnimeByIDs(lo.Uniq(lo.Filter(ids, func(id int, _ int) bool { return id > 0 })), i.logger)
+	if err != nil {
+		i.logger.Warn().Err(err).Msg("list transfer: Failed to fetch anime")
+		media = make(map[int]*anilist.BaseAnime)
+	}
+
+	added := make(map[int]struct{})
+	for idx, e := range entries {
+		m, ok := media[ids[idx]]
+		if !ok {
+			unmatched = append(unmatched, e)
+			continue
+		}
+		// Keep the first entry of each media
+		if _, ok := added[m.GetID()]; ok {
+			continue
+		}
+		added[m.GetID()] = struct{}{}
+		matched = append(matched, &MatchedEntry{
+			Entry:      e,
+			MediaID:    m.GetID(),
+			MatchedBy:  matchedBy[idx],
+			Title:      m.GetTitleSafe(),
+			CoverImage: m.GetCoverImageSafe(),
+			Total:      m.GetTotalEpisodeCount(),
+		})
+	}
+
+	return matched, unmatched
+}
+
+// findAnilistIDFromMetadata returns the AniList ID of the anime using the metadata provider, 0 if not found.
+func (i *Importer) findAnilistIDFromMetadata(malID int) int {
+	if i.metadataProvider == nil {
+		return 0
+	}
+	res, err := i.metadataProvider.GetAnimeMetadata(metadata.MalPlatform, malID)
+	if err != nil || res == nil {
+		return 0
+	}
+	return res.GetMappings().AnilistId
+}
+
+// matchManga maps the entries using their AniList ID, then their MAL ID, then their title.
+// onProgress is called with the number of entries that were mapped or couldn't be.
+func (i *Importer) matchManga(entries []*Entry, client anilist.AnilistClient, onProgress func(n int)) (matched []*MatchedEntry, unmatched []*Entry) {
+	ids := make([]int, len(entries))
+	matchedBy := make([]string, len(entries))
+
+	malIds := make([]int, 0)
+	for idx, e := range entries {
+		if e.AnilistID > 0 {
+			ids[idx], matchedBy[idx] = e.AnilistID, MatchedByAnilist
+		} else if e.MalID > 0 {
+			malIds = append(malIds, e.MalID)
+		}
+	}
+
+	if len(malIds) > 0 {
+		if res, err := anilist.FetchBaseMangaByMalIDs(malIds, i.logger); err == nil {
+			for idx, e := range entries {
+				if ids[idx] == 0 && e.MalID > 0 {
+					if media, ok := res[e.MalID]; ok {
+						ids[idx], matchedBy[idx] = media.GetID(), MatchedByMal
+					}
+				}
+			}
+		}
+	}
+
+	onProgress(len(entries) - countTitleSearches(entries, ids, client))
+	for idx, e := range entries {
+		if needsTitleSearch(e, ids[idx], client) {
+			res, err := client.SearchBaseManga(context.Background(), lo.ToPtr(1), lo.ToPtr(1), []*anilist.MediaSort{lo.ToPtr(anilist.MediaSortSearchMatch)}, lo.ToPtr(e.Title), nil)
+			if err == nil && len(res.GetPage().GetMedia()) > 0 {
+				ids[idx], matchedBy[idx] = res.GetPage().GetMedia()[0].GetID(), MatchedByTitle
+			}
+			onProgress(1)
+		}
+	}
+
+	media, err := anilist.FetchBaseMangaByIDs(lo.Uniq(lo.Filter(ids, func(id int, _ int) bool { return id > 0 })), i.logger)
+	if err != nil {
+		i.logger.Warn().Err(err).Msg("list transfer: Failed to fetch manga")
+		media = make(map[int]*anilist.BaseManga)
+	}
+
+	added := make(map[int]struct{})
+	for idx, e := range entries {
+		m, ok := media[ids[idx]]
+		if !ok {
+			unmatched = append(unmatched, e)
+			continue
+		}
+		if _, ok := added[m.GetID()]; ok {
+			continue
+		}
+		added[m.GetID()] = struct{}{}
+		matched = append(matched, &MatchedEntry{
+			Entry:      e,
+			MediaID:    m.GetID(),
+			MatchedBy:  matchedBy[idx],
+			Title:      m.GetTitleSafe(),
+			CoverImage: m.GetCoverImageSafe(),
+			Total:      lo.FromPtr(m.GetChapters()),
+		})
+	}
+
+	return matched, unmatched
+}
+
+// needsTitleSearch returns true if the entry wasn't mapped by its IDs and can be searched by title.
+func needsTitleSearch(e *Entry, id int, client anilist.AnilistClient) bool {
+	return id == 0 && e.Title != "" && client != nil
+}
+
+func countTitleSearches(entries []*Entry, ids []int, client anilist.AnilistClient) int {
+	return lo.CountBy(lo.Range(len(entries)), func(idx int) bool {
+		return needsTitleSearch(entries[idx], ids[idx], client)
+	})
+}
@@ -0,0 +1,95 @@
+package listtransfer
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePlatform fails the writes of the media in failing.
+type fakePlatform struct {
+	platform.Platform
+	mu      sync.Mutex
+	failing map[int]bool
+	updated []int
+}
+
+func (f *fakePlatform) GetRawAnimeCollection(bool) (*anilist.AnimeCollection, error) {
+	return nil, nil
+}
+
+func (f *fakePlatform) GetRawMangaCollection(bool) (*anilist.MangaCollection, error) {
+	return nil, nil
+}
+
+func (f *fakePlatform) UpdateEntry(mediaID int, _ *anilist.MediaListStatus, _ *int, _ *int, _ *anilist.FuzzyDateInput, _ *anilist.FuzzyDateInput, _ []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failing[mediaID] {
+		return errors.New("rate limited")
+	}
+	f.updated = append(f.updated, mediaID)
+	return nil
+}
+
+func TestImporter_Apply(t *testing.T) {
+	prev := applyInterval
+	applyInterval = time.Millisecond
+	defer func() { applyInterval = prev }()
+
+	importer := NewImporter(&NewImporterOptions{
+		Logger: util.NewLogger(),
+	})
+
+	_, err := importer.Apply(&ApplyOptions{ProfileID: 1})
+	require.ErrorIs(t, err, ErrNoPreview)
+
+	importer.statuses[1] = &Status{
+		State: StateReady,
+		Preview: &Preview{
+			Matched: []*MatchedEntry{
+				{Entry: &Entry{Status: anilist.MediaListStatusCurrent}, MediaID: 1},
+				{Entry: &Entry{Status: anilist.MediaListStatusCompleted}, MediaID: 2},
+			},
+		},
+	}
+
+	waitForState := func(state string) *Status {
+		var ret *Status
+		require.Eventually(t, func() bool {
+			ret, _ = importer.GetStatus(1)
+			return ret.State == state
+		}, 5*time.Second, 10*time.Millisecond)
+		return ret
+	}
+
+	// Entries that fail are kept in the preview
+	fake := &fakePlatform{failing: map[int]bool{2: true}}
+	status, err := importer.Apply(&ApplyOptions{ProfileID: 1, Platform: fake})
+	require.NoError(t, err)
+	require.Equal(t, StateApplying, status.State)
+	require.Equal(t, 2, status.Total)
+
+	status = waitForState(StateReady)
+	require.Equal(t, 1, status.Result.Applied)
+	require.Len(t, status.Result.Errors, 1)
+	require.Len(t, status.Preview.Matched, 1)
+	require.Equal(t, 2, status.Preview.Matched[0].MediaID)
+
+	// The preview is removed once all the entries are applied
+	fake.failing = nil
+	_, err = importer.Apply(&ApplyOptions{ProfileID: 1, Platform: fake})
+	require.NoError(t, err)
+
+	status = waitForState(StateApplied)
+	require.Nil(t, status.Preview)
+	require.Equal(t, []int{1, 2}, fake.updated)
+
+	_, err = importer.Apply(&ApplyOptions{ProfileID: 1, Platform: fake})
+	require.ErrorIs(t, err, ErrNoPreview)
+}
@@ -0,0 +1,403 @@
+package listtransfer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"io"
+	"seanime/internal/api/anilist"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownFormat = errors.New("list transfer: Unknown format")
+	ErrNoEntries     = errors.New("list transfer: No entries found")
+)
+
+type (
+	// malExport is a list exported from MyAnimeList.
+	malExport struct {
+		XMLName xml.Name    `xml:"myanimelist"`
+		Info    malInfo     `xml:"myinfo"`
+		Anime   []*malAnime `xml:"anime"`
+		Manga   []*malManga `xml:"manga"`
+	}
+
+	malInfo struct {
+		UserName string `xml:"user_name,omitempty"`
+		// 1 for anime, 2 for manga
+		ExportType int `xml:"user_export_type"`
+		TotalAnime int `xml:"user_total_anime,omitempty"`
+		TotalManga int `xml:"user_total_manga,omitempty"`
+	}
+
+	malAnime struct {
+		ID              string `xml:"series_animedb_id"`
+		Title           string `xml:"series_title"`
+		Type            string `xml:"series_type,omitempty"`
+		Episodes        string `xml:"series_episodes,omitempty"`
+		WatchedEpisodes string `xml:"my_watched_episodes"`
+		StartDate       string `xml:"my_start_date"`
+		FinishDate      string `xml:"my_finish_date"`
+		Score           string `xml:"my_score"`
+		Status          string `xml:"my_status"`
+		TimesWatched    string `xml:"my_times_watched"`
+		Rewatching      string `xml:"my_rewatching"`
+		UpdateOnImport  string `xml:"update_on_import,omitempty"`
+	}
+
+	malManga struct {
+		ID             string `xml:"manga_mangadb_id"`
+		Title          string `xml:"manga_title"`
+		Volumes        string `xml:"manga_volumes,omitempty"`
+		Chapters       string `xml:"manga_chapters,omitempty"`
+		ReadVolumes    string `xml:"my_read_volumes,omitempty"`
+		ReadChapters   string `xml:"my_read_chapters"`
+		StartDate      string `xml:"my_start_date"`
+		FinishDate     string `xml:"my_finish_date"`
+		Score          string `xml:"my_score"`
+		Status         string `xml:"my_status"`
+		TimesRead      string `xml:"my_times_read"`
+		Rereading      string `xml:"my_rereading"`
+		UpdateOnImport string `xml:"update_on_import,omitempty"`
+	}
+
+	// jsonEntry is an entry of the generic JSON format, the status can be in any tracker's format.
+	jsonEntry struct {
+		Type        string  `json:"type"`
+		AnilistID   int     `json:"anilistId"`
+		MalID       int     `json:"malId"`
+		KitsuID     int     `json:"kitsuId"`
+		Title       string  `json:"title"`
+		Status      string  `json:"status"`
+		Progress    int     `json:"progress"`
+		Score       float64 `json:"score"`
+		StartedAt   string  `json:"startedAt"`
+		CompletedAt string  `json:"completedAt"`
+		Repeat      int     `json:"repeat"`
+	}
+
+	// anilistCollection is a MediaListCollection as returned by the AniList API.
+	anilistCollection struct {
+		Lists []struct {
+			IsCustomList *bool `json:"isCustomList"`
+			Entries      []struct {
+				Status      string        `json:"status"`
+				Progress    *int          `json:"progress"`
+				Score       *float64      `json:"score"` // POINT_100
+				Repeat      *int          `json:"repeat"`
+				StartedAt   *anilistDate  `json:"startedAt"`
+				CompletedAt *anilistDate  `json:"completedAt"`
+				Media       *anilistMedia `json:"media"`
+			} `json:"entries"`
+		} `json:"lists"`
+	}
+
+	anilistDate struct {
+		Year  *int `json:"year"`
+		Month *int `json:"month"`
+		Day   *int `json:"day"`
+	}
+
+	anilistMedia struct {
+		ID    int    `json:"id"`
+		IDMal *int   `json:"idMal"`
+		Type  string `json:"type"`
+		Title *struct {
+			UserPreferred *string `json:"userPreferred"`
+			Romaji        *string `json:"romaji"`
+			English       *string `json:"english"`
+		} `json:"title"`
+	}
+)
+
+// Parse reads the entries of an exported list.
+func Parse(format Format, data []byte) ([]*Entry, error) {
+	var ret []*Entry
+	var err error
+
+	switch format {
+	case FormatMalXML:
+		ret, err = parseMalXML(data)
+	case FormatJSON:
+		ret, err = parseJSON(data)
+	case FormatCSV:
+		ret, err = parseCSV(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, ErrNoEntries
+	}
+	return ret, nil
+}
+
+func atoi(s string) int {
+	v, _ := strconv.Atoi(strings.TrimSpace(s))
+	return v
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func parseMalXML(data []byte) ([]*Entry, error) {
+	var export malExport
+	if err := xml.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("list transfer: Invalid MyAnimeList export: %w", err)
+	}
+
+	ret := make([]*Entry, 0, len(export.Anime)+len(export.Manga))
+
+	for _, a := range export.Anime {
+		status, ok := ParseStatus(a.Status)
+		if !ok {
+			continue
+		}
+		// MyAnimeList keeps the entries being rewatched in the completed list
+		if atoi(a.Rewatching) == 1 {
+			status = anilist.MediaListStatusRepeating
+		}
+		ret = append(ret, &Entry{
+			MediaType:   MediaTypeAnime,
+			MalID:       atoi(a.ID),
+			Title:       strings.TrimSpace(a.Title),
+			Status:      status,
+			Progress:    atoi(a.WatchedEpisodes),
+			Score:       float64(atoi(a.Score)),
+			StartedAt:   normalizeDate(a.StartDate),
+			CompletedAt: normalizeDate(a.FinishDate),
+			Repeat:      atoi(a.TimesWatched),
+		})
+	}
+
+	for _, m := range export.Manga {
+		status, ok := ParseStatus(m.Status)
+		if !ok {
+			continue
+		}
+		// MyAnimeList keeps the entries being rewatched in the completed list
+		if atoi(m.Rereading) == 1 {
+			status = anilist.MediaListStatusRepeating
+		}
+		ret = append(ret, &Entry{
+			MediaType:   MediaTypeManga,
+			MalID:       atoi(m.ID),
+			Title:       strings.TrimSpace(m.Title),
+			Status:      status,
+			Progress:    atoi(m.ReadChapters),
+			Score:       float64(atoi(m.Score)),
+			StartedAt:   normalizeDate(m.StartDate),
+			CompletedAt: normalizeDate(m.FinishDate),
+			Repeat:      atoi(m.TimesRead),
+		})
+	}
+
+	return ret, nil
+}
+
+// normalizeDate returns the date as YYYY-MM-DD, or an empty string if it is unknown.
+func normalizeDate(date string) string {
+	d := ParseDate(date)
+	if d == nil {
+		return ""
+	}
+	return FormatDate(d.Year, d.Month, d.Day)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseJSON reads a list of entries in the generic format, an object with an "entries" array,
+// or an AniList MediaListCollection, e.g. the raw collection returned by Seanime.
+func parseJSON(data []byte) ([]*Entry, error) {
+	data = bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(data, []byte("[")) {
+		var entries []*jsonEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("list transfer: Invalid JSON: %w", err)
+		}
+		return fromJSONEntries(entries), nil
+	}
+
+	var obj struct {
+		Entries             []*jsonEntry       `json:"entries"`
+		MediaListCollection *anilistCollection `json:"MediaListCollection"`
+		Data                *struct {
+			MediaListCollection *anilistCollection `json:"MediaListCollection"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("list transfer: Invalid JSON: %w", err)
+	}
+
+	switch {
+	case obj.Entries != nil:
+		return fromJSONEntries(obj.Entries), nil
+	case obj.MediaListCollection != nil:
+		return fromAnilistCollection(obj.MediaListCollection), nil
+	case obj.Data != nil && obj.Data.MediaListCollection != nil:
+		return fromAnilistCollection(obj.Data.MediaListCollection), nil
+	}
+
+	return nil, ErrNoEntries
+}
+
+func fromJSONEntries(entries []*jsonEntry) []*Entry {
+	ret := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		if e == nil {
+			continue
+		}
+		status, ok := ParseStatus(e.Status)
+		if !ok {
+			continue
+		}
+		ret = append(ret, &Entry{
+			MediaType:   parseMediaType(e.Type),
+			AnilistID:   e.AnilistID,
+			MalID:       e.MalID,
+			KitsuID:     e.KitsuID,
+			Title:       strings.TrimSpace(e.Title),
+			Status:      status,
+			Progress:    e.Progress,
+			Score:       e.Score,
+			StartedAt:   normalizeDate(e.StartedAt),
+			CompletedAt: normalizeDate(e.CompletedAt),
+			Repeat:      e.Repeat,
+		})
+	}
+	return ret
+}
+
+func fromAnilistCollection(collection *anilistCollection) []*Entry {
+	ret := make([]*Entry, 0)
+	added := make(map[int]struct{})
+
+	for _, list := range collection.Lists {
+		// Entries of custom lists are also in the status lists
+		if list.IsCustomList != nil && *list.IsCustomList {
+			continue
+		}
+		for _, e := range list.Entries {
+			if e.Media == nil || e.Media.ID == 0 {
+				continue
+			}
+			if _, found := added[e.Media.ID]; found {
+				continue
+			}
+			status, ok := ParseStatus(e.Status)
+			if !ok {
+				continue
+			}
+			added[e.Media.ID] = struct{}{}
+
+			entry := &Entry{
+				MediaType: parseMediaType(e.Media.Type),
+				AnilistID: e.Media.ID,
+				Status:    status,
+			}
+			if e.Media.IDMal != nil {
+				entry.MalID = *e.Media.IDMal
+			}
+			if e.Media.Title != nil {
+				for _, title := range []*string{e.Media.Title.UserPreferred, e.Media.Title.Romaji, e.Media.Title.English} {
+					if title != nil && *title != "" {
+						entry.Title = *title
+						break
+					}
+				}
+			}
+			if e.Progress != nil {
+				entry.Progress = *e.Progress
+			}
+			if e.Score != nil {
+				entry.Score = *e.Score / 10
+			}
+			if e.Repeat != nil {
+				entry.Repeat = *e.Repeat
+			}
+			if e.StartedAt != nil {
+				entry.StartedAt = FormatDate(e.StartedAt.Year, e.StartedAt.Month, e.StartedAt.Day)
+			}
+			if e.CompletedAt != nil {
+				entry.CompletedAt = FormatDate(e.CompletedAt.Year, e.CompletedAt.Month, e.CompletedAt.Day)
+			}
+			ret = append(ret, entry)
+		}
+	}
+
+	return ret
+}
+
+func parseMediaType(s string) MediaType {
+	if strings.EqualFold(s, string(MediaTypeManga)) {
+		return MediaTypeManga
+	}
+	return MediaTypeAnime
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseCSV reads a CSV file with a header row, see csvColumns.
+// The column names are case-insensitive and unknown columns are ignored.
+func parseCSV(data []byte) ([]*Entry, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("list transfer: Invalid CSV: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, found := columns["status"]; !found {
+		return nil, errors.New("list transfer: The CSV file has no status column")
+	}
+
+	entries := make([]*jsonEntry, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list transfer: Invalid CSV: %w", err)
+		}
+
+		get := func(column string) string {
+			i, found := columns[strings.ToLower(column)]
+			if !found || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		score, _ := strconv.ParseFloat(get("score"), 64)
+		entries = append(entries, &jsonEntry{
+			Type:        get("type"),
+			AnilistID:   atoi(get("anilistId")),
+			MalID:       atoi(get("malId")),
+			KitsuID:     atoi(get("kitsuId")),
+			Title:       get("title"),
+			Status:      get("status"),
+			Progress:    atoi(get("progress")),
+			Score:       score,
+			StartedAt:   get("startedAt"),
+			CompletedAt: get("completedAt"),
+			Repeat:      atoi(get("repeat")),
+		})
+	}
+
+	return fromJSONEntries(entries), nil
+}
@@ -0,0 +1,150 @@
+package listtransfer
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"testing"
+)
+
+func TestParseMalXML(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+	<myinfo>
+		<user_name>user</user_name>
+		<user_export_type>1</user_export_type>
+	</myinfo>
+	<anime>
+		<series_animedb_id>21</series_animedb_id>
+		<series_title><![CDATA[One Piece]]></series_title>
+		<my_watched_episodes>1000</my_watched_episodes>
+		<my_start_date>2015-03-00</my_start_date>
+		<my_finish_date>0000-00-00</my_finish_date>
+		<my_score>9</my_score>
+		<my_status>Watching</my_status>
+		<my_times_watched>0</my_times_watched>
+		<my_rewatching>0</my_rewatching>
+	</anime>
+	<anime>
+		<series_animedb_id>1</series_animedb_id>
+		<series_title><![CDATA[Cowboy Bebop]]></series_title>
+		<my_watched_episodes>5</my_watched_episodes>
+		<my_start_date>2020-01-02</my_start_date>
+		<my_finish_date>2020-01-20</my_finish_date>
+		<my_score>10</my_score>
+		<my_status>Watching</my_status>
+		<my_times_watched>2</my_times_watched>
+		<my_rewatching>1</my_rewatching>
+	</anime>
+	<manga>
+		<manga_mangadb_id>2</manga_mangadb_id>
+		<manga_title><![CDATA[Berserk]]></manga_title>
+		<my_read_chapters>300</my_read_chapters>
+		<my_start_date>0000-00-00</my_start_date>
+		<my_finish_date>0000-00-00</my_finish_date>
+		<my_score>0</my_score>
+		<my_status>On-Hold</my_status>
+		<my_times_read>0</my_times_read>
+		<my_rereading>0</my_rereading>
+	</manga>
+</myanimelist>`)
+
+	entries, err := Parse(FormatMalXML, data)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	require.Equal(t, &Entry{
+		MediaType: MediaTypeAnime,
+		MalID:     21,
+		Title:     "One Piece",
+		Status:    anilist.MediaListStatusCurrent,
+		Progress:  1000,
+		Score:     9,
+		StartedAt: "2015-03-00",
+	}, entries[0])
+
+	require.Equal(t, anilist.MediaListStatusRepeating, entries[1].Status)
+	require.Equal(t, 2, entries[1].Repeat)
+	require.Equal(t, "2020-01-20", entries[1].CompletedAt)
+
+	require.Equal(t, MediaTypeManga, entries[2].MediaType)
+	require.Equal(t, anilist.MediaListStatusPaused, entries[2].Status)
+	require.Equal(t, 300, entries[2].Progress)
+	require.Nil(t, entries[2].scoreRaw())
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []*Entry
+	}{
+		{
+			name: "Array",
+			data: `[{"type": "anime", "malId": 1, "title": "Cowboy Bebop", "status": "plan_to_watch"}]`,
+			expected: []*Entry{
+				{MediaType: MediaTypeAnime, MalID: 1, Title: "Cowboy Bebop", Status: anilist.MediaListStatusPlanning},
+			},
+		},
+		{
+			name: "Entries",
+			data: `{"entries": [{"type": "manga", "kitsuId": 5, "status": "current", "progress": 10, "score": 7.5, "startedAt": "2021-5"}]}`,
+			expected: []*Entry{
+				{MediaType: MediaTypeManga, KitsuID: 5, Status: anilist.MediaListStatusCurrent, Progress: 10, Score: 7.5, StartedAt: "2021-05-00"},
+			},
+		},
+		{
+			name: "AniList collection",
+			data: `{"data": {"MediaListCollection": {"lists": [
+				{"isCustomList": false, "entries": [{"status": "COMPLETED", "progress": 26, "score": 85, "repeat": 1,
+					"completedAt": {"year": 2022, "month": 4, "day": null},
+					"media": {"id": 1, "idMal": 1, "type": "ANIME", "title": {"userPreferred": "Cowboy Bebop"}}}]},
+				{"isCustomList": true, "entries": [{"status": "COMPLETED", "media": {"id": 1}}]}
+			]}}}`,
+			expected: []*Entry{
+				{MediaType: MediaTypeAnime, AnilistID: 1, MalID: 1, Title: "Cowboy Bebop", Status: anilist.MediaListStatusCompleted, Progress: 26, Score: 8.5, CompletedAt: "2022-04-00", Repeat: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Parse(FormatJSON, []byte(tt.data))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, entries)
+		})
+	}
+
+	_, err := Parse(FormatJSON, []byte(`{"entries": []}`))
+	require.ErrorIs(t, err, ErrNoEntries)
+}
+
+func TestParseCSV(t *testing.T) {
+	data := []byte("\xef\xbb\xbfTitle,Status,Progress,Score,anilistId,unknown\n" +
+		"\"Cowboy Bebop, the Movie\",Completed,1,8,5,x\n" +
+		"Trigun,Invalid,3,,,\n")
+
+	entries, err := Parse(FormatCSV, data)
+	require.NoError(t, err)
+	require.Equal(t, []*Entry{
+		{MediaType: MediaTypeAnime, AnilistID: 5, Title: "Cowboy Bebop, the Movie", Status: anilist.MediaListStatusCompleted, Progress: 1, Score: 8},
+	}, entries)
+
+	_, err = Parse(FormatCSV, []byte("title,progress\nTrigun,3\n"))
+	require.Error(t, err)
+}
+
+func TestParseDate(t *testing.T) {
+	require.Nil(t, ParseDate(""))
+	require.Nil(t, ParseDate("0000-00-00"))
+	require.Equal(t, &anilist.FuzzyDateInput{Year: lo.ToPtr(2020)}, ParseDate("2020-00-00"))
+	require.Equal(t, &anilist.FuzzyDateInput{Year: lo.ToPtr(2020), Month: lo.ToPtr(1), Day: lo.ToPtr(2)}, ParseDate("2020-01-02"))
+	require.Equal(t, "2020-01-00", FormatDate(lo.ToPtr(2020), lo.ToPtr(1), nil))
+	require.Equal(t, "", FormatDate(nil, nil, nil))
+}
+
+func TestScoreRaw(t *testing.T) {
+	require.Equal(t, 85, *(&Entry{Score: 8.5}).scoreRaw())
+	require.Equal(t, 100, *(&Entry{Score: 12}).scoreRaw())
+	require.Nil(t, (&Entry{}).scoreRaw())
+}
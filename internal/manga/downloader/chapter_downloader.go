@@ -19,7 +19,9 @@ import (
 	"seanime/internal/database/db"
 	"seanime/internal/events"
 	"seanime/internal/manga/providers"
+	"seanime/internal/notifier"
 	"seanime/internal/util"
+	"seanime/internal/webhook"
 	"strconv"
 	"strings"
 	"sync"
@@ -270,6 +272,15 @@ func (cd *Downloader) downloadChapterImages(queueInfo *QueueInfo) (err error) {
 
 	if queueInfo.Status != QueueStatusErrored {
 		cd.logger.Info().Msgf("chapter downloader: Finished downloading chapter %s", queueInfo.ChapterId)
+		webhook.GlobalManager.Dispatch(webhook.EventMangaChapterDownloaded, &webhook.ChapterDownloadedPayload{
+			MediaId:       queueInfo.MediaId,
+			Provider:      queueInfo.Provider,
+			ChapterId:     queueInfo.ChapterId,
+			ChapterNumber: queueInfo.ChapterNumber,
+		})
+		notifier.GlobalNotifier.NotifyWithDetails(notifier.MangaDownloader, fmt.Sprintf("Chapter %s has been downloaded.", queueInfo.ChapterNumber), &notifier.Details{
+			MediaId: queueInfo.MediaId,
+		})
 	}
 
 	if queueInfo.Status == QueueStatusErrored {
@@ -7,6 +7,7 @@ import (
 	"seanime/internal/database/db"
 	"seanime/internal/database/models"
 	"seanime/internal/events"
+	"seanime/internal/metrics"
 	"seanime/internal/util"
 	"sync"
 	"time"
@@ -78,6 +79,7 @@ func (q *Queue) Add(id DownloadID, pages []*hibikemanga.ChapterPage, runNext boo
 	}
 
 	q.logger.Info().Msgf("chapter downloader: Added chapter to download queue: %s", id.ChapterId)
+	q.updateQueueDepthMetric()
 
 	q.wsEventManager.SendEvent(events.ChapterDownloadQueueUpdated, nil)
 
@@ -106,6 +108,7 @@ func (q *Queue) HasCompleted(queueInfo *QueueInfo) {
 			return
 		}
 	}
+	q.updateQueueDepthMetric()
 
 	q.wsEventManager.SendEvent(events.ChapterDownloadQueueUpdated, nil)
 	q.wsEventManager.SendEvent(events.RefreshedMangaDownloadData, nil)
@@ -119,6 +122,15 @@ func (q *Queue) HasCompleted(queueInfo *QueueInfo) {
 	}
 }
 
+// updateQueueDepthMetric reports the number of chapters in the download queue.
+func (q *Queue) updateQueueDepthMetric() {
+	items, err := q.db.GetChapterDownloadQueue()
+	if err != nil {
+		return
+	}
+	metrics.MangaDownloadQueueDepth.Set(float64(len(items)))
+}
+
 // Run activates the queue and invokes runNext
 func (q *Queue) Run() {
 	q.mu.Lock()
@@ -129,6 +141,7 @@ func (q *Queue) Run() {
 	}
 
 	q.active = true
+	q.updateQueueDepthMetric()
 
 	// Tells queue to run next if possible
 	q.runNext()
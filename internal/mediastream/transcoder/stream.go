@@ -17,6 +17,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"seanime/internal/metrics"
 	"seanime/internal/util"
 	"slices"
 	"strings"
@@ -502,6 +503,7 @@ func (ts *Stream) run(start int32) error {
 	if err != nil {
 		return err
 	}
+	metrics.FfmpegProcesses.Inc()
 	ts.lockHeads()
 	ts.heads[encoderId].command = cmd
 	ts.heads[encoderId].stdin = stdin
@@ -589,6 +591,7 @@ func (ts *Stream) run(start int32) error {
 	// Listen for process termination
 	go func() {
 		err := cmd.Wait()
+		metrics.FfmpegProcesses.Dec()
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) && exitErr.ExitCode() == 255 {
 			streamLogger.Trace().Int("eid", encoderId).Msgf("transcoder: ffmpeg process was terminated")
@@ -162,6 +162,7 @@ func (t *Tracker) DestroyStreamIfOld(path string) {
 		return
 	}
 	t.transcoder.streams.Delete(path)
+	t.transcoder.updateStreamsMetric()
 	stream.Destroy()
 }
 
@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"seanime/internal/mediastream/videofile"
+	"seanime/internal/metrics"
 	"seanime/internal/util/result"
 	"time"
 )
@@ -99,6 +100,7 @@ func (t *Transcoder) Destroy() {
 	t.streams.Clear()
 	//close(t.clientChan)
 	t.streams = result.NewResultMap[string, *FileStream]()
+	t.updateStreamsMetric()
 	t.clientChan = make(chan ClientInfo, 10)
 	t.logger.Debug().Msg("transcoder: Transcoder destroyed")
 }
@@ -113,17 +115,24 @@ func (t *Transcoder) getFileStream(path string, hash string, mediaInfo *videofil
 	ret, _ := t.streams.GetOrSet(path, func() (*FileStream, error) {
 		return NewFileStream(path, hash, mediaInfo, &t.settings, t.logger), nil
 	})
+	t.updateStreamsMetric()
 	ret.ready.Wait()
 	if ret == nil {
 		return nil, fmt.Errorf("could not get filestream, file may not exist")
 	}
 	if err != nil || ret.err != nil {
 		t.streams.Delete(path)
+		t.updateStreamsMetric()
 		return nil, ret.err
 	}
 	return ret, nil
 }
 
+// updateStreamsMetric reports the number of files currently being transcoded.
+func (t *Transcoder) updateStreamsMetric() {
+	metrics.TranscodeStreams.Set(float64(len(t.streams.Values())))
+}
+
 func (t *Transcoder) GetMaster(path string, hash string, mediaInfo *videofile.MediaInfo, client string) (string, error) {
 	if debugStream {
 		start := time.Now()
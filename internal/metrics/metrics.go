@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "seanime"
+
+var (
+	// Registry holds all the collectors exposed by the metrics endpoint.
+	// A dedicated registry is used so that metrics registered by dependencies are not exposed.
+	Registry = prometheus.NewRegistry()
+
+	// +---------------------+
+	// |        HTTP         |
+	// +---------------------+
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// +---------------------+
+	// |     Transcoder      |
+	// +---------------------+
+
+	TranscodeStreams = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "transcoder",
+		Name:      "active_streams",
+		Help:      "Number of files currently being transcoded.",
+	})
+
+	FfmpegProcesses = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "transcoder",
+		Name:      "ffmpeg_processes",
+		Help:      "Number of running ffmpeg processes.",
+	})
+
+	// +---------------------+
+	// |    Torrentstream    |
+	// +---------------------+
+
+	TorrentstreamDownloadRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "torrentstream",
+		Name:      "download_rate_bytes",
+		Help:      "Download rate of the current torrent stream in bytes per second.",
+	})
+
+	TorrentstreamPeers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "torrentstream",
+		Name:      "peers",
+		Help:      "Number of active peers of the current torrent stream.",
+	})
+
+	// +---------------------+
+	// |   Auto Downloader   |
+	// +---------------------+
+
+	AutoDownloaderRuns = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "autodownloader",
+		Name:      "runs_total",
+		Help:      "Number of times the auto downloader checked for new episodes.",
+	})
+
+	AutoDownloaderMatches = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "autodownloader",
+		Name:      "matches_total",
+		Help:      "Number of torrents that matched a rule.",
+	})
+
+	AutoDownloaderDownloads = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "autodownloader",
+		Name:      "downloads_total",
+		Help:      "Number of torrents downloaded or added to the queue.",
+	})
+
+	// +---------------------+
+	// |       Scanner       |
+	// +---------------------+
+
+	ScanDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "scan_duration_seconds",
+		Help:      "Duration of library scans.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200},
+	})
+
+	ScanFiles = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "files",
+		Help:      "Number of files found by the last scan.",
+	}, []string{"status"})
+
+	// +---------------------+
+	// |        Manga        |
+	// +---------------------+
+
+	MangaDownloadQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "manga",
+		Name:      "download_queue_depth",
+		Help:      "Number of chapters waiting in the download queue.",
+	})
+
+	// +---------------------+
+	// |     Extensions      |
+	// +---------------------+
+
+	ExtensionErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "extensions",
+		Name:      "errors_total",
+		Help:      "Number of errors returned by extension calls.",
+	}, []string{"extension_id"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		TranscodeStreams,
+		FfmpegProcesses,
+		TorrentstreamDownloadRate,
+		TorrentstreamPeers,
+		AutoDownloaderRuns,
+		AutoDownloaderMatches,
+		AutoDownloaderDownloads,
+		ScanDuration,
+		ScanFiles,
+		MangaDownloadQueueDepth,
+		ExtensionErrors,
+	)
+}
+
+// Handler returns the HTTP handler that serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
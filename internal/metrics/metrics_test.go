@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	ExtensionErrors.WithLabelValues("test-extension").Inc()
+	TranscodeStreams.Set(2)
+	HTTPRequestDuration.WithLabelValues("GET", "/api/v1/status", "200").Observe(0.1)
+
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+
+	res, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	require.Contains(t, string(body), `seanime_extensions_errors_total{extension_id="test-extension"} 1`)
+	require.Contains(t, string(body), "seanime_transcoder_active_streams 2")
+	require.Contains(t, string(body), `seanime_http_request_duration_seconds_count{method="GET",route="/api/v1/status",status="200"} 1`)
+	require.Contains(t, string(body), "go_goroutines")
+}
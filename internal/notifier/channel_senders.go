@@ -0,0 +1,231 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/goccy/go-json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"seanime/internal/constants"
+	"seanime/internal/database/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const telegramApiUrl = "https://api.telegram.org"
+
+// sendNtfy publishes the message to the ntfy topic.
+// https://docs.ntfy.sh/publish/
+func (n *Notifier) sendNtfy(ctx context.Context, c *models.NotificationChannel, msg *channelMessage) error {
+	headers := map[string]string{
+		"Title": msg.Title,
+		"Tags":  "seanime",
+	}
+	if c.Priority > 0 {
+		headers["Priority"] = strconv.Itoa(c.Priority)
+	}
+	if c.Token != "" {
+		headers["Authorization"] = "Bearer " + c.Token
+	}
+	return n.post(ctx, c.URL, "text/plain", []byte(msg.Message), headers)
+}
+
+// sendGotify creates a message with the application token.
+// https://gotify.net/api-docs#/message/createMessage
+func (n *Notifier) sendGotify(ctx context.Context, c *models.NotificationChannel, msg *channelMessage) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"title":    msg.Title,
+		"message":  msg.Message,
+		"priority": c.Priority,
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, strings.TrimSuffix(c.URL, "/")+"/message", "application/json", body, map[string]string{
+		"X-Gotify-Key": c.Token,
+	})
+}
+
+// sendDiscord executes the Discord webhook with an embed.
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+func (n *Notifier) sendDiscord(ctx context.Context, c *models.NotificationChannel, msg *channelMessage) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"username": "Seanime",
+		"embeds": []map[string]interface{}{
+			{
+				"title":       msg.Title,
+				"description": msg.Message,
+				"timestamp":   msg.Time.Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, c.URL, "application/json", body, nil)
+}
+
+// sendTelegram sends the message to the chat with the Bot API.
+// https://core.telegram.org/bots/api#sendmessage
+func (n *Notifier) sendTelegram(ctx context.Context, c *models.NotificationChannel, msg *channelMessage) error {
+	apiUrl := telegramApiUrl
+	if c.URL != "" {
+		apiUrl = strings.TrimSuffix(c.URL, "/")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id": c.ChatID,
+		"text":    msg.Title + "\n\n" + msg.Message,
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, fmt.Sprintf("%s/bot%s/sendMessage", apiUrl, c.Token), "application/json", body, nil)
+}
+
+// sendJson posts the notification as JSON to the URL.
+func (n *Notifier) sendJson(ctx context.Context, c *models.NotificationChannel, msg *channelMessage) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"kind":      msg.Kind,
+		"title":     msg.Title,
+		"message":   msg.Message,
+		"timestamp": msg.Time.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if c.Token != "" {
+		headers["Authorization"] = "Bearer " + c.Token
+	}
+	return n.post(ctx, c.URL, "application/json", body, headers)
+}
+
+func (n *Notifier) post(ctx context.Context, url string, contentType string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "Seanime/"+constants.Version)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("unexpected response status: %s %s", res.Status, strings.TrimSpace(string(resBody)))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// smtpTimeout is the maximum duration of an SMTP session.
+const smtpTimeout = 30 * time.Second
+
+// sendSmtp sends the notification by email.
+// Port 465 uses implicit TLS, other ports use STARTTLS when the server supports it.
+func sendSmtp(c *models.NotificationChannel, msg *channelMessage) error {
+	port := c.SmtpPort
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(c.SmtpHost, strconv.Itoa(port))
+
+	from := c.SmtpFrom
+	if from == "" {
+		from = c.SmtpUsername
+	}
+
+	var auth smtp.Auth
+	if c.SmtpUsername != "" {
+		auth = smtp.PlainAuth("", c.SmtpUsername, c.SmtpPassword, c.SmtpHost)
+	}
+
+	body := buildEmail(from, c.SmtpTo, msg)
+
+	var conn net.Conn
+	var err error
+	if port == 465 {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: smtpTimeout}, "tcp", addr, &tls.Config{ServerName: c.SmtpHost})
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, smtpTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	// Fail instead of hanging if the server stops responding
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, c.SmtpHost)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: c.SmtpHost}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range c.SmtpTo {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func buildEmail(from string, to []string, msg *channelMessage) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	// Line breaks would allow injecting headers
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(msg.Title)
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + msg.Time.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(msg.Message, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
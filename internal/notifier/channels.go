@@ -0,0 +1,295 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"seanime/internal/database/models"
+	"seanime/internal/util"
+	"sync"
+	"text/template"
+	"time"
+)
+
+type ChannelType string
+
+const (
+	ChannelNtfy     ChannelType = "ntfy"
+	ChannelGotify   ChannelType = "gotify"
+	ChannelDiscord  ChannelType = "discord"
+	ChannelTelegram ChannelType = "telegram"
+	ChannelSmtp     ChannelType = "smtp"
+	ChannelJson     ChannelType = "json"
+
+	DefaultTitleTemplate   = "Seanime: {{.Kind}}"
+	DefaultMessageTemplate = "{{.Message}}"
+)
+
+// ChannelTypes are the built-in notification channels.
+var ChannelTypes = []ChannelType{
+	ChannelNtfy,
+	ChannelGotify,
+	ChannelDiscord,
+	ChannelTelegram,
+	ChannelSmtp,
+	ChannelJson,
+}
+
+var (
+	ErrRateLimited     = errors.New("rate limit reached")
+	ErrUnknownChannel  = errors.New("unknown channel type")
+	ErrMissingURL      = errors.New("invalid or missing URL")
+	ErrMissingSmtpHost = errors.New("missing SMTP host or recipients")
+	ErrMissingTelegram = errors.New("missing Telegram bot token or chat ID")
+)
+
+type (
+	// TemplateData is passed to the title and message templates of the channels.
+	TemplateData struct {
+		Kind    Notification
+		Message string
+		Time    time.Time
+	}
+
+	// channelMessage is the rendered notification sent to a channel.
+	channelMessage struct {
+		Kind    Notification
+		Title   string
+		Message string
+		Time    time.Time
+	}
+
+	// rateLimiter keeps the times of the notifications sent in the last minute.
+	rateLimiter struct {
+		mu   sync.Mutex
+		sent []time.Time
+	}
+)
+
+// SetChannels replaces the notification channels, e.g. after they are edited.
+func (n *Notifier) SetChannels(channels []*models.NotificationChannel) {
+	n.channelsMu.Lock()
+	defer n.channelsMu.Unlock()
+
+	n.channels = channels
+	// Keep the limiters of existing channels so that edits do not reset their limit
+	limiters := make(map[uint]*rateLimiter, len(channels))
+	for _, c := range channels {
+		if l, ok := n.limiters[c.ID]; ok {
+			limiters[c.ID] = l
+		} else {
+			limiters[c.ID] = &rateLimiter{}
+		}
+	}
+	n.limiters = limiters
+}
+
+// sendToChannels sends the notification to the enabled channels that receive its kind.
+func (n *Notifier) sendToChannels(id Notification, message string) {
+	n.channelsMu.RLock()
+	channels := make([]*models.NotificationChannel, 0)
+	for _, c := range n.channels {
+		if c.Enabled && isSubscribed(c, id) {
+			channels = append(channels, c)
+		}
+	}
+	n.channelsMu.RUnlock()
+
+	if len(channels) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for _, c := range channels {
+		go func(c *models.NotificationChannel) {
+			defer util.HandlePanicInModuleThen("notifier/sendToChannels", func() {})
+
+			if n.isDisabled() {
+				return
+			}
+
+			if !n.allow(c, now) {
+				n.logWarn(ErrRateLimited, c, id)
+				return
+			}
+
+			if err := n.send(context.Background(), c, id, message, now); err != nil {
+				n.logWarn(err, c, id)
+			}
+		}(c)
+	}
+}
+
+// SendTest sends a test notification to the channel and returns the error, if any.
+// The rate limit of the channel is ignored.
+func (n *Notifier) SendTest(c *models.NotificationChannel) error {
+	if err := ValidateChannel(c); err != nil {
+		return err
+	}
+	return n.send(context.Background(), c, "Test", "This is a test notification.", time.Now())
+}
+
+func (n *Notifier) send(ctx context.Context, c *models.NotificationChannel, id Notification, message string, t time.Time) error {
+	msg, err := renderMessage(c, &TemplateData{Kind: id, Message: message, Time: t})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.client.Timeout)
+	defer cancel()
+
+	switch ChannelType(c.Type) {
+	case ChannelNtfy:
+		return n.sendNtfy(ctx, c, msg)
+	case ChannelGotify:
+		return n.sendGotify(ctx, c, msg)
+	case ChannelDiscord:
+		return n.sendDiscord(ctx, c, msg)
+	case ChannelTelegram:
+		return n.sendTelegram(ctx, c, msg)
+	case ChannelSmtp:
+		return sendSmtp(c, msg)
+	case ChannelJson:
+		return n.sendJson(ctx, c, msg)
+	}
+	return ErrUnknownChannel
+}
+
+func (n *Notifier) logWarn(err error, c *models.NotificationChannel, id Notification) {
+	n.mu.Lock()
+	logger := n.logger
+	n.mu.Unlock()
+	if logger.IsPresent() {
+		logger.MustGet().Warn().Err(err).Uint("channel", c.ID).Str("kind", string(id)).Msg("notifier: Failed to send notification")
+	}
+}
+
+func isSubscribed(c *models.NotificationChannel, id Notification) bool {
+	if len(c.Kinds) == 0 {
+		return true
+	}
+	for _, k := range c.Kinds {
+		if k == string(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// allow returns true if the channel has not reached its rate limit and records the notification.
+func (n *Notifier) allow(c *models.NotificationChannel, now time.Time) bool {
+	if c.RateLimit <= 0 {
+		return true
+	}
+
+	n.channelsMu.RLock()
+	l, ok := n.limiters[c.ID]
+	n.channelsMu.RUnlock()
+	if !ok {
+		return true
+	}
+
+	return l.allow(c.RateLimit, now)
+}
+
+func (l *rateLimiter) allow(limit int, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop the notifications older than a minute
+	i := 0
+	for i < len(l.sent) && now.Sub(l.sent[i]) >= time.Minute {
+		i++
+	}
+	l.sent = l.sent[i:]
+
+	if len(l.sent) >= limit {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func renderMessage(c *models.NotificationChannel, data *TemplateData) (*channelMessage, error) {
+	title, err := renderTemplate(c.TitleTemplate, DefaultTitleTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid title template: %w", err)
+	}
+	message, err := renderTemplate(c.MessageTemplate, DefaultMessageTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template: %w", err)
+	}
+
+	return &channelMessage{
+		Kind:    data.Kind,
+		Title:   title,
+		Message: message,
+		Time:    data.Time,
+	}, nil
+}
+
+func renderTemplate(text string, defaultText string, data *TemplateData) (string, error) {
+	if text == "" {
+		text = defaultText
+	}
+
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ValidateChannel returns an error if the channel is missing the settings required by its type,
+// or if its kinds or templates are invalid.
+func ValidateChannel(c *models.NotificationChannel) error {
+	for _, k := range c.Kinds {
+		if !IsValidNotification(k) {
+			return fmt.Errorf("unknown notification kind %q", k)
+		}
+	}
+
+	if c.RateLimit < 0 {
+		c.RateLimit = 0
+	}
+
+	if _, err := renderMessage(c, &TemplateData{Kind: AutoDownloader, Time: time.Now()}); err != nil {
+		return err
+	}
+
+	switch ChannelType(c.Type) {
+	case ChannelNtfy, ChannelGotify, ChannelDiscord, ChannelJson:
+		if !isValidURL(c.URL) {
+			return ErrMissingURL
+		}
+	case ChannelTelegram:
+		if c.Token == "" || c.ChatID == "" {
+			return ErrMissingTelegram
+		}
+		if c.URL != "" && !isValidURL(c.URL) {
+			return ErrMissingURL
+		}
+	case ChannelSmtp:
+		if c.SmtpHost == "" || len(c.SmtpTo) == 0 {
+			return ErrMissingSmtpHost
+		}
+	default:
+		return ErrUnknownChannel
+	}
+
+	return nil
+}
+
+func isValidURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,163 @@
+package notifier
+
+import (
+	"bytes"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"seanime/internal/database/models"
+	"testing"
+	"time"
+)
+
+type receivedRequest struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, chan *receivedRequest) {
+	received := make(chan *receivedRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- &receivedRequest{path: r.URL.Path, header: r.Header, body: body}
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestChannels(t *testing.T) {
+	server, received := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		channel *models.NotificationChannel
+		check   func(t *testing.T, r *receivedRequest)
+	}{
+		{
+			name:    "ntfy",
+			channel: &models.NotificationChannel{Type: "ntfy", URL: server.URL + "/seanime", Token: "tk", Priority: 4},
+			check: func(t *testing.T, r *receivedRequest) {
+				require.Equal(t, "/seanime", r.path)
+				require.Equal(t, "Seanime: Test", r.header.Get("Title"))
+				require.Equal(t, "4", r.header.Get("Priority"))
+				require.Equal(t, "Bearer tk", r.header.Get("Authorization"))
+				require.Equal(t, "This is a test notification.", string(r.body))
+			},
+		},
+		{
+			name:    "gotify",
+			channel: &models.NotificationChannel{Type: "gotify", URL: server.URL + "/", Token: "app"},
+			check: func(t *testing.T, r *receivedRequest) {
+				require.Equal(t, "/message", r.path)
+				require.Equal(t, "app", r.header.Get("X-Gotify-Key"))
+				require.Contains(t, string(r.body), `"title":"Seanime: Test"`)
+			},
+		},
+		{
+			name:    "discord",
+			channel: &models.NotificationChannel{Type: "discord", URL: server.URL + "/api/webhooks/1/abc"},
+			check: func(t *testing.T, r *receivedRequest) {
+				require.Equal(t, "/api/webhooks/1/abc", r.path)
+				require.Contains(t, string(r.body), `"description":"This is a test notification."`)
+			},
+		},
+		{
+			name:    "telegram",
+			channel: &models.NotificationChannel{Type: "telegram", URL: server.URL, Token: "123:abc", ChatID: "42"},
+			check: func(t *testing.T, r *receivedRequest) {
+				require.Equal(t, "/bot123:abc/sendMessage", r.path)
+				require.Contains(t, string(r.body), `"chat_id":"42"`)
+			},
+		},
+		{
+			name: "json",
+			channel: &models.NotificationChannel{
+				Type:            "json",
+				URL:             server.URL + "/hook",
+				TitleTemplate:   "{{.Kind}}",
+				MessageTemplate: "[{{.Kind}}] {{.Message}}",
+			},
+			check: func(t *testing.T, r *receivedRequest) {
+				var body map[string]string
+				require.NoError(t, json.Unmarshal(r.body, &body))
+				require.Equal(t, "Test", body["kind"])
+				require.Equal(t, "Test", body["title"])
+				require.Equal(t, "[Test] This is a test notification.", body["message"])
+			},
+		},
+	}
+
+	n := NewNotifier()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, n.SendTest(tt.channel))
+			tt.check(t, <-received)
+		})
+	}
+}
+
+func TestSendToChannels(t *testing.T) {
+	server, received := newTestServer(t)
+
+	n := NewNotifier()
+	n.SetChannels([]*models.NotificationChannel{
+		{BaseModel: models.BaseModel{ID: 1}, Type: "json", URL: server.URL + "/scanner", Enabled: true, Kinds: models.StringList{string(AutoScanner)}},
+		{BaseModel: models.BaseModel{ID: 2}, Type: "json", URL: server.URL + "/disabled", Enabled: false},
+	})
+
+	n.Notify(AutoDownloader, "Not subscribed")
+	n.Notify(AutoScanner, "Your library has been scanned.")
+
+	r := <-received
+	require.Equal(t, "/scanner", r.path)
+	require.Contains(t, string(r.body), "Your library has been scanned.")
+
+	select {
+	case r := <-received:
+		t.Fatalf("unexpected notification sent to %s", r.path)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestBuildEmail(t *testing.T) {
+	body := buildEmail("from@example.com", []string{"to@example.com"}, &channelMessage{
+		Title:   "Seanime\r\nBcc: attacker@example.com",
+		Message: "Episode 1\nis out",
+		Time:    time.Now(),
+	})
+
+	msg, err := mail.ReadMessage(bytes.NewReader(body))
+	require.NoError(t, err)
+	require.Empty(t, msg.Header.Get("Bcc"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Seanime  Bcc: attacker@example.com", subject)
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := &rateLimiter{}
+	now := time.Now()
+
+	require.True(t, l.allow(2, now))
+	require.True(t, l.allow(2, now.Add(time.Second)))
+	require.False(t, l.allow(2, now.Add(2*time.Second)))
+	// The first notification is older than a minute
+	require.True(t, l.allow(2, now.Add(time.Minute)))
+	require.False(t, l.allow(2, now.Add(time.Minute)))
+}
+
+func TestValidateChannel(t *testing.T) {
+	require.ErrorIs(t, ValidateChannel(&models.NotificationChannel{Type: "pigeon"}), ErrUnknownChannel)
+	require.ErrorIs(t, ValidateChannel(&models.NotificationChannel{Type: "ntfy", URL: "ntfy.sh/topic"}), ErrMissingURL)
+	require.ErrorIs(t, ValidateChannel(&models.NotificationChannel{Type: "telegram", Token: "tk"}), ErrMissingTelegram)
+	require.ErrorIs(t, ValidateChannel(&models.NotificationChannel{Type: "smtp", SmtpHost: "smtp.example.com"}), ErrMissingSmtpHost)
+	require.Error(t, ValidateChannel(&models.NotificationChannel{Type: "json", URL: "https://example.com", Kinds: models.StringList{"Unknown"}}))
+	require.Error(t, ValidateChannel(&models.NotificationChannel{Type: "json", URL: "https://example.com", TitleTemplate: "{{.Kind"}))
+	require.NoError(t, ValidateChannel(&models.NotificationChannel{Type: "smtp", SmtpHost: "smtp.example.com", SmtpTo: models.StringList{"me@example.com"}}))
+}
@@ -0,0 +1,134 @@
+package notifier
+
+import (
+	"github.com/rs/zerolog"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/events"
+	"time"
+)
+
+type (
+	// Inbox stores the notifications so that they can be read later from any client.
+	Inbox struct {
+		logger         *zerolog.Logger
+		database       *db.Database
+		wsEventManager events.WSEventManagerInterface
+	}
+
+	NewInboxOptions struct {
+		Logger         *zerolog.Logger
+		Database       *db.Database
+		WSEventManager events.WSEventManagerInterface
+	}
+
+	// Details are stored with the notification in the inbox.
+	Details struct {
+		MediaId int
+		Episode int
+		Links   []models.NotificationLink
+	}
+
+	// InboxUpdatedPayload is sent to the clients when the inbox changes.
+	InboxUpdatedPayload struct {
+		UnreadCount int64 `json:"unreadCount"`
+	}
+)
+
+func NewInbox(opts *NewInboxOptions) *Inbox {
+	return &Inbox{
+		logger:         opts.Logger,
+		database:       opts.Database,
+		wsEventManager: opts.WSEventManager,
+	}
+}
+
+// SetInbox sets the inbox in which the notifications are stored.
+func (n *Notifier) SetInbox(inbox *Inbox) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.inbox = inbox
+}
+
+// Record stores a notification in the inbox without sending it.
+func (n *Notifier) Record(id Notification, message string, details *Details) {
+	n.mu.Lock()
+	inbox := n.inbox
+	n.mu.Unlock()
+
+	inbox.Add(id, message, details)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Add stores the notification and sends the new unread count to the clients.
+func (i *Inbox) Add(id Notification, message string, details *Details) {
+	if i == nil {
+		return
+	}
+
+	entry := &models.NotificationEntry{
+		Kind:    string(id),
+		Message: message,
+		Links:   make([]models.NotificationLink, 0),
+	}
+	if details != nil {
+		entry.MediaId = details.MediaId
+		entry.Episode = details.Episode
+		if details.Links != nil {
+			entry.Links = details.Links
+		}
+	}
+
+	if err := i.database.InsertNotificationEntry(entry); err != nil {
+		i.logger.Error().Err(err).Str("kind", string(id)).Msg("notifier: Failed to store notification")
+		return
+	}
+
+	i.sendUnreadCount()
+}
+
+func (i *Inbox) List(filter *db.NotificationFilter) ([]*models.NotificationEntry, int64, error) {
+	return i.database.GetNotificationEntries(filter)
+}
+
+func (i *Inbox) UnreadCount() (int64, error) {
+	return i.database.GetUnreadNotificationCount()
+}
+
+func (i *Inbox) MarkRead(ids []uint) error {
+	if err := i.database.MarkNotificationEntriesRead(ids); err != nil {
+		return err
+	}
+	i.sendUnreadCount()
+	return nil
+}
+
+func (i *Inbox) MarkAllRead() error {
+	if err := i.database.MarkAllNotificationEntriesRead(); err != nil {
+		return err
+	}
+	i.sendUnreadCount()
+	return nil
+}
+
+// Prune deletes the notifications older than the given duration and returns the number of deleted notifications.
+// If readOnly is true, unread notifications are kept.
+func (i *Inbox) Prune(olderThan time.Duration, readOnly bool) (int64, error) {
+	count, err := i.database.DeleteNotificationEntries(time.Now().Add(-olderThan), readOnly)
+	if err != nil {
+		return 0, err
+	}
+	i.sendUnreadCount()
+	return count, nil
+}
+
+// sendUnreadCount sends the unread count to all clients so that they show the same inbox.
+func (i *Inbox) sendUnreadCount() {
+	count, err := i.database.GetUnreadNotificationCount()
+	if err != nil {
+		i.logger.Error().Err(err).Msg("notifier: Failed to count unread notifications")
+		return
+	}
+	i.wsEventManager.SendEvent(events.NotificationInboxUpdated, &InboxUpdatedPayload{UnreadCount: count})
+}
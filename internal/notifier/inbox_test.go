@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"github.com/stretchr/testify/require"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/events"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingWSEventManager struct {
+	mu          sync.Mutex
+	unreadCount int64
+}
+
+func (m *recordingWSEventManager) SendEvent(t string, payload interface{}) {
+	if t != events.NotificationInboxUpdated {
+		return
+	}
+	m.mu.Lock()
+	m.unreadCount = payload.(*InboxUpdatedPayload).UnreadCount
+	m.mu.Unlock()
+}
+
+func (m *recordingWSEventManager) SendEventTo(clientId string, t string, payload interface{}) {
+	m.SendEvent(t, payload)
+}
+
+func (m *recordingWSEventManager) SendEventToProfile(profileId uint, t string, payload interface{}) {
+	m.SendEvent(t, payload)
+}
+
+func (m *recordingWSEventManager) lastUnreadCount() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.unreadCount
+}
+
+func TestInbox(t *testing.T) {
+
+	ws := &recordingWSEventManager{}
+	inbox := NewInbox(&NewInboxOptions{
+		Logger:         util.NewLogger(),
+		Database:       testdb.New(t),
+		WSEventManager: ws,
+	})
+
+	n := NewNotifier()
+	n.SetInbox(inbox)
+
+	n.Record(AutoDownloader, "[Group] Frieren - 01.mkv has been downloaded.", &Details{
+		MediaId: 154587,
+		Episode: 1,
+		Links:   []models.NotificationLink{{Label: "Torrent", URL: "https://example.com/1"}},
+	})
+	n.Record(AutoDownloader, "[Group] Frieren - 02.mkv has been downloaded.", &Details{MediaId: 154587, Episode: 2})
+	n.Record(AutoScanner, "Your library has been scanned.", nil)
+	require.EqualValues(t, 3, ws.lastUnreadCount())
+
+	// Filter
+	entries, total, err := inbox.List(&db.NotificationFilter{Kind: string(AutoDownloader)})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, total)
+	require.Equal(t, 2, entries[0].Episode) // Newest first
+	require.Equal(t, "Torrent", entries[1].Links[0].Label)
+
+	// Mark read
+	require.NoError(t, inbox.MarkRead([]uint{entries[0].ID}))
+	require.EqualValues(t, 2, ws.lastUnreadCount())
+
+	entries, total, err = inbox.List(&db.NotificationFilter{MediaId: 154587, UnreadOnly: true})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	require.Equal(t, 1, entries[0].Episode)
+
+	// Prune read notifications
+	count, err := inbox.Prune(-time.Minute, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	require.NoError(t, inbox.MarkAllRead())
+	require.EqualValues(t, 0, ws.lastUnreadCount())
+
+	entries, total, err = inbox.List(&db.NotificationFilter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, total)
+	require.True(t, entries[0].Read)
+	require.NotNil(t, entries[0].ReadAt)
+
+	// Prune all notifications
+	count, err = inbox.Prune(-time.Minute, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
@@ -3,9 +3,12 @@ package notifier
 import (
 	"github.com/rs/zerolog"
 	"github.com/samber/mo"
+	"net/http"
 	"path/filepath"
 	"seanime/internal/database/models"
+	"seanime/internal/util"
 	"sync"
+	"time"
 )
 
 type (
@@ -15,6 +18,13 @@ type (
 		mu       sync.Mutex
 		logoPath string
 		logger   mo.Option[*zerolog.Logger]
+
+		channelsMu sync.RWMutex
+		channels   []*models.NotificationChannel
+		limiters   map[uint]*rateLimiter
+		client     *http.Client
+
+		inbox *Inbox
 	}
 
 	Notification string
@@ -24,8 +34,33 @@ const (
 	AutoDownloader Notification = "Auto Downloader"
 	AutoScanner    Notification = "Auto Scanner"
 	Debrid         Notification = "Debrid"
+	// The following kinds are only sent to the notification channels
+	MangaDownloader Notification = "Manga Downloader"
+	ExtensionUpdate Notification = "Extension Update"
+	ScheduledJob    Notification = "Scheduled Job"
+	PlatformWrite   Notification = "Platform Write"
 )
 
+// Notifications are the kinds a notification channel can receive.
+var Notifications = []Notification{
+	AutoDownloader,
+	AutoScanner,
+	Debrid,
+	MangaDownloader,
+	ExtensionUpdate,
+	ScheduledJob,
+	PlatformWrite,
+}
+
+func IsValidNotification(kind string) bool {
+	for _, n := range Notifications {
+		if string(n) == kind {
+			return true
+		}
+	}
+	return false
+}
+
 var GlobalNotifier = NewNotifier()
 
 func init() {
@@ -38,9 +73,45 @@ func NewNotifier() *Notifier {
 		settings: mo.None[*models.NotificationSettings](),
 		mu:       sync.Mutex{},
 		logger:   mo.None[*zerolog.Logger](),
+		channels: make([]*models.NotificationChannel, 0),
+		limiters: make(map[uint]*rateLimiter),
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
 	}
 }
 
+// Notify sends a notification to the user and stores it in the inbox.
+// The desktop notification and the notification channels are run in goroutines.
+func (n *Notifier) Notify(id Notification, message string) {
+	n.NotifyWithDetails(id, message, nil)
+}
+
+// NotifyWithDetails is like Notify, the details are stored with the notification in the inbox.
+func (n *Notifier) NotifyWithDetails(id Notification, message string, details *Details) {
+	n.Push(id, message)
+	n.Record(id, message, details)
+}
+
+// Push sends a notification to the user without storing it in the inbox,
+// e.g. a summary of notifications that were recorded individually.
+func (n *Notifier) Push(id Notification, message string) {
+	go func() {
+		defer util.HandlePanicInModuleThen("notifier/Notify", func() {})
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		if !n.canProceed(id) {
+			return
+		}
+
+		n.pushDesktop(id, message)
+	}()
+
+	n.sendToChannels(id, message)
+}
+
 func (n *Notifier) SetSettings(datadir string, settings *models.NotificationSettings, logger *zerolog.Logger) {
 	if datadir == "" || settings == nil {
 		return
@@ -54,6 +125,14 @@ func (n *Notifier) SetSettings(datadir string, settings *models.NotificationSett
 	n.mu.Unlock()
 }
 
+// isDisabled returns true if the user disabled all notifications.
+func (n *Notifier) isDisabled() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.settings.IsPresent() && n.settings.MustGet().DisableNotifications
+}
+
+// canProceed returns true if the desktop notification should be pushed.
 func (n *Notifier) canProceed(id Notification) bool {
 	if !n.dataDir.IsPresent() || !n.settings.IsPresent() {
 		return false
@@ -57,9 +57,16 @@ func (ap *AnilistPlatform) SetAnilistClient(client anilist.AnilistClient) {
 	ap.anilistClient = client
 }
 
-func (ap *AnilistPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput) error {
+func (ap *AnilistPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error {
 	ap.logger.Trace().Msg("anilist platform: Updating entry")
-	_, err := ap.anilistClient.UpdateMediaListEntry(context.Background(), &mediaID, status, scoreRaw, progress, startedAt, completedAt)
+
+	// nil leaves the custom lists unchanged, AniList replaces them otherwise
+	var lists []*string
+	if customLists != nil {
+		lists = lo.ToSlicePtr(customLists)
+	}
+
+	_, err := ap.anilistClient.UpdateMediaListEntry(context.Background(), &mediaID, status, scoreRaw, progress, startedAt, completedAt, lists)
 	if err != nil {
 		return err
 	}
@@ -372,6 +379,7 @@ func (ap *AnilistPlatform) AddMediaToCollection(mIds []int) error {
 				lo.ToPtr(0),
 				nil,
 				nil,
+				nil,
 			)
 			if err != nil {
 				ap.logger.Error().Msg("anilist: An error occurred while adding media to planning list: " + err.Error())
@@ -0,0 +1,229 @@
+package kitsu_platform
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"math"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/kitsu"
+	"time"
+)
+
+// listStatuses is the order of the lists in the collections.
+var listStatuses = []anilist.MediaListStatus{
+	anilist.MediaListStatusCurrent,
+	anilist.MediaListStatusPlanning,
+	anilist.MediaListStatusCompleted,
+	anilist.MediaListStatusDropped,
+	anilist.MediaListStatusPaused,
+	anilist.MediaListStatusRepeating,
+}
+
+// toAnilistStatus converts a Kitsu library status to an AniList list status.
+// Kitsu has no "repeating" status, reconsumed entries are flagged instead.
+func toAnilistStatus(status kitsu.LibraryEntryStatus, isReconsuming bool) anilist.MediaListStatus {
+	switch status {
+	case kitsu.LibraryEntryStatusCurrent:
+		if isReconsuming {
+			return anilist.MediaListStatusRepeating
+		}
+		return anilist.MediaListStatusCurrent
+	case kitsu.LibraryEntryStatusCompleted:
+		return anilist.MediaListStatusCompleted
+	case kitsu.LibraryEntryStatusOnHold:
+		return anilist.MediaListStatusPaused
+	case kitsu.LibraryEntryStatusDropped:
+		return anilist.MediaListStatusDropped
+	default:
+		return anilist.MediaListStatusPlanning
+	}
+}
+
+// toKitsuStatus converts an AniList list status to a Kitsu library status and whether the entry is being reconsumed.
+func toKitsuStatus(status anilist.MediaListStatus) (kitsu.LibraryEntryStatus, bool) {
+	switch status {
+	case anilist.MediaListStatusCurrent:
+		return kitsu.LibraryEntryStatusCurrent, false
+	case anilist.MediaListStatusRepeating:
+		return kitsu.LibraryEntryStatusCurrent, true
+	case anilist.MediaListStatusCompleted:
+		return kitsu.LibraryEntryStatusCompleted, false
+	case anilist.MediaListStatusPaused:
+		return kitsu.LibraryEntryStatusOnHold, false
+	case anilist.MediaListStatusDropped:
+		return kitsu.LibraryEntryStatusDropped, false
+	default:
+		return kitsu.LibraryEntryStatusPlanned, false
+	}
+}
+
+// toRatingTwenty converts an AniList raw score (0-100) to a Kitsu rating (2-20).
+// Returns 0 if the score is 0, which removes the rating.
+func toRatingTwenty(scoreRaw int) int {
+	if scoreRaw <= 0 {
+		return 0
+	}
+	return min(max(int(math.Round(float64(scoreRaw)/5)), 2), 20)
+}
+
+// toAnilistScore converts a Kitsu rating (2-20) to an AniList raw score (0-100).
+func toAnilistScore(ratingTwenty *int) float64 {
+	if ratingTwenty == nil {
+		return 0
+	}
+	return float64(*ratingTwenty * 5)
+}
+
+// toFuzzyDate converts a Kitsu date to an AniList date.
+func toFuzzyDate(date *time.Time) *anilist.FuzzyDateInput {
+	if date == nil || date.IsZero() {
+		return nil
+	}
+	return &anilist.FuzzyDateInput{
+		Year:  lo.ToPtr(date.Year()),
+		Month: lo.ToPtr(int(date.Month())),
+		Day:   lo.ToPtr(date.Day()),
+	}
+}
+
+// formatKitsuDate formats an AniList date for Kitsu.
+// Kitsu only accepts complete dates, an empty string removes the date.
+func formatKitsuDate(date *anilist.FuzzyDateInput) string {
+	if date == nil || date.Year == nil || *date.Year == 0 {
+		return ""
+	}
+	month, day := 1, 1
+	if date.Month != nil && *date.Month > 0 {
+		month = *date.Month
+	}
+	if date.Day != nil && *date.Day > 0 {
+		day = *date.Day
+	}
+	return fmt.Sprintf("%04d-%02d-%02dT00:00:00.000Z", *date.Year, month, day)
+}
+
+func listName(status anilist.MediaListStatus, isManga bool) string {
+	switch status {
+	case anilist.MediaListStatusCurrent:
+		if isManga {
+			return "Reading"
+		}
+		return "Watching"
+	case anilist.MediaListStatusPlanning:
+		return "Planning"
+	case anilist.MediaListStatusCompleted:
+		return "Completed"
+	case anilist.MediaListStatusDropped:
+		return "Dropped"
+	case anilist.MediaListStatusPaused:
+		return "Paused"
+	case anilist.MediaListStatusRepeating:
+		if isManga {
+			return "Rereading"
+		}
+		return "Rewatching"
+	}
+	return string(status)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// newAnimeCollection creates an AniList anime collection from the Kitsu library entries.
+// The media of the entries are keyed by Kitsu ID, entries without media are skipped.
+func newAnimeCollection(entries []*kitsu.LibraryEntry, media map[string]*anilist.BaseAnime) *anilist.AnimeCollection {
+	lists := make(map[anilist.MediaListStatus]*anilist.AnimeCollection_MediaListCollection_Lists)
+	ret := &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: make([]*anilist.AnimeCollection_MediaListCollection_Lists, 0, len(listStatuses)),
+		},
+	}
+	for _, status := range listStatuses {
+		list := &anilist.AnimeCollection_MediaListCollection_Lists{
+			Status:       lo.ToPtr(status),
+			Name:         lo.ToPtr(listName(status, false)),
+			IsCustomList: lo.ToPtr(false),
+			Entries:      make([]*anilist.AnimeCollection_MediaListCollection_Lists_Entries, 0),
+		}
+		lists[status] = list
+		ret.MediaListCollection.Lists = append(ret.MediaListCollection.Lists, list)
+	}
+
+	for _, entry := range entries {
+		m, ok := media[entry.MediaID]
+		if !ok {
+			continue
+		}
+		status := toAnilistStatus(entry.Status, entry.Reconsuming)
+
+		e := &anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+			ID:       m.ID,
+			Score:    lo.ToPtr(toAnilistScore(entry.RatingTwenty)),
+			Progress: lo.ToPtr(entry.Progress),
+			Status:   lo.ToPtr(status),
+			Notes:    lo.ToPtr(entry.Notes),
+			Repeat:   lo.ToPtr(entry.ReconsumeCount),
+			Private:  lo.ToPtr(entry.Private),
+			Media:    m,
+		}
+		if date := toFuzzyDate(entry.StartedAt); date != nil {
+			e.StartedAt = &anilist.AnimeCollection_MediaListCollection_Lists_Entries_StartedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+		if date := toFuzzyDate(entry.FinishedAt); date != nil {
+			e.CompletedAt = &anilist.AnimeCollection_MediaListCollection_Lists_Entries_CompletedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+
+		lists[status].Entries = append(lists[status].Entries, e)
+	}
+
+	return ret
+}
+
+// newMangaCollection creates an AniList manga collection from the Kitsu library entries.
+// The media of the entries are keyed by Kitsu ID, entries without media are skipped.
+func newMangaCollection(entries []*kitsu.LibraryEntry, media map[string]*anilist.BaseManga) *anilist.MangaCollection {
+	lists := make(map[anilist.MediaListStatus]*anilist.MangaCollection_MediaListCollection_Lists)
+	ret := &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: make([]*anilist.MangaCollection_MediaListCollection_Lists, 0, len(listStatuses)),
+		},
+	}
+	for _, status := range listStatuses {
+		list := &anilist.MangaCollection_MediaListCollection_Lists{
+			Status:       lo.ToPtr(status),
+			Name:         lo.ToPtr(listName(status, true)),
+			IsCustomList: lo.ToPtr(false),
+			Entries:      make([]*anilist.MangaCollection_MediaListCollection_Lists_Entries, 0),
+		}
+		lists[status] = list
+		ret.MediaListCollection.Lists = append(ret.MediaListCollection.Lists, list)
+	}
+
+	for _, entry := range entries {
+		m, ok := media[entry.MediaID]
+		if !ok {
+			continue
+		}
+		status := toAnilistStatus(entry.Status, entry.Reconsuming)
+
+		e := &anilist.MangaCollection_MediaListCollection_Lists_Entries{
+			ID:       m.ID,
+			Score:    lo.ToPtr(toAnilistScore(entry.RatingTwenty)),
+			Progress: lo.ToPtr(entry.Progress),
+			Status:   lo.ToPtr(status),
+			Notes:    lo.ToPtr(entry.Notes),
+			Repeat:   lo.ToPtr(entry.ReconsumeCount),
+			Private:  lo.ToPtr(entry.Private),
+			Media:    m,
+		}
+		if date := toFuzzyDate(entry.StartedAt); date != nil {
+			e.StartedAt = &anilist.MangaCollection_MediaListCollection_Lists_Entries_StartedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+		if date := toFuzzyDate(entry.FinishedAt); date != nil {
+			e.CompletedAt = &anilist.MangaCollection_MediaListCollection_Lists_Entries_CompletedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+
+		lists[status].Entries = append(lists[status].Entries, e)
+	}
+
+	return ret
+}
@@ -0,0 +1,72 @@
+package kitsu_platform
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/kitsu"
+	"testing"
+	"time"
+)
+
+func TestStatusConversion(t *testing.T) {
+	tests := []struct {
+		status   anilist.MediaListStatus
+		expected kitsu.LibraryEntryStatus
+	}{
+		{anilist.MediaListStatusCurrent, kitsu.LibraryEntryStatusCurrent},
+		{anilist.MediaListStatusRepeating, kitsu.LibraryEntryStatusCurrent},
+		{anilist.MediaListStatusPlanning, kitsu.LibraryEntryStatusPlanned},
+		{anilist.MediaListStatusCompleted, kitsu.LibraryEntryStatusCompleted},
+		{anilist.MediaListStatusPaused, kitsu.LibraryEntryStatusOnHold},
+		{anilist.MediaListStatusDropped, kitsu.LibraryEntryStatusDropped},
+	}
+
+	for _, tt := range tests {
+		kitsuStatus, isReconsuming := toKitsuStatus(tt.status)
+		require.Equal(t, tt.expected, kitsuStatus)
+		require.Equal(t, tt.status == anilist.MediaListStatusRepeating, isReconsuming)
+		require.Equal(t, tt.status, toAnilistStatus(kitsuStatus, isReconsuming))
+	}
+}
+
+func TestScoreAndDateConversion(t *testing.T) {
+	require.Equal(t, 0, toRatingTwenty(0))
+	require.Equal(t, 2, toRatingTwenty(3))
+	require.Equal(t, 17, toRatingTwenty(85))
+	require.Equal(t, 20, toRatingTwenty(100))
+	require.Equal(t, float64(85), toAnilistScore(lo.ToPtr(17)))
+	require.Equal(t, float64(0), toAnilistScore(nil))
+
+	date := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, toFuzzyDate(nil))
+	require.Equal(t, &anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(1), Day: lo.ToPtr(31)}, toFuzzyDate(&date))
+
+	require.Equal(t, "", formatKitsuDate(nil))
+	require.Equal(t, "2024-01-31T00:00:00.000Z", formatKitsuDate(toFuzzyDate(&date)))
+	require.Equal(t, "2024-03-01T00:00:00.000Z", formatKitsuDate(&anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(3)}))
+}
+
+func TestNewMangaCollection(t *testing.T) {
+	entries := []*kitsu.LibraryEntry{
+		{ID: "1", MediaID: "10", Status: kitsu.LibraryEntryStatusOnHold, Progress: 25, RatingTwenty: lo.ToPtr(16)},
+		{ID: "2", MediaID: "11", Status: kitsu.LibraryEntryStatusCurrent},
+	}
+
+	collection := newMangaCollection(entries, map[string]*anilist.BaseManga{
+		"10": {ID: 30002},
+	})
+
+	lists := collection.GetMediaListCollection().GetLists()
+	require.Len(t, lists, len(listStatuses))
+	for _, list := range lists {
+		if *list.Status != anilist.MediaListStatusPaused {
+			require.Empty(t, list.Entries)
+			continue
+		}
+		require.Len(t, list.Entries, 1)
+		require.Equal(t, 30002, list.Entries[0].ID)
+		require.Equal(t, 25, *list.Entries[0].Progress)
+		require.Equal(t, float64(80), *list.Entries[0].Score)
+	}
+}
@@ -0,0 +1,615 @@
+package kitsu_platform
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/kitsu"
+	"seanime/internal/api/metadata"
+	"seanime/internal/database/db"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util/limiter"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotLoggedIn means the user hasn't connected their Kitsu account
+	ErrNotLoggedIn = errors.New("kitsu: Not logged in")
+	// ErrNoKitsuID means the media couldn't be mapped to a Kitsu ID
+	ErrNoKitsuID = errors.New("kitsu: Media has no Kitsu ID")
+)
+
+type (
+	// KitsuPlatform uses Kitsu as the tracking platform.
+	// The library entries are fetched from Kitsu and mapped to their AniList counterparts so that the rest of the app
+	// can keep working with AniList media. Media details are still fetched from AniList, no AniList account is needed.
+	KitsuPlatform struct {
+		logger           *zerolog.Logger
+		db               *db.Database
+		profileID        uint
+		anilistClient    anilist.AnilistClient
+		metadataProvider metadata.Provider
+		animeCollection  mo.Option[*anilist.AnimeCollection]
+		mangaCollection  mo.Option[*anilist.MangaCollection]
+		// AniList ID -> Kitsu media and library entry
+		refs map[int]*kitsuRef
+		mu   sync.RWMutex
+	}
+
+	kitsuRef struct {
+		mediaType kitsu.MediaType
+		mediaID   string
+		entryID   string // Empty if the media isn't in the library
+	}
+
+	NewKitsuPlatformOptions struct {
+		Logger           *zerolog.Logger
+		Database         *db.Database
+		ProfileID        uint
+		AnilistClient    anilist.AnilistClient
+		MetadataProvider metadata.Provider
+	}
+)
+
+func NewKitsuPlatform(opts *NewKitsuPlatformOptions) platform.Platform {
+	return &KitsuPlatform{
+		logger:           opts.Logger,
+		db:               opts.Database,
+		profileID:        opts.ProfileID,
+		anilistClient:    opts.AnilistClient,
+		metadataProvider: opts.MetadataProvider,
+		animeCollection:  mo.None[*anilist.AnimeCollection](),
+		mangaCollection:  mo.None[*anilist.MangaCollection](),
+		refs:             make(map[int]*kitsuRef),
+	}
+}
+
+// getWrapper returns a Kitsu API wrapper with a valid access token.
+func (kp *KitsuPlatform) getWrapper() (*kitsu.Wrapper, error) {
+	kitsuInfo, err := kp.db.GetKitsuInfoByID(kp.profileID)
+	if err != nil || kitsuInfo == nil || kitsuInfo.AccessToken == "" {
+		return nil, ErrNotLoggedIn
+	}
+
+	kitsuInfo, err = kitsu.VerifyKitsuAuth(kitsuInfo, kp.db, kp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return kitsu.NewWrapper(kitsuInfo.AccessToken, kitsuInfo.UserID, kp.logger), nil
+}
+
+func (kp *KitsuPlatform) isLoggedIn() bool {
+	kitsuInfo, err := kp.db.GetKitsuInfoByID(kp.profileID)
+	return err == nil && kitsuInfo != nil && kitsuInfo.AccessToken != ""
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (kp *KitsuPlatform) SetUsername(username string) {
+	// no-op, the Kitsu account is used
+}
+
+func (kp *KitsuPlatform) SetAnilistClient(client anilist.AnilistClient) {
+	kp.anilistClient = client
+}
+
+func (kp *KitsuPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error {
+	kp.logger.Trace().Msg("kitsu platform: Updating entry")
+
+	wrapper, err := kp.getWrapper()
+	if err != nil {
+		return err
+	}
+
+	ref, err := kp.resolveRef(wrapper, mediaID)
+	if err != nil {
+		return err
+	}
+
+	params := &kitsu.LibraryEntryParams{
+		Progress: progress,
+	}
+	if status != nil {
+		s, r := toKitsuStatus(*status)
+		params.Status, params.Reconsuming = &s, &r
+	}
+	if scoreRaw != nil {
+		params.RatingTwenty = lo.ToPtr(toRatingTwenty(*scoreRaw))
+	}
+	if startedAt != nil {
+		params.StartedAt = lo.ToPtr(formatKitsuDate(startedAt))
+	}
+	if completedAt != nil {
+		params.FinishedAt = lo.ToPtr(formatKitsuDate(completedAt))
+	}
+
+	if ref.entryID != "" {
+		return wrapper.UpdateLibraryEntry(ref.entryID, params)
+	}
+
+	// Not in the library, create the entry
+	if params.Status == nil {
+		params.Status = lo.ToPtr(kitsu.LibraryEntryStatusCurrent)
+	}
+	entryID, err := wrapper.CreateLibraryEntry(ref.mediaType, ref.mediaID, params)
+	if err != nil {
+		return err
+	}
+
+	kp.mu.Lock()
+	ref.entryID = entryID
+	kp.mu.Unlock()
+
+	return nil
+}
+
+func (kp *KitsuPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpisodes *int) error {
+	kp.logger.Trace().Msg("kitsu platform: Updating entry progress")
+
+	totalEp := 0
+	if totalEpisodes != nil && *totalEpisodes > 0 {
+		totalEp = *totalEpisodes
+	}
+
+	status := anilist.MediaListStatusCurrent
+	// Check if the media is in the repeating list
+	if entryStatus, ok := kp.getEntryStatus(mediaID); ok && entryStatus == anilist.MediaListStatusRepeating {
+		status = anilist.MediaListStatusRepeating
+	}
+	if totalEp > 0 && progress >= totalEp {
+		status = anilist.MediaListStatusCompleted
+	}
+
+	if totalEp > 0 && progress > totalEp {
+		progress = totalEp
+	}
+
+	return kp.UpdateEntry(mediaID, &status, nil, &progress, nil, nil, nil)
+}
+
+func (kp *KitsuPlatform) DeleteEntry(mediaID int) error {
+	kp.logger.Trace().Msg("kitsu platform: Deleting entry")
+
+	wrapper, err := kp.getWrapper()
+	if err != nil {
+		return err
+	}
+
+	ref, err := kp.resolveRef(wrapper, mediaID)
+	if err != nil {
+		return err
+	}
+	if ref.entryID == "" {
+		return nil
+	}
+
+	err = wrapper.DeleteLibraryEntry(ref.entryID)
+	if err != nil {
+		return err
+	}
+
+	kp.mu.Lock()
+	ref.entryID = ""
+	kp.mu.Unlock()
+
+	return nil
+}
+
+func (kp *KitsuPlatform) GetAnime(mediaID int) (*anilist.BaseAnime, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching anime")
+	ret, err := kp.anilistClient.BaseAnimeByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (kp *KitsuPlatform) GetAnimeByMalID(malID int) (*anilist.BaseAnime, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching anime by MAL ID")
+	ret, err := kp.anilistClient.BaseAnimeByMalID(context.Background(), &malID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (kp *KitsuPlatform) GetAnimeDetails(mediaID int) (*anilist.AnimeDetailsById_Media, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching anime details")
+	ret, err := kp.anilistClient.AnimeDetailsByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (kp *KitsuPlatform) GetAnimeWithRelations(mediaID int) (*anilist.CompleteAnime, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching anime with relations")
+	ret, err := kp.anilistClient.CompleteAnimeByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (kp *KitsuPlatform) GetManga(mediaID int) (*anilist.BaseManga, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching manga")
+	ret, err := kp.anilistClient.BaseMangaByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (kp *KitsuPlatform) GetMangaDetails(mediaID int) (*anilist.MangaDetailsById_Media, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching manga details")
+	ret, err := kp.anilistClient.MangaDetailsByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (kp *KitsuPlatform) GetAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
+	if !bypassCache && kp.animeCollection.IsPresent() {
+		return kp.animeCollection.MustGet(), nil
+	}
+
+	return kp.RefreshAnimeCollection()
+}
+
+// GetRawAnimeCollection returns the same collection as GetAnimeCollection since Kitsu has no custom lists.
+func (kp *KitsuPlatform) GetRawAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
+	return kp.GetAnimeCollection(bypassCache)
+}
+
+func (kp *KitsuPlatform) RefreshAnimeCollection() (*anilist.AnimeCollection, error) {
+	if !kp.isLoggedIn() {
+		return nil, nil
+	}
+
+	wrapper, err := kp.getWrapper()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := wrapper.GetLibrary(kitsu.MediaTypeAnime)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make(map[string]*anilist.BaseAnime, len(entries))
+
+	// Use the AniList mappings first, then the MyAnimeList mappings
+	byAnilistID, err := anilist.FetchBaseAnimeByIDs(entryIDs(entries, func(e *kitsu.LibraryEntry) int { return e.AnilistID }), kp.logger)
+	if err != nil {
+		return nil, err
+	}
+	byMalID, err := anilist.FetchBaseAnimeByMalIDs(entryIDs(entries, func(e *kitsu.LibraryEntry) int {
+		if e.AnilistID != 0 {
+			return 0
+		}
+		return e.MalID
+	}), kp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if m, ok := byAnilistID[entry.AnilistID]; ok && entry.AnilistID != 0 {
+			media[entry.MediaID] = m
+		} else if m, ok := byMalID[entry.MalID]; ok && entry.MalID != 0 {
+			media[entry.MediaID] = m
+		} else {
+			kp.logger.Warn().Str("kitsuId", entry.MediaID).Str("title", entry.Title).Msg("kitsu platform: Could not find anime on AniList, skipping")
+		}
+	}
+
+	collection := newAnimeCollection(entries, media)
+	kp.saveRefs(entries, lo.MapValues(media, func(m *anilist.BaseAnime, _ string) int { return m.ID }))
+
+	kp.mu.Lock()
+	kp.animeCollection = mo.Some(collection)
+	kp.mu.Unlock()
+
+	return collection, nil
+}
+
+func (kp *KitsuPlatform) GetAnimeCollectionWithRelations() (*anilist.AnimeCollectionWithRelations, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching anime collection with relations")
+
+	collection, err := kp.GetAnimeCollection(false)
+	if err != nil || collection == nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0)
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		for _, entry := range list.GetEntries() {
+			ids = append(ids, entry.GetMedia().GetID())
+		}
+	}
+
+	media, err := anilist.FetchCompleteAnimeByIDs(ids, kp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &anilist.AnimeCollectionWithRelations{
+		MediaListCollection: &anilist.AnimeCollectionWithRelations_MediaListCollection{
+			Lists: make([]*anilist.AnimeCollectionWithRelations_MediaListCollection_Lists, 0),
+		},
+	}
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		l := &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists{
+			Status:       list.Status,
+			Name:         list.Name,
+			IsCustomList: list.IsCustomList,
+			Entries:      make([]*anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries, 0, len(list.Entries)),
+		}
+		for _, entry := range list.GetEntries() {
+			m, ok := media[entry.GetMedia().GetID()]
+			if !ok {
+				continue
+			}
+			e := &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries{
+				ID:       entry.ID,
+				Score:    entry.Score,
+				Progress: entry.Progress,
+				Status:   entry.Status,
+				Notes:    entry.Notes,
+				Repeat:   entry.Repeat,
+				Private:  entry.Private,
+				Media:    m,
+			}
+			if entry.StartedAt != nil {
+				e.StartedAt = &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries_StartedAt{Year: entry.StartedAt.Year, Month: entry.StartedAt.Month, Day: entry.StartedAt.Day}
+			}
+			if entry.CompletedAt != nil {
+				e.CompletedAt = &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries_CompletedAt{Year: entry.CompletedAt.Year, Month: entry.CompletedAt.Month, Day: entry.CompletedAt.Day}
+			}
+			l.Entries = append(l.Entries, e)
+		}
+		ret.MediaListCollection.Lists = append(ret.MediaListCollection.Lists, l)
+	}
+
+	return ret, nil
+}
+
+func (kp *KitsuPlatform) GetMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
+	if !bypassCache && kp.mangaCollection.IsPresent() {
+		return kp.mangaCollection.MustGet(), nil
+	}
+
+	return kp.RefreshMangaCollection()
+}
+
+// GetRawMangaCollection returns the same collection as GetMangaCollection since Kitsu has no custom lists.
+func (kp *KitsuPlatform) GetRawMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
+	return kp.GetMangaCollection(bypassCache)
+}
+
+func (kp *KitsuPlatform) RefreshMangaCollection() (*anilist.MangaCollection, error) {
+	if !kp.isLoggedIn() {
+		return nil, nil
+	}
+
+	wrapper, err := kp.getWrapper()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := wrapper.GetLibrary(kitsu.MediaTypeManga)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make(map[string]*anilist.BaseManga, len(entries))
+
+	byAnilistID, err := anilist.FetchBaseMangaByIDs(entryIDs(entries, func(e *kitsu.LibraryEntry) int { return e.AnilistID }), kp.logger)
+	if err != nil {
+		return nil, err
+	}
+	byMalID, err := anilist.FetchBaseMangaByMalIDs(entryIDs(entries, func(e *kitsu.LibraryEntry) int {
+		if e.AnilistID != 0 {
+			return 0
+		}
+		return e.MalID
+	}), kp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if m, ok := byAnilistID[entry.AnilistID]; ok && entry.AnilistID != 0 {
+			media[entry.MediaID] = m
+		} else if m, ok := byMalID[entry.MalID]; ok && entry.MalID != 0 {
+			media[entry.MediaID] = m
+		} else {
+			kp.logger.Warn().Str("kitsuId", entry.MediaID).Str("title", entry.Title).Msg("kitsu platform: Could not find manga on AniList, skipping")
+		}
+	}
+
+	collection := newMangaCollection(entries, media)
+	kp.saveRefs(entries, lo.MapValues(media, func(m *anilist.BaseManga, _ string) int { return m.ID }))
+
+	kp.mu.Lock()
+	kp.mangaCollection = mo.Some(collection)
+	kp.mu.Unlock()
+
+	return collection, nil
+}
+
+func (kp *KitsuPlatform) AddMediaToCollection(mIds []int) error {
+	kp.logger.Trace().Msg("kitsu platform: Adding media to collection")
+	if len(mIds) == 0 {
+		kp.logger.Debug().Msg("kitsu platform: No media added to planning list")
+		return nil
+	}
+
+	rateLimiter := limiter.NewLimiter(1*time.Second, 1) // 1 request per second
+
+	wg := sync.WaitGroup{}
+	for _, _id := range mIds {
+		wg.Add(1)
+		go func(id int) {
+			rateLimiter.Wait()
+			defer wg.Done()
+			err := kp.UpdateEntry(id, lo.ToPtr(anilist.MediaListStatusPlanning), nil, lo.ToPtr(0), nil, nil, nil)
+			if err != nil {
+				kp.logger.Error().Err(err).Int("mediaId", id).Msg("kitsu platform: An error occurred while adding media to planning list")
+			}
+		}(_id)
+	}
+	wg.Wait()
+
+	kp.logger.Debug().Any("count", len(mIds)).Msg("kitsu platform: Media added to planning list")
+	return nil
+}
+
+func (kp *KitsuPlatform) GetStudioDetails(studioID int) (*anilist.StudioDetails, error) {
+	kp.logger.Trace().Msg("kitsu platform: Fetching studio details")
+	ret, err := kp.anilistClient.StudioDetails(context.Background(), &studioID)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (kp *KitsuPlatform) GetAnilistClient() anilist.AnilistClient {
+	return kp.anilistClient
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// entryIDs returns the non-zero IDs selected from the entries.
+func entryIDs(entries []*kitsu.LibraryEntry, fn func(e *kitsu.LibraryEntry) int) []int {
+	ret := make([]int, 0, len(entries))
+	for _, e := range entries {
+		if id := fn(e); id != 0 {
+			ret = append(ret, id)
+		}
+	}
+	return lo.Uniq(ret)
+}
+
+// saveRefs caches the Kitsu media and library entries of the AniList media.
+func (kp *KitsuPlatform) saveRefs(entries []*kitsu.LibraryEntry, anilistIDs map[string]int) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+
+	for _, entry := range entries {
+		anilistID, ok := anilistIDs[entry.MediaID]
+		if !ok {
+			continue
+		}
+		kp.refs[anilistID] = &kitsuRef{
+			mediaType: entry.MediaType,
+			mediaID:   entry.MediaID,
+			entryID:   entry.ID,
+		}
+	}
+}
+
+// resolveRef returns the Kitsu media of an AniList media, and its library entry if it is in the library.
+func (kp *KitsuPlatform) resolveRef(wrapper *kitsu.Wrapper, mediaID int) (*kitsuRef, error) {
+	kp.mu.RLock()
+	ref, ok := kp.refs[mediaID]
+	kp.mu.RUnlock()
+	if ok {
+		return ref, nil
+	}
+
+	ref = &kitsuRef{}
+
+	if anime, err := kp.GetAnime(mediaID); err == nil && anime != nil {
+		ref.mediaType = kitsu.MediaTypeAnime
+		// Try the metadata mappings first
+		if kp.metadataProvider != nil {
+			animeMetadata, err := kp.metadataProvider.GetAnimeMetadata(metadata.AnilistPlatform, mediaID)
+			if err == nil && animeMetadata != nil && animeMetadata.Mappings != nil && animeMetadata.Mappings.KitsuId != 0 {
+				ref.mediaID = strconv.Itoa(animeMetadata.Mappings.KitsuId)
+			}
+		}
+		if ref.mediaID == "" {
+			ref.mediaID, err = kp.getKitsuIDByMappings(wrapper, mediaID, anime.GetIDMal(), kitsu.ExternalSiteAnilistAnime, kitsu.ExternalSiteMyAnimeListAnime)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		manga, err := kp.GetManga(mediaID)
+		if err != nil {
+			return nil, err
+		}
+		ref.mediaType = kitsu.MediaTypeManga
+		ref.mediaID, err = kp.getKitsuIDByMappings(wrapper, mediaID, manga.GetIDMal(), kitsu.ExternalSiteAnilistManga, kitsu.ExternalSiteMyAnimeListManga)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check if the media is already in the library
+	entry, err := wrapper.GetLibraryEntry(ref.mediaType, ref.mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		ref.entryID = entry.ID
+	}
+
+	kp.mu.Lock()
+	kp.refs[mediaID] = ref
+	kp.mu.Unlock()
+
+	return ref, nil
+}
+
+// getKitsuIDByMappings finds the Kitsu ID of a media using the Kitsu mappings of its AniList ID, then its MAL ID.
+func (kp *KitsuPlatform) getKitsuIDByMappings(wrapper *kitsu.Wrapper, mediaID int, malID *int, anilistSite kitsu.ExternalSite, malSite kitsu.ExternalSite) (string, error) {
+	ret, err := wrapper.GetMediaIDByExternalID(anilistSite, mediaID)
+	if err != nil {
+		return "", err
+	}
+	if ret == "" && malID != nil && *malID != 0 {
+		ret, err = wrapper.GetMediaIDByExternalID(malSite, *malID)
+		if err != nil {
+			return "", err
+		}
+	}
+	if ret == "" {
+		return "", ErrNoKitsuID
+	}
+	return ret, nil
+}
+
+// getEntryStatus returns the status of the media in the cached collections.
+func (kp *KitsuPlatform) getEntryStatus(mediaID int) (anilist.MediaListStatus, bool) {
+	kp.mu.RLock()
+	defer kp.mu.RUnlock()
+
+	if collection, ok := kp.animeCollection.Get(); ok {
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				if entry.GetMedia().GetID() == mediaID && entry.GetStatus() != nil {
+					return *entry.GetStatus(), true
+				}
+			}
+		}
+	}
+	if collection, ok := kp.mangaCollection.Get(); ok {
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				if entry.GetMedia().GetID() == mediaID && entry.GetStatus() != nil {
+					return *entry.GetStatus(), true
+				}
+			}
+		}
+	}
+	return "", false
+}
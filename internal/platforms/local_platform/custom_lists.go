@@ -0,0 +1,124 @@
+package local_platform
+
+import (
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"slices"
+)
+
+// The local collections mirror the custom lists of AniList, they are lists with no status.
+// GetAnimeCollection and GetMangaCollection leave them out like the AniList platform does.
+
+func withoutAnimeCustomLists(collection *anilist.AnimeCollection) *anilist.AnimeCollection {
+	if collection == nil || collection.MediaListCollection == nil {
+		return collection
+	}
+	ret := *collection
+	listCollection := *collection.MediaListCollection
+	listCollection.Lists = lo.Filter(collection.MediaListCollection.Lists, func(list *anilist.AnimeCollection_MediaListCollection_Lists, _ int) bool {
+		return !list.IsCustom()
+	})
+	ret.MediaListCollection = &listCollection
+	return &ret
+}
+
+func withoutMangaCustomLists(collection *anilist.MangaCollection) *anilist.MangaCollection {
+	if collection == nil || collection.MediaListCollection == nil {
+		return collection
+	}
+	ret := *collection
+	listCollection := *collection.MediaListCollection
+	listCollection.Lists = lo.Filter(collection.MediaListCollection.Lists, func(list *anilist.MangaCollection_MediaListCollection_Lists, _ int) bool {
+		return !list.IsCustom()
+	})
+	ret.MediaListCollection = &listCollection
+	return &ret
+}
+
+// setAnimeCustomLists replaces the custom lists of the entry.
+// Lists that don't exist locally are created.
+func setAnimeCustomLists(collection *anilist.AnimeCollection, mediaID int, names []string) {
+	var entry *anilist.AnimeListEntry
+	for _, list := range collection.MediaListCollection.Lists {
+		if list.IsCustom() {
+			continue
+		}
+		for _, e := range list.GetEntries() {
+			if e.GetMedia().GetID() == mediaID {
+				entry = e
+			}
+		}
+	}
+	if entry == nil {
+		return
+	}
+
+	for _, list := range collection.MediaListCollection.Lists {
+		if !list.IsCustom() {
+			continue
+		}
+		list.Entries = lo.Filter(list.Entries, func(e *anilist.AnimeListEntry, _ int) bool {
+			return e.GetMedia().GetID() != mediaID
+		})
+		if list.GetName() != nil && slices.Contains(names, *list.GetName()) {
+			list.Entries = append(list.Entries, entry)
+		}
+	}
+
+	for _, name := range names {
+		_, found := lo.Find(collection.MediaListCollection.Lists, func(list *anilist.AnimeCollection_MediaListCollection_Lists) bool {
+			return list.IsCustom() && list.GetName() != nil && *list.GetName() == name
+		})
+		if !found {
+			collection.MediaListCollection.Lists = append(collection.MediaListCollection.Lists, &anilist.AnimeCollection_MediaListCollection_Lists{
+				Name:         lo.ToPtr(name),
+				IsCustomList: lo.ToPtr(true),
+				Entries:      []*anilist.AnimeListEntry{entry},
+			})
+		}
+	}
+}
+
+// setMangaCustomLists replaces the custom lists of the entry.
+// Lists that don't exist locally are created.
+func setMangaCustomLists(collection *anilist.MangaCollection, mediaID int, names []string) {
+	var entry *anilist.MangaListEntry
+	for _, list := range collection.MediaListCollection.Lists {
+		if list.IsCustom() {
+			continue
+		}
+		for _, e := range list.GetEntries() {
+			if e.GetMedia().GetID() == mediaID {
+				entry = e
+			}
+		}
+	}
+	if entry == nil {
+		return
+	}
+
+	for _, list := range collection.MediaListCollection.Lists {
+		if !list.IsCustom() {
+			continue
+		}
+		list.Entries = lo.Filter(list.Entries, func(e *anilist.MangaListEntry, _ int) bool {
+			return e.GetMedia().GetID() != mediaID
+		})
+		if list.GetName() != nil && slices.Contains(names, *list.GetName()) {
+			list.Entries = append(list.Entries, entry)
+		}
+	}
+
+	for _, name := range names {
+		_, found := lo.Find(collection.MediaListCollection.Lists, func(list *anilist.MangaCollection_MediaListCollection_Lists) bool {
+			return list.IsCustom() && list.GetName() != nil && *list.GetName() == name
+		})
+		if !found {
+			collection.MediaListCollection.Lists = append(collection.MediaListCollection.Lists, &anilist.MangaCollection_MediaListCollection_Lists{
+				Name:         lo.ToPtr(name),
+				IsCustomList: lo.ToPtr(true),
+				Entries:      []*anilist.MangaListEntry{entry},
+			})
+		}
+	}
+}
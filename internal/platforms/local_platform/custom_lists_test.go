@@ -0,0 +1,264 @@
+package local_platform
+
+import (
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/sync"
+	"seanime/internal/util"
+	"testing"
+)
+
+// newTestAnimeCollection returns a collection with the anime 1 and 2 in the watching list.
+// The anime 1 is in the custom list "A", the custom list "B" is empty.
+func newTestAnimeCollection() *anilist.AnimeCollection {
+	entry := func(id int) *anilist.AnimeListEntry {
+		return &anilist.AnimeListEntry{
+			Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+			Media:  &anilist.BaseAnime{ID: id},
+		}
+	}
+	entry1, entry2 := entry(1), entry(2)
+	return &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: []*anilist.AnimeCollection_MediaListCollection_Lists{
+				{Status: lo.ToPtr(anilist.MediaListStatusCurrent), Entries: []*anilist.AnimeListEntry{entry1, entry2}},
+				{Name: lo.ToPtr("A"), IsCustomList: lo.ToPtr(true), Entries: []*anilist.AnimeListEntry{entry1}},
+				{Name: lo.ToPtr("B"), IsCustomList: lo.ToPtr(true), Entries: []*anilist.AnimeListEntry{}},
+			},
+		},
+	}
+}
+
+func newTestMangaCollection() *anilist.MangaCollection {
+	entry := &anilist.MangaListEntry{
+		Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+		Media:  &anilist.BaseManga{ID: 1},
+	}
+	return &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: []*anilist.MangaCollection_MediaListCollection_Lists{
+				{Status: lo.ToPtr(anilist.MediaListStatusCurrent), Entries: []*anilist.MangaListEntry{entry}},
+				{Name: lo.ToPtr("A"), IsCustomList: lo.ToPtr(true), Entries: []*anilist.MangaListEntry{entry}},
+				{Name: lo.ToPtr("B"), IsCustomList: lo.ToPtr(true), Entries: []*anilist.MangaListEntry{}},
+			},
+		},
+	}
+}
+
+// animeCustomListIDs returns the media IDs of each custom list.
+func animeCustomListIDs(collection *anilist.AnimeCollection) map[string][]int {
+	ret := make(map[string][]int)
+	for _, list := range collection.MediaListCollection.Lists {
+		if !list.IsCustom() {
+			continue
+		}
+		ret[*list.GetName()] = lo.Map(list.GetEntries(), func(e *anilist.AnimeListEntry, _ int) int {
+			return e.GetMedia().GetID()
+		})
+	}
+	return ret
+}
+
+func mangaCustomListIDs(collection *anilist.MangaCollection) map[string][]int {
+	ret := make(map[string][]int)
+	for _, list := range collection.MediaListCollection.Lists {
+		if !list.IsCustom() {
+			continue
+		}
+		ret[*list.GetName()] = lo.Map(list.GetEntries(), func(e *anilist.MangaListEntry, _ int) int {
+			return e.GetMedia().GetID()
+		})
+	}
+	return ret
+}
+
+func TestSetAnimeCustomLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		mediaID  int
+		names    []string
+		expected map[string][]int
+	}{
+		{
+			name:     "add",
+			mediaID:  2,
+			names:    []string{"A", "B"},
+			expected: map[string][]int{"A": {1, 2}, "B": {2}},
+		},
+		{
+			name:     "move",
+			mediaID:  1,
+			names:    []string{"B"},
+			expected: map[string][]int{"A": {}, "B": {1}},
+		},
+		{
+			name:     "clear",
+			mediaID:  1,
+			names:    []string{},
+			expected: map[string][]int{"A": {}, "B": {}},
+		},
+		{
+			name:     "create missing list",
+			mediaID:  1,
+			names:    []string{"A", "C"},
+			expected: map[string][]int{"A": {1}, "B": {}, "C": {1}},
+		},
+		{
+			name:     "media not in the collection",
+			mediaID:  3,
+			names:    []string{"A", "C"},
+			expected: map[string][]int{"A": {1}, "B": {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection := newTestAnimeCollection()
+			setAnimeCustomLists(collection, tt.mediaID, tt.names)
+			require.Equal(t, tt.expected, animeCustomListIDs(collection))
+			// The status lists are left as is
+			require.Len(t, collection.MediaListCollection.Lists[0].Entries, 2)
+		})
+	}
+}
+
+func TestSetMangaCustomLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		expected map[string][]int
+	}{
+		{
+			name:     "move",
+			names:    []string{"B"},
+			expected: map[string][]int{"A": {}, "B": {1}},
+		},
+		{
+			name:     "clear",
+			names:    []string{},
+			expected: map[string][]int{"A": {}, "B": {}},
+		},
+		{
+			name:     "create missing list",
+			names:    []string{"C"},
+			expected: map[string][]int{"A": {}, "B": {}, "C": {1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection := newTestMangaCollection()
+			setMangaCustomLists(collection, 1, tt.names)
+			require.Equal(t, tt.expected, mangaCustomListIDs(collection))
+		})
+	}
+}
+
+func TestWithoutAnimeCustomLists(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection *anilist.AnimeCollection
+		expected   int
+	}{
+		{
+			name:       "nil collection",
+			collection: nil,
+		},
+		{
+			name:       "no list collection",
+			collection: &anilist.AnimeCollection{},
+		},
+		{
+			name:       "custom lists",
+			collection: newTestAnimeCollection(),
+			expected:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := withoutAnimeCustomLists(tt.collection)
+			if tt.collection == nil || tt.collection.MediaListCollection == nil {
+				require.Equal(t, tt.collection, ret)
+				return
+			}
+			require.Len(t, ret.MediaListCollection.Lists, tt.expected)
+			for _, list := range ret.MediaListCollection.Lists {
+				require.False(t, list.IsCustom())
+			}
+			// The collection itself keeps its custom lists
+			require.Len(t, tt.collection.MediaListCollection.Lists, 3)
+		})
+	}
+}
+
+// fakeSyncManager holds the local collections in memory.
+type fakeSyncManager struct {
+	sync.Manager
+	animeCollection *anilist.AnimeCollection
+	mangaCollection *anilist.MangaCollection
+}
+
+func (f *fakeSyncManager) GetLocalAnimeCollection() mo.Option[*anilist.AnimeCollection] {
+	if f.animeCollection == nil {
+		return mo.None[*anilist.AnimeCollection]()
+	}
+	return mo.Some(f.animeCollection)
+}
+
+func (f *fakeSyncManager) GetLocalMangaCollection() mo.Option[*anilist.MangaCollection] {
+	if f.mangaCollection == nil {
+		return mo.None[*anilist.MangaCollection]()
+	}
+	return mo.Some(f.mangaCollection)
+}
+
+func (f *fakeSyncManager) SaveLocalAnimeCollection(ac *anilist.AnimeCollection) {
+	f.animeCollection = ac
+}
+
+func (f *fakeSyncManager) SaveLocalMangaCollection(mc *anilist.MangaCollection) {
+	f.mangaCollection = mc
+}
+
+func (f *fakeSyncManager) SetHasLocalChanges(bool) {}
+
+func TestLocalPlatform_UpdateEntryCustomLists(t *testing.T) {
+	tests := []struct {
+		name        string
+		customLists []string
+		expected    map[string][]int
+	}{
+		{
+			name:        "nil keeps the custom lists",
+			customLists: nil,
+			expected:    map[string][]int{"A": {1}, "B": {}},
+		},
+		{
+			name:        "empty removes the entry from the custom lists",
+			customLists: []string{},
+			expected:    map[string][]int{"A": {}, "B": {}},
+		},
+		{
+			name:        "names replace the custom lists",
+			customLists: []string{"B"},
+			expected:    map[string][]int{"A": {}, "B": {1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncManager := &fakeSyncManager{animeCollection: newTestAnimeCollection()}
+			lp, err := NewLocalPlatform(syncManager, nil, util.NewLogger())
+			require.NoError(t, err)
+
+			err = lp.UpdateEntry(1, nil, nil, lo.ToPtr(3), nil, nil, tt.customLists)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expected, animeCustomListIDs(syncManager.animeCollection))
+			require.Equal(t, 3, *syncManager.animeCollection.MediaListCollection.Lists[0].Entries[0].Progress)
+		})
+	}
+}
@@ -122,9 +122,10 @@ func rearrangeMangaCollectionLists(mangaCollection *anilist.MangaCollection) {
 
 // UpdateEntry updates the entry for the given media ID.
 // It doesn't add the entry if it doesn't exist.
-func (lp *LocalPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput) error {
+func (lp *LocalPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error {
 	if lp.syncManager.GetLocalAnimeCollection().IsPresent() {
 		animeCollection := lp.syncManager.GetLocalAnimeCollection().MustGet()
+		found := false
 
 		// Find the entry
 		for _, list := range animeCollection.MediaListCollection.Lists {
@@ -154,19 +155,28 @@ func (lp *LocalPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatu
 							Day:   completedAt.Day,
 						}
 					}
-
-					// Save the collection
-					rearrangeAnimeCollectionLists(animeCollection)
-					lp.syncManager.SaveLocalAnimeCollection(animeCollection)
-					lp.syncManager.SetHasLocalChanges(true)
-					return nil
+					// Keep going, the entry is also in the custom lists
+					found = true
 				}
 			}
 		}
+
+		if found {
+			if customLists != nil {
+				setAnimeCustomLists(animeCollection, mediaID, customLists)
+			}
+
+			// Save the collection
+			rearrangeAnimeCollectionLists(animeCollection)
+			lp.syncManager.SaveLocalAnimeCollection(animeCollection)
+			lp.syncManager.SetHasLocalChanges(true)
+			return nil
+		}
 	}
 
 	if lp.syncManager.GetLocalMangaCollection().IsPresent() {
 		mangaCollection := lp.syncManager.GetLocalMangaCollection().MustGet()
+		found := false
 
 		// Find the entry
 		for _, list := range mangaCollection.MediaListCollection.Lists {
@@ -196,15 +206,22 @@ func (lp *LocalPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatu
 							Day:   completedAt.Day,
 						}
 					}
-
-					// Save the collection
-					rearrangeMangaCollectionLists(mangaCollection)
-					lp.syncManager.SaveLocalMangaCollection(mangaCollection)
-					lp.syncManager.SetHasLocalChanges(true)
-					return nil
+					found = true
 				}
 			}
 		}
+
+		if found {
+			if customLists != nil {
+				setMangaCustomLists(mangaCollection, mediaID, customLists)
+			}
+
+			// Save the collection
+			rearrangeMangaCollectionLists(mangaCollection)
+			lp.syncManager.SaveLocalMangaCollection(mangaCollection)
+			lp.syncManager.SetHasLocalChanges(true)
+			return nil
+		}
 	}
 
 	return ErrMediaNotFound
@@ -213,6 +230,7 @@ func (lp *LocalPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatu
 func (lp *LocalPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpisodes *int) error {
 	if lp.syncManager.GetLocalAnimeCollection().IsPresent() {
 		animeCollection := lp.syncManager.GetLocalAnimeCollection().MustGet()
+		found := false
 
 		// Find the entry
 		for _, list := range animeCollection.MediaListCollection.Lists {
@@ -223,19 +241,23 @@ func (lp *LocalPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpi
 					if totalEpisodes != nil {
 						entry.Media.Episodes = totalEpisodes
 					}
-
-					// Save the collection
-					rearrangeAnimeCollectionLists(animeCollection)
-					lp.syncManager.SaveLocalAnimeCollection(animeCollection)
-					lp.syncManager.SetHasLocalChanges(true)
-					return nil
+					found = true
 				}
 			}
 		}
+
+		if found {
+			// Save the collection
+			rearrangeAnimeCollectionLists(animeCollection)
+			lp.syncManager.SaveLocalAnimeCollection(animeCollection)
+			lp.syncManager.SetHasLocalChanges(true)
+			return nil
+		}
 	}
 
 	if lp.syncManager.GetLocalMangaCollection().IsPresent() {
 		mangaCollection := lp.syncManager.GetLocalMangaCollection().MustGet()
+		found := false
 
 		// Find the entry
 		for _, list := range mangaCollection.MediaListCollection.Lists {
@@ -246,15 +268,18 @@ func (lp *LocalPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpi
 					if totalEpisodes != nil {
 						entry.Media.Chapters = totalEpisodes
 					}
-
-					// Save the collection
-					rearrangeMangaCollectionLists(mangaCollection)
-					lp.syncManager.SaveLocalMangaCollection(mangaCollection)
-					lp.syncManager.SetHasLocalChanges(true)
-					return nil
+					found = true
 				}
 			}
 		}
+
+		if found {
+			// Save the collection
+			rearrangeMangaCollectionLists(mangaCollection)
+			lp.syncManager.SaveLocalMangaCollection(mangaCollection)
+			lp.syncManager.SetHasLocalChanges(true)
+			return nil
+		}
 	}
 
 	return ErrMediaNotFound
@@ -333,7 +358,7 @@ func (lp *LocalPlatform) GetMangaDetails(mediaID int) (*anilist.MangaDetailsById
 
 func (lp *LocalPlatform) GetAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
 	if lp.syncManager.GetLocalAnimeCollection().IsPresent() {
-		return lp.syncManager.GetLocalAnimeCollection().MustGet(), nil
+		return withoutAnimeCustomLists(lp.syncManager.GetLocalAnimeCollection().MustGet()), nil
 	} else {
 		return nil, ErrNoLocalAnimeCollection
 	}
@@ -354,7 +379,7 @@ func (lp *LocalPlatform) RefreshAnimeCollection() (*anilist.AnimeCollection, err
 		return nil, ErrNoLocalAnimeCollection
 	}
 
-	return animeCollection, nil
+	return withoutAnimeCustomLists(animeCollection), nil
 }
 
 func (lp *LocalPlatform) GetAnimeCollectionWithRelations() (*anilist.AnimeCollectionWithRelations, error) {
@@ -363,7 +388,7 @@ func (lp *LocalPlatform) GetAnimeCollectionWithRelations() (*anilist.AnimeCollec
 
 func (lp *LocalPlatform) GetMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
 	if lp.syncManager.GetLocalMangaCollection().IsPresent() {
-		return lp.syncManager.GetLocalMangaCollection().MustGet(), nil
+		return withoutMangaCustomLists(lp.syncManager.GetLocalMangaCollection().MustGet()), nil
 	} else {
 		return nil, ErrorNoLocalMangaCollection
 	}
@@ -383,7 +408,7 @@ func (lp *LocalPlatform) RefreshMangaCollection() (*anilist.MangaCollection, err
 		return nil, ErrorNoLocalMangaCollection
 	}
 
-	return mangaCollection, nil
+	return withoutMangaCustomLists(mangaCollection), nil
 }
 
 // AddMediaToCollection isn't supported for the local platform, always returns an error.
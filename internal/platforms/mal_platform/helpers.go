@@ -0,0 +1,245 @@
+package mal_platform
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"math"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mal"
+	"strconv"
+	"strings"
+)
+
+// listStatuses is the order of the lists in the collections.
+var listStatuses = []anilist.MediaListStatus{
+	anilist.MediaListStatusCurrent,
+	anilist.MediaListStatusPlanning,
+	anilist.MediaListStatusCompleted,
+	anilist.MediaListStatusDropped,
+	anilist.MediaListStatusPaused,
+	anilist.MediaListStatusRepeating,
+}
+
+// ToAnilistStatus converts a MAL list status to an AniList list status.
+// MAL has no "repeating" status, rewatched entries are flagged instead.
+func ToAnilistStatus(status mal.MediaListStatus, isRepeating bool) anilist.MediaListStatus {
+	if isRepeating {
+		return anilist.MediaListStatusRepeating
+	}
+	switch status {
+	case mal.MediaListStatusWatching, mal.MediaListStatusReading:
+		return anilist.MediaListStatusCurrent
+	case mal.MediaListStatusCompleted:
+		return anilist.MediaListStatusCompleted
+	case mal.MediaListStatusOnHold:
+		return anilist.MediaListStatusPaused
+	case mal.MediaListStatusDropped:
+		return anilist.MediaListStatusDropped
+	default:
+		return anilist.MediaListStatusPlanning
+	}
+}
+
+// ToMalStatus converts an AniList list status to a MAL list status and whether the entry is being rewatched.
+func ToMalStatus(status anilist.MediaListStatus, isManga bool) (mal.MediaListStatus, bool) {
+	switch status {
+	case anilist.MediaListStatusCurrent:
+		if isManga {
+			return mal.MediaListStatusReading, false
+		}
+		return mal.MediaListStatusWatching, false
+	case anilist.MediaListStatusRepeating:
+		// MAL keeps rewatched entries in the completed list
+		return mal.MediaListStatusCompleted, true
+	case anilist.MediaListStatusCompleted:
+		return mal.MediaListStatusCompleted, false
+	case anilist.MediaListStatusPaused:
+		return mal.MediaListStatusOnHold, false
+	case anilist.MediaListStatusDropped:
+		return mal.MediaListStatusDropped, false
+	default:
+		if isManga {
+			return mal.MediaListStatusPlanToRead, false
+		}
+		return mal.MediaListStatusPlanToWatch, false
+	}
+}
+
+// ToMalScore converts an AniList raw score (0-100) to a MAL score (0-10).
+func ToMalScore(scoreRaw int) int {
+	return int(math.Round(float64(scoreRaw) / 10))
+}
+
+// ToAnilistScore converts a MAL score (0-10) to an AniList raw score (0-100).
+func ToAnilistScore(score int) float64 {
+	return float64(score * 10)
+}
+
+// ParseMalDate parses a MAL date, e.g. "2024-01-31", "2024-01" or "2024".
+// Returns nil if the date is empty or invalid.
+func ParseMalDate(date string) *anilist.FuzzyDateInput {
+	if date == "" {
+		return nil
+	}
+	parts := strings.Split(date, "-")
+	ret := &anilist.FuzzyDateInput{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil
+		}
+		switch i {
+		case 0:
+			ret.Year = lo.ToPtr(n)
+		case 1:
+			ret.Month = lo.ToPtr(n)
+		case 2:
+			ret.Day = lo.ToPtr(n)
+		}
+	}
+	return ret
+}
+
+// FormatMalDate formats an AniList date for MAL.
+// MAL only accepts complete dates, an empty string removes the date.
+func FormatMalDate(date *anilist.FuzzyDateInput) string {
+	if date == nil || date.Year == nil || *date.Year == 0 {
+		return ""
+	}
+	month, day := 1, 1
+	if date.Month != nil && *date.Month > 0 {
+		month = *date.Month
+	}
+	if date.Day != nil && *date.Day > 0 {
+		day = *date.Day
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", *date.Year, month, day)
+}
+
+func listName(status anilist.MediaListStatus, isManga bool) string {
+	switch status {
+	case anilist.MediaListStatusCurrent:
+		if isManga {
+			return "Reading"
+		}
+		return "Watching"
+	case anilist.MediaListStatusPlanning:
+		return "Planning"
+	case anilist.MediaListStatusCompleted:
+		return "Completed"
+	case anilist.MediaListStatusDropped:
+		return "Dropped"
+	case anilist.MediaListStatusPaused:
+		return "Paused"
+	case anilist.MediaListStatusRepeating:
+		if isManga {
+			return "Rereading"
+		}
+		return "Rewatching"
+	}
+	return string(status)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// newAnimeCollection creates an AniList anime collection from the MAL list entries.
+// The media of the entries are keyed by MAL ID, entries without media are skipped.
+func newAnimeCollection(entries []*mal.AnimeListEntry, media map[int]*anilist.BaseAnime) *anilist.AnimeCollection {
+	lists := make(map[anilist.MediaListStatus]*anilist.AnimeCollection_MediaListCollection_Lists)
+	ret := &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: make([]*anilist.AnimeCollection_MediaListCollection_Lists, 0, len(listStatuses)),
+		},
+	}
+	for _, status := range listStatuses {
+		list := &anilist.AnimeCollection_MediaListCollection_Lists{
+			Status:       lo.ToPtr(status),
+			Name:         lo.ToPtr(listName(status, false)),
+			IsCustomList: lo.ToPtr(false),
+			Entries:      make([]*anilist.AnimeCollection_MediaListCollection_Lists_Entries, 0),
+		}
+		lists[status] = list
+		ret.MediaListCollection.Lists = append(ret.MediaListCollection.Lists, list)
+	}
+
+	for _, entry := range entries {
+		m, ok := media[entry.Node.ID]
+		if !ok {
+			continue
+		}
+		ls := entry.ListStatus
+		status := ToAnilistStatus(ls.Status, ls.IsRewatching)
+
+		e := &anilist.AnimeCollection_MediaListCollection_Lists_Entries{
+			ID:       m.ID,
+			Score:    lo.ToPtr(ToAnilistScore(ls.Score)),
+			Progress: lo.ToPtr(ls.NumEpisodesWatched),
+			Status:   lo.ToPtr(status),
+			Notes:    lo.ToPtr(ls.Comments),
+			Repeat:   lo.ToPtr(ls.NumTimesRewatched),
+			Private:  lo.ToPtr(false),
+			Media:    m,
+		}
+		if date := ParseMalDate(ls.StartDate); date != nil {
+			e.StartedAt = &anilist.AnimeCollection_MediaListCollection_Lists_Entries_StartedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+		if date := ParseMalDate(ls.FinishDate); date != nil {
+			e.CompletedAt = &anilist.AnimeCollection_MediaListCollection_Lists_Entries_CompletedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+
+		lists[status].Entries = append(lists[status].Entries, e)
+	}
+
+	return ret
+}
+
+// newMangaCollection creates an AniList manga collection from the MAL list entries.
+// The media of the entries are keyed by MAL ID, entries without media are skipped.
+func newMangaCollection(entries []*mal.MangaListEntry, media map[int]*anilist.BaseManga) *anilist.MangaCollection {
+	lists := make(map[anilist.MediaListStatus]*anilist.MangaCollection_MediaListCollection_Lists)
+	ret := &anilist.MangaCollection{
+		MediaListCollection: &anilist.MangaCollection_MediaListCollection{
+			Lists: make([]*anilist.MangaCollection_MediaListCollection_Lists, 0, len(listStatuses)),
+		},
+	}
+	for _, status := range listStatuses {
+		list := &anilist.MangaCollection_MediaListCollection_Lists{
+			Status:       lo.ToPtr(status),
+			Name:         lo.ToPtr(listName(status, true)),
+			IsCustomList: lo.ToPtr(false),
+			Entries:      make([]*anilist.MangaCollection_MediaListCollection_Lists_Entries, 0),
+		}
+		lists[status] = list
+		ret.MediaListCollection.Lists = append(ret.MediaListCollection.Lists, list)
+	}
+
+	for _, entry := range entries {
+		m, ok := media[entry.Node.ID]
+		if !ok {
+			continue
+		}
+		ls := entry.ListStatus
+		status := ToAnilistStatus(ls.Status, ls.IsRereading)
+
+		e := &anilist.MangaCollection_MediaListCollection_Lists_Entries{
+			ID:       m.ID,
+			Score:    lo.ToPtr(ToAnilistScore(ls.Score)),
+			Progress: lo.ToPtr(ls.NumChaptersRead),
+			Status:   lo.ToPtr(status),
+			Notes:    lo.ToPtr(ls.Comments),
+			Repeat:   lo.ToPtr(ls.NumTimesReread),
+			Private:  lo.ToPtr(false),
+			Media:    m,
+		}
+		if date := ParseMalDate(ls.StartDate); date != nil {
+			e.StartedAt = &anilist.MangaCollection_MediaListCollection_Lists_Entries_StartedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+		if date := ParseMalDate(ls.FinishDate); date != nil {
+			e.CompletedAt = &anilist.MangaCollection_MediaListCollection_Lists_Entries_CompletedAt{Year: date.Year, Month: date.Month, Day: date.Day}
+		}
+
+		lists[status].Entries = append(lists[status].Entries, e)
+	}
+
+	return ret
+}
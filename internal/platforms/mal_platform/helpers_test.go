@@ -0,0 +1,84 @@
+package mal_platform
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mal"
+	"testing"
+)
+
+func TestStatusConversion(t *testing.T) {
+	tests := []struct {
+		status   anilist.MediaListStatus
+		isManga  bool
+		expected mal.MediaListStatus
+	}{
+		{anilist.MediaListStatusCurrent, false, mal.MediaListStatusWatching},
+		{anilist.MediaListStatusCurrent, true, mal.MediaListStatusReading},
+		{anilist.MediaListStatusPlanning, false, mal.MediaListStatusPlanToWatch},
+		{anilist.MediaListStatusPlanning, true, mal.MediaListStatusPlanToRead},
+		{anilist.MediaListStatusCompleted, false, mal.MediaListStatusCompleted},
+		{anilist.MediaListStatusPaused, false, mal.MediaListStatusOnHold},
+		{anilist.MediaListStatusDropped, true, mal.MediaListStatusDropped},
+	}
+
+	for _, tt := range tests {
+		malStatus, isRepeating := ToMalStatus(tt.status, tt.isManga)
+		require.Equal(t, tt.expected, malStatus)
+		require.False(t, isRepeating)
+		require.Equal(t, tt.status, ToAnilistStatus(malStatus, isRepeating))
+	}
+
+	malStatus, isRepeating := ToMalStatus(anilist.MediaListStatusRepeating, false)
+	require.True(t, isRepeating)
+	require.Equal(t, anilist.MediaListStatusRepeating, ToAnilistStatus(malStatus, isRepeating))
+}
+
+func TestScoreAndDateConversion(t *testing.T) {
+	require.Equal(t, 8, ToMalScore(75))
+	require.Equal(t, 0, ToMalScore(0))
+	require.Equal(t, float64(90), ToAnilistScore(9))
+
+	require.Nil(t, ParseMalDate(""))
+	require.Nil(t, ParseMalDate("invalid"))
+	require.Equal(t, &anilist.FuzzyDateInput{Year: lo.ToPtr(2024)}, ParseMalDate("2024"))
+	require.Equal(t, &anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(1), Day: lo.ToPtr(31)}, ParseMalDate("2024-01-31"))
+
+	require.Equal(t, "", FormatMalDate(nil))
+	require.Equal(t, "2024-03-01", FormatMalDate(&anilist.FuzzyDateInput{Year: lo.ToPtr(2024), Month: lo.ToPtr(3)}))
+	require.Equal(t, "2024-01-31", FormatMalDate(ParseMalDate("2024-01-31")))
+}
+
+func TestNewAnimeCollection(t *testing.T) {
+	entry := &mal.AnimeListEntry{}
+	entry.Node.ID = 21
+	entry.ListStatus.Status = mal.MediaListStatusCompleted
+	entry.ListStatus.IsRewatching = true
+	entry.ListStatus.NumEpisodesWatched = 12
+	entry.ListStatus.Score = 9
+	entry.ListStatus.StartDate = "2024-01"
+
+	missing := &mal.AnimeListEntry{}
+	missing.Node.ID = 22
+
+	collection := newAnimeCollection([]*mal.AnimeListEntry{entry, missing}, map[int]*anilist.BaseAnime{
+		21: {ID: 21},
+	})
+
+	lists := collection.GetMediaListCollection().GetLists()
+	require.Len(t, lists, len(listStatuses))
+	for _, list := range lists {
+		if *list.Status != anilist.MediaListStatusRepeating {
+			require.Empty(t, list.Entries)
+			continue
+		}
+		require.Equal(t, "Rewatching", *list.Name)
+		require.Len(t, list.Entries, 1)
+		require.Equal(t, 21, list.Entries[0].GetMedia().GetID())
+		require.Equal(t, 12, *list.Entries[0].Progress)
+		require.Equal(t, float64(90), *list.Entries[0].Score)
+		require.Equal(t, 1, *list.Entries[0].StartedAt.Month)
+		require.Nil(t, list.Entries[0].CompletedAt)
+	}
+}
@@ -0,0 +1,563 @@
+package mal_platform
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/api/mal"
+	"seanime/internal/api/metadata"
+	"seanime/internal/database/db"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/util/limiter"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotLoggedIn means the user hasn't connected their MyAnimeList account
+	ErrNotLoggedIn = errors.New("mal: Not logged in")
+	// ErrNoMalID means the media couldn't be mapped to a MyAnimeList ID
+	ErrNoMalID = errors.New("mal: Media has no MyAnimeList ID")
+)
+
+type (
+	// MalPlatform uses MyAnimeList as the tracking platform.
+	// The list entries are fetched from MyAnimeList and mapped to their AniList counterparts so that the rest of the app
+	// can keep working with AniList media. Media details are still fetched from AniList, no AniList account is needed.
+	MalPlatform struct {
+		logger           *zerolog.Logger
+		db               *db.Database
+		profileID        uint
+		anilistClient    anilist.AnilistClient
+		metadataProvider metadata.Provider
+		animeCollection  mo.Option[*anilist.AnimeCollection]
+		mangaCollection  mo.Option[*anilist.MangaCollection]
+		// AniList ID -> MAL ID of the media in the collections
+		animeMalIDs map[int]int
+		mangaMalIDs map[int]int
+		mu          sync.RWMutex
+	}
+
+	NewMalPlatformOptions struct {
+		Logger           *zerolog.Logger
+		Database         *db.Database
+		ProfileID        uint
+		AnilistClient    anilist.AnilistClient
+		MetadataProvider metadata.Provider
+	}
+)
+
+func NewMalPlatform(opts *NewMalPlatformOptions) platform.Platform {
+	return &MalPlatform{
+		logger:           opts.Logger,
+		db:               opts.Database,
+		profileID:        opts.ProfileID,
+		anilistClient:    opts.AnilistClient,
+		metadataProvider: opts.MetadataProvider,
+		animeCollection:  mo.None[*anilist.AnimeCollection](),
+		mangaCollection:  mo.None[*anilist.MangaCollection](),
+		animeMalIDs:      make(map[int]int),
+		mangaMalIDs:      make(map[int]int),
+	}
+}
+
+// getWrapper returns a MAL API wrapper with a valid access token.
+func (mp *MalPlatform) getWrapper() (*mal.Wrapper, error) {
+	malInfo, err := mp.db.GetMalInfoByID(mp.profileID)
+	if err != nil || malInfo == nil || malInfo.AccessToken == "" {
+		return nil, ErrNotLoggedIn
+	}
+
+	malInfo, err = mal.VerifyMALAuth(malInfo, mp.db, mp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return mal.NewWrapper(malInfo.AccessToken, mp.logger), nil
+}
+
+func (mp *MalPlatform) isLoggedIn() bool {
+	malInfo, err := mp.db.GetMalInfoByID(mp.profileID)
+	return err == nil && malInfo != nil && malInfo.AccessToken != ""
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (mp *MalPlatform) SetUsername(username string) {
+	// no-op, the MAL account is used
+}
+
+func (mp *MalPlatform) SetAnilistClient(client anilist.AnilistClient) {
+	mp.anilistClient = client
+}
+
+func (mp *MalPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error {
+	mp.logger.Trace().Msg("mal platform: Updating entry")
+
+	wrapper, err := mp.getWrapper()
+	if err != nil {
+		return err
+	}
+
+	malID, isManga, err := mp.resolveMalID(mediaID)
+	if err != nil {
+		return err
+	}
+
+	var malStatus *mal.MediaListStatus
+	var isRepeating *bool
+	if status != nil {
+		s, r := ToMalStatus(*status, isManga)
+		malStatus, isRepeating = &s, &r
+	}
+	var score *int
+	if scoreRaw != nil {
+		score = lo.ToPtr(ToMalScore(*scoreRaw))
+	}
+	var startDate, finishDate *string
+	if startedAt != nil {
+		startDate = lo.ToPtr(FormatMalDate(startedAt))
+	}
+	if completedAt != nil {
+		finishDate = lo.ToPtr(FormatMalDate(completedAt))
+	}
+
+	if isManga {
+		err = wrapper.UpdateMangaListStatus(&mal.MangaListStatusParams{
+			Status:          malStatus,
+			IsRereading:     isRepeating,
+			NumChaptersRead: progress,
+			Score:           score,
+			StartDate:       startDate,
+			FinishDate:      finishDate,
+		}, malID)
+	} else {
+		err = wrapper.UpdateAnimeListStatus(&mal.AnimeListStatusParams{
+			Status:             malStatus,
+			IsRewatching:       isRepeating,
+			NumEpisodesWatched: progress,
+			Score:              score,
+			StartDate:          startDate,
+			FinishDate:         finishDate,
+		}, malID)
+	}
+	return err
+}
+
+func (mp *MalPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpisodes *int) error {
+	mp.logger.Trace().Msg("mal platform: Updating entry progress")
+
+	totalEp := 0
+	if totalEpisodes != nil && *totalEpisodes > 0 {
+		totalEp = *totalEpisodes
+	}
+
+	status := anilist.MediaListStatusCurrent
+	// Check if the media is in the repeating list
+	if entryStatus, ok := mp.getEntryStatus(mediaID); ok && entryStatus == anilist.MediaListStatusRepeating {
+		status = anilist.MediaListStatusRepeating
+	}
+	if totalEp > 0 && progress >= totalEp {
+		status = anilist.MediaListStatusCompleted
+	}
+
+	if totalEp > 0 && progress > totalEp {
+		progress = totalEp
+	}
+
+	return mp.UpdateEntry(mediaID, &status, nil, &progress, nil, nil, nil)
+}
+
+func (mp *MalPlatform) DeleteEntry(mediaID int) error {
+	mp.logger.Trace().Msg("mal platform: Deleting entry")
+
+	wrapper, err := mp.getWrapper()
+	if err != nil {
+		return err
+	}
+
+	malID, isManga, err := mp.resolveMalID(mediaID)
+	if err != nil {
+		return err
+	}
+
+	if isManga {
+		return wrapper.DeleteMangaListItem(malID)
+	}
+	return wrapper.DeleteAnimeListItem(malID)
+}
+
+func (mp *MalPlatform) GetAnime(mediaID int) (*anilist.BaseAnime, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching anime")
+	ret, err := mp.anilistClient.BaseAnimeByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (mp *MalPlatform) GetAnimeByMalID(malID int) (*anilist.BaseAnime, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching anime by MAL ID")
+	ret, err := mp.anilistClient.BaseAnimeByMalID(context.Background(), &malID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (mp *MalPlatform) GetAnimeDetails(mediaID int) (*anilist.AnimeDetailsById_Media, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching anime details")
+	ret, err := mp.anilistClient.AnimeDetailsByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (mp *MalPlatform) GetAnimeWithRelations(mediaID int) (*anilist.CompleteAnime, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching anime with relations")
+	ret, err := mp.anilistClient.CompleteAnimeByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (mp *MalPlatform) GetManga(mediaID int) (*anilist.BaseManga, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching manga")
+	ret, err := mp.anilistClient.BaseMangaByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (mp *MalPlatform) GetMangaDetails(mediaID int) (*anilist.MangaDetailsById_Media, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching manga details")
+	ret, err := mp.anilistClient.MangaDetailsByID(context.Background(), &mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return ret.GetMedia(), nil
+}
+
+func (mp *MalPlatform) GetAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
+	if !bypassCache {
+		mp.mu.RLock()
+		collection, ok := mp.animeCollection.Get()
+		mp.mu.RUnlock()
+		if ok {
+			return collection, nil
+		}
+	}
+
+	return mp.RefreshAnimeCollection()
+}
+
+// GetRawAnimeCollection returns the same collection as GetAnimeCollection since MAL has no custom lists.
+func (mp *MalPlatform) GetRawAnimeCollection(bypassCache bool) (*anilist.AnimeCollection, error) {
+	return mp.GetAnimeCollection(bypassCache)
+}
+
+func (mp *MalPlatform) RefreshAnimeCollection() (*anilist.AnimeCollection, error) {
+	if !mp.isLoggedIn() {
+		return nil, nil
+	}
+
+	wrapper, err := mp.getWrapper()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := wrapper.GetAnimeCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	malIDs := lo.Map(entries, func(e *mal.AnimeListEntry, _ int) int { return e.Node.ID })
+	media, err := anilist.FetchBaseAnimeByMalIDs(malIDs, mp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fall back to the mappings for the media AniList doesn't know the MAL ID of
+	for _, entry := range entries {
+		if _, ok := media[entry.Node.ID]; ok {
+			continue
+		}
+		m, ok := mp.getAnimeFromMappings(entry.Node.ID)
+		if !ok {
+			mp.logger.Warn().Int("malId", entry.Node.ID).Str("title", entry.Node.Title).Msg("mal platform: Could not find anime on AniList, skipping")
+			continue
+		}
+		media[entry.Node.ID] = m
+	}
+
+	collection := newAnimeCollection(entries, media)
+
+	mp.mu.Lock()
+	for malID, m := range media {
+		mp.animeMalIDs[m.ID] = malID
+	}
+	mp.animeCollection = mo.Some(collection)
+	mp.mu.Unlock()
+
+	return collection, nil
+}
+
+func (mp *MalPlatform) GetAnimeCollectionWithRelations() (*anilist.AnimeCollectionWithRelations, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching anime collection with relations")
+
+	collection, err := mp.GetAnimeCollection(false)
+	if err != nil || collection == nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0)
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		for _, entry := range list.GetEntries() {
+			ids = append(ids, entry.GetMedia().GetID())
+		}
+	}
+
+	media, err := anilist.FetchCompleteAnimeByIDs(ids, mp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &anilist.AnimeCollectionWithRelations{
+		MediaListCollection: &anilist.AnimeCollectionWithRelations_MediaListCollection{
+			Lists: make([]*anilist.AnimeCollectionWithRelations_MediaListCollection_Lists, 0),
+		},
+	}
+	for _, list := range collection.GetMediaListCollection().GetLists() {
+		l := &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists{
+			Status:       list.Status,
+			Name:         list.Name,
+			IsCustomList: list.IsCustomList,
+			Entries:      make([]*anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries, 0, len(list.Entries)),
+		}
+		for _, entry := range list.GetEntries() {
+			m, ok := media[entry.GetMedia().GetID()]
+			if !ok {
+				continue
+			}
+			e := &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries{
+				ID:       entry.ID,
+				Score:    entry.Score,
+				Progress: entry.Progress,
+				Status:   entry.Status,
+				Notes:    entry.Notes,
+				Repeat:   entry.Repeat,
+				Private:  entry.Private,
+				Media:    m,
+			}
+			if entry.StartedAt != nil {
+				e.StartedAt = &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries_StartedAt{Year: entry.StartedAt.Year, Month: entry.StartedAt.Month, Day: entry.StartedAt.Day}
+			}
+			if entry.CompletedAt != nil {
+				e.CompletedAt = &anilist.AnimeCollectionWithRelations_MediaListCollection_Lists_Entries_CompletedAt{Year: entry.CompletedAt.Year, Month: entry.CompletedAt.Month, Day: entry.CompletedAt.Day}
+			}
+			l.Entries = append(l.Entries, e)
+		}
+		ret.MediaListCollection.Lists = append(ret.MediaListCollection.Lists, l)
+	}
+
+	return ret, nil
+}
+
+func (mp *MalPlatform) GetMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
+	if !bypassCache {
+		mp.mu.RLock()
+		collection, ok := mp.mangaCollection.Get()
+		mp.mu.RUnlock()
+		if ok {
+			return collection, nil
+		}
+	}
+
+	return mp.RefreshMangaCollection()
+}
+
+// GetRawMangaCollection returns the same collection as GetMangaCollection since MAL has no custom lists.
+func (mp *MalPlatform) GetRawMangaCollection(bypassCache bool) (*anilist.MangaCollection, error) {
+	return mp.GetMangaCollection(bypassCache)
+}
+
+func (mp *MalPlatform) RefreshMangaCollection() (*anilist.MangaCollection, error) {
+	if !mp.isLoggedIn() {
+		return nil, nil
+	}
+
+	wrapper, err := mp.getWrapper()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := wrapper.GetMangaCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	malIDs := lo.Map(entries, func(e *mal.MangaListEntry, _ int) int { return e.Node.ID })
+	media, err := anilist.FetchBaseMangaByMalIDs(malIDs, mp.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if _, ok := media[entry.Node.ID]; !ok {
+			mp.logger.Warn().Int("malId", entry.Node.ID).Str("title", entry.Node.Title).Msg("mal platform: Could not find manga on AniList, skipping")
+		}
+	}
+
+	collection := newMangaCollection(entries, media)
+
+	mp.mu.Lock()
+	for malID, m := range media {
+		mp.mangaMalIDs[m.ID] = malID
+	}
+	mp.mangaCollection = mo.Some(collection)
+	mp.mu.Unlock()
+
+	return collection, nil
+}
+
+func (mp *MalPlatform) AddMediaToCollection(mIds []int) error {
+	mp.logger.Trace().Msg("mal platform: Adding media to collection")
+	if len(mIds) == 0 {
+		mp.logger.Debug().Msg("mal platform: No media added to planning list")
+		return nil
+	}
+
+	rateLimiter := limiter.NewLimiter(1*time.Second, 1) // 1 request per second
+
+	wg := sync.WaitGroup{}
+	for _, _id := range mIds {
+		wg.Add(1)
+		go func(id int) {
+			rateLimiter.Wait()
+			defer wg.Done()
+			err := mp.UpdateEntry(id, lo.ToPtr(anilist.MediaListStatusPlanning), nil, lo.ToPtr(0), nil, nil, nil)
+			if err != nil {
+				mp.logger.Error().Err(err).Int("mediaId", id).Msg("mal platform: An error occurred while adding media to planning list")
+			}
+		}(_id)
+	}
+	wg.Wait()
+
+	mp.logger.Debug().Any("count", len(mIds)).Msg("mal platform: Media added to planning list")
+	return nil
+}
+
+func (mp *MalPlatform) GetStudioDetails(studioID int) (*anilist.StudioDetails, error) {
+	mp.logger.Trace().Msg("mal platform: Fetching studio details")
+	ret, err := mp.anilistClient.StudioDetails(context.Background(), &studioID)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (mp *MalPlatform) GetAnilistClient() anilist.AnilistClient {
+	return mp.anilistClient
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// getAnimeFromMappings finds the AniList anime of a MAL ID using the metadata mappings.
+func (mp *MalPlatform) getAnimeFromMappings(malID int) (*anilist.BaseAnime, bool) {
+	if mp.metadataProvider == nil {
+		return nil, false
+	}
+	animeMetadata, err := mp.metadataProvider.GetAnimeMetadata(metadata.MalPlatform, malID)
+	if err != nil || animeMetadata == nil || animeMetadata.Mappings == nil || animeMetadata.Mappings.AnilistId == 0 {
+		return nil, false
+	}
+	m, err := mp.GetAnime(animeMetadata.Mappings.AnilistId)
+	if err != nil || m == nil {
+		return nil, false
+	}
+	return m, true
+}
+
+// resolveMalID returns the MAL ID of an AniList media and whether it is a manga.
+func (mp *MalPlatform) resolveMalID(mediaID int) (int, bool, error) {
+	mp.mu.RLock()
+	if malID, ok := mp.animeMalIDs[mediaID]; ok {
+		mp.mu.RUnlock()
+		return malID, false, nil
+	}
+	if malID, ok := mp.mangaMalIDs[mediaID]; ok {
+		mp.mu.RUnlock()
+		return malID, true, nil
+	}
+	mp.mu.RUnlock()
+
+	// Not in the collections, fetch the media
+	// The media is a manga if AniList has no anime with this ID
+	anime, err := mp.GetAnime(mediaID)
+	if err != nil && !anilist.IsNotFound(err) {
+		return 0, false, err
+	}
+	if err == nil && anime != nil {
+		malID := anime.GetIDMal()
+		if malID == nil || *malID == 0 {
+			// Try the mappings
+			if mp.metadataProvider == nil {
+				return 0, false, ErrNoMalID
+			}
+			animeMetadata, err := mp.metadataProvider.GetAnimeMetadata(metadata.AnilistPlatform, mediaID)
+			if err != nil || animeMetadata == nil || animeMetadata.Mappings == nil || animeMetadata.Mappings.MalId == 0 {
+				return 0, false, ErrNoMalID
+			}
+			malID = &animeMetadata.Mappings.MalId
+		}
+		mp.mu.Lock()
+		mp.animeMalIDs[mediaID] = *malID
+		mp.mu.Unlock()
+		return *malID, false, nil
+	}
+
+	manga, err := mp.GetManga(mediaID)
+	if err != nil {
+		return 0, false, err
+	}
+	malID := manga.GetIDMal()
+	if malID == nil || *malID == 0 {
+		return 0, true, ErrNoMalID
+	}
+	mp.mu.Lock()
+	mp.mangaMalIDs[mediaID] = *malID
+	mp.mu.Unlock()
+	return *malID, true, nil
+}
+
+// getEntryStatus returns the status of the media in the cached collections.
+func (mp *MalPlatform) getEntryStatus(mediaID int) (anilist.MediaListStatus, bool) {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	if collection, ok := mp.animeCollection.Get(); ok {
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				if entry.GetMedia().GetID() == mediaID && entry.GetStatus() != nil {
+					return *entry.GetStatus(), true
+				}
+			}
+		}
+	}
+	if collection, ok := mp.mangaCollection.Get(); ok {
+		for _, list := range collection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				if entry.GetMedia().GetID() == mediaID && entry.GetStatus() != nil {
+					return *entry.GetStatus(), true
+				}
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,81 @@
+package mutation_queue
+
+import (
+	"seanime/internal/api/anilist"
+	"seanime/internal/platforms/platform"
+)
+
+// Mutation is the write that will be sent to the platform.
+// Writes to the same media are collapsed, the latest value of each field wins.
+type Mutation struct {
+	Delete   bool                     `json:"delete,omitempty"`
+	Status   *anilist.MediaListStatus `json:"status,omitempty"`
+	ScoreRaw *int                     `json:"scoreRaw,omitempty"`
+	Progress *int                     `json:"progress,omitempty"`
+	// AutoProgress is true if the progress was set by UpdateEntryProgress,
+	// the platform then decides the status of the entry from the progress.
+	AutoProgress  bool                    `json:"autoProgress,omitempty"`
+	TotalEpisodes *int                    `json:"totalEpisodes,omitempty"`
+	StartedAt     *anilist.FuzzyDateInput `json:"startedAt,omitempty"`
+	CompletedAt   *anilist.FuzzyDateInput `json:"completedAt,omitempty"`
+	// CustomLists is nil if the custom lists are unchanged, an empty slice removes the entry from all custom lists.
+	// Not omitted when empty for that reason.
+	CustomLists []string `json:"customLists"`
+}
+
+func (m *Mutation) updateEntry(status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) {
+	m.Delete = false
+	if status != nil {
+		m.Status = status
+	}
+	if scoreRaw != nil {
+		m.ScoreRaw = scoreRaw
+	}
+	if progress != nil {
+		m.Progress = progress
+		m.AutoProgress = false
+		m.TotalEpisodes = nil
+	}
+	if startedAt != nil {
+		m.StartedAt = startedAt
+	}
+	if completedAt != nil {
+		m.CompletedAt = completedAt
+	}
+	if customLists != nil {
+		m.CustomLists = customLists
+	}
+}
+
+func (m *Mutation) updateEntryProgress(progress int, totalEpisodes *int) {
+	m.Delete = false
+	m.Progress = &progress
+	m.AutoProgress = true
+	m.TotalEpisodes = totalEpisodes
+	// The status is decided by the platform from the progress
+	m.Status = nil
+}
+
+func (m *Mutation) deleteEntry() {
+	*m = Mutation{Delete: true}
+}
+
+// apply sends the mutation to the platform.
+// When the progress was set by UpdateEntryProgress, it is sent first so that the other fields can override the status.
+func (m *Mutation) apply(p platform.Platform, mediaID int) error {
+	if m.Delete {
+		return p.DeleteEntry(mediaID)
+	}
+
+	if m.AutoProgress && m.Progress != nil {
+		if err := p.UpdateEntryProgress(mediaID, *m.Progress, m.TotalEpisodes); err != nil {
+			return err
+		}
+		if m.Status == nil && m.ScoreRaw == nil && m.StartedAt == nil && m.CompletedAt == nil && m.CustomLists == nil {
+			return nil
+		}
+		return p.UpdateEntry(mediaID, m.Status, m.ScoreRaw, nil, m.StartedAt, m.CompletedAt, m.CustomLists)
+	}
+
+	return p.UpdateEntry(mediaID, m.Status, m.ScoreRaw, m.Progress, m.StartedAt, m.CompletedAt, m.CustomLists)
+}
@@ -0,0 +1,31 @@
+package mutation_queue
+
+import (
+	"seanime/internal/api/anilist"
+	"seanime/internal/platforms/platform"
+)
+
+// queuedPlatform records the writes to the platform so that they are retried if they fail.
+type queuedPlatform struct {
+	platform.Platform
+	manager *Manager
+	key     platformKey
+}
+
+func (qp *queuedPlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error {
+	return qp.manager.write(qp.key, qp.Platform, mediaID, func(m *Mutation) {
+		m.updateEntry(status, scoreRaw, progress, startedAt, completedAt, customLists)
+	})
+}
+
+func (qp *queuedPlatform) UpdateEntryProgress(mediaID int, progress int, totalEpisodes *int) error {
+	return qp.manager.write(qp.key, qp.Platform, mediaID, func(m *Mutation) {
+		m.updateEntryProgress(progress, totalEpisodes)
+	})
+}
+
+func (qp *queuedPlatform) DeleteEntry(mediaID int) error {
+	return qp.manager.write(qp.key, qp.Platform, mediaID, func(m *Mutation) {
+		m.deleteEntry()
+	})
+}
@@ -0,0 +1,408 @@
+package mutation_queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"net/http"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/notifier"
+	"seanime/internal/platforms/kitsu_platform"
+	"seanime/internal/platforms/mal_platform"
+	"seanime/internal/platforms/platform"
+	"sync"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusFailed  = "failed"
+
+	// MaxAttempts is the number of attempts after which a mutation is marked as failed.
+	// Failed mutations are kept until they are retried or discarded by the user.
+	MaxAttempts = 10
+
+	maxRetryBackoff = time.Hour
+)
+
+// initialRetryBackoff is doubled after each failed attempt.
+var initialRetryBackoff = 30 * time.Second
+
+var (
+	ErrMutationNotFound    = errors.New("mutation not found")
+	ErrPlatformUnavailable = errors.New("mutation queue: The platform of the mutation is not in use")
+)
+
+type (
+	// Manager records the writes to the tracking platforms and retries the ones that failed,
+	// e.g. when the platform is down or rate limiting.
+	// This is separate from the offline mode, the writes are still sent to the platform right away.
+	Manager struct {
+		logger    *zerolog.Logger
+		database  *db.Database
+		mu        sync.Mutex
+		platforms map[platformKey]platform.Platform
+		retrying  map[uint]struct{}
+		started   bool
+		ctx       context.Context
+		cancel    context.CancelFunc
+		// onRetrySucceeded is called when a mutation that failed is sent
+		onRetrySucceeded func(profileID uint, platformName string, mediaID int)
+		// notify is replaced in tests
+		notify func(message string)
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+	}
+
+	platformKey struct {
+		profileID uint
+		platform  string
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		logger:    opts.Logger,
+		database:  opts.Database,
+		platforms: make(map[platformKey]platform.Platform),
+		retrying:  make(map[uint]struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+		notify: func(message string) {
+			notifier.GlobalNotifier.Notify(notifier.PlatformWrite, message)
+		},
+	}
+}
+
+// SetOnRetrySucceeded sets the function called when a mutation that failed is sent,
+// e.g. to synchronize the entry since the write didn't go through the wrapped platform.
+func (m *Manager) SetOnRetrySucceeded(f func(profileID uint, platformName string, mediaID int)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onRetrySucceeded = f
+}
+
+// Shutdown stops the pending retries. They are resumed by ResumePendingMutations on the next start.
+func (m *Manager) Shutdown() {
+	if m == nil {
+		return
+	}
+	m.cancel()
+}
+
+// WrapPlatform returns a platform whose writes are recorded and retried if they fail.
+// platformName is the tracking platform p writes to, e.g. "anilist".
+func (m *Manager) WrapPlatform(p platform.Platform, profileID uint, platformName string) platform.Platform {
+	key := platformKey{profileID: profileID, platform: platformName}
+
+	m.mu.Lock()
+	m.platforms[key] = p
+	started := m.started
+	m.mu.Unlock()
+
+	// Resume the mutations of profiles whose platform is created after the start
+	if started {
+		go m.resumePending(key)
+	}
+
+	return &queuedPlatform{
+		Platform: p,
+		manager:  m,
+		key:      key,
+	}
+}
+
+// ResumePendingMutations schedules the retries of the pending mutations.
+// Mutations of platforms that are not in use yet are resumed when the platform is wrapped.
+func (m *Manager) ResumePendingMutations() {
+	m.mu.Lock()
+	m.started = true
+	m.mu.Unlock()
+
+	mutations, err := m.database.GetPendingPlatformMutations()
+	if err != nil {
+		m.logger.Error().Err(err).Msg("mutation queue: Failed to get pending mutations")
+		return
+	}
+
+	for _, mutation := range mutations {
+		m.resume(mutation)
+	}
+
+	if len(mutations) > 0 {
+		m.logger.Debug().Int("count", len(mutations)).Msg("mutation queue: Resumed pending mutations")
+	}
+}
+
+func (m *Manager) resumePending(key platformKey) {
+	mutations, err := m.database.GetPendingPlatformMutations()
+	if err != nil {
+		return
+	}
+	for _, mutation := range mutations {
+		if mutation.ProfileID == key.profileID && mutation.Platform == key.platform {
+			m.resume(mutation)
+		}
+	}
+}
+
+// resume schedules the retry of a pending mutation.
+// Mutations that were interrupted while being sent are retried right away.
+func (m *Manager) resume(mutation *models.PlatformMutation) {
+	if mutation.NextAttemptAt == nil {
+		now := time.Now()
+		mutation.NextAttemptAt = &now
+		if err := m.database.SavePlatformMutation(mutation); err != nil {
+			return
+		}
+	}
+	m.schedule(mutation.ID)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMutations returns the queued mutations of the profile.
+func (m *Manager) GetMutations(profileID uint) ([]*models.PlatformMutation, error) {
+	return m.database.GetPlatformMutations(profileID)
+}
+
+// Retry sends the mutation right away, resetting its attempts.
+func (m *Manager) Retry(profileID uint, id uint) error {
+	m.mu.Lock()
+	mutation, err := m.database.GetPlatformMutation(id)
+	if err != nil || mutation.ProfileID != profileID {
+		m.mu.Unlock()
+		return ErrMutationNotFound
+	}
+	mutation.Status = StatusPending
+	mutation.Attempts = 0
+	mutation.NextAttemptAt = nil
+	err = m.database.SavePlatformMutation(mutation)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return m.attempt(mutation, true)
+}
+
+// Discard deletes the mutation without sending it.
+func (m *Manager) Discard(profileID uint, id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mutation, err := m.database.GetPlatformMutation(id)
+	if err != nil || mutation.ProfileID != profileID {
+		return ErrMutationNotFound
+	}
+	return m.database.DeletePlatformMutation(id)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// write records the write to the media, collapsing it with the queued mutation of the media if there is one,
+// and sends the resulting mutation to the platform.
+func (m *Manager) write(key platformKey, p platform.Platform, mediaID int, merge func(*Mutation)) error {
+	m.mu.Lock()
+	mutation, err := m.database.GetPlatformMutationByMediaID(key.profileID, key.platform, mediaID)
+	if err != nil {
+		m.mu.Unlock()
+		m.logger.Error().Err(err).Int("mediaId", mediaID).Msg("mutation queue: Failed to get mutation, sending write without queueing")
+		mut := &Mutation{}
+		merge(mut)
+		return mut.apply(p, mediaID)
+	}
+
+	mut := &Mutation{}
+	if mutation == nil {
+		mutation = &models.PlatformMutation{
+			ProfileID: key.profileID,
+			Platform:  key.platform,
+			MediaID:   mediaID,
+		}
+	} else {
+		_ = json.Unmarshal([]byte(mutation.Payload), mut)
+	}
+	merge(mut)
+
+	payload, _ := json.Marshal(mut)
+	mutation.Payload = string(payload)
+	mutation.Status = StatusPending
+	mutation.Attempts = 0
+	mutation.NextAttemptAt = nil
+	mutation.Revision++
+	err = m.database.SavePlatformMutation(mutation)
+	m.mu.Unlock()
+	if err != nil {
+		m.logger.Error().Err(err).Int("mediaId", mediaID).Msg("mutation queue: Failed to save mutation, sending write without queueing")
+		return mut.apply(p, mediaID)
+	}
+
+	return m.attempt(mutation, false)
+}
+
+// attempt sends the mutation to its platform.
+// The mutation is deleted if it succeeds, unless another write was collapsed into it in the meantime.
+// It is also deleted if the error is permanent, e.g. the media doesn't exist on the platform.
+// Otherwise, the next attempt is scheduled.
+// retried is true if the mutation isn't sent by the write that created it.
+func (m *Manager) attempt(mutation *models.PlatformMutation, retried bool) error {
+	m.mu.Lock()
+	p, found := m.platforms[platformKey{profileID: mutation.ProfileID, platform: mutation.Platform}]
+	m.mu.Unlock()
+	if !found {
+		return ErrPlatformUnavailable
+	}
+
+	mut := &Mutation{}
+	if err := json.Unmarshal([]byte(mutation.Payload), mut); err != nil {
+		return err
+	}
+
+	err := mut.apply(p, mutation.MediaID)
+	if err == nil {
+		if err := m.database.DeletePlatformMutationRevision(mutation.ID, mutation.Revision); err != nil {
+			m.logger.Error().Err(err).Uint("mutation", mutation.ID).Msg("mutation queue: Failed to delete mutation")
+		}
+		m.mu.Lock()
+		onRetrySucceeded := m.onRetrySucceeded
+		m.mu.Unlock()
+		if retried && !mut.Delete && onRetrySucceeded != nil {
+			onRetrySucceeded(mutation.ProfileID, mutation.Platform, mutation.MediaID)
+		}
+		return nil
+	}
+
+	m.mu.Lock()
+	// Reload the mutation, it might have been collapsed or discarded
+	current, getErr := m.database.GetPlatformMutation(mutation.ID)
+	if getErr != nil || current.Revision != mutation.Revision {
+		m.mu.Unlock()
+		return err
+	}
+
+	// Retrying won't help, e.g. the user is logged out or the media can't be mapped
+	if !isTransientError(err) {
+		if delErr := m.database.DeletePlatformMutation(current.ID); delErr != nil {
+			m.logger.Error().Err(delErr).Uint("mutation", current.ID).Msg("mutation queue: Failed to delete mutation")
+		}
+		m.mu.Unlock()
+		m.logger.Warn().Err(err).Int("mediaId", current.MediaID).Str("platform", current.Platform).Msg("mutation queue: Dropping mutation")
+		// The error is returned to the client when the write is sent right away
+		if retried {
+			m.notify(fmt.Sprintf("The update of the entry %d could not be sent to %s: %s", current.MediaID, current.Platform, err.Error()))
+		}
+		return err
+	}
+	current.Attempts++
+	current.Error = err.Error()
+	current.NextAttemptAt = nil
+	if current.Attempts >= MaxAttempts {
+		current.Status = StatusFailed
+	} else {
+		next := time.Now().Add(retryBackoff(current.Attempts))
+		current.NextAttemptAt = &next
+	}
+	if saveErr := m.database.SavePlatformMutation(current); saveErr != nil {
+		m.logger.Error().Err(saveErr).Uint("mutation", current.ID).Msg("mutation queue: Failed to save mutation")
+	}
+	m.mu.Unlock()
+
+	if current.Status == StatusFailed {
+		m.logger.Warn().Err(err).Int("mediaId", current.MediaID).Str("platform", current.Platform).Msg("mutation queue: Giving up on mutation")
+	} else {
+		m.logger.Debug().Err(err).Int("mediaId", current.MediaID).Str("platform", current.Platform).Int("attempts", current.Attempts).Msg("mutation queue: Mutation failed, will retry")
+		m.schedule(current.ID)
+	}
+
+	return err
+}
+
+// schedule starts retrying the mutation in the background if it isn't already.
+func (m *Manager) schedule(id uint) {
+	m.mu.Lock()
+	if _, found := m.retrying[id]; found {
+		m.mu.Unlock()
+		return
+	}
+	m.retrying[id] = struct{}{}
+	m.mu.Unlock()
+
+	go m.retry(id)
+}
+
+// retry sends the mutation until it succeeds, fails permanently or is removed.
+func (m *Manager) retry(id uint) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.retrying, id)
+		m.mu.Unlock()
+	}()
+
+	for {
+		mutation, err := m.database.GetPlatformMutation(id)
+		if err != nil || mutation.Status != StatusPending {
+			return
+		}
+
+		// The mutation is being sent by the write that created it
+		if mutation.NextAttemptAt == nil {
+			return
+		}
+
+		if wait := time.Until(*mutation.NextAttemptAt); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-m.ctx.Done():
+				return // Left pending
+			}
+			continue // Reload the mutation
+		}
+
+		err = m.attempt(mutation, true)
+		if err == nil || errors.Is(err, ErrPlatformUnavailable) || !isTransientError(err) {
+			return
+		}
+	}
+}
+
+// retryBackoff returns the delay before the next attempt.
+func retryBackoff(attempts int) time.Duration {
+	ret := initialRetryBackoff
+	for i := 1; i < attempts; i++ {
+		ret *= 2
+		if ret >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return ret
+}
+
+// isTransientError returns true if the write might succeed later,
+// i.e. the platform couldn't be reached, is rate limiting or had a server error.
+// Errors that can't be classified are treated as transient.
+func isTransientError(err error) bool {
+	if errors.Is(err, mal_platform.ErrNoMalID) ||
+		errors.Is(err, mal_platform.ErrNotLoggedIn) ||
+		errors.Is(err, kitsu_platform.ErrNotLoggedIn) {
+		return false
+	}
+
+	code := anilist.StatusCode(err)
+	switch {
+	case code == http.StatusTooManyRequests || code >= http.StatusInternalServerError:
+		return true
+	case code >= http.StatusBadRequest:
+		return false
+	}
+
+	return true
+}
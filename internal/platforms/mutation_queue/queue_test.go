@@ -0,0 +1,257 @@
+package mutation_queue
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"seanime/internal/api/anilist"
+	"seanime/internal/platforms/kitsu_platform"
+	"seanime/internal/platforms/mal_platform"
+	"seanime/internal/platforms/platform"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePlatform records the writes and fails with err while it is set.
+type fakePlatform struct {
+	platform.Platform
+	mu       sync.Mutex
+	err      error
+	progress map[int]int
+	statuses map[int]anilist.MediaListStatus
+	deleted  map[int]bool
+}
+
+func newFakePlatform() *fakePlatform {
+	return &fakePlatform{
+		progress: make(map[int]int),
+		statuses: make(map[int]anilist.MediaListStatus),
+		deleted:  make(map[int]bool),
+	}
+}
+
+func (f *fakePlatform) setFailing(failing bool) {
+	if failing {
+		f.failWith(errors.New("rate limited"))
+	} else {
+		f.failWith(nil)
+	}
+}
+
+func (f *fakePlatform) failWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakePlatform) UpdateEntry(mediaID int, status *anilist.MediaListStatus, _ *int, progress *int, _ *anilist.FuzzyDateInput, _ *anilist.FuzzyDateInput, _ []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	if status != nil {
+		f.statuses[mediaID] = *status
+	}
+	if progress != nil {
+		f.progress[mediaID] = *progress
+	}
+	return nil
+}
+
+func (f *fakePlatform) UpdateEntryProgress(mediaID int, progress int, _ *int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.progress[mediaID] = progress
+	return nil
+}
+
+func (f *fakePlatform) DeleteEntry(mediaID int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted[mediaID] = true
+	return nil
+}
+
+func newTestManager(t *testing.T) *Manager {
+	m := NewManager(&NewManagerOptions{
+		Logger:   util.NewLogger(),
+		Database: testdb.New(t),
+	})
+	t.Cleanup(m.Shutdown)
+	return m
+}
+
+func TestQueue(t *testing.T) {
+	prev := initialRetryBackoff
+	initialRetryBackoff = 50 * time.Millisecond
+	defer func() { initialRetryBackoff = prev }()
+
+	m := newTestManager(t)
+	fake := newFakePlatform()
+	p := m.WrapPlatform(fake, 1, "anilist")
+
+	var retriedMu sync.Mutex
+	var retried []int
+	m.SetOnRetrySucceeded(func(_ uint, _ string, mediaID int) {
+		retriedMu.Lock()
+		defer retriedMu.Unlock()
+		retried = append(retried, mediaID)
+	})
+
+	// Successful writes are not kept
+	require.NoError(t, p.UpdateEntryProgress(1, 3, nil))
+	mutations, err := m.GetMutations(1)
+	require.NoError(t, err)
+	require.Empty(t, mutations)
+
+	// Failed writes to the same media are collapsed
+	fake.setFailing(true)
+	require.Error(t, p.UpdateEntryProgress(1, 4, nil))
+	require.Error(t, p.UpdateEntry(1, lo.ToPtr(anilist.MediaListStatusPaused), nil, nil, nil, nil, nil))
+	require.Error(t, p.UpdateEntryProgress(2, 1, nil))
+
+	mutations, err = m.GetMutations(1)
+	require.NoError(t, err)
+	require.Len(t, mutations, 2)
+	require.Equal(t, 1, mutations[0].MediaID)
+	require.Equal(t, 2, mutations[0].Revision)
+	require.Equal(t, StatusPending, mutations[0].Status)
+	require.Equal(t, 1, mutations[0].Attempts)
+	require.NotNil(t, mutations[0].NextAttemptAt)
+
+	// Discarded mutations are not sent
+	require.NoError(t, m.Discard(1, mutations[1].ID))
+	require.ErrorIs(t, m.Discard(2, mutations[0].ID), ErrMutationNotFound)
+
+	// The mutation is retried once the platform recovers
+	fake.setFailing(false)
+	require.Eventually(t, func() bool {
+		mutations, err := m.GetMutations(1)
+		return err == nil && len(mutations) == 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	fake.mu.Lock()
+	require.Equal(t, 4, fake.progress[1])
+	require.Equal(t, anilist.MediaListStatusPaused, fake.statuses[1])
+	_, found := fake.progress[2]
+	require.False(t, found)
+	fake.mu.Unlock()
+
+	retriedMu.Lock()
+	require.Equal(t, []int{1}, retried)
+	retriedMu.Unlock()
+}
+
+func TestPermanentError(t *testing.T) {
+	m := newTestManager(t)
+	var notifications []string
+	m.notify = func(message string) {
+		notifications = append(notifications, message)
+	}
+	fake := newFakePlatform()
+	p := m.WrapPlatform(fake, 1, "mal")
+
+	// Permanent errors are returned without queueing the write
+	fake.failWith(mal_platform.ErrNoMalID)
+	require.ErrorIs(t, p.UpdateEntryProgress(1, 3, nil), mal_platform.ErrNoMalID)
+	mutations, err := m.GetMutations(1)
+	require.NoError(t, err)
+	require.Empty(t, mutations)
+	require.Empty(t, notifications)
+
+	// Queued mutations are dropped with a notification
+	fake.setFailing(true)
+	require.Error(t, p.UpdateEntryProgress(1, 4, nil))
+	mutations, err = m.GetMutations(1)
+	require.NoError(t, err)
+	require.Len(t, mutations, 1)
+
+	fake.failWith(mal_platform.ErrNotLoggedIn)
+	require.ErrorIs(t, m.Retry(1, mutations[0].ID), mal_platform.ErrNotLoggedIn)
+	mutations, err = m.GetMutations(1)
+	require.NoError(t, err)
+	require.Empty(t, mutations)
+	require.Len(t, notifications, 1)
+}
+
+func TestIsTransientError(t *testing.T) {
+	anilistError := func(code int) error {
+		return &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: code}}
+	}
+
+	require.True(t, isTransientError(errors.New("connection reset")))
+	require.True(t, isTransientError(anilistError(http.StatusTooManyRequests)))
+	require.True(t, isTransientError(anilistError(http.StatusBadGateway)))
+	require.False(t, isTransientError(anilistError(http.StatusBadRequest)))
+	require.False(t, isTransientError(anilistError(http.StatusUnauthorized)))
+	require.False(t, isTransientError(fmt.Errorf("update: %w", mal_platform.ErrNoMalID)))
+	require.False(t, isTransientError(kitsu_platform.ErrNotLoggedIn))
+}
+
+func TestRetry(t *testing.T) {
+	m := newTestManager(t)
+	fake := newFakePlatform()
+	p := m.WrapPlatform(fake, 1, "anilist")
+
+	fake.setFailing(true)
+	require.Error(t, p.DeleteEntry(1))
+
+	mutations, err := m.GetMutations(1)
+	require.NoError(t, err)
+	require.Len(t, mutations, 1)
+
+	fake.setFailing(false)
+	require.NoError(t, m.Retry(1, mutations[0].ID))
+
+	mutations, err = m.GetMutations(1)
+	require.NoError(t, err)
+	require.Empty(t, mutations)
+	require.True(t, fake.deleted[1])
+}
+
+func TestMutationMerge(t *testing.T) {
+	mut := &Mutation{}
+	mut.updateEntry(lo.ToPtr(anilist.MediaListStatusCurrent), lo.ToPtr(80), lo.ToPtr(2), nil, nil, nil)
+	mut.updateEntryProgress(5, lo.ToPtr(12))
+	require.Nil(t, mut.Status)
+	require.Equal(t, 80, *mut.ScoreRaw)
+	require.Equal(t, 5, *mut.Progress)
+	require.True(t, mut.AutoProgress)
+
+	mut.updateEntry(nil, nil, lo.ToPtr(6), nil, nil, nil)
+	require.False(t, mut.AutoProgress)
+	require.Nil(t, mut.TotalEpisodes)
+
+	mut.deleteEntry()
+	require.Equal(t, &Mutation{Delete: true}, mut)
+
+	mut.updateEntry(lo.ToPtr(anilist.MediaListStatusPlanning), nil, nil, nil, nil, nil)
+	require.False(t, mut.Delete)
+
+	// Custom lists are kept until they are replaced, an empty slice is a change
+	mut.updateEntry(nil, nil, nil, nil, nil, []string{"Rewatch"})
+	mut.updateEntry(nil, nil, nil, nil, nil, nil)
+	require.Equal(t, []string{"Rewatch"}, mut.CustomLists)
+	mut.updateEntry(nil, nil, nil, nil, nil, []string{})
+	require.NotNil(t, mut.CustomLists)
+	require.Empty(t, mut.CustomLists)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	require.Equal(t, initialRetryBackoff, retryBackoff(1))
+	require.Equal(t, initialRetryBackoff*4, retryBackoff(3))
+	require.Equal(t, maxRetryBackoff, retryBackoff(MaxAttempts))
+}
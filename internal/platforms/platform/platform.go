@@ -7,7 +7,7 @@ import (
 type Platform interface {
 	SetUsername(username string)
 	SetAnilistClient(client anilist.AnilistClient)
-	UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput) error
+	UpdateEntry(mediaID int, status *anilist.MediaListStatus, scoreRaw *int, progress *int, startedAt *anilist.FuzzyDateInput, completedAt *anilist.FuzzyDateInput, customLists []string) error
 	UpdateEntryProgress(mediaID int, progress int, totalEpisodes *int) error
 	DeleteEntry(mediaID int) error
 	GetAnime(mediaID int) (*anilist.BaseAnime, error)
@@ -0,0 +1,369 @@
+package profile
+
+import (
+	"cmp"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/bcrypt"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Number of wrong PINs before an IP address is locked out of a profile
+	maxFailedPinAttempts = 5
+	pinLockoutDuration   = 5 * time.Minute
+)
+
+var (
+	ErrProfileNotFound     = errors.New("profile not found")
+	ErrInvalidPin          = errors.New("invalid PIN")
+	ErrPinRequired         = errors.New("a PIN is required to access this profile")
+	ErrCannotDeleteDefault = errors.New("the default profile cannot be deleted")
+	ErrProfileNameRequired = errors.New("profile name is required")
+	ErrInvalidProfileToken = errors.New("invalid profile token")
+	ErrTooManyAttempts     = errors.New("too many wrong PINs, try again later")
+)
+
+type (
+	// Manager handles the profiles of the server.
+	// The library and local files are shared, while the AniList/MAL accounts, watch history, playlists
+	// and Discord settings belong to a profile.
+	//
+	// A client selects a profile by sending a profile token with each request.
+	// The token is the profile ID for profiles without a PIN, and "<id>.<session key>" for profiles with a PIN.
+	Manager struct {
+		logger   *zerolog.Logger
+		db       *db.Database
+		profiles map[uint]*models.Profile
+		// Wrong PINs, keyed by profile ID and IP address
+		failedAttempts map[string]*failedAttempt
+		mu             sync.RWMutex
+	}
+
+	failedAttempt struct {
+		count       int
+		lockedUntil time.Time
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+	}
+)
+
+// NewManager creates a new Manager and creates the default profile if it does not exist.
+func NewManager(opts *NewManagerOptions) (*Manager, error) {
+	ret := &Manager{
+		logger:         opts.Logger,
+		db:             opts.Database,
+		profiles:       make(map[uint]*models.Profile),
+		failedAttempts: make(map[string]*failedAttempt),
+	}
+
+	if err := ret.load(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (m *Manager) load() error {
+	profiles, err := m.db.GetProfiles()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range profiles {
+		m.profiles[p.ID] = p
+	}
+
+	if _, found := m.profiles[models.DefaultProfileID]; !found {
+		defaultProfile, err := m.db.UpsertProfile(&models.Profile{
+			BaseModel: models.BaseModel{ID: models.DefaultProfileID},
+			Name:      "Default",
+			Discord:   &models.DiscordSettings{},
+		})
+		if err != nil {
+			return err
+		}
+		m.profiles[defaultProfile.ID] = defaultProfile
+		m.logger.Debug().Msg("profile: Created default profile")
+	}
+
+	return nil
+}
+
+// GetProfiles returns all the profiles ordered by ID.
+func (m *Manager) GetProfiles() []*models.Profile {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make([]*models.Profile, 0, len(m.profiles))
+	for _, p := range m.profiles {
+		ret = append(ret, p)
+	}
+	slices.SortFunc(ret, func(a, b *models.Profile) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return ret
+}
+
+// GetProfile returns the profile with the given ID.
+func (m *Manager) GetProfile(id uint) (*models.Profile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	p, found := m.profiles[id]
+	if !found {
+		return nil, ErrProfileNotFound
+	}
+	return p, nil
+}
+
+// CreateProfile creates a new profile with an optional PIN.
+func (m *Manager) CreateProfile(name string, pin string) (*models.Profile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrProfileNameRequired
+	}
+
+	p := &models.Profile{
+		Name:    name,
+		Discord: &models.DiscordSettings{},
+	}
+	if err := setPin(p, pin); err != nil {
+		return nil, err
+	}
+
+	p, err := m.db.UpsertProfile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.profiles[p.ID] = p
+	m.mu.Unlock()
+
+	m.logger.Info().Str("name", p.Name).Msg("profile: Created profile")
+
+	return p, nil
+}
+
+// UpdateProfile updates the name and Discord settings of a profile.
+// Nil values are left unchanged.
+func (m *Manager) UpdateProfile(id uint, name *string, discord *models.DiscordSettings) (*models.Profile, error) {
+	p, err := m.GetProfile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *p
+	if name != nil {
+		updated.Name = strings.TrimSpace(*name)
+		if updated.Name == "" {
+			return nil, ErrProfileNameRequired
+		}
+	}
+	if discord != nil {
+		updated.Discord = discord
+	}
+
+	return m.save(&updated)
+}
+
+// SetPin sets or removes (empty pin) the PIN of a profile.
+// Existing profile tokens are invalidated.
+func (m *Manager) SetPin(id uint, currentPin string, pin string, ip string) (*models.Profile, error) {
+	p, err := m.GetProfile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.verifyPin(p, currentPin, ip); err != nil {
+		return nil, err
+	}
+
+	updated := *p
+	if err := setPin(&updated, pin); err != nil {
+		return nil, err
+	}
+
+	return m.save(&updated)
+}
+
+// DeleteProfile deletes a profile and its data.
+func (m *Manager) DeleteProfile(id uint) error {
+	if id == models.DefaultProfileID {
+		return ErrCannotDeleteDefault
+	}
+
+	if _, err := m.GetProfile(id); err != nil {
+		return err
+	}
+
+	if err := m.db.DeleteProfile(id); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	delete(m.profiles, id)
+	m.mu.Unlock()
+
+	m.logger.Info().Uint("id", id).Msg("profile: Deleted profile")
+
+	return nil
+}
+
+// Select checks the PIN of the profile and returns the token that the client should send with each request.
+// The IP address is locked out of the profile after too many wrong PINs.
+func (m *Manager) Select(id uint, pin string, ip string) (string, error) {
+	p, err := m.GetProfile(id)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.verifyPin(p, pin, ip); err != nil {
+		return "", err
+	}
+
+	return GetToken(p), nil
+}
+
+// Resolve returns the profile associated with the token.
+// An empty token resolves to the default profile.
+func (m *Manager) Resolve(token string) (*models.Profile, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		token = strconv.Itoa(int(models.DefaultProfileID))
+	}
+
+	idStr, key, _ := strings.Cut(token, ".")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidProfileToken
+	}
+
+	p, err := m.GetProfile(uint(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if p.PinHash == "" {
+		return p, nil
+	}
+
+	if key == "" || subtle.ConstantTimeCompare([]byte(key), []byte(p.SessionKey)) != 1 {
+		return nil, ErrPinRequired
+	}
+
+	return p, nil
+}
+
+// GetToken returns the token of a profile.
+func GetToken(p *models.Profile) string {
+	if p.PinHash == "" {
+		return strconv.Itoa(int(p.ID))
+	}
+	return fmt.Sprintf("%d.%s", p.ID, p.SessionKey)
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (m *Manager) save(p *models.Profile) (*models.Profile, error) {
+	p, err := m.db.UpsertProfile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.profiles[p.ID] = p
+	m.mu.Unlock()
+
+	return p, nil
+}
+
+// verifyPin checks the PIN of the profile, unless the IP address is locked out.
+func (m *Manager) verifyPin(p *models.Profile, pin string, ip string) error {
+	if p.PinHash == "" {
+		return nil
+	}
+
+	key := fmt.Sprintf("%d-%s", p.ID, ip)
+
+	m.mu.RLock()
+	attempt, found := m.failedAttempts[key]
+	lockedOut := found && time.Now().Before(attempt.lockedUntil)
+	m.mu.RUnlock()
+	if lockedOut {
+		return ErrTooManyAttempts
+	}
+
+	// bcrypt is slow, don't hold the lock
+	ok := checkPin(p, pin)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ok {
+		delete(m.failedAttempts, key)
+		return nil
+	}
+
+	attempt, found = m.failedAttempts[key]
+	if !found || !attempt.lockedUntil.IsZero() {
+		attempt = &failedAttempt{}
+		m.failedAttempts[key] = attempt
+	}
+	attempt.count++
+	if attempt.count >= maxFailedPinAttempts {
+		attempt.lockedUntil = time.Now().Add(pinLockoutDuration)
+		m.logger.Warn().Uint("id", p.ID).Str("ip", ip).Msg("profile: Too many wrong PINs, locking out")
+	}
+
+	return ErrInvalidPin
+}
+
+func setPin(p *models.Profile, pin string) error {
+	pin = strings.TrimSpace(pin)
+	if pin == "" {
+		p.PinHash = ""
+		p.SessionKey = ""
+		p.HasPin = false
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	p.PinHash = string(hash)
+	p.SessionKey = hex.EncodeToString(key)
+	p.HasPin = true
+	return nil
+}
+
+func checkPin(p *models.Profile, pin string) bool {
+	if p.PinHash == "" {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(p.PinHash), []byte(pin)) == nil
+}
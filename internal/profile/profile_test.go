@@ -0,0 +1,105 @@
+package profile
+
+import (
+	"github.com/stretchr/testify/require"
+	"seanime/internal/database/models"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"strconv"
+	"testing"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	m, err := NewManager(&NewManagerOptions{
+		Logger:   util.NewLogger(),
+		Database: testdb.New(t),
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func TestManager(t *testing.T) {
+	m := newTestManager(t)
+
+	// The default profile is created on first run
+	profiles := m.GetProfiles()
+	require.Len(t, profiles, 1)
+	require.Equal(t, models.DefaultProfileID, profiles[0].ID)
+
+	// An empty token resolves to the default profile
+	p, err := m.Resolve("")
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultProfileID, p.ID)
+
+	// Profile without PIN
+	alice, err := m.CreateProfile("Alice", "")
+	require.NoError(t, err)
+	require.False(t, alice.HasPin)
+
+	p, err = m.Resolve(GetToken(alice))
+	require.NoError(t, err)
+	require.Equal(t, alice.ID, p.ID)
+
+	// Profile with PIN
+	bob, err := m.CreateProfile("Bob", "1234")
+	require.NoError(t, err)
+	require.True(t, bob.HasPin)
+
+	_, err = m.Resolve(strconv.Itoa(int(bob.ID)))
+	require.ErrorIs(t, err, ErrPinRequired)
+
+	_, err = m.Select(bob.ID, "0000", "127.0.0.1")
+	require.ErrorIs(t, err, ErrInvalidPin)
+
+	token, err := m.Select(bob.ID, "1234", "127.0.0.1")
+	require.NoError(t, err)
+
+	p, err = m.Resolve(token)
+	require.NoError(t, err)
+	require.Equal(t, bob.ID, p.ID)
+
+	// Changing the PIN invalidates the previous token
+	_, err = m.SetPin(bob.ID, "1234", "5678", "127.0.0.1")
+	require.NoError(t, err)
+	_, err = m.Resolve(token)
+	require.ErrorIs(t, err, ErrPinRequired)
+
+	// Updating the Discord settings only keeps the name
+	p, err = m.UpdateProfile(bob.ID, nil, &models.DiscordSettings{EnableRichPresence: true})
+	require.NoError(t, err)
+	require.Equal(t, "Bob", p.Name)
+	require.True(t, p.Discord.EnableRichPresence)
+
+	blank := " "
+	_, err = m.UpdateProfile(bob.ID, &blank, nil)
+	require.ErrorIs(t, err, ErrProfileNameRequired)
+
+	// Deleting profiles
+	require.ErrorIs(t, m.DeleteProfile(models.DefaultProfileID), ErrCannotDeleteDefault)
+	require.NoError(t, m.DeleteProfile(alice.ID))
+	_, err = m.Resolve(GetToken(alice))
+	require.ErrorIs(t, err, ErrProfileNotFound)
+	require.Len(t, m.GetProfiles(), 2)
+}
+
+func TestManager_PinLockout(t *testing.T) {
+	m := newTestManager(t)
+
+	bob, err := m.CreateProfile("Bob", "1234")
+	require.NoError(t, err)
+
+	for i := 0; i < maxFailedPinAttempts; i++ {
+		_, err = m.Select(bob.ID, "0000", "10.0.0.1")
+		require.ErrorIs(t, err, ErrInvalidPin)
+	}
+
+	// The right PIN is rejected while locked out
+	_, err = m.Select(bob.ID, "1234", "10.0.0.1")
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+	_, err = m.SetPin(bob.ID, "1234", "", "10.0.0.1")
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+
+	// Other IP addresses are not affected
+	_, err = m.Select(bob.ID, "1234", "10.0.0.2")
+	require.NoError(t, err)
+}
@@ -0,0 +1,233 @@
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"seanime/internal/api/anilist"
+	"sort"
+	"strings"
+)
+
+// ratingSaturation is the AniList recommendation rating from which a recommendation counts fully
+const ratingSaturation = 50
+
+type (
+	// profile holds the preferences of the user, computed from the seeds.
+	profile struct {
+		// Preferences are between -1 and 1
+		genres  map[string]float64
+		tags    map[string]float64
+		studios map[int]float64
+		// Entry the user liked the most for each studio
+		studioSeeds map[int]*seed
+		// Recommendation edges and relations of the seeds the user liked, keyed by target media ID
+		recommendations map[int][]*recommendationEdge
+		relations       map[int][]*relationEdge
+	}
+
+	recommendationEdge struct {
+		seed   *seed
+		rating int
+	}
+
+	relationEdge struct {
+		seed         *seed
+		relationType anilist.MediaRelation
+	}
+
+	// scoredReason is a reason and its contribution to the score.
+	scoredReason struct {
+		*Reason
+		contribution float64
+	}
+)
+
+func newProfile(seeds []*seed) *profile {
+	p := &profile{
+		genres:          make(map[string]float64),
+		tags:            make(map[string]float64),
+		studios:         make(map[int]float64),
+		studioSeeds:     make(map[int]*seed),
+		recommendations: make(map[int][]*recommendationEdge),
+		relations:       make(map[int][]*relationEdge),
+	}
+
+	for _, s := range seeds {
+		for _, genre := range s.features.Genres {
+			p.genres[genre] += s.weight
+		}
+		for _, tag := range s.features.Tags {
+			p.tags[tag.Name] += s.weight * float64(tag.Rank) / 100
+		}
+		for _, studio := range s.features.Studios {
+			p.studios[studio.ID] += s.weight
+			if s.weight > 0 && (p.studioSeeds[studio.ID] == nil || s.weight > p.studioSeeds[studio.ID].weight) {
+				p.studioSeeds[studio.ID] = s
+			}
+		}
+		if s.weight <= 0 {
+			continue
+		}
+		for _, rec := range s.features.Recommendations {
+			p.recommendations[rec.ID] = append(p.recommendations[rec.ID], &recommendationEdge{seed: s, rating: rec.Rating})
+		}
+		for _, rel := range s.features.Relations {
+			if isFollowUp(rel.RelationType) {
+				p.relations[rel.ID] = append(p.relations[rel.ID], &relationEdge{seed: s, relationType: rel.RelationType})
+			}
+		}
+	}
+
+	normalize(p.genres)
+	normalize(p.tags)
+	normalize(p.studios)
+
+	return p
+}
+
+// normalize scales the values to [-1, 1].
+func normalize[K comparable](m map[K]float64) {
+	maxAbs := 0.0
+	for _, v := range m {
+		maxAbs = math.Max(maxAbs, math.Abs(v))
+	}
+	if maxAbs == 0 {
+		return
+	}
+	for k, v := range m {
+		m[k] = v / maxAbs
+	}
+}
+
+// score rates the candidate against the profile.
+func (p *profile) score(candidate *anilist.MediaFeatures) *Recommendation {
+	reasons := make([]*scoredReason, 0)
+	total := 0.0
+
+	// Genres
+	if len(candidate.Genres) > 0 {
+		sum := 0.0
+		liked := make([]string, 0)
+		for _, genre := range candidate.Genres {
+			sum += p.genres[genre]
+			if p.genres[genre] > 0 {
+				liked = append(liked, genre)
+			}
+		}
+		contribution := genreWeight * sum / float64(len(candidate.Genres))
+		total += contribution
+		sort.SliceStable(liked, func(i, j int) bool { return p.genres[liked[i]] > p.genres[liked[j]] })
+		if len(liked) > 0 {
+			reasons = append(reasons, &scoredReason{
+				Reason:       &Reason{Type: ReasonGenres, Text: "Matches genres you like: " + strings.Join(liked[:min(3, len(liked))], ", ")},
+				contribution: contribution,
+			})
+		}
+	}
+
+	// Tags, weighted by their rank
+	rankSum := 0.0
+	tagSum := 0.0
+	likedTags := make([]*anilist.MediaFeaturesTag, 0)
+	for _, tag := range candidate.Tags {
+		rankSum += float64(tag.Rank)
+		tagSum += p.tags[tag.Name] * float64(tag.Rank)
+		if p.tags[tag.Name] > 0 {
+			likedTags = append(likedTags, tag)
+		}
+	}
+	if rankSum > 0 {
+		contribution := tagWeight * tagSum / rankSum
+		total += contribution
+		sort.SliceStable(likedTags, func(i, j int) bool {
+			return p.tags[likedTags[i].Name]*float64(likedTags[i].Rank) > p.tags[likedTags[j].Name]*float64(likedTags[j].Rank)
+		})
+		if len(likedTags) > 0 {
+			names := make([]string, 0, 3)
+			for _, tag := range likedTags[:min(3, len(likedTags))] {
+				names = append(names, tag.Name)
+			}
+			reasons = append(reasons, &scoredReason{
+				Reason:       &Reason{Type: ReasonTags, Text: "Matches tags you like: " + strings.Join(names, ", ")},
+				contribution: contribution,
+			})
+		}
+	}
+
+	// Studios, the one the user likes the most
+	var bestStudio *anilist.MediaFeaturesStudio
+	for _, studio := range candidate.Studios {
+		if bestStudio == nil || p.studios[studio.ID] > p.studios[bestStudio.ID] {
+			bestStudio = studio
+		}
+	}
+	if bestStudio != nil {
+		contribution := studioWeight * p.studios[bestStudio.ID]
+		total += contribution
+		if s, found := p.studioSeeds[bestStudio.ID]; found && s.mediaId != candidate.ID {
+			reasons = append(reasons, &scoredReason{
+				Reason:       &Reason{Type: ReasonStudio, MediaId: s.mediaId, Text: fmt.Sprintf("By %s, who also made %s", bestStudio.Name, s.title)},
+				contribution: contribution,
+			})
+		}
+	}
+
+	// AniList recommendations of the entries the user liked
+	if edges := p.recommendations[candidate.ID]; len(edges) > 0 {
+		support := 0.0
+		var best *recommendationEdge
+		bestSupport := 0.0
+		for _, edge := range edges {
+			s := edge.seed.weight * math.Min(1, math.Log1p(float64(edge.rating))/math.Log1p(ratingSaturation))
+			support += s
+			if best == nil || s > bestSupport {
+				best, bestSupport = edge, s
+			}
+		}
+		contribution := recommendationWeight * math.Min(1, support)
+		total += contribution
+		text := "Because you completed " + best.seed.title
+		if score := formatScore(best.seed.score); score != "" {
+			text = fmt.Sprintf("Because you rated %s %s", best.seed.title, score)
+		}
+		reasons = append(reasons, &scoredReason{
+			Reason:       &Reason{Type: ReasonRecommendation, MediaId: best.seed.mediaId, Text: text},
+			contribution: contribution,
+		})
+	}
+
+	// Follow-ups of the entries the user liked
+	if edges := p.relations[candidate.ID]; len(edges) > 0 {
+		var best *relationEdge
+		for _, edge := range edges {
+			if best == nil || edge.seed.weight > best.seed.weight {
+				best = edge
+			}
+		}
+		contribution := relationWeight * best.seed.weight
+		total += contribution
+		text := fmt.Sprintf("%s of %s, which you completed", formatRelationType(best.relationType), best.seed.title)
+		if score := formatScore(best.seed.score); score != "" {
+			text = fmt.Sprintf("%s of %s, which you rated %s", formatRelationType(best.relationType), best.seed.title, score)
+		}
+		reasons = append(reasons, &scoredReason{
+			Reason:       &Reason{Type: ReasonRelation, MediaId: best.seed.mediaId, Text: text},
+			contribution: contribution,
+		})
+	}
+
+	sort.SliceStable(reasons, func(i, j int) bool { return reasons[i].contribution > reasons[j].contribution })
+	ret := &Recommendation{
+		Media:   candidate,
+		Score:   math.Round(math.Max(0, math.Min(1, total))*1000) / 10,
+		Reasons: make([]*Reason, 0, maxReasons),
+	}
+	for _, r := range reasons {
+		if r.contribution <= 0 || len(ret.Reasons) == maxReasons {
+			break
+		}
+		ret.Reasons = append(ret.Reasons, r.Reason)
+	}
+
+	return ret
+}
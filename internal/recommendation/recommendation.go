@@ -0,0 +1,359 @@
+package recommendation
+
+import (
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"math"
+	"seanime/internal/api/anilist"
+	"seanime/internal/util/result"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReasonRecommendation ReasonType = "recommendation"
+	ReasonRelation       ReasonType = "relation"
+	ReasonGenres         ReasonType = "genres"
+	ReasonTags           ReasonType = "tags"
+	ReasonStudio         ReasonType = "studio"
+)
+
+const (
+	cacheTTL = 6 * time.Hour
+	// maxSeeds is the number of collection entries the recommendations are based on
+	maxSeeds = 50
+	// maxCandidates is the number of candidates that are scored
+	maxCandidates = 150
+	// defaultLimit is the number of recommendations returned when no limit is given
+	defaultLimit = 50
+	// highScore is the score (out of 100) from which an entry that isn't completed is used
+	highScore = 70
+	// Weight of a completed entry without a score
+	unscoredWeight = 0.4
+	maxReasons     = 3
+)
+
+// Weights of the score components, they add up to 1
+const (
+	genreWeight          = 0.2
+	tagWeight            = 0.25
+	studioWeight         = 0.1
+	recommendationWeight = 0.3
+	relationWeight       = 0.15
+)
+
+type (
+	ReasonType string
+
+	// Engine ranks anime and manga against the completed and high-scored entries of the collection.
+	Engine struct {
+		logger *zerolog.Logger
+		// Ranked recommendations keyed by user and media type, before filtering
+		cache *result.Cache[string, []*Recommendation]
+		// fetchMediaFeatures is replaced in tests
+		fetchMediaFeatures func(ids []int, withRecommendations bool) ([]*anilist.MediaFeatures, error)
+	}
+
+	NewEngineOptions struct {
+		Logger *zerolog.Logger
+	}
+
+	GetOptions struct {
+		// CacheKey identifies the user, e.g. the profile ID
+		CacheKey string
+		// Refresh ignores the cached recommendations
+		Refresh   bool
+		MediaType anilist.MediaType
+		// AnimeCollection is used when MediaType is ANIME, MangaCollection otherwise
+		AnimeCollection *anilist.AnimeCollection
+		MangaCollection *anilist.MangaCollection
+		// Optional filters
+		Format       *anilist.MediaFormat
+		Season       *anilist.MediaSeason
+		SeasonYear   *int
+		IncludeAdult bool
+		Limit        int
+	}
+
+	Recommendation struct {
+		Media *anilist.MediaFeatures `json:"media"`
+		Score float64                `json:"score"` // 0-100
+		// Whether the media is in the planning list
+		Planned bool      `json:"planned"`
+		Reasons []*Reason `json:"reasons"`
+	}
+
+	Reason struct {
+		Type ReasonType `json:"type"`
+		// Collection entry behind the reason, if any
+		MediaId int    `json:"mediaId,omitempty"`
+		Text    string `json:"text"`
+	}
+
+	// collectionEntry is the part of an anime or manga list entry used by the engine.
+	collectionEntry struct {
+		mediaId int
+		title   string
+		status  anilist.MediaListStatus
+		score   float64 // 0-100, 0 if not scored
+	}
+
+	// seed is a collection entry the recommendations are based on.
+	seed struct {
+		*collectionEntry
+		// Between -1 and 1, negative if the user didn't like it
+		weight   float64
+		features *anilist.MediaFeatures
+	}
+)
+
+func NewEngine(opts *NewEngineOptions) *Engine {
+	ret := &Engine{
+		logger: opts.Logger,
+		cache:  result.NewCache[string, []*Recommendation](),
+	}
+	ret.fetchMediaFeatures = func(ids []int, withRecommendations bool) ([]*anilist.MediaFeatures, error) {
+		return anilist.FetchMediaFeatures(ids, withRecommendations, ret.logger)
+	}
+	return ret
+}
+
+// Get returns the recommendations for the collection, best first.
+// The ranking is cached per user and media type, the filters are applied to the cached ranking.
+func (e *Engine) Get(opts *GetOptions) ([]*Recommendation, error) {
+	if opts.MediaType == "" {
+		opts.MediaType = anilist.MediaTypeAnime
+	}
+
+	key := fmt.Sprintf("%s-%s", opts.CacheKey, opts.MediaType)
+	ranked, found := e.cache.Get(key)
+	if !found || opts.Refresh {
+		var err error
+		ranked, err = e.rank(opts)
+		if err != nil {
+			return nil, err
+		}
+		e.cache.SetT(key, ranked, cacheTTL)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	ret := make([]*Recommendation, 0, limit)
+	for _, r := range ranked {
+		if len(ret) == limit {
+			break
+		}
+		if opts.Format != nil && lo.FromPtr(r.Media.Format) != *opts.Format {
+			continue
+		}
+		if opts.Season != nil && lo.FromPtr(r.Media.Season) != *opts.Season {
+			continue
+		}
+		if opts.SeasonYear != nil && lo.FromPtr(r.Media.SeasonYear) != *opts.SeasonYear {
+			continue
+		}
+		if r.Media.IsAdult && !opts.IncludeAdult {
+			continue
+		}
+		ret = append(ret, r)
+	}
+
+	return ret, nil
+}
+
+func (e *Engine) rank(opts *GetOptions) ([]*Recommendation, error) {
+	entries := getCollectionEntries(opts)
+
+	seeds := getSeeds(entries)
+	if len(seeds) == 0 {
+		return make([]*Recommendation, 0), nil
+	}
+
+	seedFeatures, err := e.fetchMediaFeatures(lo.Map(seeds, func(s *seed, _ int) int { return s.mediaId }), true)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range seedFeatures {
+		if s, found := lo.Find(seeds, func(s *seed) bool { return s.mediaId == f.ID }); found {
+			s.features = f
+		}
+	}
+	seeds = lo.Filter(seeds, func(s *seed, _ int) bool { return s.features != nil })
+
+	candidateIds := getCandidateIds(opts.MediaType, entries, seeds)
+	if len(candidateIds) == 0 {
+		return make([]*Recommendation, 0), nil
+	}
+
+	candidates, err := e.fetchMediaFeatures(candidateIds, false)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newProfile(seeds)
+
+	ret := make([]*Recommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Type != opts.MediaType {
+			continue
+		}
+		r := p.score(candidate)
+		if entry, found := entries[candidate.ID]; found && entry.status == anilist.MediaListStatusPlanning {
+			r.Planned = true
+		}
+		ret = append(ret, r)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].Media.ID < ret[j].Media.ID
+	})
+
+	return ret, nil
+}
+
+func getCollectionEntries(opts *GetOptions) map[int]*collectionEntry {
+	ret := make(map[int]*collectionEntry)
+	if opts.MediaType == anilist.MediaTypeManga {
+		for _, list := range opts.MangaCollection.GetMediaListCollection().GetLists() {
+			for _, entry := range list.GetEntries() {
+				if entry.GetMedia() == nil || entry.GetStatus() == nil {
+					continue
+				}
+				ret[entry.GetMedia().GetID()] = &collectionEntry{
+					mediaId: entry.GetMedia().GetID(),
+					title:   entry.GetMedia().GetPreferredTitle(),
+					status:  *entry.GetStatus(),
+					score:   lo.FromPtr(entry.GetScore()),
+				}
+			}
+		}
+		return ret
+	}
+	for _, list := range opts.AnimeCollection.GetMediaListCollection().GetLists() {
+		for _, entry := range list.GetEntries() {
+			if entry.GetMedia() == nil || entry.GetStatus() == nil {
+				continue
+			}
+			ret[entry.GetMedia().GetID()] = &collectionEntry{
+				mediaId: entry.GetMedia().GetID(),
+				title:   entry.GetMedia().GetPreferredTitle(),
+				status:  *entry.GetStatus(),
+				score:   lo.FromPtr(entry.GetScore()),
+			}
+		}
+	}
+	return ret
+}
+
+// getSeeds returns the completed and high-scored entries, the ones the user liked the most first.
+func getSeeds(entries map[int]*collectionEntry) []*seed {
+	ret := make([]*seed, 0)
+	for _, entry := range entries {
+		completed := entry.status == anilist.MediaListStatusCompleted || entry.status == anilist.MediaListStatusRepeating
+		if !completed && entry.score < highScore {
+			continue
+		}
+		weight := unscoredWeight
+		if entry.score > 0 {
+			// 50/100 is neutral
+			weight = (entry.score - 50) / 50
+		}
+		ret = append(ret, &seed{collectionEntry: entry, weight: weight})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].weight != ret[j].weight {
+			return ret[i].weight > ret[j].weight
+		}
+		return ret[i].mediaId < ret[j].mediaId
+	})
+
+	if len(ret) > maxSeeds {
+		ret = ret[:maxSeeds]
+	}
+	return ret
+}
+
+// getCandidateIds returns the media recommended by AniList for the seeds, the related media and the planned entries.
+// Media in the collection that aren't planned are left out.
+func getCandidateIds(mediaType anilist.MediaType, entries map[int]*collectionEntry, seeds []*seed) []int {
+	support := make(map[int]float64)
+	add := func(id int, t anilist.MediaType, weight float64) {
+		if t != mediaType {
+			return
+		}
+		if entry, found := entries[id]; found && entry.status != anilist.MediaListStatusPlanning {
+			return
+		}
+		support[id] += weight
+	}
+
+	for _, entry := range entries {
+		if entry.status == anilist.MediaListStatusPlanning {
+			add(entry.mediaId, mediaType, 0)
+		}
+	}
+	for _, s := range seeds {
+		if s.weight <= 0 {
+			continue
+		}
+		for _, rec := range s.features.Recommendations {
+			if rec.Rating > 0 {
+				add(rec.ID, rec.Type, s.weight)
+			}
+		}
+		for _, rel := range s.features.Relations {
+			if isFollowUp(rel.RelationType) {
+				add(rel.ID, rel.Type, s.weight)
+			}
+		}
+	}
+
+	ret := lo.Keys(support)
+	sort.Slice(ret, func(i, j int) bool {
+		if support[ret[i]] != support[ret[j]] {
+			return support[ret[i]] > support[ret[j]]
+		}
+		return ret[i] < ret[j]
+	})
+	if len(ret) > maxCandidates {
+		ret = ret[:maxCandidates]
+	}
+	return ret
+}
+
+// isFollowUp returns true for the relations that are worth watching or reading after the media.
+func isFollowUp(relationType anilist.MediaRelation) bool {
+	switch relationType {
+	case anilist.MediaRelationSequel, anilist.MediaRelationPrequel, anilist.MediaRelationSideStory,
+		anilist.MediaRelationSpinOff, anilist.MediaRelationParent, anilist.MediaRelationAlternative:
+		return true
+	}
+	return false
+}
+
+// formatScore returns e.g. "9/10", or an empty string if the entry isn't scored.
+func formatScore(score float64) string {
+	if score <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(math.Round(score)/10, 'f', -1, 64) + "/10"
+}
+
+// formatRelationType returns e.g. "Side story" for SIDE_STORY.
+func formatRelationType(relationType anilist.MediaRelation) string {
+	s := strings.ToLower(strings.ReplaceAll(string(relationType), "_", " "))
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,174 @@
+package recommendation
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/util"
+	"testing"
+)
+
+func getTestAnimeCollection() *anilist.AnimeCollection {
+	entry := func(id int, title string, status anilist.MediaListStatus, score float64) *anilist.AnimeListEntry {
+		return &anilist.AnimeListEntry{
+			Status: lo.ToPtr(status),
+			Score:  lo.ToPtr(score),
+			Media:  &anilist.BaseAnime{ID: id, Title: &anilist.BaseAnime_Title{UserPreferred: lo.ToPtr(title)}},
+		}
+	}
+	return &anilist.AnimeCollection{
+		MediaListCollection: &anilist.AnimeCollection_MediaListCollection{
+			Lists: []*anilist.AnimeCollection_MediaListCollection_Lists{
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusCompleted),
+					Entries: []*anilist.AnimeListEntry{
+						entry(1, "Mushishi", anilist.MediaListStatusCompleted, 90),
+						entry(2, "Bad Show", anilist.MediaListStatusCompleted, 20),
+					},
+				},
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusPlanning),
+					Entries: []*anilist.AnimeListEntry{
+						entry(10, "Planned", anilist.MediaListStatusPlanning, 0),
+					},
+				},
+				{
+					Status: lo.ToPtr(anilist.MediaListStatusCurrent),
+					Entries: []*anilist.AnimeListEntry{
+						entry(3, "Watching", anilist.MediaListStatusCurrent, 0),
+					},
+				},
+			},
+		},
+	}
+}
+
+func getTestFeatures() map[int]*anilist.MediaFeatures {
+	ret := map[int]*anilist.MediaFeatures{
+		1: {
+			Genres:  []string{"Mystery", "Slice of Life"},
+			Tags:    []*anilist.MediaFeaturesTag{{Name: "Iyashikei", Rank: 90}},
+			Studios: []*anilist.MediaFeaturesStudio{{ID: 100, Name: "Artland"}},
+			Relations: []*anilist.MediaFeaturesRelation{
+				{ID: 11, Type: anilist.MediaTypeAnime, RelationType: anilist.MediaRelationSequel},
+				{ID: 50, Type: anilist.MediaTypeManga, RelationType: anilist.MediaRelationSource},
+			},
+			Recommendations: []*anilist.MediaFeaturesRecommendation{
+				{ID: 12, Type: anilist.MediaTypeAnime, Rating: 200},
+				// Already watching
+				{ID: 3, Type: anilist.MediaTypeAnime, Rating: 100},
+			},
+		},
+		2: {
+			Genres: []string{"Action"},
+			Tags:   []*anilist.MediaFeaturesTag{{Name: "Isekai", Rank: 100}},
+			Recommendations: []*anilist.MediaFeaturesRecommendation{
+				// Not used, the user didn't like it
+				{ID: 13, Type: anilist.MediaTypeAnime, Rating: 500},
+			},
+		},
+		10: {
+			Format:  lo.ToPtr(anilist.MediaFormatMovie),
+			Genres:  []string{"Action"},
+			Tags:    []*anilist.MediaFeaturesTag{{Name: "Isekai", Rank: 80}},
+			Studios: []*anilist.MediaFeaturesStudio{{ID: 200, Name: "Other"}},
+		},
+		11: {
+			Format:  lo.ToPtr(anilist.MediaFormatTv),
+			Season:  lo.ToPtr(anilist.MediaSeasonFall),
+			Genres:  []string{"Mystery", "Slice of Life"},
+			Studios: []*anilist.MediaFeaturesStudio{{ID: 100, Name: "Artland"}},
+		},
+		12: {
+			Format: lo.ToPtr(anilist.MediaFormatTv),
+			Season: lo.ToPtr(anilist.MediaSeasonSpring),
+			Genres: []string{"Slice of Life"},
+			Tags:   []*anilist.MediaFeaturesTag{{Name: "Iyashikei", Rank: 80}},
+		},
+		13: {IsAdult: true},
+	}
+	for id, f := range ret {
+		f.ID = id
+		f.Type = anilist.MediaTypeAnime
+	}
+	return ret
+}
+
+func newTestEngine(t *testing.T) (*Engine, *int) {
+	features := getTestFeatures()
+	fetchCount := 0
+
+	e := NewEngine(&NewEngineOptions{Logger: util.NewLogger()})
+	e.fetchMediaFeatures = func(ids []int, withRecommendations bool) ([]*anilist.MediaFeatures, error) {
+		fetchCount++
+		ret := make([]*anilist.MediaFeatures, 0)
+		for _, id := range ids {
+			f, found := features[id]
+			require.True(t, found, "unexpected media %d", id)
+			ret = append(ret, f)
+		}
+		return ret, nil
+	}
+	return e, &fetchCount
+}
+
+func TestEngine_Get(t *testing.T) {
+	e, fetchCount := newTestEngine(t)
+
+	recs, err := e.Get(&GetOptions{
+		CacheKey:        "1",
+		MediaType:       anilist.MediaTypeAnime,
+		AnimeCollection: getTestAnimeCollection(),
+	})
+	require.NoError(t, err)
+
+	ids := lo.Map(recs, func(r *Recommendation, _ int) int { return r.Media.ID })
+	// The recommendation and the sequel of the liked show first, the planned show that looks like the disliked one last
+	require.Equal(t, []int{12, 11, 10}, ids)
+
+	reason, found := lo.Find(recs[0].Reasons, func(r *Reason) bool { return r.Type == ReasonRecommendation })
+	require.True(t, found)
+	require.Equal(t, 1, reason.MediaId)
+	require.Equal(t, "Because you rated Mushishi 9/10", reason.Text)
+
+	texts := lo.Map(recs[1].Reasons, func(r *Reason, _ int) string { return r.Text })
+	require.Contains(t, texts, "Sequel of Mushishi, which you rated 9/10")
+	require.Contains(t, texts, "By Artland, who also made Mushishi")
+
+	require.True(t, recs[2].Planned)
+	require.Empty(t, recs[2].Reasons)
+	require.Less(t, recs[2].Score, recs[1].Score)
+
+	// Filters are applied to the cached ranking
+	recs, err = e.Get(&GetOptions{
+		CacheKey:        "1",
+		MediaType:       anilist.MediaTypeAnime,
+		AnimeCollection: getTestAnimeCollection(),
+		Format:          lo.ToPtr(anilist.MediaFormatTv),
+		Season:          lo.ToPtr(anilist.MediaSeasonSpring),
+	})
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	require.Equal(t, 12, recs[0].Media.ID)
+	require.Equal(t, 2, *fetchCount)
+
+	// Other users have their own ranking
+	_, err = e.Get(&GetOptions{CacheKey: "2", MediaType: anilist.MediaTypeAnime, AnimeCollection: getTestAnimeCollection()})
+	require.NoError(t, err)
+	require.Equal(t, 4, *fetchCount)
+}
+
+func TestEngine_GetEmptyCollection(t *testing.T) {
+	e, fetchCount := newTestEngine(t)
+
+	recs, err := e.Get(&GetOptions{CacheKey: "1", MediaType: anilist.MediaTypeManga})
+	require.NoError(t, err)
+	require.Empty(t, recs)
+	require.Equal(t, 0, *fetchCount)
+}
+
+func TestFormatScore(t *testing.T) {
+	require.Equal(t, "9/10", formatScore(90))
+	require.Equal(t, "8.5/10", formatScore(85))
+	require.Equal(t, "", formatScore(0))
+}
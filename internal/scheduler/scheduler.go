@@ -0,0 +1,463 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/robfig/cron"
+	"github.com/rs/zerolog"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/notifier"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+var (
+	ErrJobNotFound       = errors.New("job not found")
+	ErrJobRunning        = errors.New("job is already running")
+	ErrJobRequiresOnline = errors.New("job cannot run in offline mode")
+	ErrInvalidSchedule   = errors.New("invalid schedule")
+)
+
+type (
+	// Scheduler runs the registered jobs on their schedule and records each run in the database.
+	Scheduler struct {
+		logger    *zerolog.Logger
+		database  *db.Database
+		isOffline bool
+		mu        sync.Mutex
+		jobs      []*scheduledJob
+		// Settings and last runs loaded from the database, applied when a job is registered
+		storedSettings map[string]*models.ScheduledJobSettings
+		storedRuns     map[string]*models.ScheduledJobRun
+		started        bool
+		wakeCh         chan struct{}
+		stopCh         chan struct{}
+		// notify is replaced in tests
+		notify func(message string)
+	}
+
+	// Job is a task that runs in the background.
+	Job struct {
+		Name        string
+		Description string
+		// DefaultSchedule is used until a schedule is set by the user.
+		// It can be a cron expression, a descriptor such as "@every 1h" or an interval such as "30m".
+		DefaultSchedule string
+		DefaultEnabled  bool
+		// RequiresOnline jobs do not run in offline mode
+		RequiresOnline bool
+		Run            func() error
+	}
+
+	// JobStatus is the current state of a job.
+	JobStatus struct {
+		Name            string                  `json:"name"`
+		Description     string                  `json:"description"`
+		Enabled         bool                    `json:"enabled"`
+		Schedule        string                  `json:"schedule"`
+		DefaultSchedule string                  `json:"defaultSchedule"`
+		RequiresOnline  bool                    `json:"requiresOnline"`
+		Running         bool                    `json:"running"`
+		LastRun         *models.ScheduledJobRun `json:"lastRun"`
+		NextRun         *time.Time              `json:"nextRun"`
+	}
+
+	scheduledJob struct {
+		*Job
+		enabled  bool
+		schedule string
+		parsed   cron.Schedule
+		next     time.Time
+		running  bool
+		lastRun  *models.ScheduledJobRun
+	}
+
+	NewSchedulerOptions struct {
+		Logger    *zerolog.Logger
+		Database  *db.Database
+		IsOffline bool
+	}
+)
+
+func NewScheduler(opts *NewSchedulerOptions) *Scheduler {
+	s := &Scheduler{
+		logger:         opts.Logger,
+		database:       opts.Database,
+		isOffline:      opts.IsOffline,
+		jobs:           make([]*scheduledJob, 0),
+		storedSettings: make(map[string]*models.ScheduledJobSettings),
+		storedRuns:     make(map[string]*models.ScheduledJobRun),
+		wakeCh:         make(chan struct{}, 1),
+		stopCh:         make(chan struct{}),
+		notify: func(message string) {
+			notifier.GlobalNotifier.Notify(notifier.ScheduledJob, message)
+		},
+	}
+
+	if settings, err := s.database.GetScheduledJobSettings(); err == nil {
+		for _, setting := range settings {
+			s.storedSettings[setting.Name] = setting
+		}
+	}
+	if runs, err := s.database.GetLastScheduledJobRuns(); err == nil {
+		for _, run := range runs {
+			s.storedRuns[run.Name] = run
+		}
+	}
+
+	return s
+}
+
+// ParseSchedule parses a cron expression (e.g. "0 */6 * * *"), a descriptor (e.g. "@daily", "@every 1h")
+// or an interval (e.g. "30m").
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	schedule = strings.TrimSpace(schedule)
+	if schedule == "" {
+		return nil, fmt.Errorf("%w: empty schedule", ErrInvalidSchedule)
+	}
+
+	if d, err := time.ParseDuration(schedule); err == nil {
+		if d < time.Minute {
+			return nil, fmt.Errorf("%w: interval must be at least 1 minute", ErrInvalidSchedule)
+		}
+		return cron.Every(d), nil
+	}
+
+	ret, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err.Error())
+	}
+	return ret, nil
+}
+
+// Register adds a job to the scheduler.
+// The schedule and enabled flag saved by the user take precedence over the job's defaults.
+func (s *Scheduler) Register(job *Job) error {
+	parsed, err := ParseSchedule(job.DefaultSchedule)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.getJob(job.Name); found {
+		return fmt.Errorf("scheduler: job %q is already registered", job.Name)
+	}
+
+	sj := &scheduledJob{
+		Job:      job,
+		enabled:  job.DefaultEnabled,
+		schedule: job.DefaultSchedule,
+		parsed:   parsed,
+		lastRun:  s.storedRuns[job.Name],
+	}
+
+	if setting, ok := s.storedSettings[job.Name]; ok {
+		sj.enabled = setting.Enabled
+		if setting.Schedule != "" {
+			if p, err := ParseSchedule(setting.Schedule); err == nil {
+				sj.schedule = setting.Schedule
+				sj.parsed = p
+			} else {
+				s.logger.Warn().Err(err).Str("job", job.Name).Msg("scheduler: Invalid saved schedule, using the default one")
+			}
+		}
+	}
+
+	s.jobs = append(s.jobs, sj)
+	s.wake()
+
+	return nil
+}
+
+// Start runs the scheduling loop in a goroutine.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	s.logger.Debug().Int("jobs", len(s.jobs)).Msg("scheduler: Started")
+
+	go s.loop()
+}
+
+// Stop stops the scheduling loop. Running jobs are not interrupted.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return
+	}
+	s.started = false
+	close(s.stopCh)
+	s.stopCh = make(chan struct{})
+}
+
+func (s *Scheduler) loop() {
+	s.mu.Lock()
+	stopCh := s.stopCh
+	s.mu.Unlock()
+
+	for {
+		now := time.Now()
+		due := make([]*scheduledJob, 0)
+		// Wake up at least every hour in case the clock changed
+		nextWake := now.Add(time.Hour)
+
+		s.mu.Lock()
+		for _, j := range s.jobs {
+			if !s.isScheduled(j) {
+				j.next = time.Time{}
+				continue
+			}
+			if j.next.IsZero() {
+				j.next = j.parsed.Next(now)
+			}
+			if !j.next.After(now) {
+				due = append(due, j)
+				j.next = j.parsed.Next(now)
+			}
+			if j.next.Before(nextWake) {
+				nextWake = j.next
+			}
+		}
+		s.mu.Unlock()
+
+		for _, j := range due {
+			go func(j *scheduledJob) {
+				_ = s.run(j, TriggerSchedule)
+			}(j)
+		}
+
+		timer := time.NewTimer(time.Until(nextWake))
+		select {
+		case <-timer.C:
+		case <-s.wakeCh:
+			timer.Stop()
+		case <-stopCh:
+			timer.Stop()
+			s.logger.Debug().Msg("scheduler: Stopped")
+			return
+		}
+	}
+}
+
+// wake tells the loop to recompute the next runs.
+func (s *Scheduler) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) isScheduled(j *scheduledJob) bool {
+	return j.enabled && (!j.RequiresOnline || !s.isOffline)
+}
+
+func (s *Scheduler) getJob(name string) (*scheduledJob, bool) {
+	for _, j := range s.jobs {
+		if j.Name == name {
+			return j, true
+		}
+	}
+	return nil, false
+}
+
+// run runs the job and records the run.
+// Panics are recovered and recorded as errors.
+// The user is only notified when a job starts failing and when it recovers, not on every failed run.
+func (s *Scheduler) run(j *scheduledJob, trigger string) error {
+	s.mu.Lock()
+	if j.running {
+		s.mu.Unlock()
+		if trigger == TriggerSchedule {
+			s.logger.Debug().Str("job", j.Name).Msg("scheduler: Skipping run, job is still running")
+		}
+		return ErrJobRunning
+	}
+	j.running = true
+	wasFailing := j.lastRun != nil && j.lastRun.Error != ""
+	s.mu.Unlock()
+
+	s.logger.Debug().Str("job", j.Name).Str("trigger", trigger).Msg("scheduler: Running job")
+
+	start := time.Now()
+	err := runSafely(j.Job)
+	duration := time.Since(start)
+
+	run := &models.ScheduledJobRun{
+		Name:        j.Name,
+		TriggeredBy: trigger,
+		StartedAt:   start,
+		DurationMs:  duration.Milliseconds(),
+	}
+	if err != nil {
+		run.Error = err.Error()
+		s.logger.Error().Err(err).Str("job", j.Name).Msg("scheduler: Job failed")
+		if !wasFailing {
+			s.notify(fmt.Sprintf("The job %q failed: %s", j.Name, err.Error()))
+		}
+	} else {
+		s.logger.Debug().Str("job", j.Name).Dur("duration", duration).Msg("scheduler: Job completed")
+		if wasFailing {
+			s.notify(fmt.Sprintf("The job %q has recovered", j.Name))
+		}
+	}
+
+	if dbErr := s.database.InsertScheduledJobRun(run); dbErr != nil {
+		s.logger.Error().Err(dbErr).Str("job", j.Name).Msg("scheduler: Failed to record job run")
+	}
+
+	s.mu.Lock()
+	j.running = false
+	j.lastRun = run
+	s.mu.Unlock()
+
+	return err
+}
+
+func runSafely(job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run()
+}
+
+// Trigger runs the job immediately in a goroutine, regardless of its schedule and enabled flag.
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.Lock()
+	j, found := s.getJob(name)
+	if !found {
+		s.mu.Unlock()
+		return ErrJobNotFound
+	}
+	if j.RequiresOnline && s.isOffline {
+		s.mu.Unlock()
+		return ErrJobRequiresOnline
+	}
+	if j.running {
+		s.mu.Unlock()
+		return ErrJobRunning
+	}
+	s.mu.Unlock()
+
+	go func() {
+		_ = s.run(j, TriggerManual)
+	}()
+
+	return nil
+}
+
+// UpdateJob saves the enabled flag and schedule of a job.
+// An empty schedule resets the job to its default schedule.
+func (s *Scheduler) UpdateJob(name string, enabled bool, schedule string) (*JobStatus, error) {
+	s.mu.Lock()
+	j, found := s.getJob(name)
+	s.mu.Unlock()
+	if !found {
+		return nil, ErrJobNotFound
+	}
+
+	schedule = strings.TrimSpace(schedule)
+	toParse := schedule
+	if toParse == "" {
+		toParse = j.DefaultSchedule
+	}
+	parsed, err := ParseSchedule(toParse)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.database.UpsertScheduledJobSettings(&models.ScheduledJobSettings{
+		Name:     name,
+		Enabled:  enabled,
+		Schedule: schedule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	j.enabled = enabled
+	j.schedule = toParse
+	j.parsed = parsed
+	j.next = time.Time{}
+	s.mu.Unlock()
+
+	s.wake()
+
+	s.logger.Info().Str("job", name).Bool("enabled", enabled).Str("schedule", toParse).Msg("scheduler: Job updated")
+
+	return s.GetJob(name)
+}
+
+// GetJob returns the status of a job.
+func (s *Scheduler) GetJob(name string) (*JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, found := s.getJob(name)
+	if !found {
+		return nil, ErrJobNotFound
+	}
+	return s.getStatus(j), nil
+}
+
+// ListJobs returns the status of all jobs, in registration order.
+func (s *Scheduler) ListJobs() []*JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]*JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		ret = append(ret, s.getStatus(j))
+	}
+	return ret
+}
+
+// GetJobRuns returns the latest runs of a job, newest first.
+func (s *Scheduler) GetJobRuns(name string, limit int) ([]*models.ScheduledJobRun, error) {
+	s.mu.Lock()
+	_, found := s.getJob(name)
+	s.mu.Unlock()
+	if !found {
+		return nil, ErrJobNotFound
+	}
+	return s.database.GetScheduledJobRuns(name, limit)
+}
+
+func (s *Scheduler) getStatus(j *scheduledJob) *JobStatus {
+	ret := &JobStatus{
+		Name:            j.Name,
+		Description:     j.Description,
+		Enabled:         j.enabled,
+		Schedule:        j.schedule,
+		DefaultSchedule: j.DefaultSchedule,
+		RequiresOnline:  j.RequiresOnline,
+		Running:         j.running,
+		LastRun:         j.lastRun,
+	}
+	if s.isScheduled(j) {
+		next := j.next
+		if next.IsZero() {
+			next = j.parsed.Next(time.Now())
+		}
+		ret.NextRun = &next
+	}
+	return ret
+}
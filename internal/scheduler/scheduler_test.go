@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/database/db"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(t *testing.T, isOffline bool) (*Scheduler, *db.Database) {
+	database := testdb.New(t)
+
+	return NewScheduler(&NewSchedulerOptions{
+		Logger:    util.NewLogger(),
+		Database:  database,
+		IsOffline: isOffline,
+	}), database
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, schedule := range []string{"30m", "@every 1h", "@daily", "0 */6 * * *"} {
+		_, err := ParseSchedule(schedule)
+		require.NoError(t, err, schedule)
+	}
+
+	for _, schedule := range []string{"", "10s", "every hour", "* * *"} {
+		_, err := ParseSchedule(schedule)
+		require.ErrorIs(t, err, ErrInvalidSchedule, schedule)
+	}
+
+	s, err := ParseSchedule("2h")
+	require.NoError(t, err)
+	now := time.Now()
+	require.WithinDuration(t, now.Add(2*time.Hour), s.Next(now), time.Second)
+}
+
+func TestTriggerRecordsRuns(t *testing.T) {
+	s, database := newTestScheduler(t, false)
+
+	var calls atomic.Int32
+	require.NoError(t, s.Register(&Job{
+		Name:            "failing",
+		DefaultSchedule: "@every 1h",
+		Run: func() error {
+			if calls.Add(1) == 1 {
+				return errors.New("something went wrong")
+			}
+			panic("unexpected")
+		},
+	}))
+
+	require.ErrorIs(t, s.Trigger("unknown"), ErrJobNotFound)
+
+	require.NoError(t, s.Trigger("failing"))
+	require.Eventually(t, func() bool {
+		status, _ := s.GetJob("failing")
+		return status.LastRun != nil && !status.Running
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, s.Trigger("failing"))
+	require.Eventually(t, func() bool {
+		runs, _ := s.GetJobRuns("failing", 10)
+		return len(runs) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	runs, err := database.GetScheduledJobRuns("failing", 10)
+	require.NoError(t, err)
+	require.Equal(t, "panic: unexpected", runs[0].Error)
+	require.Equal(t, "something went wrong", runs[1].Error)
+	require.Equal(t, TriggerManual, runs[0].TriggeredBy)
+}
+
+func TestNotifyOnFailureTransitions(t *testing.T) {
+	s, _ := newTestScheduler(t, false)
+
+	notifications := make([]string, 0)
+	s.notify = func(message string) {
+		notifications = append(notifications, message)
+	}
+
+	var fail bool
+	require.NoError(t, s.Register(&Job{
+		Name:            "refresh",
+		DefaultSchedule: "@every 1h",
+		Run: func() error {
+			if fail {
+				return errors.New("offline")
+			}
+			return nil
+		},
+	}))
+	j, _ := s.getJob("refresh")
+
+	for _, f := range []bool{false, true, true, true, false, false, true} {
+		fail = f
+		_ = s.run(j, TriggerManual)
+	}
+
+	// Repeated failures are only notified once
+	require.Equal(t, []string{
+		`The job "refresh" failed: offline`,
+		`The job "refresh" has recovered`,
+		`The job "refresh" failed: offline`,
+	}, notifications)
+}
+
+func TestUpdateJob(t *testing.T) {
+	s, database := newTestScheduler(t, true)
+
+	job := &Job{
+		Name:            "job",
+		DefaultSchedule: "@every 1h",
+		DefaultEnabled:  true,
+		RequiresOnline:  true,
+		Run:             func() error { return nil },
+	}
+	require.NoError(t, s.Register(job))
+
+	// Online jobs are not scheduled in offline mode
+	status, err := s.GetJob("job")
+	require.NoError(t, err)
+	require.Nil(t, status.NextRun)
+	require.ErrorIs(t, s.Trigger("job"), ErrJobRequiresOnline)
+
+	_, err = s.UpdateJob("job", false, "not a schedule")
+	require.ErrorIs(t, err, ErrInvalidSchedule)
+
+	status, err = s.UpdateJob("job", false, "0 3 * * *")
+	require.NoError(t, err)
+	require.False(t, status.Enabled)
+	require.Equal(t, "0 3 * * *", status.Schedule)
+
+	// The settings are applied when the job is registered again
+	s2 := NewScheduler(&NewSchedulerOptions{Logger: util.NewLogger(), Database: database})
+	require.NoError(t, s2.Register(job))
+	status, err = s2.GetJob("job")
+	require.NoError(t, err)
+	require.False(t, status.Enabled)
+	require.Equal(t, "0 3 * * *", status.Schedule)
+	require.Nil(t, status.NextRun)
+
+	// An empty schedule resets the default one
+	status, err = s2.UpdateJob("job", true, "")
+	require.NoError(t, err)
+	require.Equal(t, "@every 1h", status.Schedule)
+	require.NotNil(t, status.NextRun)
+}
+
+func TestScheduledRun(t *testing.T) {
+	s, _ := newTestScheduler(t, false)
+
+	done := make(chan struct{}, 1)
+	require.NoError(t, s.Register(&Job{
+		Name:            "job",
+		DefaultSchedule: "@every 1h",
+		DefaultEnabled:  true,
+		Run: func() error {
+			done <- struct{}{}
+			return nil
+		},
+	}))
+
+	// Make the job due
+	s.mu.Lock()
+	s.jobs[0].next = time.Now().Add(-time.Second)
+	s.mu.Unlock()
+
+	s.Start()
+	defer s.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run")
+	}
+}
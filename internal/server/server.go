@@ -2,11 +2,13 @@ package server
 
 import (
 	"embed"
+	"flag"
 	"fmt"
 	"github.com/rs/zerolog/log"
 	golog "log"
 	"os"
 	"path/filepath"
+	"seanime/internal/cli"
 	"seanime/internal/core"
 	"seanime/internal/cron"
 	"seanime/internal/handlers"
@@ -17,11 +19,29 @@ import (
 )
 
 func startApp(embeddedLogo []byte) (*core.App, core.SeanimeFlags, *updater.SelfUpdater) {
+	// Get the flags
+	flags := core.GetSeanimeFlags()
+
+	// Run the command and exit, e.g. "seanime scan"
+	if args := flag.Args(); len(args) > 0 {
+		if !cli.IsCommand(args[0]) {
+			fmt.Fprintf(os.Stderr, "Unknown command: %s\n", args[0])
+			os.Exit(cli.Run(flags.DataDir, nil))
+		}
+		os.Exit(cli.Run(flags.DataDir, args))
+	}
+
 	// Print the header
 	core.PrintHeader()
 
-	// Get the flags
-	flags := core.GetSeanimeFlags()
+	// Create a backup and exit
+	if flags.Backup != "" {
+		if err := core.RunBackupCommand(&core.ConfigOptions{DataDir: flags.DataDir}, flags.Backup); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create backup: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
 	selfupdater := updater.NewSelfUpdater()
 
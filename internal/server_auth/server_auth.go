@@ -0,0 +1,385 @@
+package server_auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/bcrypt"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PasswordSourceNone     PasswordSource = ""
+	PasswordSourceConfig   PasswordSource = "config"
+	PasswordSourceSettings PasswordSource = "settings"
+
+	// Number of failed attempts before an IP address is locked out
+	maxFailedAttempts = 5
+	lockoutDuration   = 5 * time.Minute
+	// The device's last used time is only persisted once in a while
+	lastUsedUpdateInterval = 5 * time.Minute
+)
+
+var (
+	ErrAuthDisabled       = errors.New("server password is not set")
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrTooManyAttempts    = errors.New("too many failed attempts, try again later")
+	ErrPasswordFromConfig = errors.New("the server password is defined in the config file")
+)
+
+type (
+	// Manager handles the server password and the devices that have logged in.
+	// When no password is set, every request is allowed.
+	Manager struct {
+		logger         *zerolog.Logger
+		db             *db.Database
+		configPassword string
+		// Hash of the password set from the settings
+		passwordHash string
+		// Devices indexed by token hash
+		devices        map[string]*models.AuthDevice
+		devicesLoaded  bool
+		failedAttempts map[string]*failedAttempt
+		mu             sync.RWMutex
+	}
+
+	PasswordSource string
+
+	failedAttempt struct {
+		count       int
+		lockedUntil time.Time
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+		// Password defined in the config file, if any
+		ConfigPassword string
+	}
+
+	LoginOptions struct {
+		Password   string
+		DeviceName string
+		UserAgent  string
+		IP         string
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	ret := &Manager{
+		logger:         opts.Logger,
+		db:             opts.Database,
+		configPassword: strings.TrimSpace(opts.ConfigPassword),
+		passwordHash:   opts.Database.GetServerPasswordHash(),
+		devices:        make(map[string]*models.AuthDevice),
+		failedAttempts: make(map[string]*failedAttempt),
+	}
+
+	if ret.IsEnabled() {
+		ret.logger.Info().Str("source", string(ret.GetPasswordSource())).Msg("server auth: Password protection enabled")
+	}
+
+	return ret
+}
+
+// GetPasswordSource returns where the server password is defined.
+func (m *Manager) GetPasswordSource() PasswordSource {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.configPassword != "" {
+		return PasswordSourceConfig
+	}
+	if m.passwordHash != "" {
+		return PasswordSourceSettings
+	}
+	return PasswordSourceNone
+}
+
+// IsEnabled returns true if a server password is set.
+func (m *Manager) IsEnabled() bool {
+	return m.GetPasswordSource() != PasswordSourceNone
+}
+
+// Login checks the password and registers a new device.
+// It returns the device and the token that the client should send with each request.
+func (m *Manager) Login(opts *LoginOptions) (*models.AuthDevice, string, error) {
+	if !m.IsEnabled() {
+		return nil, "", ErrAuthDisabled
+	}
+
+	if m.isLockedOut(opts.IP) {
+		return nil, "", ErrTooManyAttempts
+	}
+
+	if !m.checkPassword(opts.Password) {
+		m.recordFailedAttempt(opts.IP)
+		m.logger.Warn().Str("ip", opts.IP).Msg("server auth: Failed login attempt")
+		return nil, "", ErrInvalidPassword
+	}
+
+	m.mu.Lock()
+	delete(m.failedAttempts, opts.IP)
+	m.mu.Unlock()
+
+	name := strings.TrimSpace(opts.DeviceName)
+	if name == "" {
+		name = "Unknown device"
+	}
+
+	device := &models.AuthDevice{
+		Name:       name,
+		Scope:      models.AuthDeviceScopeFull,
+		UserAgent:  opts.UserAgent,
+		LastIP:     opts.IP,
+		LastUsedAt: time.Now(),
+	}
+
+	token, err := m.addDevice(device)
+	if err != nil {
+		return nil, "", err
+	}
+
+	m.logger.Info().Str("device", device.Name).Str("ip", opts.IP).Msg("server auth: Device logged in")
+
+	return device, token, nil
+}
+
+// CreateCalendarFeedToken registers a token that can only be used to access the iCalendar feed.
+// Calendar apps send it in the feed's URL, it can be revoked like a device.
+func (m *Manager) CreateCalendarFeedToken(name string) (*models.AuthDevice, string, error) {
+	if !m.IsEnabled() {
+		return nil, "", ErrAuthDisabled
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Calendar feed"
+	}
+
+	device := &models.AuthDevice{
+		Name:       name,
+		Scope:      models.AuthDeviceScopeCalendarFeed,
+		LastUsedAt: time.Now(),
+	}
+
+	token, err := m.addDevice(device)
+	if err != nil {
+		return nil, "", err
+	}
+
+	m.logger.Info().Str("device", device.Name).Msg("server auth: Calendar feed token created")
+
+	return device, token, nil
+}
+
+// Authenticate returns the device associated with the token.
+func (m *Manager) Authenticate(token string, ip string) (*models.AuthDevice, bool) {
+	if token == "" {
+		return nil, false
+	}
+
+	if err := m.loadDevices(); err != nil {
+		m.logger.Error().Err(err).Msg("server auth: Failed to load devices")
+		return nil, false
+	}
+
+	tokenHash := hashToken(token)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	device, found := m.devices[tokenHash]
+	if !found {
+		return nil, false
+	}
+
+	if time.Since(device.LastUsedAt) > lastUsedUpdateInterval || device.LastIP != ip {
+		device.LastUsedAt = time.Now()
+		device.LastIP = ip
+		go func(id uint, lastUsedAt time.Time) {
+			_ = m.db.UpdateAuthDeviceLastUsed(id, ip, lastUsedAt)
+		}(device.ID, device.LastUsedAt)
+	}
+
+	return device, true
+}
+
+// GetDevices returns all the devices that are logged in.
+func (m *Manager) GetDevices() ([]*models.AuthDevice, error) {
+	return m.db.GetAuthDevices()
+}
+
+// RevokeDevice logs out the device with the given ID.
+func (m *Manager) RevokeDevice(id uint) error {
+	if err := m.db.DeleteAuthDevice(id); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for hash, device := range m.devices {
+		if device.ID == id {
+			delete(m.devices, hash)
+		}
+	}
+	m.mu.Unlock()
+
+	m.logger.Info().Uint("id", id).Msg("server auth: Device revoked")
+	return nil
+}
+
+// RevokeAllDevicesExcept logs out all the devices except the one with the given ID.
+func (m *Manager) RevokeAllDevicesExcept(id uint) error {
+	if err := m.db.DeleteAuthDevicesExcept(id); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for hash, device := range m.devices {
+		if device.ID != id {
+			delete(m.devices, hash)
+		}
+	}
+	m.mu.Unlock()
+
+	return nil
+}
+
+// SetPassword updates the password stored in the database.
+// An empty password disables password protection.
+// It returns an error if the password is defined in the config file.
+func (m *Manager) SetPassword(currentPassword string, newPassword string) error {
+	if m.configPassword != "" {
+		return ErrPasswordFromConfig
+	}
+
+	if m.IsEnabled() && !m.checkPassword(currentPassword) {
+		return ErrInvalidPassword
+	}
+
+	newPassword = strings.TrimSpace(newPassword)
+	if newPassword == "" {
+		if err := m.db.UpsertServerPasswordHash(""); err != nil {
+			return err
+		}
+		m.mu.Lock()
+		m.passwordHash = ""
+		m.mu.Unlock()
+		m.logger.Info().Msg("server auth: Password protection disabled")
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := m.db.UpsertServerPasswordHash(string(hash)); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.passwordHash = string(hash)
+	m.mu.Unlock()
+
+	m.logger.Info().Msg("server auth: Password updated")
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (m *Manager) checkPassword(password string) bool {
+	if m.configPassword != "" {
+		a := sha256.Sum256([]byte(password))
+		b := sha256.Sum256([]byte(m.configPassword))
+		return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+	}
+
+	m.mu.RLock()
+	hash := m.passwordHash
+	m.mu.RUnlock()
+	if hash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// addDevice generates the device's token and saves the device.
+func (m *Manager) addDevice(device *models.AuthDevice) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	device.TokenHash = hashToken(token)
+	if err := m.db.InsertAuthDevice(device); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.devices[device.TokenHash] = device
+	m.mu.Unlock()
+
+	return token, nil
+}
+
+func (m *Manager) loadDevices() error {
+	m.mu.RLock()
+	loaded := m.devicesLoaded
+	m.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	devices, err := m.db.GetAuthDevices()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, device := range devices {
+		m.devices[device.TokenHash] = device
+	}
+	m.devicesLoaded = true
+	return nil
+}
+
+func (m *Manager) isLockedOut(ip string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	attempt, found := m.failedAttempts[ip]
+	return found && time.Now().Before(attempt.lockedUntil)
+}
+
+func (m *Manager) recordFailedAttempt(ip string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempt, found := m.failedAttempts[ip]
+	if !found || (!attempt.lockedUntil.IsZero() && time.Now().After(attempt.lockedUntil)) {
+		attempt = &failedAttempt{}
+		m.failedAttempts[ip] = attempt
+	}
+	attempt.count++
+	if attempt.count >= maxFailedAttempts {
+		attempt.lockedUntil = time.Now().Add(lockoutDuration)
+	}
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
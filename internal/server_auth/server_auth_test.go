@@ -0,0 +1,115 @@
+package server_auth
+
+import (
+	"github.com/stretchr/testify/require"
+	"seanime/internal/database/models"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"testing"
+)
+
+func newTestManager(t *testing.T, configPassword string) *Manager {
+	return NewManager(&NewManagerOptions{
+		Logger:         util.NewLogger(),
+		Database:       testdb.New(t),
+		ConfigPassword: configPassword,
+	})
+}
+
+func TestManager_SettingsPassword(t *testing.T) {
+	m := newTestManager(t, "")
+
+	require.False(t, m.IsEnabled())
+
+	_, _, err := m.Login(&LoginOptions{Password: "pass", IP: "127.0.0.1"})
+	require.ErrorIs(t, err, ErrAuthDisabled)
+
+	require.NoError(t, m.SetPassword("", "hunter2"))
+	require.True(t, m.IsEnabled())
+	require.Equal(t, PasswordSourceSettings, m.GetPasswordSource())
+
+	_, _, err = m.Login(&LoginOptions{Password: "wrong", IP: "127.0.0.1"})
+	require.ErrorIs(t, err, ErrInvalidPassword)
+
+	device, token, err := m.Login(&LoginOptions{Password: "hunter2", DeviceName: "Phone", IP: "127.0.0.1"})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.Equal(t, "Phone", device.Name)
+
+	authenticated, ok := m.Authenticate(token, "127.0.0.1")
+	require.True(t, ok)
+	require.Equal(t, device.ID, authenticated.ID)
+
+	_, ok = m.Authenticate("invalid", "127.0.0.1")
+	require.False(t, ok)
+
+	// Changing the password requires the current one
+	require.ErrorIs(t, m.SetPassword("wrong", "new"), ErrInvalidPassword)
+
+	require.NoError(t, m.RevokeDevice(device.ID))
+	_, ok = m.Authenticate(token, "127.0.0.1")
+	require.False(t, ok)
+
+	// Removing the password disables protection
+	require.NoError(t, m.SetPassword("hunter2", ""))
+	require.False(t, m.IsEnabled())
+}
+
+func TestManager_ConfigPassword(t *testing.T) {
+	m := newTestManager(t, "secret")
+
+	require.Equal(t, PasswordSourceConfig, m.GetPasswordSource())
+	require.ErrorIs(t, m.SetPassword("secret", "other"), ErrPasswordFromConfig)
+
+	_, token, err := m.Login(&LoginOptions{Password: "secret", IP: "127.0.0.1"})
+	require.NoError(t, err)
+
+	_, ok := m.Authenticate(token, "192.168.1.2")
+	require.True(t, ok)
+}
+
+func TestManager_Lockout(t *testing.T) {
+	m := newTestManager(t, "secret")
+
+	for i := 0; i < maxFailedAttempts; i++ {
+		_, _, err := m.Login(&LoginOptions{Password: "wrong", IP: "10.0.0.1"})
+		require.ErrorIs(t, err, ErrInvalidPassword)
+	}
+
+	// Even the right password is rejected while locked out
+	_, _, err := m.Login(&LoginOptions{Password: "secret", IP: "10.0.0.1"})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+
+	// Other addresses are not affected
+	_, _, err = m.Login(&LoginOptions{Password: "secret", IP: "10.0.0.2"})
+	require.NoError(t, err)
+}
+
+func TestManager_CalendarFeedToken(t *testing.T) {
+	m := newTestManager(t, "")
+
+	_, _, err := m.CreateCalendarFeedToken("")
+	require.ErrorIs(t, err, ErrAuthDisabled)
+
+	require.NoError(t, m.SetPassword("", "hunter2"))
+
+	device, token, err := m.CreateCalendarFeedToken("")
+	require.NoError(t, err)
+	require.Equal(t, "Calendar feed", device.Name)
+	require.Equal(t, models.AuthDeviceScopeCalendarFeed, device.Scope)
+
+	authenticated, ok := m.Authenticate(token, "127.0.0.1")
+	require.True(t, ok)
+	require.Equal(t, models.AuthDeviceScopeCalendarFeed, authenticated.Scope)
+
+	// The token is revoked without logging out the other devices
+	loggedIn, deviceToken, err := m.Login(&LoginOptions{Password: "hunter2", IP: "127.0.0.1"})
+	require.NoError(t, err)
+	require.Equal(t, models.AuthDeviceScopeFull, loggedIn.Scope)
+
+	require.NoError(t, m.RevokeDevice(device.ID))
+	_, ok = m.Authenticate(token, "127.0.0.1")
+	require.False(t, ok)
+	_, ok = m.Authenticate(deviceToken, "127.0.0.1")
+	require.True(t, ok)
+}
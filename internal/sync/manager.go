@@ -15,6 +15,7 @@ import (
 	"seanime/internal/library/anime"
 	"seanime/internal/manga"
 	"seanime/internal/platforms/platform"
+	"slices"
 )
 
 var (
@@ -505,6 +506,19 @@ func (m *ManagerImpl) synchronize(lfs []*anime.LocalFile, mangaChapterContainers
 
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// sameCustomLists returns true if both slices have the same list names.
+func sameCustomLists(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, name := range a {
+		if !slices.Contains(b, name) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *ManagerImpl) SynchronizeAnilist() error {
 	if m.animeCollection.IsAbsent() {
 		return fmt.Errorf("sync: Anime collection not set")
@@ -518,6 +532,9 @@ func (m *ManagerImpl) SynchronizeAnilist() error {
 	m.loadLocalMangaCollection()
 
 	if localAnimeCollection, ok := m.localAnimeCollection.Get(); ok {
+		// The raw collection has the custom lists
+		rawAnimeCollection, _ := m.anilistPlatform.GetRawAnimeCollection(false)
+
 		for _, list := range localAnimeCollection.MediaListCollection.Lists {
 			if list.GetStatus() == nil || list.GetEntries() == nil {
 				continue
@@ -539,8 +556,17 @@ func (m *ManagerImpl) SynchronizeAnilist() error {
 				key1 := GetAnimeListDataKey(entry)
 				key2 := GetAnimeListDataKey(originalEntry)
 
+				// Only send the custom lists if they were changed offline
+				var customLists []string
+				if rawAnimeCollection != nil {
+					localNames := localAnimeCollection.GetCustomListNames(entry.GetMedia().GetID())
+					if !sameCustomLists(localNames, rawAnimeCollection.GetCustomListNames(entry.GetMedia().GetID())) {
+						customLists = localNames
+					}
+				}
+
 				// If the entry is the same, skip
-				if key1 == key2 {
+				if key1 == key2 && customLists == nil {
 					continue
 				}
 
@@ -574,12 +600,16 @@ func (m *ManagerImpl) SynchronizeAnilist() error {
 					entry.GetProgress(),
 					startDate,
 					endDate,
+					customLists,
 				)
 			}
 		}
 	}
 
 	if localMangaCollection, ok := m.localMangaCollection.Get(); ok {
+		// The raw collection has the custom lists
+		rawMangaCollection, _ := m.anilistPlatform.GetRawMangaCollection(false)
+
 		for _, list := range localMangaCollection.MediaListCollection.Lists {
 			if list.GetStatus() == nil || list.GetEntries() == nil {
 				continue
@@ -601,8 +631,17 @@ func (m *ManagerImpl) SynchronizeAnilist() error {
 				key1 := GetMangaListDataKey(entry)
 				key2 := GetMangaListDataKey(originalEntry)
 
+				// Only send the custom lists if they were changed offline
+				var customLists []string
+				if rawMangaCollection != nil {
+					localNames := localMangaCollection.GetCustomListNames(entry.GetMedia().GetID())
+					if !sameCustomLists(localNames, rawMangaCollection.GetCustomListNames(entry.GetMedia().GetID())) {
+						customLists = localNames
+					}
+				}
+
 				// If the entry is the same, skip
-				if key1 == key2 {
+				if key1 == key2 && customLists == nil {
 					continue
 				}
 
@@ -636,6 +675,7 @@ func (m *ManagerImpl) SynchronizeAnilist() error {
 					entry.GetProgress(),
 					startDate,
 					endDate,
+					customLists,
 				)
 			}
 		}
@@ -397,6 +397,57 @@ func (q *Syncer) synchronizeCollections() (err error) {
 		}
 	}
 
+	// Mirror the custom lists, their entries are the tracked entries of the status lists
+	if _rawAnimeCollection, err := q.manager.anilistPlatform.GetRawAnimeCollection(false); err == nil && _rawAnimeCollection != nil {
+		localEntries := make(map[int]*anilist.AnimeListEntry)
+		for _, list := range localAnimeCollection.MediaListCollection.GetLists() {
+			for _, entry := range list.GetEntries() {
+				localEntries[entry.GetMedia().GetID()] = entry
+			}
+		}
+		for _, _animeList := range _rawAnimeCollection.MediaListCollection.GetLists() {
+			if !_animeList.IsCustom() {
+				continue
+			}
+			list := &anilist.AnimeCollection_MediaListCollection_Lists{
+				Name:         ToNewPointer(_animeList.Name),
+				IsCustomList: ToNewPointer(_animeList.IsCustomList),
+				Entries:      []*anilist.AnimeListEntry{},
+			}
+			for _, _animeEntry := range _animeList.GetEntries() {
+				if entry, found := localEntries[_animeEntry.GetMedia().GetID()]; found {
+					list.Entries = append(list.Entries, entry)
+				}
+			}
+			localAnimeCollection.MediaListCollection.Lists = append(localAnimeCollection.MediaListCollection.Lists, list)
+		}
+	}
+
+	if _rawMangaCollection, err := q.manager.anilistPlatform.GetRawMangaCollection(false); err == nil && _rawMangaCollection != nil {
+		localEntries := make(map[int]*anilist.MangaListEntry)
+		for _, list := range localMangaCollection.MediaListCollection.GetLists() {
+			for _, entry := range list.GetEntries() {
+				localEntries[entry.GetMedia().GetID()] = entry
+			}
+		}
+		for _, _mangaList := range _rawMangaCollection.MediaListCollection.GetLists() {
+			if !_mangaList.IsCustom() {
+				continue
+			}
+			list := &anilist.MangaCollection_MediaListCollection_Lists{
+				Name:         ToNewPointer(_mangaList.Name),
+				IsCustomList: ToNewPointer(_mangaList.IsCustomList),
+				Entries:      []*anilist.MangaListEntry{},
+			}
+			for _, _mangaEntry := range _mangaList.GetEntries() {
+				if entry, found := localEntries[_mangaEntry.GetMedia().GetID()]; found {
+					list.Entries = append(list.Entries, entry)
+				}
+			}
+			localMangaCollection.MediaListCollection.Lists = append(localMangaCollection.MediaListCollection.Lists, list)
+		}
+	}
+
 	// Save the local collections
 	err = q.manager.localDb.SaveAnimeCollection(localAnimeCollection)
 	if err != nil {
@@ -133,3 +133,26 @@ func TestSync(t *testing.T) {
 	}
 
 }
+
+func TestSameCustomLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []string
+		expected bool
+	}{
+		{name: "both empty", a: nil, b: []string{}, expected: true},
+		{name: "same order", a: []string{"A", "B"}, b: []string{"A", "B"}, expected: true},
+		{name: "different order", a: []string{"B", "A"}, b: []string{"A", "B"}, expected: true},
+		{name: "different names", a: []string{"A", "B"}, b: []string{"A", "C"}, expected: false},
+		{name: "different length", a: []string{"A"}, b: []string{"A", "B"}, expected: false},
+		{name: "one empty", a: []string{}, b: []string{"A"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, sameCustomLists(tt.a, tt.b))
+			require.Equal(t, tt.expected, sameCustomLists(tt.b, tt.a))
+		})
+	}
+}
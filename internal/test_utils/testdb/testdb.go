@@ -0,0 +1,34 @@
+// Package testdb creates the databases used by the tests.
+// It is separate from test_utils since the database package depends on test_utils.
+package testdb
+
+import (
+	"github.com/stretchr/testify/require"
+	"seanime/internal/database/db"
+	"seanime/internal/util"
+	"testing"
+)
+
+// Name is the name of the databases created by New.
+const Name = "seanime"
+
+// New creates a database in a temporary directory that is removed when the test ends.
+func New(t testing.TB) *db.Database {
+	return Open(t, t.TempDir(), Name)
+}
+
+// Open opens the database in the directory, it is closed when the test ends.
+func Open(t testing.TB, dir string, name string) *db.Database {
+	t.Helper()
+
+	database, err := db.NewDatabase(dir, name, util.NewLogger())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if sqlDB, err := database.Gorm().DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	return database
+}
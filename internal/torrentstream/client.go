@@ -16,6 +16,7 @@ import (
 	"os"
 	"path"
 	"seanime/internal/mediaplayers/mediaplayer"
+	"seanime/internal/metrics"
 	"strings"
 	"time"
 )
@@ -119,6 +120,7 @@ func (c *Client) initializeClient() error {
 
 	go func(ctx context.Context) {
 
+		lastStatusUpdate := time.Now()
 		for {
 			select {
 			case <-ctx.Done():
@@ -209,7 +211,18 @@ func (c *Client) initializeClient() error {
 						c.currentTorrentStatus.UploadSpeed,
 						c.currentTorrentStatus.Size)
 					c.timeSinceLoggedSeeding = time.Now()
+
+					if elapsed := time.Since(lastStatusUpdate).Seconds(); elapsed > 0 && progressDiff > 0 {
+						metrics.TorrentstreamDownloadRate.Set(float64(progressDiff) / elapsed)
+					} else {
+						metrics.TorrentstreamDownloadRate.Set(0)
+					}
+					metrics.TorrentstreamPeers.Set(float64(t.Stats().ActivePeers))
+				} else {
+					metrics.TorrentstreamDownloadRate.Set(0)
+					metrics.TorrentstreamPeers.Set(0)
 				}
+				lastStatusUpdate = time.Now()
 				c.mu.Unlock()
 				if c.torrentClient.IsPresent() {
 					if time.Since(c.timeSinceLoggedSeeding) > 20*time.Second {
@@ -9,6 +9,7 @@ import (
 	"seanime/internal/api/metadata"
 	"seanime/internal/events"
 	"seanime/internal/library/playbackmanager"
+	"seanime/internal/watchstats"
 	"strconv"
 	"time"
 )
@@ -121,6 +122,7 @@ func (r *Repository) StartStream(opts *StartStreamOptions) error {
 				Payload:   r.client.GetStreamingUrl(),
 				UserAgent: opts.UserAgent,
 				ClientId:  opts.ClientId,
+				Source:    watchstats.SourceTorrentstream,
 			}, media.ToBaseAnime(), aniDbEpisode)
 			if err != nil {
 				// Failed to start the stream, we'll drop the torrents and stop the server
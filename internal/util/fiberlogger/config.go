@@ -3,6 +3,7 @@ package fiberlogger
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
+	"net/url"
 	"os"
 	"time"
 )
@@ -152,7 +153,7 @@ func (c *Config) logger(fc *fiber.Ctx, latency time.Duration, err error) zerolog
 		case FieldPath:
 			zc = zc.Str(field, fc.Path())
 		case FieldURL:
-			zc = zc.Str(field, fc.OriginalURL())
+			zc = zc.Str(field, redactURL(fc.OriginalURL()))
 		case FieldUserAgent:
 			zc = zc.Str(field, fc.Get(fiber.HeaderUserAgent))
 		case FieldLatency:
@@ -282,3 +283,15 @@ func configDefault(config ...Config) Config {
 
 	return cfg
 }
+
+// redactURL hides the value of the "token" query parameter.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.Query().Has("token") {
+		return rawURL
+	}
+	q := u.Query()
+	q.Set("token", "redacted")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
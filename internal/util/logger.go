@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/rs/zerolog/log"
+	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -35,13 +36,22 @@ const (
 var logBuffer bytes.Buffer
 var logBufferMutex = &sync.Mutex{}
 
+// Where loggers print their logs to the console.
+// The CLI prints logs to stderr so that stdout only contains the output of the command.
+var logConsoleOutput io.Writer = os.Stdout
+
+// SetLogConsoleOutput sets where the loggers created afterward print their logs to the console.
+func SetLogConsoleOutput(w io.Writer) {
+	logConsoleOutput = w
+}
+
 func NewLogger() *zerolog.Logger {
 
 	timeFormat := fmt.Sprintf("%s", time.DateTime)
 
 	// Set up logger
 	consoleOutput := zerolog.ConsoleWriter{
-		Out:           os.Stdout,
+		Out:           logConsoleOutput,
 		TimeFormat:    timeFormat,
 		FormatLevel:   ZerologFormatLevelPretty,
 		FormatMessage: ZerologFormatMessagePretty,
@@ -0,0 +1,450 @@
+package watchstats
+
+import (
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"seanime/internal/api/anilist"
+	"seanime/internal/calendar"
+	"seanime/internal/database/models"
+	"sort"
+	"time"
+)
+
+const (
+	IntervalDay  Interval = "day"
+	IntervalWeek Interval = "week"
+)
+
+const (
+	// maxPeriods limits the number of periods returned by GetActivity
+	maxPeriods = 400
+	// defaultTopLimit is the number of media, genres and studios returned by GetTop by default
+	defaultTopLimit = 10
+	// featuresCacheTTL is how long the genres and studios of a media are kept
+	featuresCacheTTL = 24 * time.Hour
+)
+
+var (
+	ErrInvalidInterval = errors.New("watch stats: interval should be 'day' or 'week'")
+	ErrInvalidRange    = errors.New("watch stats: invalid range")
+)
+
+type (
+	Interval string
+
+	ActivityOptions struct {
+		ProfileID uint
+		Interval  Interval
+		// The sessions are grouped by day in the timezone of From
+		From time.Time
+		To   time.Time
+	}
+
+	// Activity holds the time watched per day or week, periods without any activity included.
+	Activity struct {
+		Interval Interval  `json:"interval"`
+		Periods  []*Period `json:"periods"`
+		// Total time watched in seconds
+		Duration int `json:"duration"`
+	}
+
+	Period struct {
+		Date string `json:"date"` // YYYY-MM-DD, first day of the period
+		// Time watched in seconds
+		Duration int `json:"duration"`
+		Sessions int `json:"sessions"`
+		// Number of different episodes watched
+		Episodes int `json:"episodes"`
+	}
+
+	// Streaks holds the number of consecutive days with at least one session.
+	Streaks struct {
+		Current *Streak `json:"current,omitempty"`
+		Longest *Streak `json:"longest,omitempty"`
+	}
+
+	Streak struct {
+		Days  int    `json:"days"`
+		Start string `json:"start"` // YYYY-MM-DD
+		End   string `json:"end"`   // YYYY-MM-DD
+	}
+
+	TopOptions struct {
+		ProfileID uint
+		// Both are optional, all the sessions are used by default
+		From time.Time
+		To   time.Time
+		// Number of media, genres and studios, defaults to 10
+		Limit int
+	}
+
+	// Top holds the most-watched media, genres and studios, by time watched.
+	Top struct {
+		Media   []*TopMedia  `json:"media"`
+		Genres  []*TopGenre  `json:"genres"`
+		Studios []*TopStudio `json:"studios"`
+	}
+
+	TopMedia struct {
+		MediaId int `json:"mediaId"`
+		// Nil if the media couldn't be fetched
+		Media    *anilist.MediaFeatures `json:"media,omitempty"`
+		Duration int                    `json:"duration"`
+		Episodes int                    `json:"episodes"`
+	}
+
+	TopGenre struct {
+		Name     string `json:"name"`
+		Duration int    `json:"duration"`
+		// Number of media with this genre
+		Count int `json:"count"`
+	}
+
+	TopStudio struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Duration int    `json:"duration"`
+		Count    int    `json:"count"`
+	}
+
+	// YearSummary is the year-in-review report.
+	YearSummary struct {
+		Year     int `json:"year"`
+		Duration int `json:"duration"`
+		Sessions int `json:"sessions"`
+		Episodes int `json:"episodes"`
+		// Number of different anime watched
+		Anime         int     `json:"anime"`
+		DaysWatched   int     `json:"daysWatched"`
+		LongestStreak *Streak `json:"longestStreak,omitempty"`
+		BusiestDay    *Period `json:"busiestDay,omitempty"`
+		// Time watched per month, January first
+		Months []int `json:"months"`
+		// Time watched per source
+		Sources map[Source]int `json:"sources"`
+		Top     *Top           `json:"top"`
+	}
+)
+
+// GetActivity returns the time watched per day or week between opts.From and opts.To.
+// Weeks start on Monday.
+func (m *Manager) GetActivity(opts *ActivityOptions) (*Activity, error) {
+	var start time.Time
+	var next func(time.Time) time.Time
+	switch opts.Interval {
+	case IntervalDay:
+		start = startOfDay(opts.From)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case IntervalWeek:
+		start, _, _ = calendar.Bounds(calendar.RangeWeek, opts.From)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	default:
+		return nil, ErrInvalidInterval
+	}
+	if !opts.To.After(opts.From) {
+		return nil, ErrInvalidRange
+	}
+
+	ret := &Activity{
+		Interval: opts.Interval,
+		Periods:  make([]*Period, 0),
+	}
+	periodStarts := make([]time.Time, 0)
+	for t := start; t.Before(opts.To); t = next(t) {
+		if len(ret.Periods) == maxPeriods {
+			return nil, fmt.Errorf("watch stats: range is too large, at most %d periods can be returned", maxPeriods)
+		}
+		periodStarts = append(periodStarts, t)
+		ret.Periods = append(ret.Periods, &Period{Date: t.Format(time.DateOnly)})
+	}
+
+	sessions, err := m.database.GetWatchSessions(opts.ProfileID, start, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes := make([]map[string]struct{}, len(ret.Periods))
+	for _, s := range sessions {
+		startedAt := s.StartedAt.In(opts.From.Location())
+		// Index of the last period starting before the session
+		i := sort.Search(len(periodStarts), func(i int) bool { return periodStarts[i].After(startedAt) }) - 1
+		if i < 0 {
+			continue
+		}
+		p := ret.Periods[i]
+		p.Duration += s.Duration
+		p.Sessions++
+		if episodes[i] == nil {
+			episodes[i] = make(map[string]struct{})
+		}
+		episodes[i][episodeKey(s)] = struct{}{}
+		p.Episodes = len(episodes[i])
+		ret.Duration += s.Duration
+	}
+
+	return ret, nil
+}
+
+// GetStreaks returns the current and longest watching streaks, days are in the given timezone.
+// The current streak is kept until the end of the day after the last session.
+func (m *Manager) GetStreaks(profileId uint, loc *time.Location) (*Streaks, error) {
+	sessions, err := m.database.GetAllWatchSessions(profileId)
+	if err != nil {
+		return nil, err
+	}
+
+	streaks := getStreaks(sessions, loc)
+	ret := &Streaks{}
+	if len(streaks) == 0 {
+		return ret, nil
+	}
+
+	ret.Longest = lo.MaxBy(streaks, func(a, b *Streak) bool { return a.Days > b.Days })
+
+	today := m.now().In(loc).Format(time.DateOnly)
+	yesterday := m.now().In(loc).AddDate(0, 0, -1).Format(time.DateOnly)
+	if last := streaks[len(streaks)-1]; last.End == today || last.End == yesterday {
+		ret.Current = last
+	}
+
+	return ret, nil
+}
+
+// GetTop returns the media, genres and studios the user spent the most time on.
+// The genres and studios are fetched from AniList, only the media are returned if that fails.
+func (m *Manager) GetTop(opts *TopOptions) (*Top, error) {
+	var sessions []*models.WatchSession
+	var err error
+	if opts.From.IsZero() && opts.To.IsZero() {
+		sessions, err = m.database.GetAllWatchSessions(opts.ProfileID)
+	} else {
+		if opts.To.IsZero() {
+			opts.To = m.now()
+		}
+		sessions, err = m.database.GetWatchSessions(opts.ProfileID, opts.From, opts.To)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return m.getTop(sessions, opts.Limit), nil
+}
+
+// GetYearSummary returns the year-in-review report, days and months are in the given timezone.
+func (m *Manager) GetYearSummary(profileId uint, year int, loc *time.Location) (*YearSummary, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	sessions, err := m.database.GetWatchSessions(profileId, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &YearSummary{
+		Year:     year,
+		Sessions: len(sessions),
+		Months:   make([]int, 12),
+		Sources:  make(map[Source]int),
+	}
+
+	episodes := make(map[string]struct{})
+	anime := make(map[int]struct{})
+	days := make(map[string]*Period)
+	dayEpisodes := make(map[string]map[string]struct{})
+	for _, s := range sessions {
+		startedAt := s.StartedAt.In(loc)
+		ret.Duration += s.Duration
+		ret.Months[startedAt.Month()-1] += s.Duration
+		ret.Sources[Source(s.Source)] += s.Duration
+		episodes[episodeKey(s)] = struct{}{}
+		anime[s.MediaID] = struct{}{}
+
+		date := startedAt.Format(time.DateOnly)
+		if _, found := days[date]; !found {
+			days[date] = &Period{Date: date}
+			dayEpisodes[date] = make(map[string]struct{})
+		}
+		days[date].Duration += s.Duration
+		days[date].Sessions++
+		dayEpisodes[date][episodeKey(s)] = struct{}{}
+		days[date].Episodes = len(dayEpisodes[date])
+	}
+	ret.Episodes = len(episodes)
+	ret.Anime = len(anime)
+	ret.DaysWatched = len(days)
+
+	for _, day := range days {
+		if ret.BusiestDay == nil || day.Duration > ret.BusiestDay.Duration ||
+			(day.Duration == ret.BusiestDay.Duration && day.Date < ret.BusiestDay.Date) {
+			ret.BusiestDay = day
+		}
+	}
+
+	if streaks := getStreaks(sessions, loc); len(streaks) > 0 {
+		ret.LongestStreak = lo.MaxBy(streaks, func(a, b *Streak) bool { return a.Days > b.Days })
+	}
+
+	ret.Top = m.getTop(sessions, defaultTopLimit)
+
+	return ret, nil
+}
+
+func (m *Manager) getTop(sessions []*models.WatchSession, limit int) *Top {
+	if limit <= 0 {
+		limit = defaultTopLimit
+	}
+
+	ret := &Top{
+		Media:   make([]*TopMedia, 0),
+		Genres:  make([]*TopGenre, 0),
+		Studios: make([]*TopStudio, 0),
+	}
+
+	media := make(map[int]*TopMedia)
+	episodes := make(map[int]map[int]struct{})
+	for _, s := range sessions {
+		if _, found := media[s.MediaID]; !found {
+			media[s.MediaID] = &TopMedia{MediaId: s.MediaID}
+			episodes[s.MediaID] = make(map[int]struct{})
+		}
+		media[s.MediaID].Duration += s.Duration
+		episodes[s.MediaID][s.Episode] = struct{}{}
+		media[s.MediaID].Episodes = len(episodes[s.MediaID])
+	}
+	if len(media) == 0 {
+		return ret
+	}
+
+	allMedia := lo.Values(media)
+	sort.Slice(allMedia, func(i, j int) bool {
+		if allMedia[i].Duration != allMedia[j].Duration {
+			return allMedia[i].Duration > allMedia[j].Duration
+		}
+		return allMedia[i].MediaId < allMedia[j].MediaId
+	})
+
+	features, err := m.getMediaFeatures(lo.Keys(media))
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("watch stats: Failed to fetch media, genres and studios will be empty")
+		ret.Media = allMedia[:min(limit, len(allMedia))]
+		return ret
+	}
+
+	genres := make(map[string]*TopGenre)
+	studios := make(map[int]*TopStudio)
+	for _, tm := range allMedia {
+		f, found := features[tm.MediaId]
+		if !found {
+			continue
+		}
+		tm.Media = f
+		for _, genre := range f.Genres {
+			if _, found := genres[genre]; !found {
+				genres[genre] = &TopGenre{Name: genre}
+			}
+			genres[genre].Duration += tm.Duration
+			genres[genre].Count++
+		}
+		for _, studio := range f.Studios {
+			if _, found := studios[studio.ID]; !found {
+				studios[studio.ID] = &TopStudio{ID: studio.ID, Name: studio.Name}
+			}
+			studios[studio.ID].Duration += tm.Duration
+			studios[studio.ID].Count++
+		}
+	}
+
+	ret.Media = allMedia[:min(limit, len(allMedia))]
+
+	ret.Genres = lo.Values(genres)
+	sort.Slice(ret.Genres, func(i, j int) bool {
+		if ret.Genres[i].Duration != ret.Genres[j].Duration {
+			return ret.Genres[i].Duration > ret.Genres[j].Duration
+		}
+		return ret.Genres[i].Name < ret.Genres[j].Name
+	})
+	ret.Genres = ret.Genres[:min(limit, len(ret.Genres))]
+
+	ret.Studios = lo.Values(studios)
+	sort.Slice(ret.Studios, func(i, j int) bool {
+		if ret.Studios[i].Duration != ret.Studios[j].Duration {
+			return ret.Studios[i].Duration > ret.Studios[j].Duration
+		}
+		return ret.Studios[i].Name < ret.Studios[j].Name
+	})
+	ret.Studios = ret.Studios[:min(limit, len(ret.Studios))]
+
+	return ret
+}
+
+// getMediaFeatures returns the features of the media keyed by ID, only the uncached media are fetched.
+func (m *Manager) getMediaFeatures(ids []int) (map[int]*anilist.MediaFeatures, error) {
+	ret := make(map[int]*anilist.MediaFeatures, len(ids))
+	missing := make([]int, 0)
+	for _, id := range ids {
+		if f, found := m.featuresCache.Get(id); found {
+			ret[id] = f
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return ret, nil
+	}
+
+	sort.Ints(missing)
+	fetched, err := m.fetchMediaFeatures(missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fetched {
+		m.featuresCache.SetT(f.ID, f, featuresCacheTTL)
+		ret[f.ID] = f
+	}
+
+	return ret, nil
+}
+
+// getStreaks returns the runs of consecutive days with at least one session, oldest first.
+func getStreaks(sessions []*models.WatchSession, loc *time.Location) []*Streak {
+	dates := make([]string, 0)
+	for _, s := range sessions {
+		date := s.StartedAt.In(loc).Format(time.DateOnly)
+		if len(dates) == 0 || dates[len(dates)-1] != date {
+			dates = append(dates, date)
+		}
+	}
+	// The sessions are sorted by start time, but sort anyway in case of a timezone change
+	sort.Strings(dates)
+	dates = lo.Uniq(dates)
+
+	ret := make([]*Streak, 0)
+	var current *Streak
+	for _, date := range dates {
+		if current != nil && nextDate(current.End) == date {
+			current.End = date
+			current.Days++
+			continue
+		}
+		current = &Streak{Days: 1, Start: date, End: date}
+		ret = append(ret, current)
+	}
+
+	return ret
+}
+
+// nextDate returns the day after the given YYYY-MM-DD date.
+func nextDate(date string) string {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return ""
+	}
+	return t.AddDate(0, 0, 1).Format(time.DateOnly)
+}
+
+func startOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+func episodeKey(s *models.WatchSession) string {
+	return fmt.Sprintf("%d-%d", s.MediaID, s.Episode)
+}
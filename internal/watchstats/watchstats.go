@@ -0,0 +1,200 @@
+package watchstats
+
+import (
+	"fmt"
+	"github.com/rs/zerolog"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"seanime/internal/util/result"
+	"sync"
+	"time"
+)
+
+const (
+	SourceLocal         Source = "local"
+	SourceTorrentstream Source = "torrentstream"
+	SourceDebrid        Source = "debrid"
+	SourceOnline        Source = "online"
+)
+
+const (
+	// sessionTimeout is the time without updates after which a session ends,
+	// the web players report the position at long intervals
+	sessionTimeout = 10 * time.Minute
+	// minSessionDuration is the watch time (in seconds) from which a session is saved
+	minSessionDuration = 30
+	// saveInterval is how often an ongoing session is saved
+	saveInterval = time.Minute
+	// positionSlack is added to the time elapsed between two updates when counting the watch time,
+	// the players don't report the position at regular intervals
+	positionSlack = 2.0
+)
+
+// GlobalManager is used by the playback modules to record the watch time.
+// It is set when the app is initialized, Track and End do nothing until then.
+var GlobalManager *Manager
+
+type (
+	Source string
+
+	// Manager records the playback sessions and computes the watch statistics.
+	Manager struct {
+		logger   *zerolog.Logger
+		database *db.Database
+		mu       sync.Mutex
+		// Ongoing sessions, keyed by profile and source
+		active map[string]*activeSession
+		// Media features keyed by media ID, used for the top genres and studios
+		featuresCache *result.Cache[int, *anilist.MediaFeatures]
+		// fetchMediaFeatures and now are replaced in tests
+		fetchMediaFeatures func(ids []int) ([]*anilist.MediaFeatures, error)
+		now                func() time.Time
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+	}
+
+	TrackOptions struct {
+		ProfileID uint
+		MediaID   int
+		Episode   int
+		Source    Source
+		// Playback position in seconds
+		Position float64
+	}
+
+	activeSession struct {
+		session      *models.WatchSession
+		watched      float64 // seconds
+		lastPosition float64
+		lastSeen     time.Time
+		lastSaved    time.Time
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	ret := &Manager{
+		logger:        opts.Logger,
+		database:      opts.Database,
+		active:        make(map[string]*activeSession),
+		featuresCache: result.NewCache[int, *anilist.MediaFeatures](),
+		now:           time.Now,
+	}
+	ret.fetchMediaFeatures = func(ids []int) ([]*anilist.MediaFeatures, error) {
+		return anilist.FetchMediaFeatures(ids, false, ret.logger)
+	}
+	return ret
+}
+
+func activeKey(profileId uint, source Source) string {
+	return fmt.Sprintf("%d-%s", profileId, source)
+}
+
+// Track should be called whenever the playback position is reported by a player.
+// The time between two positions is added to the session of the episode, unless the user paused or skipped ahead.
+// A new session starts when the episode changes or after a long pause.
+func (m *Manager) Track(opts *TrackOptions) {
+	if m == nil || opts.MediaID == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now().UTC()
+
+	// End the sessions that haven't been updated in a while, e.g. the player was closed without notice
+	for key, s := range m.active {
+		if now.Sub(s.lastSeen) > sessionTimeout {
+			m.endSession(key)
+		}
+	}
+
+	key := activeKey(opts.ProfileID, opts.Source)
+	s, found := m.active[key]
+	if found && (s.session.MediaID != opts.MediaID || s.session.Episode != opts.Episode) {
+		m.endSession(key)
+		found = false
+	}
+
+	if !found {
+		m.active[key] = &activeSession{
+			session: &models.WatchSession{
+				ProfileID: opts.ProfileID,
+				MediaID:   opts.MediaID,
+				Episode:   opts.Episode,
+				Source:    string(opts.Source),
+				StartedAt: now,
+				EndedAt:   now,
+			},
+			lastPosition: opts.Position,
+			lastSeen:     now,
+		}
+		return
+	}
+
+	// Going back or staying at the same position (paused) doesn't count,
+	// and only the elapsed time counts when skipping ahead
+	if delta := opts.Position - s.lastPosition; delta > 0 {
+		s.watched += min(delta, now.Sub(s.lastSeen).Seconds()+positionSlack)
+	}
+	s.lastPosition = opts.Position
+	s.lastSeen = now
+	s.session.EndedAt = now
+	s.session.Duration = int(s.watched)
+
+	if s.session.Duration >= minSessionDuration && now.Sub(s.lastSaved) >= saveInterval {
+		m.saveSession(s)
+	}
+}
+
+// End ends the session of the profile for the given source, e.g. when the player is closed.
+func (m *Manager) End(profileId uint, source Source) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endSession(activeKey(profileId, source))
+}
+
+// Shutdown saves the ongoing sessions.
+func (m *Manager) Shutdown() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.active {
+		m.endSession(key)
+	}
+}
+
+// endSession saves the session and removes it from the active sessions. mu must be held.
+func (m *Manager) endSession(key string) {
+	s, found := m.active[key]
+	if !found {
+		return
+	}
+	delete(m.active, key)
+
+	if s.session.Duration >= minSessionDuration {
+		m.saveSession(s)
+	}
+}
+
+// saveSession inserts or updates the session. mu must be held.
+func (m *Manager) saveSession(s *activeSession) {
+	if err := m.database.SaveWatchSession(s.session); err != nil {
+		m.logger.Error().Err(err).Msg("watch stats: Failed to save session")
+		return
+	}
+	s.lastSaved = s.session.EndedAt
+}
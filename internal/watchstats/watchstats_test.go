@@ -0,0 +1,245 @@
+package watchstats
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"seanime/internal/api/anilist"
+	"seanime/internal/database/models"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) (*Manager, *time.Time) {
+	m := NewManager(&NewManagerOptions{
+		Logger:   util.NewLogger(),
+		Database: testdb.New(t),
+	})
+	now := time.Date(2024, time.March, 10, 20, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	m.fetchMediaFeatures = func(ids []int) ([]*anilist.MediaFeatures, error) {
+		return nil, errors.New("unexpected fetch")
+	}
+	return m, &now
+}
+
+func addSession(t *testing.T, m *Manager, mediaId int, episode int, source Source, startedAt time.Time, duration int) {
+	err := m.database.SaveWatchSession(&models.WatchSession{
+		ProfileID: 1,
+		MediaID:   mediaId,
+		Episode:   episode,
+		Source:    string(source),
+		StartedAt: startedAt.UTC(),
+		EndedAt:   startedAt.Add(time.Duration(duration) * time.Second).UTC(),
+		Duration:  duration,
+	})
+	require.NoError(t, err)
+}
+
+func TestManager_Track(t *testing.T) {
+	m, now := newTestManager(t)
+
+	track := func(elapsed time.Duration, mediaId int, episode int, position float64) {
+		*now = now.Add(elapsed)
+		m.Track(&TrackOptions{ProfileID: 1, MediaID: mediaId, Episode: episode, Source: SourceLocal, Position: position})
+	}
+
+	track(0, 1, 1, 0)
+	track(10*time.Second, 1, 1, 10)
+	// Paused
+	track(10*time.Second, 1, 1, 10)
+	track(10*time.Second, 1, 1, 10)
+	// Skipped the opening, only the elapsed time counts
+	track(5*time.Second, 1, 1, 100)
+	track(20*time.Second, 1, 1, 120)
+	// Seeked back
+	track(5*time.Second, 1, 1, 60)
+	track(10*time.Second, 1, 1, 70)
+
+	// Saved once the session is long enough, then once a minute
+	sessions, err := m.database.GetAllWatchSessions(1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, 37, sessions[0].Duration)
+
+	// The next episode starts a new session, too short to be saved
+	track(5*time.Second, 1, 2, 0)
+	track(20*time.Second, 1, 2, 20)
+	m.End(1, SourceLocal)
+
+	sessions, err = m.database.GetAllWatchSessions(1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, 1, sessions[0].Episode)
+	require.Equal(t, "local", sessions[0].Source)
+	require.Equal(t, 10+7+20+10, sessions[0].Duration)
+
+	// Other profiles and sources have their own sessions
+	m.Track(&TrackOptions{ProfileID: 2, MediaID: 5, Episode: 1, Source: SourceOnline, Position: 0})
+	track(0, 1, 3, 0)
+	for i := 1; i <= 4; i++ {
+		*now = now.Add(30 * time.Second)
+		m.Track(&TrackOptions{ProfileID: 2, MediaID: 5, Episode: 1, Source: SourceOnline, Position: float64(i * 30)})
+	}
+
+	// The online session is saved periodically
+	sessions, err = m.database.GetAllWatchSessions(2)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, 90, sessions[0].Duration)
+
+	// Stale sessions end on the next update, the local one is too short to be saved
+	*now = now.Add(15 * time.Minute)
+	m.Track(&TrackOptions{ProfileID: 3, MediaID: 6, Episode: 1, Source: SourceDebrid})
+
+	sessions, err = m.database.GetAllWatchSessions(2)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, 120, sessions[0].Duration)
+
+	sessions, err = m.database.GetAllWatchSessions(1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	// Nil managers are ignored
+	var nilManager *Manager
+	nilManager.Track(&TrackOptions{MediaID: 1})
+	nilManager.End(1, SourceLocal)
+}
+
+func TestManager_GetActivity(t *testing.T) {
+	m, _ := newTestManager(t)
+	loc := time.FixedZone("UTC+9", 9*60*60)
+
+	// March 4th in UTC, March 5th in UTC+9
+	addSession(t, m, 1, 1, SourceLocal, time.Date(2024, time.March, 4, 20, 0, 0, 0, time.UTC), 1200)
+	addSession(t, m, 1, 1, SourceLocal, time.Date(2024, time.March, 5, 1, 0, 0, 0, time.UTC), 300)
+	addSession(t, m, 1, 2, SourceTorrentstream, time.Date(2024, time.March, 5, 2, 0, 0, 0, time.UTC), 1400)
+	addSession(t, m, 2, 1, SourceDebrid, time.Date(2024, time.March, 11, 2, 0, 0, 0, time.UTC), 1400)
+	// Out of range
+	addSession(t, m, 2, 2, SourceDebrid, time.Date(2024, time.February, 1, 2, 0, 0, 0, time.UTC), 1400)
+
+	activity, err := m.GetActivity(&ActivityOptions{
+		ProfileID: 1,
+		Interval:  IntervalDay,
+		From:      time.Date(2024, time.March, 4, 0, 0, 0, 0, loc),
+		To:        time.Date(2024, time.March, 7, 0, 0, 0, 0, loc),
+	})
+	require.NoError(t, err)
+	require.Len(t, activity.Periods, 3)
+	require.Equal(t, &Period{Date: "2024-03-04"}, activity.Periods[0])
+	require.Equal(t, &Period{Date: "2024-03-05", Duration: 2900, Sessions: 3, Episodes: 2}, activity.Periods[1])
+	require.Equal(t, &Period{Date: "2024-03-06"}, activity.Periods[2])
+	require.Equal(t, 2900, activity.Duration)
+
+	activity, err = m.GetActivity(&ActivityOptions{
+		ProfileID: 1,
+		Interval:  IntervalWeek,
+		From:      time.Date(2024, time.March, 6, 0, 0, 0, 0, loc),
+		To:        time.Date(2024, time.March, 12, 0, 0, 0, 0, loc),
+	})
+	require.NoError(t, err)
+	require.Len(t, activity.Periods, 2)
+	require.Equal(t, "2024-03-04", activity.Periods[0].Date)
+	require.Equal(t, 2900, activity.Periods[0].Duration)
+	require.Equal(t, "2024-03-11", activity.Periods[1].Date)
+	require.Equal(t, 1400, activity.Periods[1].Duration)
+
+	_, err = m.GetActivity(&ActivityOptions{ProfileID: 1, Interval: "month", From: time.Now(), To: time.Now().Add(time.Hour)})
+	require.ErrorIs(t, err, ErrInvalidInterval)
+}
+
+func TestManager_GetStreaks(t *testing.T) {
+	m, now := newTestManager(t)
+
+	streaks, err := m.GetStreaks(1, time.UTC)
+	require.NoError(t, err)
+	require.Nil(t, streaks.Current)
+	require.Nil(t, streaks.Longest)
+
+	for _, day := range []int{1, 2, 3, 4, 6, 8, 9} {
+		addSession(t, m, 1, day, SourceLocal, time.Date(2024, time.March, day, 12, 0, 0, 0, time.UTC), 600)
+	}
+
+	streaks, err = m.GetStreaks(1, time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, &Streak{Days: 4, Start: "2024-03-01", End: "2024-03-04"}, streaks.Longest)
+	// Today is the 10th, the streak goes on until the end of the day
+	require.Equal(t, &Streak{Days: 2, Start: "2024-03-08", End: "2024-03-09"}, streaks.Current)
+
+	*now = now.AddDate(0, 0, 1)
+	streaks, err = m.GetStreaks(1, time.UTC)
+	require.NoError(t, err)
+	require.Nil(t, streaks.Current)
+}
+
+func TestManager_GetYearSummary(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	fetchCount := 0
+	m.fetchMediaFeatures = func(ids []int) ([]*anilist.MediaFeatures, error) {
+		fetchCount++
+		features := map[int]*anilist.MediaFeatures{
+			1: {ID: 1, Genres: []string{"Action", "Drama"}, Studios: []*anilist.MediaFeaturesStudio{{ID: 10, Name: "Bones"}}},
+			2: {ID: 2, Genres: []string{"Comedy", "Drama"}, Studios: []*anilist.MediaFeaturesStudio{{ID: 20, Name: "Shaft"}}},
+		}
+		ret := make([]*anilist.MediaFeatures, 0)
+		for _, id := range ids {
+			ret = append(ret, features[id])
+		}
+		return ret, nil
+	}
+
+	addSession(t, m, 1, 1, SourceLocal, time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC), 1400)
+	addSession(t, m, 1, 2, SourceLocal, time.Date(2024, time.January, 11, 12, 0, 0, 0, time.UTC), 1400)
+	addSession(t, m, 1, 2, SourceDebrid, time.Date(2024, time.January, 11, 13, 0, 0, 0, time.UTC), 200)
+	addSession(t, m, 2, 1, SourceOnline, time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC), 1000)
+	// Previous year
+	addSession(t, m, 2, 2, SourceOnline, time.Date(2023, time.December, 31, 12, 0, 0, 0, time.UTC), 1000)
+
+	summary, err := m.GetYearSummary(1, 2024, time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, 4000, summary.Duration)
+	require.Equal(t, 4, summary.Sessions)
+	require.Equal(t, 3, summary.Episodes)
+	require.Equal(t, 2, summary.Anime)
+	require.Equal(t, 3, summary.DaysWatched)
+	require.Equal(t, &Streak{Days: 2, Start: "2024-01-10", End: "2024-01-11"}, summary.LongestStreak)
+	require.Equal(t, &Period{Date: "2024-01-11", Duration: 1600, Sessions: 2, Episodes: 1}, summary.BusiestDay)
+	require.Equal(t, 3000, summary.Months[0])
+	require.Equal(t, 1000, summary.Months[5])
+	require.Equal(t, map[Source]int{SourceLocal: 2800, SourceDebrid: 200, SourceOnline: 1000}, summary.Sources)
+
+	require.Len(t, summary.Top.Media, 2)
+	require.Equal(t, 1, summary.Top.Media[0].MediaId)
+	require.Equal(t, 2, summary.Top.Media[0].Episodes)
+	require.NotNil(t, summary.Top.Media[0].Media)
+	require.Equal(t, []*TopGenre{
+		{Name: "Drama", Duration: 4000, Count: 2},
+		{Name: "Action", Duration: 3000, Count: 1},
+		{Name: "Comedy", Duration: 1000, Count: 1},
+	}, summary.Top.Genres)
+	require.Equal(t, []*TopStudio{
+		{ID: 10, Name: "Bones", Duration: 3000, Count: 1},
+		{ID: 20, Name: "Shaft", Duration: 1000, Count: 1},
+	}, summary.Top.Studios)
+
+	// The media are cached
+	_, err = m.GetTop(&TopOptions{ProfileID: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, fetchCount)
+}
+
+func TestManager_GetTopFetchError(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	addSession(t, m, 1, 1, SourceLocal, time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC), 1400)
+
+	top, err := m.GetTop(&TopOptions{ProfileID: 1})
+	require.NoError(t, err)
+	require.Len(t, top.Media, 1)
+	require.Nil(t, top.Media[0].Media)
+	require.Empty(t, top.Genres)
+	require.Empty(t, top.Studios)
+}
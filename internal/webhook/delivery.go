@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"seanime/internal/constants"
+	"seanime/internal/database/models"
+	"strconv"
+	"time"
+)
+
+const (
+	EventHeader     = "X-Seanime-Event"
+	DeliveryHeader  = "X-Seanime-Delivery"
+	SignatureHeader = "X-Seanime-Signature"
+
+	maxRetryBackoff = time.Hour
+)
+
+// Sign returns the HMAC-SHA256 signature of the body, as sent in the X-Seanime-Signature header.
+//
+//	e.g., "sha256=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd"
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver sends the delivery to the webhook, retrying with an exponential backoff until it succeeds
+// or the maximum number of retries is reached.
+// The webhook is reloaded before each attempt so that edits apply to the retries,
+// the delivery fails if the webhook was deleted or disabled in the meantime.
+func (m *Manager) deliver(d *models.WebhookDelivery) {
+	for {
+		// Wait for the scheduled retry, e.g. when resuming a delivery
+		if d.NextAttemptAt != nil {
+			if wait := time.Until(*d.NextAttemptAt); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-m.ctx.Done():
+					return // Left pending
+				}
+			}
+		}
+
+		w, err := m.database.GetWebhook(d.WebhookID)
+		if err != nil {
+			m.cancelDelivery(d, ErrWebhookNotFound)
+			return
+		}
+		if !w.Enabled {
+			m.cancelDelivery(d, ErrWebhookDisabled)
+			return
+		}
+
+		if m.attempt(w, d) {
+			return
+		}
+	}
+}
+
+// attempt sends the delivery once and saves the result.
+// It returns true when there is nothing left to do, i.e. the delivery succeeded or has no retries left.
+func (m *Manager) attempt(w *models.Webhook, d *models.WebhookDelivery) bool {
+	status, err := m.send(w, d)
+	d.Attempts++
+	d.ResponseStatus = status
+	d.NextAttemptAt = nil
+
+	if err == nil {
+		d.Status = DeliveryStatusSuccess
+		d.Error = ""
+		m.saveDelivery(d)
+		m.logger.Debug().Uint("webhook", w.ID).Str("event", d.Event).Msg("webhook: Delivered event")
+		return true
+	}
+
+	d.Error = err.Error()
+	if d.Attempts > w.MaxRetries {
+		d.Status = DeliveryStatusFailed
+		m.saveDelivery(d)
+		m.logger.Warn().Err(err).Uint("webhook", w.ID).Str("event", d.Event).Int("attempts", d.Attempts).Msg("webhook: Failed to deliver event")
+		return true
+	}
+
+	next := time.Now().Add(retryBackoff(w, d.Attempts))
+	d.NextAttemptAt = &next
+	m.saveDelivery(d)
+	return false
+}
+
+// cancelDelivery marks the delivery as failed without sending it.
+func (m *Manager) cancelDelivery(d *models.WebhookDelivery, reason error) {
+	d.Status = DeliveryStatusFailed
+	d.Error = reason.Error()
+	d.NextAttemptAt = nil
+	m.saveDelivery(d)
+	m.logger.Debug().Uint("webhook", d.WebhookID).Str("event", d.Event).Err(reason).Msg("webhook: Cancelled delivery")
+}
+
+func (m *Manager) saveDelivery(d *models.WebhookDelivery) {
+	if err := m.database.UpdateWebhookDelivery(d); err != nil {
+		m.logger.Error().Err(err).Uint("delivery", d.ID).Msg("webhook: Failed to save delivery")
+	}
+}
+
+// retryBackoff returns the delay before the next attempt.
+// The backoff of the webhook is doubled after each failed attempt.
+func retryBackoff(w *models.Webhook, attempts int) time.Duration {
+	backoff := w.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	ret := time.Duration(backoff) * time.Second
+	for i := 1; i < attempts; i++ {
+		ret *= 2
+		if ret >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return ret
+}
+
+// send posts the payload to the webhook and returns the response status.
+func (m *Manager) send(w *models.Webhook, d *models.WebhookDelivery) (int, error) {
+	body := []byte(d.Payload)
+
+	req, err := http.NewRequestWithContext(m.ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Seanime/"+constants.Version)
+	req.Header.Set(EventHeader, d.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(d.ID), 10))
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.Secret, body))
+	}
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,80 @@
+package webhook
+
+type Event string
+
+const (
+	EventAutoDownloaderTorrentAdded Event = "auto_downloader.torrent_added"
+	EventDebridDownloadFinished     Event = "debrid.download_finished"
+	EventScanCompleted              Event = "library.scan_completed"
+	EventEpisodeWatched             Event = "playback.episode_watched"
+	EventMangaChapterDownloaded     Event = "manga.chapter_downloaded"
+	EventExtensionUpdateAvailable   Event = "extensions.update_available"
+	// EventPing is sent when a webhook is tested
+	EventPing Event = "ping"
+)
+
+// Events are the events a webhook can subscribe to.
+var Events = []Event{
+	EventAutoDownloaderTorrentAdded,
+	EventDebridDownloadFinished,
+	EventScanCompleted,
+	EventEpisodeWatched,
+	EventMangaChapterDownloaded,
+	EventExtensionUpdateAvailable,
+}
+
+func IsValidEvent(event string) bool {
+	for _, e := range Events {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+type (
+	// Payload is the body sent to the webhooks.
+	Payload struct {
+		Event     Event       `json:"event"`
+		Timestamp string      `json:"timestamp"` // RFC 3339
+		Data      interface{} `json:"data"`
+	}
+
+	TorrentAddedPayload struct {
+		MediaId     int    `json:"mediaId"`
+		Episode     int    `json:"episode"`
+		TorrentName string `json:"torrentName"`
+		Hash        string `json:"hash"`
+		// Whether the torrent was sent to the torrent client or debrid service, or only added to the queue
+		Downloaded bool `json:"downloaded"`
+	}
+
+	DebridDownloadFinishedPayload struct {
+		TorrentName string `json:"torrentName"`
+		Destination string `json:"destination"`
+	}
+
+	ScanCompletedPayload struct {
+		NewFiles   []string `json:"newFiles"`
+		TotalFiles int      `json:"totalFiles"`
+	}
+
+	EpisodeWatchedPayload struct {
+		MediaId       int `json:"mediaId"`
+		Episode       int `json:"episode"`
+		TotalEpisodes int `json:"totalEpisodes"` // -1 if unknown
+	}
+
+	ChapterDownloadedPayload struct {
+		MediaId       int    `json:"mediaId"`
+		Provider      string `json:"provider"`
+		ChapterId     string `json:"chapterId"`
+		ChapterNumber string `json:"chapterNumber"`
+	}
+
+	ExtensionUpdatePayload struct {
+		ExtensionId    string `json:"extensionId"`
+		CurrentVersion string `json:"currentVersion"`
+		Version        string `json:"version"`
+	}
+)
@@ -0,0 +1,306 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"net/http"
+	"net/url"
+	"seanime/internal/database/db"
+	"seanime/internal/database/models"
+	"sync"
+	"time"
+)
+
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSuccess = "success"
+	DeliveryStatusFailed  = "failed"
+
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 30 // seconds
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrWebhookDisabled = errors.New("webhook is disabled")
+	ErrInvalidURL      = errors.New("invalid webhook URL")
+)
+
+// GlobalManager is used by the modules to dispatch events.
+// It is set when the app is initialized, Dispatch does nothing until then.
+var GlobalManager *Manager
+
+type (
+	// Manager stores the webhooks and delivers the events they subscribed to.
+	Manager struct {
+		logger   *zerolog.Logger
+		database *db.Database
+		client   *http.Client
+		mu       sync.RWMutex
+		webhooks []*models.Webhook
+		ctx      context.Context
+		cancel   context.CancelFunc
+	}
+
+	NewManagerOptions struct {
+		Logger   *zerolog.Logger
+		Database *db.Database
+	}
+)
+
+func NewManager(opts *NewManagerOptions) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Manager{
+		logger:   opts.Logger,
+		database: opts.Database,
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		webhooks: make([]*models.Webhook, 0),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	m.loadWebhooks()
+
+	return m
+}
+
+// Shutdown stops the pending retries. They are resumed by ResumePendingDeliveries on the next start.
+func (m *Manager) Shutdown() {
+	if m == nil {
+		return
+	}
+	m.cancel()
+}
+
+func (m *Manager) loadWebhooks() {
+	webhooks, err := m.database.GetWebhooks()
+	if err != nil {
+		m.logger.Error().Err(err).Msg("webhook: Failed to load webhooks")
+		return
+	}
+
+	m.mu.Lock()
+	m.webhooks = webhooks
+	m.mu.Unlock()
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Dispatch sends the event to the enabled webhooks that subscribed to it.
+// The deliveries run in the background.
+func (m *Manager) Dispatch(event Event, data interface{}) {
+	if m == nil {
+		return
+	}
+
+	payload, err := json.Marshal(&Payload{
+		Event:     event,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		m.logger.Error().Err(err).Str("event", string(event)).Msg("webhook: Failed to marshal payload")
+		return
+	}
+
+	m.mu.RLock()
+	webhooks := make([]*models.Webhook, 0)
+	for _, w := range m.webhooks {
+		if w.Enabled && isSubscribed(w, event) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, w := range webhooks {
+		delivery, err := m.createDelivery(w, event, string(payload))
+		if err != nil {
+			continue
+		}
+		go m.deliver(delivery)
+	}
+}
+
+func isSubscribed(w *models.Webhook, event Event) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == string(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manager) createDelivery(w *models.Webhook, event Event, payload string) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		WebhookID: w.ID,
+		Event:     string(event),
+		Payload:   payload,
+		Status:    DeliveryStatusPending,
+	}
+	if err := m.database.InsertWebhookDelivery(delivery); err != nil {
+		m.logger.Error().Err(err).Uint("webhook", w.ID).Msg("webhook: Failed to save delivery")
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Redeliver sends the payload of a previous delivery again.
+// A new delivery is created so that the log keeps the previous attempts.
+func (m *Manager) Redeliver(deliveryId uint) (*models.WebhookDelivery, error) {
+	prev, err := m.database.GetWebhookDelivery(deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := m.database.GetWebhook(prev.WebhookID)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	delivery, err := m.createDelivery(w, Event(prev.Event), prev.Payload)
+	if err != nil {
+		return nil, err
+	}
+	go m.deliver(delivery)
+
+	return delivery, nil
+}
+
+// Test sends a ping event to the webhook and waits for the response.
+// Failed test deliveries are not retried.
+func (m *Manager) Test(webhookId uint) (*models.WebhookDelivery, error) {
+	w, err := m.database.GetWebhook(webhookId)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	payload, err := json.Marshal(&Payload{
+		Event:     EventPing,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Data:      map[string]interface{}{"webhookId": w.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := m.createDelivery(w, EventPing, string(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	noRetry := *w
+	noRetry.MaxRetries = 0
+	m.attempt(&noRetry, delivery)
+
+	return delivery, nil
+}
+
+// ResumePendingDeliveries resumes the deliveries that were interrupted by a shutdown.
+func (m *Manager) ResumePendingDeliveries() {
+	deliveries, err := m.database.GetPendingWebhookDeliveries()
+	if err != nil {
+		m.logger.Error().Err(err).Msg("webhook: Failed to get pending deliveries")
+		return
+	}
+
+	for _, d := range deliveries {
+		go m.deliver(d)
+	}
+
+	if len(deliveries) > 0 {
+		m.logger.Debug().Int("count", len(deliveries)).Msg("webhook: Resumed pending deliveries")
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (m *Manager) GetWebhooks() ([]*models.Webhook, error) {
+	return m.database.GetWebhooks()
+}
+
+func (m *Manager) CreateWebhook(w *models.Webhook) (*models.Webhook, error) {
+	w.ID = 0
+	if err := validate(w); err != nil {
+		return nil, err
+	}
+
+	ret, err := m.database.InsertWebhook(w)
+	if err != nil {
+		return nil, err
+	}
+	m.loadWebhooks()
+
+	m.logger.Info().Uint("id", ret.ID).Str("name", ret.Name).Msg("webhook: Created webhook")
+
+	return ret, nil
+}
+
+// UpdateWebhook saves the webhook.
+// The secret is kept if the client sends back the redacted placeholder.
+func (m *Manager) UpdateWebhook(w *models.Webhook) (*models.Webhook, error) {
+	prev, err := m.database.GetWebhook(w.ID)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	w.CreatedAt = prev.CreatedAt
+	w.Secret = models.KeepExisting(w.Secret, prev.Secret)
+	if err := validate(w); err != nil {
+		return nil, err
+	}
+
+	ret, err := m.database.UpdateWebhook(w)
+	if err != nil {
+		return nil, err
+	}
+	m.loadWebhooks()
+
+	return ret, nil
+}
+
+func (m *Manager) DeleteWebhook(id uint) error {
+	if _, err := m.database.GetWebhook(id); err != nil {
+		return ErrWebhookNotFound
+	}
+
+	if err := m.database.DeleteWebhook(id); err != nil {
+		return err
+	}
+	m.loadWebhooks()
+
+	return nil
+}
+
+func (m *Manager) GetDeliveries(webhookId uint, limit int) ([]*models.WebhookDelivery, error) {
+	return m.database.GetWebhookDeliveries(webhookId, limit)
+}
+
+func validate(w *models.Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+
+	for _, e := range w.Events {
+		if !IsValidEvent(e) {
+			return fmt.Errorf("unknown event %q", e)
+		}
+	}
+
+	if w.MaxRetries < 0 {
+		w.MaxRetries = 0
+	}
+	if w.RetryBackoff <= 0 {
+		w.RetryBackoff = DefaultRetryBackoff
+	}
+
+	return nil
+}
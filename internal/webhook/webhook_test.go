@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"seanime/internal/database/models"
+	"seanime/internal/test_utils/testdb"
+	"seanime/internal/util"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	m := NewManager(&NewManagerOptions{
+		Logger:   util.NewLogger(),
+		Database: testdb.New(t),
+	})
+	t.Cleanup(m.Shutdown)
+	return m
+}
+
+func waitForDelivery(t *testing.T, m *Manager, webhookId uint, status string) *models.WebhookDelivery {
+	var ret *models.WebhookDelivery
+	require.Eventually(t, func() bool {
+		deliveries, err := m.GetDeliveries(webhookId, 1)
+		if err != nil || len(deliveries) == 0 {
+			return false
+		}
+		ret = deliveries[0]
+		return ret.Status == status
+	}, 10*time.Second, 20*time.Millisecond)
+	return ret
+}
+
+func TestDispatch(t *testing.T) {
+	m := newTestManager(t)
+
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	w, err := m.CreateWebhook(&models.Webhook{
+		Name:    "Home",
+		URL:     server.URL,
+		Secret:  "secret",
+		Events:  models.StringList{string(EventEpisodeWatched)},
+		Enabled: true,
+	})
+	require.NoError(t, err)
+
+	// Not subscribed
+	m.Dispatch(EventScanCompleted, &ScanCompletedPayload{})
+	m.Dispatch(EventEpisodeWatched, &EpisodeWatchedPayload{MediaId: 1, Episode: 2})
+
+	req := <-received
+	body := <-bodies
+	require.Equal(t, string(EventEpisodeWatched), req.Header.Get(EventHeader))
+	require.Equal(t, Sign("secret", body), req.Header.Get(SignatureHeader))
+
+	var payload struct {
+		Event Event                 `json:"event"`
+		Data  EpisodeWatchedPayload `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, EventEpisodeWatched, payload.Event)
+	require.Equal(t, 2, payload.Data.Episode)
+
+	delivery := waitForDelivery(t, m, w.ID, DeliveryStatusSuccess)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, http.StatusOK, delivery.ResponseStatus)
+
+	select {
+	case <-received:
+		t.Fatal("unsubscribed event was delivered")
+	default:
+	}
+}
+
+func TestRetryAndRedeliver(t *testing.T) {
+	m := newTestManager(t)
+
+	var calls atomic.Int32
+	var fail atomic.Bool
+	fail.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	w, err := m.CreateWebhook(&models.Webhook{
+		URL:          server.URL,
+		Enabled:      true,
+		MaxRetries:   1,
+		RetryBackoff: 1,
+	})
+	require.NoError(t, err)
+
+	m.Dispatch(EventMangaChapterDownloaded, &ChapterDownloadedPayload{MediaId: 1})
+
+	delivery := waitForDelivery(t, m, w.ID, DeliveryStatusFailed)
+	require.Equal(t, 2, delivery.Attempts)
+	require.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	require.EqualValues(t, 2, calls.Load())
+
+	fail.Store(false)
+	redelivery, err := m.Redeliver(delivery.ID)
+	require.NoError(t, err)
+	require.NotEqual(t, delivery.ID, redelivery.ID)
+
+	redelivery = waitForDelivery(t, m, w.ID, DeliveryStatusSuccess)
+	require.Equal(t, delivery.Payload, redelivery.Payload)
+}
+
+func TestRetryDisabledWebhook(t *testing.T) {
+	m := newTestManager(t)
+
+	calls := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls <- struct{}{}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	w, err := m.CreateWebhook(&models.Webhook{
+		URL:          server.URL,
+		Enabled:      true,
+		MaxRetries:   3,
+		RetryBackoff: 1,
+	})
+	require.NoError(t, err)
+
+	m.Dispatch(EventScanCompleted, &ScanCompletedPayload{})
+	<-calls
+
+	// The retries are not sent once the webhook is disabled
+	w.Enabled = false
+	_, err = m.UpdateWebhook(w)
+	require.NoError(t, err)
+
+	delivery := waitForDelivery(t, m, w.ID, DeliveryStatusFailed)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, ErrWebhookDisabled.Error(), delivery.Error)
+	require.Len(t, calls, 0)
+}
+
+func TestWebhookValidation(t *testing.T) {
+	m := newTestManager(t)
+
+	_, err := m.CreateWebhook(&models.Webhook{URL: "ftp://example.com"})
+	require.ErrorIs(t, err, ErrInvalidURL)
+
+	_, err = m.CreateWebhook(&models.Webhook{URL: "https://example.com", Events: models.StringList{"unknown"}})
+	require.Error(t, err)
+
+	w, err := m.CreateWebhook(&models.Webhook{URL: "https://example.com", Secret: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, DefaultRetryBackoff, w.RetryBackoff)
+
+	// The redacted secret keeps the stored one
+	update := *w.Redacted()
+	update.Name = "Updated"
+	_, err = m.UpdateWebhook(&update)
+	require.NoError(t, err)
+
+	webhooks, err := m.GetWebhooks()
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, "Updated", webhooks[0].Name)
+	require.Equal(t, "secret", webhooks[0].Secret)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	w := &models.Webhook{RetryBackoff: 10}
+	require.Equal(t, 10*time.Second, retryBackoff(w, 1))
+	require.Equal(t, 20*time.Second, retryBackoff(w, 2))
+	require.Equal(t, 40*time.Second, retryBackoff(w, 3))
+	require.Equal(t, maxRetryBackoff, retryBackoff(w, 20))
+}
@@ -15,12 +15,13 @@ import type {
     Anime_LocalFileMetadata,
     ChapterDownloader_DownloadID,
     Continuity_UpdateWatchHistoryItemOptions,
-    Debrid_TorrentItem,
     DebridClient_CancelStreamOptions,
     DebridClient_StreamPlaybackType,
+    Debrid_TorrentItem,
     HibikeTorrent_AnimeTorrent,
     Mediastream_StreamType,
     Models_AnilistSettings,
+    Models_BackupSettings,
     Models_DebridSettings,
     Models_DiscordSettings,
     Models_LibrarySettings,
@@ -53,6 +54,12 @@ export type EditAnilistListEntry_Variables = {
     progress?: number
     startedAt?: AL_FuzzyDateInput
     completedAt?: AL_FuzzyDateInput
+    /**
+     *  Names of the custom lists, nil if unchanged
+     *  
+     *  Names of the custom lists, nil if unchanged
+     */
+    customLists: Array<string>
     type: string
 }
 
@@ -355,6 +362,61 @@ export type DeleteAutoDownloaderItem_Variables = {
     id: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// backup
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/backup.go
+ * - Filename: backup.go
+ * - Endpoint: /api/v1/backups/download/{name}
+ * @description
+ * Route downloads a backup archive.
+ */
+export type DownloadBackup_Variables = {
+    /**
+     *  The name of the backup file
+     */
+    name: string
+}
+
+/**
+ * - Filepath: internal/handlers/backup.go
+ * - Filename: backup.go
+ * - Endpoint: /api/v1/backups
+ * @description
+ * Route deletes a backup from the backup directory.
+ */
+export type DeleteBackup_Variables = {
+    name: string
+}
+
+/**
+ * - Filepath: internal/handlers/backup.go
+ * - Filename: backup.go
+ * - Endpoint: /api/v1/backups/restore
+ * @description
+ * Route restores a backup.
+ */
+export type RestoreBackup_Variables = {
+    name: string
+}
+
+/**
+ * - Filepath: internal/handlers/backup.go
+ * - Filename: backup.go
+ * - Endpoint: /api/v1/backups/settings
+ * @description
+ * Route saves the settings of the scheduled backups.
+ */
+export type SaveBackupSettings_Variables = {
+    settings: Models_BackupSettings
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// calendar
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // continuity
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -552,6 +614,22 @@ export type DownloadRelease_Variables = {
     destination: string
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// event_stream
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/event_stream.go
+ * - Filename: event_stream.go
+ * - Endpoint: /api/v1/events/history
+ * @description
+ * Route returns the events recorded in the event journal after the given sequence number.
+ */
+export type GetEventHistory_Variables = {
+    since: number
+    types: Array<string>
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // explorer
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -638,6 +716,20 @@ export type RunExtensionPlaygroundCode_Variables = {
     params?: RunPlaygroundCodeParams
 }
 
+/**
+ * - Filepath: internal/handlers/extensions.go
+ * - Filename: extensions.go
+ * - Endpoint: /api/v1/extensions/user-config/{id}
+ * @description
+ * Route returns the user config definition and current values for the extension with the given ID.
+ */
+export type GetExtensionUserConfig_Variables = {
+    /**
+     *  The extension ID
+     */
+    id: string
+}
+
 /**
  * - Filepath: internal/handlers/extensions.go
  * - Filename: extensions.go
@@ -666,6 +758,81 @@ export type RemoveFileCacheBucket_Variables = {
     bucket: string
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// kitsu
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/kitsu.go
+ * - Filename: kitsu.go
+ * - Endpoint: /api/v1/kitsu/login
+ * @description
+ * Route logs the user in to Kitsu.
+ */
+export type KitsuLogin_Variables = {
+    username: string
+    password: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// list_export
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// list_import
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/list_import.go
+ * - Filename: list_import.go
+ * - Endpoint: /api/v1/list-import/preview
+ * @description
+ * Route parses an exported list and starts mapping its entries to AniList media.
+ */
+export type GetListImportPreview_Variables = {
+    format: string
+    content: string
+}
+
+/**
+ * - Filepath: internal/handlers/list_import.go
+ * - Filename: list_import.go
+ * - Endpoint: /api/v1/list-import/apply
+ * @description
+ * Route starts adding the entries of the import preview to the collection.
+ */
+export type ApplyListImport_Variables = {
+    mediaIds: Array<number>
+    overwrite: boolean
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// list_sync
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/list_sync.go
+ * - Filename: list_sync.go
+ * - Endpoint: /api/v1/list-sync/sync
+ * @description
+ * Route synchronizes the AniList and MyAnimeList lists.
+ */
+export type RunListSync_Variables = {
+    origin: string
+}
+
+/**
+ * - Filepath: internal/handlers/list_sync.go
+ * - Filename: list_sync.go
+ * - Endpoint: /api/v1/settings/list-sync
+ * @description
+ * Route updates the list sync settings.
+ */
+export type SaveListSyncSettings_Variables = {
+    automatic: boolean
+    origin: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // localfiles
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1076,6 +1243,98 @@ export type RemoveFillerData_Variables = {
     mediaId: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// metrics
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// notification_channels
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/notification_channels.go
+ * - Filename: notification_channels.go
+ * - Endpoint: /api/v1/notification-channels/{id}
+ * @description
+ * Route updates a notification channel.
+ */
+export type UpdateNotificationChannel_Variables = {
+    /**
+     *  The ID of the notification channel
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/notification_channels.go
+ * - Filename: notification_channels.go
+ * - Endpoint: /api/v1/notification-channels/{id}
+ * @description
+ * Route deletes a notification channel.
+ */
+export type DeleteNotificationChannel_Variables = {
+    /**
+     *  The ID of the notification channel
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/notification_channels.go
+ * - Filename: notification_channels.go
+ * - Endpoint: /api/v1/notification-channels/{id}/test
+ * @description
+ * Route sends a test notification to a channel.
+ */
+export type TestNotificationChannel_Variables = {
+    /**
+     *  The ID of the notification channel
+     */
+    id: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// notifications
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/notifications.go
+ * - Filename: notifications.go
+ * - Endpoint: /api/v1/notifications/list
+ * @description
+ * Route returns the notifications stored in the inbox.
+ */
+export type GetNotifications_Variables = {
+    kind: string
+    mediaId: number
+    unreadOnly: boolean
+    limit: number
+    offset: number
+}
+
+/**
+ * - Filepath: internal/handlers/notifications.go
+ * - Filename: notifications.go
+ * - Endpoint: /api/v1/notifications/read
+ * @description
+ * Route marks the given notifications as read.
+ */
+export type MarkNotificationsRead_Variables = {
+    ids: Array<number>
+}
+
+/**
+ * - Filepath: internal/handlers/notifications.go
+ * - Filename: notifications.go
+ * - Endpoint: /api/v1/notifications/prune
+ * @description
+ * Route deletes the notifications older than the given number of days.
+ */
+export type PruneNotifications_Variables = {
+    olderThanDays: number
+    readOnly: boolean
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // onlinestream
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1168,6 +1427,38 @@ export type RemoveOnlinestreamMapping_Variables = {
     mediaId: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// platform_mutations
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/platform_mutations.go
+ * - Filename: platform_mutations.go
+ * - Endpoint: /api/v1/platform-mutations/{id}/retry
+ * @description
+ * Route sends a queued write to the tracking platform right away.
+ */
+export type RetryPlatformMutation_Variables = {
+    /**
+     *  The ID of the mutation
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/platform_mutations.go
+ * - Filename: platform_mutations.go
+ * - Endpoint: /api/v1/platform-mutations/{id}
+ * @description
+ * Route deletes a queued write without sending it.
+ */
+export type DiscardPlatformMutation_Variables = {
+    /**
+     *  The ID of the mutation
+     */
+    id: number
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // playback_manager
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1265,6 +1556,74 @@ export type GetPlaylistEpisodes_Variables = {
     progress: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// profile
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/profile.go
+ * - Filename: profile.go
+ * - Endpoint: /api/v1/profiles
+ * @description
+ * Route creates a new profile.
+ */
+export type CreateProfile_Variables = {
+    name: string
+    pin: string
+}
+
+/**
+ * - Filepath: internal/handlers/profile.go
+ * - Filename: profile.go
+ * - Endpoint: /api/v1/profiles
+ * @description
+ * Route updates the current profile.
+ */
+export type UpdateProfile_Variables = {
+    name?: string
+    discord?: Models_DiscordSettings
+}
+
+/**
+ * - Filepath: internal/handlers/profile.go
+ * - Filename: profile.go
+ * - Endpoint: /api/v1/profiles/pin
+ * @description
+ * Route sets or removes the PIN of the current profile.
+ */
+export type SetProfilePin_Variables = {
+    currentPin: string
+    pin: string
+}
+
+/**
+ * - Filepath: internal/handlers/profile.go
+ * - Filename: profile.go
+ * - Endpoint: /api/v1/profiles
+ * @description
+ * Route deletes a profile and its data.
+ */
+export type DeleteProfile_Variables = {
+    id: number
+    pin: string
+}
+
+/**
+ * - Filepath: internal/handlers/profile.go
+ * - Filename: profile.go
+ * - Endpoint: /api/v1/profiles/select
+ * @description
+ * Route selects the profile used by the client.
+ */
+export type SelectProfile_Variables = {
+    id: number
+    pin: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// recommendation
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // releases
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1305,6 +1664,106 @@ export type ScanLocalFiles_Variables = {
 // scan_summary
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// scheduler
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/scheduler.go
+ * - Filename: scheduler.go
+ * - Endpoint: /api/v1/scheduler/jobs/{name}
+ * @description
+ * Route updates the schedule of a background job.
+ */
+export type UpdateScheduledJob_Variables = {
+    enabled: boolean
+    schedule: string
+}
+
+/**
+ * - Filepath: internal/handlers/scheduler.go
+ * - Filename: scheduler.go
+ * - Endpoint: /api/v1/scheduler/jobs/{name}/run
+ * @description
+ * Route runs a background job immediately.
+ */
+export type RunScheduledJob_Variables = {
+    /**
+     *  The name of the job
+     */
+    name: string
+}
+
+/**
+ * - Filepath: internal/handlers/scheduler.go
+ * - Filename: scheduler.go
+ * - Endpoint: /api/v1/scheduler/jobs/{name}/runs
+ * @description
+ * Route returns the latest runs of a background job.
+ */
+export type GetScheduledJobRuns_Variables = {
+    /**
+     *  The name of the job
+     */
+    name: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// server_auth
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Endpoint: /api/v1/server-auth/login
+ * @description
+ * Route logs in a device using the server password.
+ */
+export type ServerAuthLogin_Variables = {
+    password: string
+    deviceName: string
+}
+
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Endpoint: /api/v1/server-auth/device
+ * @description
+ * Route revokes the token of a device.
+ */
+export type RevokeServerAuthDevice_Variables = {
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Endpoint: /api/v1/server-auth/calendar-feed-token
+ * @description
+ * Route creates a token that can only be used to access the calendar feed.
+ */
+export type CreateServerAuthCalendarFeedToken_Variables = {
+    name: string
+}
+
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Endpoint: /api/v1/server-auth/password
+ * @description
+ * Route sets or removes the server password.
+ */
+export type SetServerPassword_Variables = {
+    currentPassword: string
+    newPassword: string
+    /**
+     *  Name of the current device when the password is set for the first time
+     *  
+     *  Name of the current device when the password is set for the first time
+     */
+    deviceName: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // settings
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1507,7 +1966,7 @@ export type TorrentClientAddMagnetFromRule_Variables = {
 export type SearchTorrent_Variables = {
     /**
      *  "smart" or "simple"
-     *
+     *  
      *  "smart" or "simple"
      */
     type?: string
@@ -1592,7 +2051,103 @@ export type GetTorrentstreamBatchHistory_Variables = {
     mediaId: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// watch_stats
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/watch_stats.go
+ * - Filename: watch_stats.go
+ * - Endpoint: /api/v1/watch-stats/year/{year}
+ * @description
+ * Route returns the year-in-review report.
+ */
+export type GetWatchStatsYearSummary_Variables = {
+    /**
+     *  The year, e.g. 2024
+     */
+    year: number
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// webhooks
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}
+ * @description
+ * Route updates a webhook.
+ */
+export type UpdateWebhook_Variables = {
+    /**
+     *  The ID of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}
+ * @description
+ * Route deletes a webhook and its delivery log.
+ */
+export type DeleteWebhook_Variables = {
+    /**
+     *  The ID of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}/test
+ * @description
+ * Route sends a ping event to a webhook.
+ */
+export type TestWebhook_Variables = {
+    /**
+     *  The ID of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/{id}/deliveries
+ * @description
+ * Route returns the delivery log of a webhook.
+ */
+export type GetWebhookDeliveries_Variables = {
+    /**
+     *  The ID of the webhook
+     */
+    id: number
+}
+
+/**
+ * - Filepath: internal/handlers/webhooks.go
+ * - Filename: webhooks.go
+ * - Endpoint: /api/v1/webhooks/deliveries/{id}/redeliver
+ * @description
+ * Route sends the payload of a previous delivery again.
+ */
+export type RedeliverWebhook_Variables = {
+    /**
+     *  The ID of the delivery
+     */
+    id: number
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // websocket
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// websocket_commands
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
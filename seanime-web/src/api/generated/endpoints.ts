@@ -36,6 +36,7 @@ export const API_ENDPOINTS = {
          *  This is used to edit an entry on AniList.
          *  The "type" field is used to determine if the entry is an anime or manga and refreshes the collection accordingly.
          *  The client should refetch collection-dependent queries after this mutation.
+         *  "customLists" replaces the custom lists of the entry, they are left unchanged if it is omitted.
          */
         EditAnilistListEntry: {
             key: "ANILIST-edit-anilist-list-entry",
@@ -354,12 +355,97 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/auto-downloader/item",
         },
     },
+    BACKUP: {
+        /**
+         *  @description
+         *  Route returns the backups stored in the backup directory.
+         *  The backups are sorted from newest to oldest.
+         */
+        GetBackups: {
+            key: "BACKUP-get-backups",
+            methods: ["GET"],
+            endpoint: "/api/v1/backups",
+        },
+        /**
+         *  @description
+         *  Route creates a backup in the backup directory.
+         *  The archive contains the database, the offline database, the watch history and the installed extensions.
+         *  The oldest backups are deleted if there are more than the maximum number of backups.
+         *  The archive can be downloaded with HandleDownloadBackup.
+         */
+        CreateBackup: {
+            key: "BACKUP-create-backup",
+            methods: ["POST"],
+            endpoint: "/api/v1/backups",
+        },
+        DownloadBackup: {
+            key: "BACKUP-download-backup",
+            methods: ["GET"],
+            endpoint: "/api/v1/backups/download/{name}",
+        },
+        DeleteBackup: {
+            key: "BACKUP-delete-backup",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/backups",
+        },
+        /**
+         *  @description
+         *  Route restores a backup.
+         *  The archive can be uploaded as a multipart form file named "file", or a backup from the backup directory can be selected by name.
+         *  The archive is validated and staged, it replaces the current data when Seanime is restarted.
+         *  Stored credentials can only be read back if the encryption key is the same as the one used when the backup was created.
+         *  'undecryptableCredentials' lists the credentials that were encrypted with another key, they will be empty after the restore and need to be entered again.
+         *  To keep them, copy the key file of the previous installation to the data directory (or set SEANIME_ENCRYPTION_KEY) before restoring.
+         */
+        RestoreBackup: {
+            key: "BACKUP-restore-backup",
+            methods: ["POST"],
+            endpoint: "/api/v1/backups/restore",
+        },
+        GetBackupSettings: {
+            key: "BACKUP-get-backup-settings",
+            methods: ["GET"],
+            endpoint: "/api/v1/backups/settings",
+        },
+        SaveBackupSettings: {
+            key: "BACKUP-save-backup-settings",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/backups/settings",
+        },
+    },
+    CALENDAR: {
+        /**
+         *  @description
+         *  Route returns the airing calendar of the anime being watched or planned.
+         *  'range' is either 'week' (default) or 'month', 'date' (YYYY-MM-DD) is any day in the range and defaults to today.
+         *  'timezone' is an IANA time zone name used to group the episodes by day, the server time zone is used by default.
+         *  Each episode is annotated with its local availability and auto downloader rule status.
+         */
+        GetCalendar: {
+            key: "CALENDAR-get-calendar",
+            methods: ["GET"],
+            endpoint: "/api/v1/calendar",
+        },
+        /**
+         *  @description
+         *  Route returns the airing calendar as an iCalendar feed.
+         *  The feed covers the previous week and the upcoming weeks, calendar apps can subscribe to it.
+         *  Calendar apps can't send headers, pass 'token' and 'profile' as query parameters instead.
+         *  'token' must be a calendar feed token created with /api/v1/server-auth/calendar-feed-token, device tokens are not accepted in the URL.
+         */
+        GetCalendarFeed: {
+            key: "CALENDAR-get-calendar-feed",
+            methods: ["GET"],
+            endpoint: "/api/v1/calendar/feed.ics",
+        },
+    },
     CONTINUITY: {
         /**
          *  @description
          *  Route Updates watch history item.
          *  This endpoint is used to update a watch history item.
          *  Since this is low priority, we ignore any errors.
+         *  The playback position of the online and media streams is also recorded in the watch stats.
          */
         UpdateContinuityWatchHistoryItem: {
             key: "CONTINUITY-update-continuity-watch-history-item",
@@ -522,6 +608,16 @@ export const API_ENDPOINTS = {
             methods: ["GET"],
             endpoint: "/api/v1/internal/docs",
         },
+        /**
+         *  @description
+         *  Route returns the OpenAPI 3 specification of the API.
+         *  The specification is generated by codegen and is not wrapped in the response envelope.
+         */
+        GetOpenAPISpec: {
+            key: "DOCS-get-open-a-p-i-spec",
+            methods: ["GET"],
+            endpoint: "/api/v1/internal/openapi.json",
+        },
     },
     DOWNLOAD: {
         DownloadTorrentFile: {
@@ -543,6 +639,31 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/download-release",
         },
     },
+    EVENT_STREAM: {
+        /**
+         *  @description
+         *  Route streams the server events using Server-Sent Events.
+         *  The "id" of each event is its sequence number in the event journal.
+         *  Clients resume from the last event they received with the "Last-Event-ID" header or the "lastEventId" query parameter.
+         *  The "types" query parameter is a comma-separated list of event types to receive, e.g. "scan-progress,playback-manager-*".
+         *  Only the events broadcast to all clients are streamed. The journal keeps the latest 1000 events.
+         */
+        GetEventStream: {
+            key: "EVENT-STREAM-get-event-stream",
+            methods: ["GET"],
+            endpoint: "/api/v1/events/stream",
+        },
+        /**
+         *  @description
+         *  Route returns the events recorded in the event journal after the given sequence number.
+         *  If "types" is empty, all events are returned. The journal keeps the latest 1000 events.
+         */
+        GetEventHistory: {
+            key: "EVENT-STREAM-get-event-history",
+            methods: ["POST"],
+            endpoint: "/api/v1/events/history",
+        },
+    },
     EXPLORER: {
         /**
          *  @description
@@ -670,6 +791,117 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/filecache/mediastream/videofiles",
         },
     },
+    KITSU: {
+        /**
+         *  @description
+         *  Route logs the user in to Kitsu.
+         *  This uses the OAuth password grant to fetch the access and refresh tokens.
+         *  It will save the info in the database, the password is not stored.
+         *  The client should re-fetch the server status after this.
+         */
+        KitsuLogin: {
+            key: "KITSU-kitsu-login",
+            methods: ["POST"],
+            endpoint: "/api/v1/kitsu/login",
+        },
+        /**
+         *  @description
+         *  Route logs the user out of Kitsu.
+         *  This will delete the Kitsu info from the database, effectively logging the user out.
+         *  The client should re-fetch the server status after this.
+         */
+        KitsuLogout: {
+            key: "KITSU-kitsu-logout",
+            methods: ["POST"],
+            endpoint: "/api/v1/kitsu/logout",
+        },
+    },
+    LIST_EXPORT: {
+        /**
+         *  @description
+         *  Route exports the collection as a file.
+         *  Supported formats are 'mal-xml' (MyAnimeList import file), 'json' and 'csv'.
+         *  The 'type' query parameter ('anime' or 'manga') is required for MyAnimeList exports, both collections are exported otherwise.
+         *  Entries without a MyAnimeList ID are reported in 'missingMalId' and left out of MyAnimeList exports.
+         */
+        ExportList: {
+            key: "LIST-EXPORT-export-list",
+            methods: ["GET"],
+            endpoint: "/api/v1/list-export",
+        },
+    },
+    LIST_IMPORT: {
+        /**
+         *  @description
+         *  Route returns the status of the list import of the current profile.
+         *  The preview is set once the entries are matched, until all of them are applied.
+         */
+        GetListImportStatus: {
+            key: "LIST-IMPORT-get-list-import-status",
+            methods: ["GET"],
+            endpoint: "/api/v1/list-import",
+        },
+        /**
+         *  @description
+         *  Route parses an exported list and starts mapping its entries to AniList media.
+         *  Supported formats are 'mal-xml' (MyAnimeList export), 'json' and 'csv'.
+         *  The entries are mapped in the background, the progress is sent with the 'list-import-status' event.
+         *  The preview can be fetched once the state is 'ready'. Nothing is added to the collection, the preview is kept until it is applied.
+         */
+        GetListImportPreview: {
+            key: "LIST-IMPORT-get-list-import-preview",
+            methods: ["POST"],
+            endpoint: "/api/v1/list-import/preview",
+        },
+        /**
+         *  @description
+         *  Route starts adding the entries of the import preview to the collection.
+         *  If 'mediaIds' is empty, all matched entries are added.
+         *  Entries already in the collection are skipped unless 'overwrite' is true.
+         *  The entries are added to the local collection when offline.
+         *  The entries are added in the background, the progress and the result are sent with the 'list-import-status' event.
+         *  Entries that could not be added are kept in the preview so that they can be applied again.
+         */
+        ApplyListImport: {
+            key: "LIST-IMPORT-apply-list-import",
+            methods: ["POST"],
+            endpoint: "/api/v1/list-import/apply",
+        },
+    },
+    LIST_SYNC: {
+        /**
+         *  @description
+         *  Route returns the changes a list synchronization would make.
+         *  The entries are matched by AniList ID, nothing is applied.
+         *  The 'origin' query parameter overrides the origin from the settings.
+         */
+        GetListSyncPreview: {
+            key: "LIST-SYNC-get-list-sync-preview",
+            methods: ["GET"],
+            endpoint: "/api/v1/list-sync/preview",
+        },
+        /**
+         *  @description
+         *  Route synchronizes the AniList and MyAnimeList lists.
+         *  Entries missing on a platform are added to it, entries that differ are resolved using the origin.
+         *  The origin from the settings is used if 'origin' is empty.
+         */
+        RunListSync: {
+            key: "LIST-SYNC-run-list-sync",
+            methods: ["POST"],
+            endpoint: "/api/v1/list-sync/sync",
+        },
+        /**
+         *  @description
+         *  Route updates the list sync settings.
+         *  When 'automatic' is true, entries are synchronized after they are updated and on a schedule.
+         */
+        SaveListSyncSettings: {
+            key: "LIST-SYNC-save-list-sync-settings",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/settings/list-sync",
+        },
+    },
     LOCALFILES: {
         /**
          *  @description
@@ -1113,14 +1345,116 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/metadata-provider/filler",
         },
     },
+    NOTIFICATION_CHANNELS: {
+        /**
+         *  @description
+         *  Route returns the notification channels.
+         *  The tokens and passwords are redacted.
+         */
+        GetNotificationChannels: {
+            key: "NOTIFICATION-CHANNELS-get-notification-channels",
+            methods: ["GET"],
+            endpoint: "/api/v1/notification-channels",
+        },
+        GetNotificationKinds: {
+            key: "NOTIFICATION-CHANNELS-get-notification-kinds",
+            methods: ["GET"],
+            endpoint: "/api/v1/notification-channels/kinds",
+        },
+        /**
+         *  @description
+         *  Route creates a notification channel.
+         *  If no kinds are selected, the channel receives all notifications.
+         *  The title and message templates use Go template syntax with the fields "Kind", "Message" and "Time".
+         */
+        CreateNotificationChannel: {
+            key: "NOTIFICATION-CHANNELS-create-notification-channel",
+            methods: ["POST"],
+            endpoint: "/api/v1/notification-channels",
+        },
+        /**
+         *  @description
+         *  Route updates a notification channel.
+         *  The stored credentials are kept if the redacted values are sent back.
+         */
+        UpdateNotificationChannel: {
+            key: "NOTIFICATION-CHANNELS-update-notification-channel",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/notification-channels/{id}",
+        },
+        DeleteNotificationChannel: {
+            key: "NOTIFICATION-CHANNELS-delete-notification-channel",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/notification-channels/{id}",
+        },
+        /**
+         *  @description
+         *  Route sends a test notification to a channel.
+         *  The request waits for the channel to respond and returns its error, if any. The rate limit of the channel is ignored.
+         */
+        TestNotificationChannel: {
+            key: "NOTIFICATION-CHANNELS-test-notification-channel",
+            methods: ["POST"],
+            endpoint: "/api/v1/notification-channels/{id}/test",
+        },
+    },
+    NOTIFICATIONS: {
+        /**
+         *  @description
+         *  Route returns the notifications stored in the inbox.
+         *  The notifications are sorted from newest to oldest.
+         *  "total" is the number of notifications matching the filter, "unreadCount" is the number of unread notifications in the inbox.
+         */
+        GetNotifications: {
+            key: "NOTIFICATIONS-get-notifications",
+            methods: ["POST"],
+            endpoint: "/api/v1/notifications/list",
+        },
+        GetUnreadNotificationCount: {
+            key: "NOTIFICATIONS-get-unread-notification-count",
+            methods: ["GET"],
+            endpoint: "/api/v1/notifications/unread-count",
+        },
+        /**
+         *  @description
+         *  Route marks the given notifications as read.
+         *  The new unread count is sent to the clients.
+         */
+        MarkNotificationsRead: {
+            key: "NOTIFICATIONS-mark-notifications-read",
+            methods: ["POST"],
+            endpoint: "/api/v1/notifications/read",
+        },
+        /**
+         *  @description
+         *  Route marks all notifications as read.
+         *  The new unread count is sent to the clients.
+         */
+        MarkAllNotificationsRead: {
+            key: "NOTIFICATIONS-mark-all-notifications-read",
+            methods: ["POST"],
+            endpoint: "/api/v1/notifications/read-all",
+        },
+        /**
+         *  @description
+         *  Route deletes the notifications older than the given number of days.
+         *  If "readOnly" is true, unread notifications are kept. A value of 0 days deletes all matching notifications.
+         *  Returns the number of deleted notifications.
+         */
+        PruneNotifications: {
+            key: "NOTIFICATIONS-prune-notifications",
+            methods: ["POST"],
+            endpoint: "/api/v1/notifications/prune",
+        },
+    },
     ONLINESTREAM: {
         /**
          *  @description
          *  Route returns the episode list for the given media and provider.
          *  It returns the episode list for the given media and provider.
          *  The episodes are cached using a file cache.
-         *  The episode list is just a list of episodes with no video sources, it's what the client uses to display the episodes and subsequently
-         *     fetch the sources. The episode list might be nil or empty if nothing could be found, but the media will always be returned.
+         *  The episode list is just a list of episodes with no video sources, it's what the client uses to display the episodes and subsequently fetch the sources.
+         *  The episode list might be nil or empty if nothing could be found, but the media will always be returned.
          */
         GetOnlineStreamEpisodeList: {
             key: "ONLINESTREAM-get-online-stream-episode-list",
@@ -1181,6 +1515,34 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/onlinestream/remove-mapping",
         },
     },
+    PLATFORM_MUTATIONS: {
+        /**
+         *  @description
+         *  Route returns the queued writes to the tracking platform.
+         *  Writes that failed are retried with a backoff, writes to the same media are collapsed.
+         *  Mutations that are still failing after the maximum number of attempts have the status "failed".
+         */
+        GetPlatformMutations: {
+            key: "PLATFORM-MUTATIONS-get-platform-mutations",
+            methods: ["GET"],
+            endpoint: "/api/v1/platform-mutations",
+        },
+        /**
+         *  @description
+         *  Route sends a queued write to the tracking platform right away.
+         *  The attempts are reset. If it fails again, it is retried with a backoff.
+         */
+        RetryPlatformMutation: {
+            key: "PLATFORM-MUTATIONS-retry-platform-mutation",
+            methods: ["POST"],
+            endpoint: "/api/v1/platform-mutations/{id}/retry",
+        },
+        DiscardPlatformMutation: {
+            key: "PLATFORM-MUTATIONS-discard-platform-mutation",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/platform-mutations/{id}",
+        },
+    },
     PLAYBACK_MANAGER: {
         /**
          *  @description
@@ -1338,6 +1700,92 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/playlist/episodes/{id}/{progress}",
         },
     },
+    PROFILE: {
+        /**
+         *  @description
+         *  Route returns all the profiles.
+         *  This route is accessible without selecting a profile so that the client can display the profile picker.
+         */
+        GetProfiles: {
+            key: "PROFILE-get-profiles",
+            methods: ["GET"],
+            endpoint: "/api/v1/profiles",
+        },
+        GetCurrentProfile: {
+            key: "PROFILE-get-current-profile",
+            methods: ["GET"],
+            endpoint: "/api/v1/profiles/current",
+        },
+        /**
+         *  @description
+         *  Route creates a new profile.
+         *  The PIN is optional.
+         */
+        CreateProfile: {
+            key: "PROFILE-create-profile",
+            methods: ["POST"],
+            endpoint: "/api/v1/profiles",
+        },
+        /**
+         *  @description
+         *  Route updates the current profile.
+         *  The name and Discord settings are only updated if they are provided.
+         *  The Discord settings are ignored for the default profile, which uses the ones from the settings.
+         */
+        UpdateProfile: {
+            key: "PROFILE-update-profile",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/profiles",
+        },
+        /**
+         *  @description
+         *  Route sets or removes the PIN of the current profile.
+         *  An empty PIN removes it. Other clients using the profile will need to select it again.
+         *  The new profile token is returned and set as a cookie.
+         */
+        SetProfilePin: {
+            key: "PROFILE-set-profile-pin",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/profiles/pin",
+        },
+        /**
+         *  @description
+         *  Route deletes a profile and its data.
+         *  The PIN of the profile is required if it has one.
+         *  The default profile cannot be deleted.
+         */
+        DeleteProfile: {
+            key: "PROFILE-delete-profile",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/profiles",
+        },
+        /**
+         *  @description
+         *  Route selects the profile used by the client.
+         *  The PIN is required if the profile has one.
+         *  It returns a token that should be sent with each request, the token is also set as a cookie.
+         */
+        SelectProfile: {
+            key: "PROFILE-select-profile",
+            methods: ["POST"],
+            endpoint: "/api/v1/profiles/select",
+        },
+    },
+    RECOMMENDATION: {
+        /**
+         *  @description
+         *  Route returns anime or manga recommendations based on the collection.
+         *  Candidates are the AniList recommendations and follow-ups of the completed and high-scored entries, and the planned entries.
+         *  They are scored against the genres, tags and studios of these entries. Each recommendation comes with the reasons behind it.
+         *  'type' is either 'anime' (default) or 'manga'. 'format', 'season' and 'seasonYear' filter the results, e.g. 'TV', 'WINTER' and '2024'.
+         *  The ranking is cached for a few hours, 'refresh=true' computes it again.
+         */
+        GetRecommendations: {
+            key: "RECOMMENDATION-get-recommendations",
+            methods: ["GET"],
+            endpoint: "/api/v1/recommendations",
+        },
+    },
     RELEASES: {
         /**
          *  @description
@@ -1381,6 +1829,123 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/library/scan-summaries",
         },
     },
+    SCHEDULER: {
+        /**
+         *  @description
+         *  Route returns the background jobs.
+         *  Each job contains its schedule, its last run and its next run.
+         *  The next run is not set if the job is disabled or cannot run in offline mode.
+         */
+        GetScheduledJobs: {
+            key: "SCHEDULER-get-scheduled-jobs",
+            methods: ["GET"],
+            endpoint: "/api/v1/scheduler/jobs",
+        },
+        /**
+         *  @description
+         *  Route updates the schedule of a background job.
+         *  The schedule can be a cron expression (e.g. "0 */6 * * *"), a descriptor (e.g. "@daily", "@every 1h") or an interval (e.g. "30m").
+         *  An empty schedule resets the job to its default schedule.
+         */
+        UpdateScheduledJob: {
+            key: "SCHEDULER-update-scheduled-job",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/scheduler/jobs/{name}",
+        },
+        /**
+         *  @description
+         *  Route runs a background job immediately.
+         *  The job runs in the background, even if it is disabled.
+         *  The client should re-fetch the jobs to see the result of the run.
+         */
+        RunScheduledJob: {
+            key: "SCHEDULER-run-scheduled-job",
+            methods: ["POST"],
+            endpoint: "/api/v1/scheduler/jobs/{name}/run",
+        },
+        /**
+         *  @description
+         *  Route returns the latest runs of a background job.
+         *  The runs are sorted from newest to oldest.
+         */
+        GetScheduledJobRuns: {
+            key: "SCHEDULER-get-scheduled-job-runs",
+            methods: ["GET"],
+            endpoint: "/api/v1/scheduler/jobs/{name}/runs",
+        },
+    },
+    SERVER_AUTH: {
+        /**
+         *  @description
+         *  Route returns the server authentication status.
+         *  This route is accessible without a token so that the client knows if it needs to log in.
+         */
+        GetServerAuthStatus: {
+            key: "SERVER-AUTH-get-server-auth-status",
+            methods: ["GET"],
+            endpoint: "/api/v1/server-auth/status",
+        },
+        /**
+         *  @description
+         *  Route logs in a device using the server password.
+         *  It returns a token that should be sent with each request.
+         *  The token is also set as a cookie so that the web interface does not need to handle it.
+         */
+        ServerAuthLogin: {
+            key: "SERVER-AUTH-server-auth-login",
+            methods: ["POST"],
+            endpoint: "/api/v1/server-auth/login",
+        },
+        /**
+         *  @description
+         *  Route logs out the current device.
+         *  The device's token is revoked and the cookie is cleared.
+         */
+        ServerAuthLogout: {
+            key: "SERVER-AUTH-server-auth-logout",
+            methods: ["POST"],
+            endpoint: "/api/v1/server-auth/logout",
+        },
+        GetServerAuthDevices: {
+            key: "SERVER-AUTH-get-server-auth-devices",
+            methods: ["GET"],
+            endpoint: "/api/v1/server-auth/devices",
+        },
+        /**
+         *  @description
+         *  Route revokes the token of a device.
+         *  The device will need to log in again.
+         */
+        RevokeServerAuthDevice: {
+            key: "SERVER-AUTH-revoke-server-auth-device",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/server-auth/device",
+        },
+        /**
+         *  @description
+         *  Route creates a token that can only be used to access the calendar feed.
+         *  The token is meant to be added to the feed's URL as the 'token' query parameter, it does not give access to any other route.
+         *  It is listed with the devices and can be revoked the same way.
+         */
+        CreateServerAuthCalendarFeedToken: {
+            key: "SERVER-AUTH-create-server-auth-calendar-feed-token",
+            methods: ["POST"],
+            endpoint: "/api/v1/server-auth/calendar-feed-token",
+        },
+        /**
+         *  @description
+         *  Route sets or removes the server password.
+         *  An empty password disables password protection.
+         *  All other devices are logged out when the password changes.
+         *  When the password is set for the first time, the current device is logged in and its token is returned.
+         *  This will fail if the password is defined in the config file.
+         */
+        SetServerPassword: {
+            key: "SERVER-AUTH-set-server-password",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/server-auth/password",
+        },
+    },
     SETTINGS: {
         GetSettings: {
             key: "SETTINGS-get-settings",
@@ -1672,5 +2237,125 @@ export const API_ENDPOINTS = {
             endpoint: "/api/v1/torrentstream/batch-history",
         },
     },
+    WATCH_STATS: {
+        /**
+         *  @description
+         *  Route returns the time watched per day or week.
+         *  'interval' is either 'day' (default) or 'week', weeks start on Monday.
+         *  'from' and 'to' (YYYY-MM-DD) are the first and last days included, the last 30 days or 12 weeks are returned by default.
+         *  'timezone' is an IANA time zone name used to group the sessions by day, the server time zone is used by default.
+         */
+        GetWatchStatsActivity: {
+            key: "WATCH-STATS-get-watch-stats-activity",
+            methods: ["GET"],
+            endpoint: "/api/v1/watch-stats/activity",
+        },
+        /**
+         *  @description
+         *  Route returns the current and longest watching streaks.
+         *  A streak is a run of consecutive days with at least one playback session.
+         *  'timezone' is an IANA time zone name, the server time zone is used by default.
+         */
+        GetWatchStatsStreaks: {
+            key: "WATCH-STATS-get-watch-stats-streaks",
+            methods: ["GET"],
+            endpoint: "/api/v1/watch-stats/streaks",
+        },
+        /**
+         *  @description
+         *  Route returns the most-watched anime, genres and studios.
+         *  'from' and 'to' (YYYY-MM-DD) are the first and last days included, all the sessions are used by default.
+         *  'limit' is the number of items in each list, 10 by default.
+         */
+        GetWatchStatsTop: {
+            key: "WATCH-STATS-get-watch-stats-top",
+            methods: ["GET"],
+            endpoint: "/api/v1/watch-stats/top",
+        },
+        /**
+         *  @description
+         *  Route returns the year-in-review report.
+         *  The report contains the time watched per month and per source, the busiest day, the longest streak and the most-watched anime, genres and studios.
+         *  'timezone' is an IANA time zone name, the server time zone is used by default.
+         */
+        GetWatchStatsYearSummary: {
+            key: "WATCH-STATS-get-watch-stats-year-summary",
+            methods: ["GET"],
+            endpoint: "/api/v1/watch-stats/year/{year}",
+        },
+    },
+    WEBHOOKS: {
+        /**
+         *  @description
+         *  Route returns the webhooks.
+         *  The secrets are redacted.
+         */
+        GetWebhooks: {
+            key: "WEBHOOKS-get-webhooks",
+            methods: ["GET"],
+            endpoint: "/api/v1/webhooks",
+        },
+        GetWebhookEvents: {
+            key: "WEBHOOKS-get-webhook-events",
+            methods: ["GET"],
+            endpoint: "/api/v1/webhooks/events",
+        },
+        /**
+         *  @description
+         *  Route creates a webhook.
+         *  If no events are selected, the webhook receives all events.
+         *  The payloads are signed with HMAC-SHA256 using the secret, the signature is sent in the "X-Seanime-Signature" header.
+         */
+        CreateWebhook: {
+            key: "WEBHOOKS-create-webhook",
+            methods: ["POST"],
+            endpoint: "/api/v1/webhooks",
+        },
+        /**
+         *  @description
+         *  Route updates a webhook.
+         *  The stored secret is kept if the redacted secret is sent back.
+         */
+        UpdateWebhook: {
+            key: "WEBHOOKS-update-webhook",
+            methods: ["PATCH"],
+            endpoint: "/api/v1/webhooks/{id}",
+        },
+        DeleteWebhook: {
+            key: "WEBHOOKS-delete-webhook",
+            methods: ["DELETE"],
+            endpoint: "/api/v1/webhooks/{id}",
+        },
+        /**
+         *  @description
+         *  Route sends a ping event to a webhook.
+         *  The request waits for the response of the webhook. Failed test deliveries are not retried.
+         */
+        TestWebhook: {
+            key: "WEBHOOKS-test-webhook",
+            methods: ["POST"],
+            endpoint: "/api/v1/webhooks/{id}/test",
+        },
+        /**
+         *  @description
+         *  Route returns the delivery log of a webhook.
+         *  The deliveries are sorted from newest to oldest.
+         */
+        GetWebhookDeliveries: {
+            key: "WEBHOOKS-get-webhook-deliveries",
+            methods: ["GET"],
+            endpoint: "/api/v1/webhooks/{id}/deliveries",
+        },
+        /**
+         *  @description
+         *  Route sends the payload of a previous delivery again.
+         *  A new delivery is added to the log. The client should re-fetch the deliveries to see the result.
+         */
+        RedeliverWebhook: {
+            key: "WEBHOOKS-redeliver-webhook",
+            methods: ["POST"],
+            endpoint: "/api/v1/webhooks/deliveries/{id}/redeliver",
+        },
+    },
 } satisfies ApiEndpoints
 
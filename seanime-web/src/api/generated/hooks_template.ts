@@ -375,6 +375,103 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// backup
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetBackups() {
+//     return useServerQuery<Array<BackupFile>>({
+//         endpoint: API_ENDPOINTS.BACKUP.GetBackups.endpoint,
+//         method: API_ENDPOINTS.BACKUP.GetBackups.methods[0],
+//         queryKey: [API_ENDPOINTS.BACKUP.GetBackups.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useCreateBackup() {
+//     return useServerMutation<BackupFile>({
+//         endpoint: API_ENDPOINTS.BACKUP.CreateBackup.endpoint,
+//         method: API_ENDPOINTS.BACKUP.CreateBackup.methods[0],
+//         mutationKey: [API_ENDPOINTS.BACKUP.CreateBackup.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDownloadBackup(name: string) {
+//     return useServerQuery<boolean>({
+//         endpoint: API_ENDPOINTS.BACKUP.DownloadBackup.endpoint.replace("{name}", String(name)),
+//         method: API_ENDPOINTS.BACKUP.DownloadBackup.methods[0],
+//         queryKey: [API_ENDPOINTS.BACKUP.DownloadBackup.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useDeleteBackup() {
+//     return useServerMutation<Array<BackupFile>, DeleteBackup_Variables>({
+//         endpoint: API_ENDPOINTS.BACKUP.DeleteBackup.endpoint,
+//         method: API_ENDPOINTS.BACKUP.DeleteBackup.methods[0],
+//         mutationKey: [API_ENDPOINTS.BACKUP.DeleteBackup.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useRestoreBackup() {
+//     return useServerMutation<RestoreResult, RestoreBackup_Variables>({
+//         endpoint: API_ENDPOINTS.BACKUP.RestoreBackup.endpoint,
+//         method: API_ENDPOINTS.BACKUP.RestoreBackup.methods[0],
+//         mutationKey: [API_ENDPOINTS.BACKUP.RestoreBackup.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetBackupSettings() {
+//     return useServerQuery<Models_BackupSettings>({
+//         endpoint: API_ENDPOINTS.BACKUP.GetBackupSettings.endpoint,
+//         method: API_ENDPOINTS.BACKUP.GetBackupSettings.methods[0],
+//         queryKey: [API_ENDPOINTS.BACKUP.GetBackupSettings.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useSaveBackupSettings() {
+//     return useServerMutation<Models_BackupSettings, SaveBackupSettings_Variables>({
+//         endpoint: API_ENDPOINTS.BACKUP.SaveBackupSettings.endpoint,
+//         method: API_ENDPOINTS.BACKUP.SaveBackupSettings.methods[0],
+//         mutationKey: [API_ENDPOINTS.BACKUP.SaveBackupSettings.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// calendar
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetCalendar() {
+//     return useServerQuery<View>({
+//         endpoint: API_ENDPOINTS.CALENDAR.GetCalendar.endpoint,
+//         method: API_ENDPOINTS.CALENDAR.GetCalendar.methods[0],
+//         queryKey: [API_ENDPOINTS.CALENDAR.GetCalendar.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetCalendarFeed() {
+//     return useServerQuery<string>({
+//         endpoint: API_ENDPOINTS.CALENDAR.GetCalendarFeed.endpoint,
+//         method: API_ENDPOINTS.CALENDAR.GetCalendarFeed.methods[0],
+//         queryKey: [API_ENDPOINTS.CALENDAR.GetCalendarFeed.key],
+//         enabled: true,
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // continuity
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -572,6 +669,15 @@
 //     })
 // }
 
+// export function useGetOpenAPISpec() {
+//     return useServerQuery<string>({
+//         endpoint: API_ENDPOINTS.DOCS.GetOpenAPISpec.endpoint,
+//         method: API_ENDPOINTS.DOCS.GetOpenAPISpec.methods[0],
+//         queryKey: [API_ENDPOINTS.DOCS.GetOpenAPISpec.key],
+//         enabled: true,
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // download
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -598,6 +704,30 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// event_stream
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetEventStream() {
+//     return useServerQuery<string>({
+//         endpoint: API_ENDPOINTS.EVENT_STREAM.GetEventStream.endpoint,
+//         method: API_ENDPOINTS.EVENT_STREAM.GetEventStream.methods[0],
+//         queryKey: [API_ENDPOINTS.EVENT_STREAM.GetEventStream.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetEventHistory() {
+//     return useServerMutation<Array<Events_JournalEntry>, GetEventHistory_Variables>({
+//         endpoint: API_ENDPOINTS.EVENT_STREAM.GetEventHistory.endpoint,
+//         method: API_ENDPOINTS.EVENT_STREAM.GetEventHistory.methods[0],
+//         mutationKey: [API_ENDPOINTS.EVENT_STREAM.GetEventHistory.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // explorer
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -730,9 +860,9 @@
 //     })
 // }
 
-// export function useGetExtensionUserConfig() {
+// export function useGetExtensionUserConfig(id: string) {
 //     return useServerQuery<ExtensionRepo_ExtensionUserConfig>({
-//         endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionUserConfig.endpoint,
+//         endpoint: API_ENDPOINTS.EXTENSIONS.GetExtensionUserConfig.endpoint.replace("{id}", String(id)),
 //         method: API_ENDPOINTS.EXTENSIONS.GetExtensionUserConfig.methods[0],
 //         queryKey: [API_ENDPOINTS.EXTENSIONS.GetExtensionUserConfig.key],
 //         enabled: true,
@@ -794,6 +924,115 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// kitsu
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useKitsuLogin() {
+//     return useServerMutation<User, KitsuLogin_Variables>({
+//         endpoint: API_ENDPOINTS.KITSU.KitsuLogin.endpoint,
+//         method: API_ENDPOINTS.KITSU.KitsuLogin.methods[0],
+//         mutationKey: [API_ENDPOINTS.KITSU.KitsuLogin.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useKitsuLogout() {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.KITSU.KitsuLogout.endpoint,
+//         method: API_ENDPOINTS.KITSU.KitsuLogout.methods[0],
+//         mutationKey: [API_ENDPOINTS.KITSU.KitsuLogout.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// list_export
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useExportList() {
+//     return useServerQuery<ExportResult>({
+//         endpoint: API_ENDPOINTS.LIST_EXPORT.ExportList.endpoint,
+//         method: API_ENDPOINTS.LIST_EXPORT.ExportList.methods[0],
+//         queryKey: [API_ENDPOINTS.LIST_EXPORT.ExportList.key],
+//         enabled: true,
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// list_import
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetListImportStatus() {
+//     return useServerQuery<Status>({
+//         endpoint: API_ENDPOINTS.LIST_IMPORT.GetListImportStatus.endpoint,
+//         method: API_ENDPOINTS.LIST_IMPORT.GetListImportStatus.methods[0],
+//         queryKey: [API_ENDPOINTS.LIST_IMPORT.GetListImportStatus.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetListImportPreview() {
+//     return useServerMutation<Status, GetListImportPreview_Variables>({
+//         endpoint: API_ENDPOINTS.LIST_IMPORT.GetListImportPreview.endpoint,
+//         method: API_ENDPOINTS.LIST_IMPORT.GetListImportPreview.methods[0],
+//         mutationKey: [API_ENDPOINTS.LIST_IMPORT.GetListImportPreview.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useApplyListImport() {
+//     return useServerMutation<Status, ApplyListImport_Variables>({
+//         endpoint: API_ENDPOINTS.LIST_IMPORT.ApplyListImport.endpoint,
+//         method: API_ENDPOINTS.LIST_IMPORT.ApplyListImport.methods[0],
+//         mutationKey: [API_ENDPOINTS.LIST_IMPORT.ApplyListImport.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// list_sync
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetListSyncPreview() {
+//     return useServerQuery<ListSync_Result>({
+//         endpoint: API_ENDPOINTS.LIST_SYNC.GetListSyncPreview.endpoint,
+//         method: API_ENDPOINTS.LIST_SYNC.GetListSyncPreview.methods[0],
+//         queryKey: [API_ENDPOINTS.LIST_SYNC.GetListSyncPreview.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRunListSync() {
+//     return useServerMutation<ListSync_Result, RunListSync_Variables>({
+//         endpoint: API_ENDPOINTS.LIST_SYNC.RunListSync.endpoint,
+//         method: API_ENDPOINTS.LIST_SYNC.RunListSync.methods[0],
+//         mutationKey: [API_ENDPOINTS.LIST_SYNC.RunListSync.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useSaveListSyncSettings() {
+//     return useServerMutation<boolean, SaveListSyncSettings_Variables>({
+//         endpoint: API_ENDPOINTS.LIST_SYNC.SaveListSyncSettings.endpoint,
+//         method: API_ENDPOINTS.LIST_SYNC.SaveListSyncSettings.methods[0],
+//         mutationKey: [API_ENDPOINTS.LIST_SYNC.SaveListSyncSettings.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // localfiles
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1312,6 +1551,129 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// notification_channels
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetNotificationChannels() {
+//     return useServerQuery<Array<Models_NotificationChannel>>({
+//         endpoint: API_ENDPOINTS.NOTIFICATION_CHANNELS.GetNotificationChannels.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATION_CHANNELS.GetNotificationChannels.methods[0],
+//         queryKey: [API_ENDPOINTS.NOTIFICATION_CHANNELS.GetNotificationChannels.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetNotificationKinds() {
+//     return useServerQuery<Array<Notification>>({
+//         endpoint: API_ENDPOINTS.NOTIFICATION_CHANNELS.GetNotificationKinds.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATION_CHANNELS.GetNotificationKinds.methods[0],
+//         queryKey: [API_ENDPOINTS.NOTIFICATION_CHANNELS.GetNotificationKinds.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useCreateNotificationChannel() {
+//     return useServerMutation<Models_NotificationChannel>({
+//         endpoint: API_ENDPOINTS.NOTIFICATION_CHANNELS.CreateNotificationChannel.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATION_CHANNELS.CreateNotificationChannel.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATION_CHANNELS.CreateNotificationChannel.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useUpdateNotificationChannel(id: number) {
+//     return useServerMutation<Models_NotificationChannel>({
+//         endpoint: API_ENDPOINTS.NOTIFICATION_CHANNELS.UpdateNotificationChannel.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.NOTIFICATION_CHANNELS.UpdateNotificationChannel.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATION_CHANNELS.UpdateNotificationChannel.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDeleteNotificationChannel(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.NOTIFICATION_CHANNELS.DeleteNotificationChannel.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.NOTIFICATION_CHANNELS.DeleteNotificationChannel.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATION_CHANNELS.DeleteNotificationChannel.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useTestNotificationChannel(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.NOTIFICATION_CHANNELS.TestNotificationChannel.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.NOTIFICATION_CHANNELS.TestNotificationChannel.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATION_CHANNELS.TestNotificationChannel.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// notifications
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetNotifications() {
+//     return useServerMutation<NotificationInbox, GetNotifications_Variables>({
+//         endpoint: API_ENDPOINTS.NOTIFICATIONS.GetNotifications.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATIONS.GetNotifications.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATIONS.GetNotifications.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetUnreadNotificationCount() {
+//     return useServerQuery<number>({
+//         endpoint: API_ENDPOINTS.NOTIFICATIONS.GetUnreadNotificationCount.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATIONS.GetUnreadNotificationCount.methods[0],
+//         queryKey: [API_ENDPOINTS.NOTIFICATIONS.GetUnreadNotificationCount.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useMarkNotificationsRead() {
+//     return useServerMutation<boolean, MarkNotificationsRead_Variables>({
+//         endpoint: API_ENDPOINTS.NOTIFICATIONS.MarkNotificationsRead.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATIONS.MarkNotificationsRead.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATIONS.MarkNotificationsRead.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useMarkAllNotificationsRead() {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.NOTIFICATIONS.MarkAllNotificationsRead.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATIONS.MarkAllNotificationsRead.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATIONS.MarkAllNotificationsRead.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function usePruneNotifications() {
+//     return useServerMutation<number, PruneNotifications_Variables>({
+//         endpoint: API_ENDPOINTS.NOTIFICATIONS.PruneNotifications.endpoint,
+//         method: API_ENDPOINTS.NOTIFICATIONS.PruneNotifications.methods[0],
+//         mutationKey: [API_ENDPOINTS.NOTIFICATIONS.PruneNotifications.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // onlinestream
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1393,6 +1755,41 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// platform_mutations
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetPlatformMutations() {
+//     return useServerQuery<Array<Models_PlatformMutation>>({
+//         endpoint: API_ENDPOINTS.PLATFORM_MUTATIONS.GetPlatformMutations.endpoint,
+//         method: API_ENDPOINTS.PLATFORM_MUTATIONS.GetPlatformMutations.methods[0],
+//         queryKey: [API_ENDPOINTS.PLATFORM_MUTATIONS.GetPlatformMutations.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRetryPlatformMutation(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.PLATFORM_MUTATIONS.RetryPlatformMutation.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.PLATFORM_MUTATIONS.RetryPlatformMutation.methods[0],
+//         mutationKey: [API_ENDPOINTS.PLATFORM_MUTATIONS.RetryPlatformMutation.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDiscardPlatformMutation(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.PLATFORM_MUTATIONS.DiscardPlatformMutation.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.PLATFORM_MUTATIONS.DiscardPlatformMutation.methods[0],
+//         mutationKey: [API_ENDPOINTS.PLATFORM_MUTATIONS.DiscardPlatformMutation.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // playback_manager
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1571,6 +1968,96 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// profile
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetProfiles() {
+//     return useServerQuery<Array<Models_Profile>>({
+//         endpoint: API_ENDPOINTS.PROFILE.GetProfiles.endpoint,
+//         method: API_ENDPOINTS.PROFILE.GetProfiles.methods[0],
+//         queryKey: [API_ENDPOINTS.PROFILE.GetProfiles.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetCurrentProfile() {
+//     return useServerQuery<Models_Profile>({
+//         endpoint: API_ENDPOINTS.PROFILE.GetCurrentProfile.endpoint,
+//         method: API_ENDPOINTS.PROFILE.GetCurrentProfile.methods[0],
+//         queryKey: [API_ENDPOINTS.PROFILE.GetCurrentProfile.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useCreateProfile() {
+//     return useServerMutation<Models_Profile, CreateProfile_Variables>({
+//         endpoint: API_ENDPOINTS.PROFILE.CreateProfile.endpoint,
+//         method: API_ENDPOINTS.PROFILE.CreateProfile.methods[0],
+//         mutationKey: [API_ENDPOINTS.PROFILE.CreateProfile.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useUpdateProfile() {
+//     return useServerMutation<Models_Profile, UpdateProfile_Variables>({
+//         endpoint: API_ENDPOINTS.PROFILE.UpdateProfile.endpoint,
+//         method: API_ENDPOINTS.PROFILE.UpdateProfile.methods[0],
+//         mutationKey: [API_ENDPOINTS.PROFILE.UpdateProfile.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useSetProfilePin() {
+//     return useServerMutation<ProfileSelection, SetProfilePin_Variables>({
+//         endpoint: API_ENDPOINTS.PROFILE.SetProfilePin.endpoint,
+//         method: API_ENDPOINTS.PROFILE.SetProfilePin.methods[0],
+//         mutationKey: [API_ENDPOINTS.PROFILE.SetProfilePin.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDeleteProfile() {
+//     return useServerMutation<Array<Models_Profile>, DeleteProfile_Variables>({
+//         endpoint: API_ENDPOINTS.PROFILE.DeleteProfile.endpoint,
+//         method: API_ENDPOINTS.PROFILE.DeleteProfile.methods[0],
+//         mutationKey: [API_ENDPOINTS.PROFILE.DeleteProfile.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useSelectProfile() {
+//     return useServerMutation<ProfileSelection, SelectProfile_Variables>({
+//         endpoint: API_ENDPOINTS.PROFILE.SelectProfile.endpoint,
+//         method: API_ENDPOINTS.PROFILE.SelectProfile.methods[0],
+//         mutationKey: [API_ENDPOINTS.PROFILE.SelectProfile.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// recommendation
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetRecommendations() {
+//     return useServerQuery<Array<Recommendation>>({
+//         endpoint: API_ENDPOINTS.RECOMMENDATION.GetRecommendations.endpoint,
+//         method: API_ENDPOINTS.RECOMMENDATION.GetRecommendations.methods[0],
+//         queryKey: [API_ENDPOINTS.RECOMMENDATION.GetRecommendations.key],
+//         enabled: true,
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // releases
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1623,6 +2110,127 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// scheduler
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetScheduledJobs() {
+//     return useServerQuery<Array<JobStatus>>({
+//         endpoint: API_ENDPOINTS.SCHEDULER.GetScheduledJobs.endpoint,
+//         method: API_ENDPOINTS.SCHEDULER.GetScheduledJobs.methods[0],
+//         queryKey: [API_ENDPOINTS.SCHEDULER.GetScheduledJobs.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useUpdateScheduledJob(name: string) {
+//     return useServerMutation<JobStatus, UpdateScheduledJob_Variables>({
+//         endpoint: API_ENDPOINTS.SCHEDULER.UpdateScheduledJob.endpoint.replace("{name}", String(name)),
+//         method: API_ENDPOINTS.SCHEDULER.UpdateScheduledJob.methods[0],
+//         mutationKey: [API_ENDPOINTS.SCHEDULER.UpdateScheduledJob.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useRunScheduledJob(name: string) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.SCHEDULER.RunScheduledJob.endpoint.replace("{name}", String(name)),
+//         method: API_ENDPOINTS.SCHEDULER.RunScheduledJob.methods[0],
+//         mutationKey: [API_ENDPOINTS.SCHEDULER.RunScheduledJob.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetScheduledJobRuns(name: string) {
+//     return useServerQuery<Array<Models_ScheduledJobRun>>({
+//         endpoint: API_ENDPOINTS.SCHEDULER.GetScheduledJobRuns.endpoint.replace("{name}", String(name)),
+//         method: API_ENDPOINTS.SCHEDULER.GetScheduledJobRuns.methods[0],
+//         queryKey: [API_ENDPOINTS.SCHEDULER.GetScheduledJobRuns.key],
+//         enabled: true,
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// server_auth
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetServerAuthStatus() {
+//     return useServerQuery<ServerAuthStatus>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.GetServerAuthStatus.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.GetServerAuthStatus.methods[0],
+//         queryKey: [API_ENDPOINTS.SERVER_AUTH.GetServerAuthStatus.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useServerAuthLogin() {
+//     return useServerMutation<ServerAuthLoginResponse, ServerAuthLogin_Variables>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.ServerAuthLogin.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.ServerAuthLogin.methods[0],
+//         mutationKey: [API_ENDPOINTS.SERVER_AUTH.ServerAuthLogin.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useServerAuthLogout() {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.ServerAuthLogout.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.ServerAuthLogout.methods[0],
+//         mutationKey: [API_ENDPOINTS.SERVER_AUTH.ServerAuthLogout.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetServerAuthDevices() {
+//     return useServerQuery<Array<Models_AuthDevice>>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.GetServerAuthDevices.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.GetServerAuthDevices.methods[0],
+//         queryKey: [API_ENDPOINTS.SERVER_AUTH.GetServerAuthDevices.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRevokeServerAuthDevice() {
+//     return useServerMutation<Array<Models_AuthDevice>, RevokeServerAuthDevice_Variables>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.RevokeServerAuthDevice.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.RevokeServerAuthDevice.methods[0],
+//         mutationKey: [API_ENDPOINTS.SERVER_AUTH.RevokeServerAuthDevice.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useCreateServerAuthCalendarFeedToken() {
+//     return useServerMutation<ServerAuthCalendarFeedTokenResponse, CreateServerAuthCalendarFeedToken_Variables>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.CreateServerAuthCalendarFeedToken.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.CreateServerAuthCalendarFeedToken.methods[0],
+//         mutationKey: [API_ENDPOINTS.SERVER_AUTH.CreateServerAuthCalendarFeedToken.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useSetServerPassword() {
+//     return useServerMutation<ServerAuthStatus, SetServerPassword_Variables>({
+//         endpoint: API_ENDPOINTS.SERVER_AUTH.SetServerPassword.endpoint,
+//         method: API_ENDPOINTS.SERVER_AUTH.SetServerPassword.methods[0],
+//         mutationKey: [API_ENDPOINTS.SERVER_AUTH.SetServerPassword.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // settings
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1979,3 +2587,129 @@
 //     })
 // }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// watch_stats
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetWatchStatsActivity() {
+//     return useServerQuery<Activity>({
+//         endpoint: API_ENDPOINTS.WATCH_STATS.GetWatchStatsActivity.endpoint,
+//         method: API_ENDPOINTS.WATCH_STATS.GetWatchStatsActivity.methods[0],
+//         queryKey: [API_ENDPOINTS.WATCH_STATS.GetWatchStatsActivity.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetWatchStatsStreaks() {
+//     return useServerQuery<Streaks>({
+//         endpoint: API_ENDPOINTS.WATCH_STATS.GetWatchStatsStreaks.endpoint,
+//         method: API_ENDPOINTS.WATCH_STATS.GetWatchStatsStreaks.methods[0],
+//         queryKey: [API_ENDPOINTS.WATCH_STATS.GetWatchStatsStreaks.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetWatchStatsTop() {
+//     return useServerQuery<Top>({
+//         endpoint: API_ENDPOINTS.WATCH_STATS.GetWatchStatsTop.endpoint,
+//         method: API_ENDPOINTS.WATCH_STATS.GetWatchStatsTop.methods[0],
+//         queryKey: [API_ENDPOINTS.WATCH_STATS.GetWatchStatsTop.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetWatchStatsYearSummary(year: number) {
+//     return useServerQuery<YearSummary>({
+//         endpoint: API_ENDPOINTS.WATCH_STATS.GetWatchStatsYearSummary.endpoint.replace("{year}", String(year)),
+//         method: API_ENDPOINTS.WATCH_STATS.GetWatchStatsYearSummary.methods[0],
+//         queryKey: [API_ENDPOINTS.WATCH_STATS.GetWatchStatsYearSummary.key],
+//         enabled: true,
+//     })
+// }
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// webhooks
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// export function useGetWebhooks() {
+//     return useServerQuery<Array<Models_Webhook>>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhooks.endpoint,
+//         method: API_ENDPOINTS.WEBHOOKS.GetWebhooks.methods[0],
+//         queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhooks.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useGetWebhookEvents() {
+//     return useServerQuery<Array<Event>>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.endpoint,
+//         method: API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.methods[0],
+//         queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookEvents.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useCreateWebhook() {
+//     return useServerMutation<Models_Webhook>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.CreateWebhook.endpoint,
+//         method: API_ENDPOINTS.WEBHOOKS.CreateWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.CreateWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useUpdateWebhook(id: number) {
+//     return useServerMutation<Models_Webhook>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.UpdateWebhook.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.UpdateWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.UpdateWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useDeleteWebhook(id: number) {
+//     return useServerMutation<boolean>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.DeleteWebhook.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.DeleteWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.DeleteWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useTestWebhook(id: number) {
+//     return useServerMutation<Models_WebhookDelivery>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.TestWebhook.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.TestWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.TestWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
+// export function useGetWebhookDeliveries(id: number) {
+//     return useServerQuery<Array<Models_WebhookDelivery>>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.methods[0],
+//         queryKey: [API_ENDPOINTS.WEBHOOKS.GetWebhookDeliveries.key],
+//         enabled: true,
+//     })
+// }
+
+// export function useRedeliverWebhook(id: number) {
+//     return useServerMutation<Models_WebhookDelivery>({
+//         endpoint: API_ENDPOINTS.WEBHOOKS.RedeliverWebhook.endpoint.replace("{id}", String(id)),
+//         method: API_ENDPOINTS.WEBHOOKS.RedeliverWebhook.methods[0],
+//         mutationKey: [API_ENDPOINTS.WEBHOOKS.RedeliverWebhook.key],
+//         onSuccess: async () => {
+// 
+//         },
+//     })
+// }
+
@@ -1034,6 +1034,95 @@ export type AL_MangaStats = {
     releaseYears?: Array<AL_UserReleaseYearStats>
 }
 
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeatures = {
+    id: number
+    type: AL_MediaType
+    format?: AL_MediaFormat
+    status?: AL_MediaStatus
+    season?: AL_MediaSeason
+    seasonYear?: number
+    isAdult: boolean
+    meanScore?: number
+    genres?: Array<string>
+    title?: AL_MediaFeaturesTitle
+    coverImage?: AL_MediaFeaturesCover
+    tags?: Array<AL_MediaFeaturesTag>
+    studios?: Array<AL_MediaFeaturesStudio>
+    -?: Array<AL_MediaFeaturesRelation>
+    -?: Array<AL_MediaFeaturesRecommendation>
+}
+
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeaturesCover = {
+    large?: string
+    color?: string
+}
+
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeaturesRecommendation = {
+    id: number
+    type: AL_MediaType
+    rating: number
+}
+
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeaturesRelation = {
+    id: number
+    type: AL_MediaType
+    relationType: AL_MediaRelation
+}
+
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeaturesStudio = {
+    id: number
+    name: string
+}
+
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeaturesTag = {
+    name: string
+    /**
+     * 0-100
+     */
+    rank: number
+}
+
+/**
+ * - Filepath: internal/api/anilist/media_features.go
+ * - Filename: media_features.go
+ * - Package: anilist
+ */
+export type AL_MediaFeaturesTitle = {
+    userPreferred?: string
+    romaji?: string
+    english?: string
+}
+
 /**
  * - Filepath: internal/api/anilist/models_gen.go
  * - Filename: models_gen.go
@@ -1493,6 +1582,10 @@ export type Anime_EpisodeMetadata = {
 export type Anime_LibraryCollection = {
     continueWatchingList?: Array<Anime_Episode>
     lists?: Array<Anime_LibraryCollectionList>
+    /**
+     * AniList custom lists, their entries are also in Lists
+     */
+    customLists?: Array<Anime_LibraryCollectionCustomList>
     unmatchedLocalFiles?: Array<Anime_LocalFile>
     unmatchedGroups?: Array<Anime_UnmatchedGroup>
     ignoredLocalFiles?: Array<Anime_LocalFile>
@@ -1504,6 +1597,16 @@ export type Anime_LibraryCollection = {
     stream?: Anime_StreamCollection
 }
 
+/**
+ * - Filepath: internal/library/anime/collection.go
+ * - Filename: collection.go
+ * - Package: anime
+ */
+export type Anime_LibraryCollectionCustomList = {
+    name: string
+    entries?: Array<Anime_LibraryCollectionEntry>
+}
+
 /**
  * - Filepath: internal/library/anime/collection.go
  * - Filename: collection.go
@@ -1675,6 +1778,113 @@ export type Anime_User = {
     token: string
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Backup
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/backup/schedule.go
+ * - Filename: schedule.go
+ * - Package: backup
+ */
+export type BackupFile = {
+    name: string
+    size: number
+    createdAt?: string
+}
+
+/**
+ * - Filepath: internal/backup/backup.go
+ * - Filename: backup.go
+ * - Package: backup
+ */
+export type Manifest = {
+    formatVersion: number
+    appVersion: string
+    createdAt?: string
+    files?: Array<ManifestFile>
+}
+
+/**
+ * - Filepath: internal/backup/backup.go
+ * - Filename: backup.go
+ * - Package: backup
+ */
+export type ManifestFile = {
+    path: string
+    type: string
+    size: number
+    sha256: string
+}
+
+/**
+ * - Filepath: internal/backup/restore.go
+ * - Filename: restore.go
+ * - Package: backup
+ * @description
+ *  RestoreResult describes a staged restore.
+ */
+export type RestoreResult = {
+    manifest?: Manifest
+    undecryptableCredentials?: Array<string>
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Calendar
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/calendar/calendar.go
+ * - Filename: calendar.go
+ * - Package: calendar
+ */
+export type AutoDownloaderStatus = "none" | "disabled" | "skipped" | "scheduled"
+
+/**
+ * - Filepath: internal/calendar/calendar.go
+ * - Filename: calendar.go
+ * - Package: calendar
+ */
+export type Day = {
+    /**
+     * YYYY-MM-DD, in the timezone of the range
+     */
+    date: string
+    items?: Array<Item>
+}
+
+/**
+ * - Filepath: internal/calendar/calendar.go
+ * - Filename: calendar.go
+ * - Package: calendar
+ */
+export type Item = {
+    mediaId: number
+    media?: AL_BaseAnime
+    listStatus?: AL_MediaListStatus
+    progress: number
+    episode: number
+    /**
+     * Unix timestamp
+     */
+    airingAt: number
+    aired: boolean
+    downloaded: boolean
+    autoDownloaderStatus: AutoDownloaderStatus
+    autoDownloaderRuleId?: number
+}
+
+/**
+ * - Filepath: internal/calendar/calendar.go
+ * - Filename: calendar.go
+ * - Package: calendar
+ */
+export type View = {
+    start?: string
+    end?: string
+    days?: Array<Day>
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // ChapterDownloader
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1929,6 +2139,22 @@ export type DebridClient_StreamState = {
  */
 export type DebridClient_StreamStatus = "downloading" | "ready" | "failed" | "started"
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Events
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/events/journal.go
+ * - Filename: journal.go
+ * - Package: events
+ */
+export type Events_JournalEntry = {
+    seq: number
+    type: string
+    payload?: RawMessage
+    time?: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Extension
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2246,6 +2472,27 @@ export type MalAuthResponse = {
     token_type: string
 }
 
+/**
+ * - Filepath: internal/handlers/notifications.go
+ * - Filename: notifications.go
+ * - Package: handlers
+ */
+export type NotificationInbox = {
+    notifications?: Array<Models_NotificationEntry>
+    total: number
+    unreadCount: number
+}
+
+/**
+ * - Filepath: internal/handlers/profile.go
+ * - Filename: profile.go
+ * - Package: handlers
+ */
+export type ProfileSelection = {
+    token: string
+    profile?: Models_Profile
+}
+
 /**
  * - Filepath: internal/handlers/docs.go
  * - Filename: docs.go
@@ -2301,6 +2548,39 @@ export type RouteHandlerParam = {
     descriptions?: Array<string>
 }
 
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Package: handlers
+ */
+export type ServerAuthCalendarFeedTokenResponse = {
+    token: string
+    device?: Models_AuthDevice
+}
+
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Package: handlers
+ */
+export type ServerAuthLoginResponse = {
+    token: string
+    device?: Models_AuthDevice
+}
+
+/**
+ * - Filepath: internal/handlers/server_auth.go
+ * - Filename: server_auth.go
+ * - Package: handlers
+ */
+export type ServerAuthStatus = {
+    enabled: boolean
+    authenticated: boolean
+    passwordSource?: PasswordSource
+    deviceId?: number
+    token?: string
+}
+
 /**
  * - Filepath: internal/handlers/status.go
  * - Filename: status.go
@@ -2315,6 +2595,7 @@ export type Status = {
     clientPlatform: string
     clientUserAgent: string
     user?: Anime_User
+    profile?: Models_Profile
     settings?: Models_Settings
     mal?: Models_Mal
     version: string
@@ -2330,6 +2611,192 @@ export type Status = {
     updating: boolean
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Kitsu
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/api/kitsu/types.go
+ * - Filename: types.go
+ * - Package: kitsu
+ */
+export type User = {
+    id: string
+    name: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Listsync
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/listsync/diff.go
+ * - Filename: diff.go
+ * - Package: listsync
+ */
+export type ListSync_Diff = {
+    mediaId: number
+    malId: number
+    mediaType: ListSync_MediaType
+    title: string
+    target: ListSync_Source
+    fields?: Array<string>
+    anilist?: ListSync_Entry
+    mal?: ListSync_Entry
+}
+
+/**
+ * - Filepath: internal/listsync/diff.go
+ * - Filename: diff.go
+ * - Package: listsync
+ */
+export type ListSync_Entry = {
+    status?: AL_MediaListStatus
+    progress: number
+    /**
+     * 0-100
+     */
+    score: number
+    startedAt?: AL_FuzzyDateInput
+    completedAt?: AL_FuzzyDateInput
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/listsync/diff.go
+ * - Filename: diff.go
+ * - Package: listsync
+ */
+export type ListSync_MediaType = "anime" | "manga"
+
+/**
+ * - Filepath: internal/listsync/diff.go
+ * - Filename: diff.go
+ * - Package: listsync
+ */
+export type ListSync_Origin = "anilist" | "mal" | "latest"
+
+/**
+ * - Filepath: internal/listsync/listsync.go
+ * - Filename: listsync.go
+ * - Package: listsync
+ */
+export type ListSync_Result = {
+    origin: ListSync_Origin
+    dryRun: boolean
+    diffs?: Array<ListSync_Diff>
+    unmapped?: Array<string>
+    applied: number
+    errors?: Array<string>
+}
+
+/**
+ * - Filepath: internal/listsync/diff.go
+ * - Filename: diff.go
+ * - Package: listsync
+ */
+export type ListSync_Source = "anilist" | "mal"
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Listtransfer
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/listtransfer/importer.go
+ * - Filename: importer.go
+ * - Package: listtransfer
+ */
+export type ApplyResult = {
+    applied: number
+    skipped: number
+    errors?: Array<string>
+}
+
+/**
+ * - Filepath: internal/listtransfer/entry.go
+ * - Filename: entry.go
+ * - Package: listtransfer
+ */
+export type Entry = {
+    type: MediaType
+    anilistId?: number
+    malId?: number
+    kitsuId?: number
+    title: string
+    status?: AL_MediaListStatus
+    progress: number
+    score: number
+    startedAt?: string
+    completedAt?: string
+    repeat: number
+}
+
+/**
+ * - Filepath: internal/listtransfer/export.go
+ * - Filename: export.go
+ * - Package: listtransfer
+ */
+export type ExportResult = {
+    format: Format
+    filename: string
+    content: string
+    exported: number
+    missingMalId?: Array<Entry>
+}
+
+/**
+ * - Filepath: internal/listtransfer/entry.go
+ * - Filename: entry.go
+ * - Package: listtransfer
+ */
+export type Format = "mal-xml" | "json" | "csv"
+
+/**
+ * - Filepath: internal/listtransfer/importer.go
+ * - Filename: importer.go
+ * - Package: listtransfer
+ */
+export type MatchedEntry = {
+    entry?: Entry
+    mediaId: number
+    matchedBy: string
+    title: string
+    coverImage: string
+    total: number
+}
+
+/**
+ * - Filepath: internal/listtransfer/entry.go
+ * - Filename: entry.go
+ * - Package: listtransfer
+ */
+export type MediaType = "anime" | "manga"
+
+/**
+ * - Filepath: internal/listtransfer/importer.go
+ * - Filename: importer.go
+ * - Package: listtransfer
+ */
+export type Preview = {
+    format: Format
+    matched?: Array<MatchedEntry>
+    unmatched?: Array<Entry>
+}
+
+/**
+ * - Filepath: internal/listtransfer/importer.go
+ * - Filename: importer.go
+ * - Package: listtransfer
+ */
+export type Status = {
+    state: string
+    progress: number
+    total: number
+    preview?: Preview
+    result?: ApplyResult
+    error?: string
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Manga
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2529,16 +2996,45 @@ export type Models_AnilistSettings = {
  * - Filepath: internal/database/models/models.go
  * - Filename: models.go
  * - Package: models
+ * @description
+ *  AuthDevice is a device that has logged in to the server.
+ *  Only the hash of the device's token is stored.
  */
-export type Models_AutoDownloaderItem = {
-    ruleId: number
-    mediaId: number
-    episode: number
-    link: string
-    hash: string
-    magnet: string
-    torrentName: string
-    downloaded: boolean
+export type Models_AuthDevice = {
+    name: string
+    -: string
+    scope: Models_AuthDeviceScope
+    userAgent: string
+    lastIp: string
+    lastUsedAt?: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  AuthDeviceScope limits the routes a device's token can access.
+ */
+export type Models_AuthDeviceScope = "" | "calendar_feed"
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ */
+export type Models_AutoDownloaderItem = {
+    ruleId: number
+    mediaId: number
+    episode: number
+    link: string
+    hash: string
+    magnet: string
+    torrentName: string
+    downloaded: boolean
     id: number
     createdAt?: string
     updatedAt?: string
@@ -2559,6 +3055,23 @@ export type Models_AutoDownloaderSettings = {
     useDebrid: boolean
 }
 
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  BackupSettings configures the scheduled backups.
+ */
+export type Models_BackupSettings = {
+    enabled: boolean
+    interval: number
+    maxBackups: number
+    dir: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
 /**
  * - Filepath: internal/database/models/models.go
  * - Filename: models.go
@@ -2640,6 +3153,7 @@ export type Models_LibrarySettings = {
     enableWatchContinuity: boolean
     libraryPaths: Models_LibraryPaths
     autoSyncOfflineLocalData: boolean
+    trackingPlatform: string
 }
 
 /**
@@ -2719,6 +3233,81 @@ export type Models_MediastreamSettings = {
     updatedAt?: string
 }
 
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  NotificationChannel is a service that receives the notifications in addition to the desktop notifications.
+ */
+export type Models_NotificationChannel = {
+    name: string
+    type: string
+    enabled: boolean
+    /**
+     * Empty means all kinds
+     */
+    kinds: Models_StringList
+    titleTemplate: string
+    messageTemplate: string
+    rateLimit: number
+    url: string
+    token: string
+    /**
+     * Telegram
+     */
+    chatId: string
+    /**
+     * ntfy and Gotify
+     */
+    priority: number
+    /**
+     * SMTP
+     */
+    smtpHost: string
+    /**
+     * SMTP, 465 uses implicit TLS
+     */
+    smtpPort: number
+    smtpUsername: string
+    smtpPassword: string
+    smtpFrom: string
+    smtpTo: Models_StringList
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  NotificationEntry is a notification stored in the inbox.
+ */
+export type Models_NotificationEntry = {
+    kind: string
+    message: string
+    mediaId: number
+    episode: number
+    links?: Array<Models_NotificationLink>
+    read: boolean
+    readAt?: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ */
+export type Models_NotificationLink = {
+    label: string
+    url: string
+}
+
 /**
  * - Filepath: internal/database/models/models.go
  * - Filename: models.go
@@ -2730,6 +3319,66 @@ export type Models_NotificationSettings = {
     disableAutoScannerNotifications: boolean
 }
 
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  PlatformMutation is a write to a tracking platform that is retried until it succeeds.
+ *  Writes to the same media are collapsed into a single mutation.
+ */
+export type Models_PlatformMutation = {
+    profileId: number
+    platform: string
+    mediaId: number
+    payload: string
+    status: string
+    attempts: number
+    error: string
+    nextAttemptAt?: string
+    revision: number
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  Profile is a user of the server.
+ *  The Account and Mal rows of a profile share its ID.
+ */
+export type Models_Profile = {
+    name: string
+    -: string
+    -: string
+    discord?: Models_DiscordSettings
+    hasPin: boolean
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  ScheduledJobRun is a record of a job run.
+ */
+export type Models_ScheduledJobRun = {
+    name: string
+    triggeredBy: string
+    startedAt?: string
+    durationMs: number
+    error: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
 /**
  * - Filepath: internal/database/models/models.go
  * - Filename: models.go
@@ -2761,6 +3410,13 @@ export type Models_SilencedMediaEntry = {
     updatedAt?: string
 }
 
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ */
+export type Models_StringList = Array<string>
+
 /**
  * - Filepath: internal/database/models/models.go
  * - Filename: models.go
@@ -2842,6 +3498,67 @@ export type Models_TorrentstreamSettings = {
     updatedAt?: string
 }
 
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  Webhook is a URL that receives the events selected by the user.
+ */
+export type Models_Webhook = {
+    name: string
+    url: string
+    secret: string
+    /**
+     * Empty means all events
+     */
+    events: Models_StringList
+    enabled: boolean
+    maxRetries: number
+    retryBackoff: number
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+/**
+ * - Filepath: internal/database/models/models.go
+ * - Filename: models.go
+ * - Package: models
+ * @description
+ *  WebhookDelivery is a record of an event sent to a webhook.
+ */
+export type Models_WebhookDelivery = {
+    webhookId: number
+    event: string
+    payload: string
+    status: string
+    attempts: number
+    responseStatus: number
+    error: string
+    nextAttemptAt?: string
+    id: number
+    createdAt?: string
+    updatedAt?: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Notifier
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/notifier/notifier.go
+ * - Filename: notifier.go
+ * - Package: notifier
+ */
+export type Notification = "Auto Downloader" |
+    "Auto Scanner" |
+    "Debrid" |
+    "Manga Downloader" |
+    "Extension Update" |
+    "Scheduled Job" |
+    "Platform Write"
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Onlinestream
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2911,6 +3628,75 @@ export type Onlinestream_VideoSource = {
     quality: string
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Recommendation
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/recommendation/recommendation.go
+ * - Filename: recommendation.go
+ * - Package: recommendation
+ */
+export type Reason = {
+    type: ReasonType
+    mediaId?: number
+    text: string
+}
+
+/**
+ * - Filepath: internal/recommendation/recommendation.go
+ * - Filename: recommendation.go
+ * - Package: recommendation
+ */
+export type ReasonType = "recommendation" | "relation" | "genres" | "tags" | "studio"
+
+/**
+ * - Filepath: internal/recommendation/recommendation.go
+ * - Filename: recommendation.go
+ * - Package: recommendation
+ */
+export type Recommendation = {
+    media?: AL_MediaFeatures
+    /**
+     * 0-100
+     */
+    score: number
+    planned: boolean
+    reasons?: Array<Reason>
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Scheduler
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/scheduler/scheduler.go
+ * - Filename: scheduler.go
+ * - Package: scheduler
+ */
+export type JobStatus = {
+    name: string
+    description: string
+    enabled: boolean
+    schedule: string
+    defaultSchedule: string
+    requiresOnline: boolean
+    running: boolean
+    lastRun?: Models_ScheduledJobRun
+    nextRun?: string
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// ServerAuth
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/server_auth/server_auth.go
+ * - Filename: server_auth.go
+ * - Package: server_auth
+ */
+export type PasswordSource = "" | "config" | "settings"
+
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Summary
 //////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -3453,3 +4239,149 @@ export type Video = {
     bitrate: number
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Watchstats
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type Activity = {
+    interval: Interval
+    periods?: Array<Period>
+    duration: number
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type Interval = "day" | "week"
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type Period = {
+    /**
+     * YYYY-MM-DD, first day of the period
+     */
+    date: string
+    duration: number
+    sessions: number
+    episodes: number
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type Streak = {
+    days: number
+    /**
+     * YYYY-MM-DD
+     */
+    start: string
+    /**
+     * YYYY-MM-DD
+     */
+    end: string
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type Streaks = {
+    current?: Streak
+    longest?: Streak
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type Top = {
+    media?: Array<TopMedia>
+    genres?: Array<TopGenre>
+    studios?: Array<TopStudio>
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type TopGenre = {
+    name: string
+    duration: number
+    count: number
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type TopMedia = {
+    mediaId: number
+    media?: AL_MediaFeatures
+    duration: number
+    episodes: number
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type TopStudio = {
+    id: number
+    name: string
+    duration: number
+    count: number
+}
+
+/**
+ * - Filepath: internal/watchstats/stats.go
+ * - Filename: stats.go
+ * - Package: watchstats
+ */
+export type YearSummary = {
+    year: number
+    duration: number
+    sessions: number
+    episodes: number
+    anime: number
+    daysWatched: number
+    longestStreak?: Streak
+    busiestDay?: Period
+    months?: Array<number>
+    sources?: Record<Source, number>
+    top?: Top
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Webhook
+//////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * - Filepath: internal/webhook/events.go
+ * - Filename: events.go
+ * - Package: webhook
+ */
+export type Event = "auto_downloader.torrent_added" |
+    "debrid.download_finished" |
+    "library.scan_completed" |
+    "playback.episode_watched" |
+    "manga.chapter_downloaded" |
+    "extensions.update_available" |
+    "ping"
+